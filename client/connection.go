@@ -2,6 +2,7 @@ package incus
 
 import (
 	"context"
+	"crypto"
 	"crypto/sha256"
 	"fmt"
 	"net/http"
@@ -32,6 +33,9 @@ type ConnectionArgs struct {
 	// TLS key to use for client authentication.
 	TLSClientKey string
 
+	// Signer to use for client authentication instead of TLSClientKey (e.g. a key held in a hardware token).
+	TLSClientSigner crypto.Signer
+
 	// TLS CA to validate against when in PKI mode.
 	TLSCA string
 
@@ -65,11 +69,17 @@ type ConnectionArgs struct {
 	// Caching support for image servers
 	CachePath   string
 	CacheExpiry time.Duration
+
+	// Keep the responses to GET requests in memory and revalidate them with the server using their ETag
+	ResponseCache bool
+
+	// Credentials and settings for authenticated OCI registries
+	RegistryAuth *api.ImageRegistryAuth
 }
 
 // ConnectIncus lets you connect to a remote Incus daemon over HTTPs.
 //
-// A client certificate (TLSClientCert) and key (TLSClientKey) must be provided.
+// A client certificate (TLSClientCert) and key (TLSClientKey or TLSClientSigner) must be provided.
 //
 // If connecting to an Incus daemon running in PKI mode, the PKI CA (TLSCA) must also be provided.
 //
@@ -80,7 +90,7 @@ func ConnectIncus(uri string, args *ConnectionArgs) (InstanceServer, error) {
 
 // ConnectIncusWithContext lets you connect to a remote Incus daemon over HTTPs with context.Context.
 //
-// A client certificate (TLSClientCert) and key (TLSClientKey) must be provided.
+// A client certificate (TLSClientCert) and key (TLSClientKey or TLSClientSigner) must be provided.
 //
 // If connecting to an Incus daemon running in PKI mode, the PKI CA (TLSCA) must also be provided.
 //
@@ -130,6 +140,10 @@ func ConnectIncusHTTPWithContext(ctx context.Context, args *ConnectionArgs, clie
 	// Setup the HTTP client
 	server.http = client
 
+	if args.ResponseCache {
+		server.responseCache = &responseCache{}
+	}
+
 	// Test the connection and seed the server information
 	if !args.SkipGetServer {
 		serverStatus, _, err := server.GetServer()
@@ -223,6 +237,10 @@ func ConnectIncusUnixWithContext(ctx context.Context, path string, args *Connect
 
 	server.http = httpClient
 
+	if args.ResponseCache {
+		server.responseCache = &responseCache{}
+	}
+
 	// Test the connection and seed the server information
 	if !args.SkipGetServer {
 		serverStatus, _, err := server.GetServer()
@@ -278,7 +296,7 @@ func ConnectSimpleStreams(uri string, args *ConnectionArgs) (ImageServer, error)
 	}
 
 	// Setup the HTTP client
-	httpClient, err := tlsHTTPClient(args.HTTPClient, args.TLSClientCert, args.TLSClientKey, args.TLSCA, args.TLSServerCert, args.InsecureSkipVerify, args.Proxy, args.TransportWrapper)
+	httpClient, err := tlsHTTPClient(args.HTTPClient, args.TLSClientCert, args.TLSClientKey, args.TLSClientSigner, args.TLSCA, args.TLSServerCert, args.InsecureSkipVerify, args.Proxy, args.TransportWrapper)
 	if err != nil {
 		return nil, err
 	}
@@ -335,12 +353,13 @@ func ConnectOCI(uri string, args *ConnectionArgs) (ImageServer, error) {
 		httpHost:        uri,
 		httpUserAgent:   args.UserAgent,
 		httpCertificate: args.TLSServerCert,
+		registryAuth:    args.RegistryAuth,
 
 		cache: map[string]ociInfo{},
 	}
 
 	// Setup the HTTP client
-	httpClient, err := tlsHTTPClient(args.HTTPClient, args.TLSClientCert, args.TLSClientKey, args.TLSCA, args.TLSServerCert, args.InsecureSkipVerify, args.Proxy, args.TransportWrapper)
+	httpClient, err := tlsHTTPClient(args.HTTPClient, args.TLSClientCert, args.TLSClientKey, args.TLSClientSigner, args.TLSCA, args.TLSServerCert, args.InsecureSkipVerify, args.Proxy, args.TransportWrapper)
 	if err != nil {
 		return nil, err
 	}
@@ -382,7 +401,7 @@ func httpsIncus(ctx context.Context, requestURL string, args *ConnectionArgs) (I
 	}
 
 	// Setup the HTTP client
-	httpClient, err := tlsHTTPClient(args.HTTPClient, args.TLSClientCert, args.TLSClientKey, args.TLSCA, args.TLSServerCert, args.InsecureSkipVerify, args.Proxy, args.TransportWrapper)
+	httpClient, err := tlsHTTPClient(args.HTTPClient, args.TLSClientCert, args.TLSClientKey, args.TLSClientSigner, args.TLSCA, args.TLSServerCert, args.InsecureSkipVerify, args.Proxy, args.TransportWrapper)
 	if err != nil {
 		return nil, err
 	}
@@ -396,6 +415,10 @@ func httpsIncus(ctx context.Context, requestURL string, args *ConnectionArgs) (I
 		server.setupOIDCClient(args.OIDCTokens)
 	}
 
+	if args.ResponseCache {
+		server.responseCache = &responseCache{}
+	}
+
 	// Test the connection and seed the server information
 	if !args.SkipGetServer {
 		_, _, err := server.GetServer()
@@ -48,8 +48,57 @@ type ProtocolIncus struct {
 
 	clusterTarget string
 	project       string
+	priority      string
 
 	oidcClient *oidcClient
+
+	// responseCache holds the responses to GET requests, revalidated with the server using their ETag.
+	responseCache *responseCache
+}
+
+// responseCacheSize is the maximum number of responses kept in the response cache.
+const responseCacheSize = 256
+
+// responseCacheEntry is a response held in the response cache.
+type responseCacheEntry struct {
+	etag     string
+	response api.Response
+}
+
+// responseCache is an in-memory cache of the responses to GET requests, indexed by URL.
+type responseCache struct {
+	lock    sync.Mutex
+	entries map[string]responseCacheEntry
+}
+
+// get returns the cached response for the URL.
+func (c *responseCache) get(url string) (responseCacheEntry, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry, ok := c.entries[url]
+
+	return entry, ok
+}
+
+// set records the response for the URL.
+func (c *responseCache) set(url string, etag string, response api.Response) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.entries == nil || len(c.entries) >= responseCacheSize {
+		c.entries = map[string]responseCacheEntry{}
+	}
+
+	c.entries[url] = responseCacheEntry{etag: etag, response: response}
+}
+
+// remove drops the cached response for the URL.
+func (c *responseCache) remove(url string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.entries, url)
 }
 
 // Disconnect gets rid of any background goroutines.
@@ -182,12 +231,17 @@ func (r *ProtocolIncus) DoWebsocket(dialer websocket.Dialer, uri string, req *ht
 // addClientHeaders sets headers from client settings.
 // User-Agent (if r.httpUserAgent is set).
 // X-Incus-authenticated (if r.requireAuthenticated is set).
+// X-Incus-priority (if r.priority is set).
 // OIDC Authorization header (if r.oidcClient is set).
 func (r *ProtocolIncus) addClientHeaders(req *http.Request) {
 	if r.httpUserAgent != "" {
 		req.Header.Set("User-Agent", r.httpUserAgent)
 	}
 
+	if r.priority != "" {
+		req.Header.Set("X-Incus-priority", r.priority)
+	}
+
 	if r.requireAuthenticated {
 		req.Header.Set("X-Incus-authenticated", "true")
 	}
@@ -315,6 +369,17 @@ func (r *ProtocolIncus) rawQuery(method string, url string, data any, ETag strin
 		req.Header.Set("If-Match", ETag)
 	}
 
+	// Revalidate the cached response (if any).
+	cacheable := r.responseCache != nil && method == http.MethodGet && data == nil
+	var cached responseCacheEntry
+	if cacheable {
+		var ok bool
+		cached, ok = r.responseCache.get(url)
+		if ok {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+	}
+
 	// Send the request
 	resp, err := r.DoHTTP(req)
 	if err != nil {
@@ -323,7 +388,23 @@ func (r *ProtocolIncus) rawQuery(method string, url string, data any, ETag strin
 
 	defer func() { _ = resp.Body.Close() }()
 
-	return incusParseResponse(resp)
+	if cacheable && cached.etag != "" && resp.StatusCode == http.StatusNotModified {
+		logger.Debug("Using cached response", logger.Ctx{"url": url, "etag": cached.etag})
+
+		response := cached.response
+		return &response, cached.etag, nil
+	}
+
+	response, etag, err := incusParseResponse(resp)
+	if cacheable {
+		if err == nil && etag != "" && response.Type == api.SyncResponse {
+			r.responseCache.set(url, etag, *response)
+		} else {
+			r.responseCache.remove(url)
+		}
+	}
+
+	return response, etag, err
 }
 
 // setURLQueryAttributes modifies the supplied URL's query string with the client's current target and project.
@@ -531,7 +612,13 @@ func (r *ProtocolIncus) getSourceImageConnectionInfo(source ImageServer, image a
 
 	// Optimization for the local image case
 	if r.isSameServer(source) {
-		// Always use fingerprints for local case
+		// Let the server resolve the alias so the project's image pins apply.
+		if instSrc.Alias != "" && r.HasExtension("image_channels") {
+			instSrc.Fingerprint = ""
+			return nil, nil
+		}
+
+		// Otherwise always use fingerprints for local case
 		instSrc.Fingerprint = image.Fingerprint
 		instSrc.Alias = ""
 		return nil, nil
@@ -557,6 +644,15 @@ func (r *ProtocolIncus) getSourceImageConnectionInfo(source ImageServer, image a
 	instSrc.Protocol = info.Protocol
 	instSrc.Certificate = info.Certificate
 
+	// Forward the registry credentials
+	if info.RegistryAuth != nil {
+		if !r.HasExtension("oci_registry_auth") {
+			return nil, fmt.Errorf("The server is missing the required \"oci_registry_auth\" API extension")
+		}
+
+		instSrc.RegistryAuth = info.RegistryAuth
+	}
+
 	// Generate secret token if needed
 	if !image.Public {
 		secret, err := source.GetImageSecret(image.Fingerprint)
@@ -0,0 +1,41 @@
+package incus
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// GetChanges returns the entities of the current project modified since the provided generation.
+func (r *ProtocolIncus) GetChanges(since uint64) (*api.Changes, error) {
+	return r.getChanges(since, false)
+}
+
+// GetChangesAllProjects returns the entities from all projects modified since the provided generation.
+func (r *ProtocolIncus) GetChangesAllProjects(since uint64) (*api.Changes, error) {
+	return r.getChanges(since, true)
+}
+
+func (r *ProtocolIncus) getChanges(since uint64, allProjects bool) (*api.Changes, error) {
+	err := r.CheckExtension("changes_api")
+	if err != nil {
+		return nil, err
+	}
+
+	v := url.Values{}
+	v.Set("since", fmt.Sprintf("%d", since))
+
+	if allProjects {
+		v.Set("all-projects", "true")
+	}
+
+	changes := api.Changes{}
+
+	_, err = r.queryStruct("GET", fmt.Sprintf("/changes?%s", v.Encode()), nil, "", &changes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &changes, nil
+}
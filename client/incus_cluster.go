@@ -2,6 +2,7 @@ package incus
 
 import (
 	"fmt"
+	"net/url"
 
 	"github.com/lxc/incus/v6/shared/api"
 )
@@ -307,3 +308,77 @@ func (r *ProtocolIncus) GetClusterGroup(name string) (*api.ClusterGroup, string,
 
 	return &group, etag, nil
 }
+
+// GetClusterEnrollments returns the pending and approved cluster enrollments.
+func (r *ProtocolIncus) GetClusterEnrollments() ([]api.ClusterEnrollment, error) {
+	if !r.HasExtension("cluster_enrollment") {
+		return nil, fmt.Errorf("The server is missing the required \"cluster_enrollment\" API extension")
+	}
+
+	enrollments := []api.ClusterEnrollment{}
+	_, err := r.queryStruct("GET", "/cluster/enrollments", nil, "", &enrollments)
+	if err != nil {
+		return nil, err
+	}
+
+	return enrollments, nil
+}
+
+// GetClusterEnrollment returns information about the given cluster enrollment.
+func (r *ProtocolIncus) GetClusterEnrollment(id string) (*api.ClusterEnrollment, error) {
+	if !r.HasExtension("cluster_enrollment") {
+		return nil, fmt.Errorf("The server is missing the required \"cluster_enrollment\" API extension")
+	}
+
+	enrollment := api.ClusterEnrollment{}
+	_, err := r.queryStruct("GET", fmt.Sprintf("/cluster/enrollments/%s", url.PathEscape(id)), nil, "", &enrollment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &enrollment, nil
+}
+
+// CreateClusterEnrollment requests for the server to join the cluster.
+// The request is authenticated through the client certificate of the connection.
+func (r *ProtocolIncus) CreateClusterEnrollment(enrollment api.ClusterEnrollmentsPost) (*api.ClusterEnrollment, error) {
+	if !r.HasExtension("cluster_enrollment") {
+		return nil, fmt.Errorf("The server is missing the required \"cluster_enrollment\" API extension")
+	}
+
+	result := api.ClusterEnrollment{}
+	_, err := r.queryStruct("POST", "/cluster/enrollments", enrollment, "", &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ApproveClusterEnrollment approves a pending cluster enrollment.
+func (r *ProtocolIncus) ApproveClusterEnrollment(id string) error {
+	if !r.HasExtension("cluster_enrollment") {
+		return fmt.Errorf("The server is missing the required \"cluster_enrollment\" API extension")
+	}
+
+	_, _, err := r.query("POST", fmt.Sprintf("/cluster/enrollments/%s", url.PathEscape(id)), nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteClusterEnrollment rejects a cluster enrollment.
+func (r *ProtocolIncus) DeleteClusterEnrollment(id string) error {
+	if !r.HasExtension("cluster_enrollment") {
+		return fmt.Errorf("The server is missing the required \"cluster_enrollment\" API extension")
+	}
+
+	_, _, err := r.query("DELETE", fmt.Sprintf("/cluster/enrollments/%s", url.PathEscape(id)), nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
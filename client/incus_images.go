@@ -962,6 +962,15 @@ func (r *ProtocolIncus) CopyImage(source ImageServer, image api.Image, args *Ima
 		req.Source.ImageType = args.Type
 	}
 
+	// Forward the registry credentials
+	if info.RegistryAuth != nil {
+		if !r.HasExtension("oci_registry_auth") {
+			return nil, fmt.Errorf("The server is missing the required \"oci_registry_auth\" API extension")
+		}
+
+		req.Source.RegistryAuth = info.RegistryAuth
+	}
+
 	// Generate secret token if needed
 	if !image.Public {
 		secret, err := source.GetImageSecret(image.Fingerprint)
@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/gorilla/websocket"
@@ -289,6 +290,13 @@ func (r *ProtocolIncus) RebuildInstanceFromImage(source ImageServer, image api.I
 		return nil, err
 	}
 
+	if req.Upgrade {
+		err = r.CheckExtension("instances_rebuild_upgrade")
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	info, err := r.getSourceImageConnectionInfo(source, image, &req.Source)
 	if err != nil {
 		return nil, err
@@ -317,16 +325,163 @@ func (r *ProtocolIncus) RebuildInstanceFromImage(source ImageServer, image api.I
 	return r.tryRebuildInstance(instanceName, req, info.Addresses, nil)
 }
 
-// RebuildInstance rebuilds an instance as empty.
+// RebuildInstance rebuilds an instance as empty (or, when upgrading, from its current image source).
 func (r *ProtocolIncus) RebuildInstance(instanceName string, instance api.InstanceRebuildPost) (op Operation, err error) {
 	err = r.CheckExtension("instances_rebuild")
 	if err != nil {
 		return nil, err
 	}
 
+	if instance.Upgrade {
+		err = r.CheckExtension("instances_rebuild_upgrade")
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return r.rebuildInstance(instanceName, instance)
 }
 
+func (r *ProtocolIncus) convertInstance(instanceName string, req api.InstanceConvertPost) (Operation, error) {
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send the request
+	op, _, err := r.queryOperation("POST", fmt.Sprintf("%s/%s/convert", path, url.PathEscape(instanceName)), req, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
+// tryConvertInstance attempts to convert a specific instance using multiple image server URLs.
+// It runs the conversion asynchronously and returns a RemoteOperation to monitor the progress and any errors.
+func (r *ProtocolIncus) tryConvertInstance(instanceName string, req api.InstanceConvertPost, urls []string) (RemoteOperation, error) {
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("The source server isn't listening on the network")
+	}
+
+	rop := remoteOperation{
+		chDone: make(chan bool),
+	}
+
+	// Forward targetOp to remote op
+	go func() {
+		success := false
+		var errors []remoteOperationResult
+		for _, serverURL := range urls {
+			req.Source.Server = serverURL
+
+			op, err := r.convertInstance(instanceName, req)
+			if err != nil {
+				errors = append(errors, remoteOperationResult{URL: serverURL, Error: err})
+				continue
+			}
+
+			rop.handlerLock.Lock()
+			rop.targetOp = op
+			rop.handlerLock.Unlock()
+
+			for _, handler := range rop.handlers {
+				_, _ = rop.targetOp.AddHandler(handler)
+			}
+
+			err = rop.targetOp.Wait()
+			if err != nil {
+				errors = append(errors, remoteOperationResult{URL: serverURL, Error: err})
+				if localtls.IsConnectionError(err) {
+					continue
+				}
+
+				break
+			}
+
+			success = true
+			break
+		}
+
+		if !success {
+			rop.err = remoteOperationError("Failed instance conversion", errors)
+		}
+
+		close(rop.chDone)
+	}()
+
+	return &rop, nil
+}
+
+// ConvertInstanceFromImage converts an instance into a new instance of another type, using the provided image
+// for the kernel and bootloader.
+func (r *ProtocolIncus) ConvertInstanceFromImage(source ImageServer, image api.Image, instanceName string, req api.InstanceConvertPost) (RemoteOperation, error) {
+	err := r.CheckExtension("instances_convert")
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := r.getSourceImageConnectionInfo(source, image, &req.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	if info == nil {
+		op, err := r.convertInstance(instanceName, req)
+		if err != nil {
+			return nil, err
+		}
+
+		rop := remoteOperation{
+			targetOp: op,
+			chDone:   make(chan bool),
+		}
+
+		// Forward targetOp to remote op
+		go func() {
+			rop.err = rop.targetOp.Wait()
+			close(rop.chDone)
+		}()
+
+		return &rop, nil
+	}
+
+	return r.tryConvertInstance(instanceName, req, info.Addresses)
+}
+
+// ConvertInstance converts an instance into a new instance of another type, using the image the instance was
+// created from for the kernel and bootloader.
+func (r *ProtocolIncus) ConvertInstance(instanceName string, req api.InstanceConvertPost) (Operation, error) {
+	err := r.CheckExtension("instances_convert")
+	if err != nil {
+		return nil, err
+	}
+
+	return r.convertInstance(instanceName, req)
+}
+
+// WaitInstance waits for an instance to reach a condition (such as running, having an IP address or
+// having completed its cloud-init run).
+func (r *ProtocolIncus) WaitInstance(instanceName string, req api.InstanceWaitPost) (Operation, error) {
+	err := r.CheckExtension("instance_wait")
+	if err != nil {
+		return nil, err
+	}
+
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send the request
+	op, _, err := r.queryOperation("POST", fmt.Sprintf("%s/%s/wait", path, url.PathEscape(instanceName)), req, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
 // GetInstancesFull returns a list of instances including snapshots, backups and state.
 func (r *ProtocolIncus) GetInstancesFull(instanceType api.InstanceType) ([]api.InstanceFull, error) {
 	instances := []api.InstanceFull{}
@@ -1136,6 +1291,35 @@ func (r *ProtocolIncus) MigrateInstance(name string, instance api.InstancePost)
 	return op, nil
 }
 
+// CheckMigrateInstance checks whether the instance can be migrated as requested, returning the problems found.
+func (r *ProtocolIncus) CheckMigrateInstance(name string, instance api.InstancePost) (*api.InstanceMigrationCheck, error) {
+	if !r.HasExtension("instance_migration_check") {
+		return nil, fmt.Errorf("The server is missing the required \"instance_migration_check\" API extension")
+	}
+
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
+	if err != nil {
+		return nil, err
+	}
+
+	// Quick check.
+	if !instance.Migration {
+		return nil, fmt.Errorf("Can't check a rename through CheckMigrateInstance")
+	}
+
+	instance.CheckOnly = true
+
+	check := api.InstanceMigrationCheck{}
+
+	// Send the request
+	_, err = r.queryStruct("POST", fmt.Sprintf("%s/%s", path, url.PathEscape(name)), instance, "", &check)
+	if err != nil {
+		return nil, err
+	}
+
+	return &check, nil
+}
+
 // DeleteInstance requests that Incus deletes the instance.
 func (r *ProtocolIncus) DeleteInstance(name string) (Operation, error) {
 	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
@@ -2231,6 +2415,34 @@ func (r *ProtocolIncus) GetInstanceAccess(name string) (api.Access, error) {
 	return access, nil
 }
 
+// GetInstanceAttestation retrieves and verifies the measured boot attestation of the instance.
+// An empty list of PCRs uses those of the instance's reference policy.
+func (r *ProtocolIncus) GetInstanceAttestation(name string, pcrs []int) (*api.InstanceAttestation, error) {
+	err := r.CheckExtension("instance_attestation")
+	if err != nil {
+		return nil, err
+	}
+
+	uri := fmt.Sprintf("/instances/%s/attestation", url.PathEscape(name))
+	if len(pcrs) > 0 {
+		values := make([]string, 0, len(pcrs))
+		for _, pcr := range pcrs {
+			values = append(values, strconv.Itoa(pcr))
+		}
+
+		uri = fmt.Sprintf("%s?pcrs=%s", uri, url.QueryEscape(strings.Join(values, ",")))
+	}
+
+	result := api.InstanceAttestation{}
+
+	_, err = r.queryStruct("GET", uri, nil, "", &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
 // GetInstanceLogfiles returns a list of logfiles for the instance.
 func (r *ProtocolIncus) GetInstanceLogfiles(name string) ([]string, error) {
 	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
@@ -2866,6 +3078,13 @@ func (r *ProtocolIncus) CreateInstanceBackup(instanceName string, backup api.Ins
 		return nil, fmt.Errorf("The server is missing the required \"container_backup\" API extension")
 	}
 
+	if len(backup.EncryptionRecipients) > 0 {
+		err := r.CheckExtension("backup_encryption")
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Send the request
 	op, _, err := r.queryOperation("POST", fmt.Sprintf("%s/%s/backups", path, url.PathEscape(instanceName)), backup, "")
 	if err != nil {
@@ -2915,6 +3134,27 @@ func (r *ProtocolIncus) DeleteInstanceBackup(instanceName string, name string) (
 	return op, nil
 }
 
+// VerifyInstanceBackup requests that Incus verifies the backup against its signed manifest.
+func (r *ProtocolIncus) VerifyInstanceBackup(instanceName string, name string, req api.BackupVerifyPost) (Operation, error) {
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.CheckExtension("backup_encryption")
+	if err != nil {
+		return nil, err
+	}
+
+	// Send the request
+	op, _, err := r.queryOperation("POST", fmt.Sprintf("%s/%s/backups/%s/verify", path, url.PathEscape(instanceName), url.PathEscape(name)), req, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
 // GetInstanceBackupFile requests the instance backup content.
 func (r *ProtocolIncus) GetInstanceBackupFile(instanceName string, name string, req *BackupFileRequest) (*BackupFileResponse, error) {
 	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
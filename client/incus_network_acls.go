@@ -113,6 +113,23 @@ func (r *ProtocolIncus) GetNetworkACLLogfile(name string) (io.ReadCloser, error)
 	return resp.Body, err
 }
 
+// GetNetworkACLState returns the hit counters of the rules of the Network ACL.
+func (r *ProtocolIncus) GetNetworkACLState(name string) (*api.NetworkACLState, error) {
+	if !r.HasExtension("network_acl_counters") {
+		return nil, fmt.Errorf(`The server is missing the required "network_acl_counters" API extension`)
+	}
+
+	state := api.NetworkACLState{}
+
+	// Fetch the raw value.
+	_, err := r.queryStruct("GET", fmt.Sprintf("/network-acls/%s/state", url.PathEscape(name)), nil, "", &state)
+	if err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
+
 // CreateNetworkACL defines a new network ACL using the provided struct.
 func (r *ProtocolIncus) CreateNetworkACL(acl api.NetworkACLsPost) error {
 	if !r.HasExtension("network_acl") {
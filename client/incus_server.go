@@ -115,9 +115,11 @@ func (r *ProtocolIncus) UseProject(name string) InstanceServer {
 		requireAuthenticated: r.requireAuthenticated,
 		clusterTarget:        r.clusterTarget,
 		project:              name,
+		priority:             r.priority,
 		eventConns:           make(map[string]*websocket.Conn),  // New project specific listener conns.
 		eventListeners:       make(map[string][]*EventListener), // New project specific listeners.
 		oidcClient:           r.oidcClient,
+		responseCache:        r.responseCache,
 	}
 }
 
@@ -138,10 +140,37 @@ func (r *ProtocolIncus) UseTarget(name string) InstanceServer {
 		httpUnixPath:         r.httpUnixPath,
 		requireAuthenticated: r.requireAuthenticated,
 		project:              r.project,
+		priority:             r.priority,
 		eventConns:           make(map[string]*websocket.Conn),  // New target specific listener conns.
 		eventListeners:       make(map[string][]*EventListener), // New target specific listeners.
 		oidcClient:           r.oidcClient,
 		clusterTarget:        name,
+		responseCache:        r.responseCache,
+	}
+}
+
+// UsePriority returns a client that will request the given scheduling priority (bulk, normal or
+// interactive) for the operations it creates.
+func (r *ProtocolIncus) UsePriority(priority string) InstanceServer {
+	return &ProtocolIncus{
+		ctx:                  r.ctx,
+		ctxConnected:         r.ctxConnected,
+		ctxConnectedCancel:   r.ctxConnectedCancel,
+		server:               r.server,
+		http:                 r.http,
+		httpCertificate:      r.httpCertificate,
+		httpBaseURL:          r.httpBaseURL,
+		httpProtocol:         r.httpProtocol,
+		httpUserAgent:        r.httpUserAgent,
+		httpUnixPath:         r.httpUnixPath,
+		requireAuthenticated: r.requireAuthenticated,
+		project:              r.project,
+		priority:             priority,
+		eventConns:           make(map[string]*websocket.Conn),  // New priority specific listener conns.
+		eventListeners:       make(map[string][]*EventListener), // New priority specific listeners.
+		oidcClient:           r.oidcClient,
+		clusterTarget:        r.clusterTarget,
+		responseCache:        r.responseCache,
 	}
 }
 
@@ -267,6 +267,13 @@ func (r *ProtocolIncus) CreateStoragePoolBucketBackup(poolName string, bucketNam
 		return nil, err
 	}
 
+	if len(backup.EncryptionRecipients) > 0 {
+		err = r.CheckExtension("backup_encryption")
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	op, _, err := r.queryOperation("POST", fmt.Sprintf("/storage-pools/%s/buckets/%s/backups", url.PathEscape(poolName), url.PathEscape(bucketName)), backup, "")
 	if err != nil {
 		return nil, err
@@ -290,6 +297,21 @@ func (r *ProtocolIncus) DeleteStoragePoolBucketBackup(pool string, bucketName st
 	return op, nil
 }
 
+// VerifyStoragePoolBucketBackup requests that Incus verifies the storage bucket backup against its signed manifest.
+func (r *ProtocolIncus) VerifyStoragePoolBucketBackup(pool string, bucketName string, name string, req api.BackupVerifyPost) (Operation, error) {
+	err := r.CheckExtension("backup_encryption")
+	if err != nil {
+		return nil, err
+	}
+
+	op, _, err := r.queryOperation("POST", fmt.Sprintf("/storage-pools/%s/buckets/%s/backups/%s/verify", url.PathEscape(pool), url.PathEscape(bucketName), url.PathEscape(name)), req, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
 // GetStoragePoolBucketBackupFile returns the storage bucket file.
 func (r *ProtocolIncus) GetStoragePoolBucketBackupFile(pool string, bucketName string, name string, req *BackupFileRequest) (*BackupFileResponse, error) {
 	err := r.CheckExtension("storage_bucket_backup")
@@ -885,6 +885,13 @@ func (r *ProtocolIncus) CreateStorageVolumeBackup(pool string, volName string, b
 		return nil, fmt.Errorf("The server is missing the required \"custom_volume_backup\" API extension")
 	}
 
+	if len(backup.EncryptionRecipients) > 0 {
+		err := r.CheckExtension("backup_encryption")
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Send the request
 	op, _, err := r.queryOperation("POST", fmt.Sprintf("/storage-pools/%s/volumes/custom/%s/backups", url.PathEscape(pool), url.PathEscape(volName)), backup, "")
 	if err != nil {
@@ -924,6 +931,22 @@ func (r *ProtocolIncus) DeleteStorageVolumeBackup(pool string, volName string, n
 	return op, nil
 }
 
+// VerifyStorageVolumeBackup requests that Incus verifies the custom volume backup against its signed manifest.
+func (r *ProtocolIncus) VerifyStorageVolumeBackup(pool string, volName string, name string, req api.BackupVerifyPost) (Operation, error) {
+	err := r.CheckExtension("backup_encryption")
+	if err != nil {
+		return nil, err
+	}
+
+	// Send the request
+	op, _, err := r.queryOperation("POST", fmt.Sprintf("/storage-pools/%s/volumes/custom/%s/backups/%s/verify", url.PathEscape(pool), url.PathEscape(volName), url.PathEscape(name)), req, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
 // GetStorageVolumeBackupFile requests the custom volume backup content.
 func (r *ProtocolIncus) GetStorageVolumeBackupFile(pool string, volName string, name string, req *BackupFileRequest) (*BackupFileResponse, error) {
 	if !r.HasExtension("custom_volume_backup") {
@@ -85,6 +85,7 @@ type InstanceServer interface {
 	IsClustered() (clustered bool)
 	UseTarget(name string) (client InstanceServer)
 	UseProject(name string) (client InstanceServer)
+	UsePriority(priority string) (client InstanceServer)
 
 	// Certificate functions
 	GetCertificateFingerprints() (fingerprints []string, err error)
@@ -114,10 +115,14 @@ type InstanceServer interface {
 	UpdateInstance(name string, instance api.InstancePut, ETag string) (op Operation, err error)
 	RenameInstance(name string, instance api.InstancePost) (op Operation, err error)
 	MigrateInstance(name string, instance api.InstancePost) (op Operation, err error)
+	CheckMigrateInstance(name string, instance api.InstancePost) (check *api.InstanceMigrationCheck, err error)
 	DeleteInstance(name string) (op Operation, err error)
 	UpdateInstances(state api.InstancesPut, ETag string) (op Operation, err error)
 	RebuildInstance(instanceName string, req api.InstanceRebuildPost) (op Operation, err error)
 	RebuildInstanceFromImage(source ImageServer, image api.Image, instanceName string, req api.InstanceRebuildPost) (op RemoteOperation, err error)
+	ConvertInstance(instanceName string, req api.InstanceConvertPost) (op Operation, err error)
+	ConvertInstanceFromImage(source ImageServer, image api.Image, instanceName string, req api.InstanceConvertPost) (op RemoteOperation, err error)
+	WaitInstance(instanceName string, req api.InstanceWaitPost) (op Operation, err error)
 
 	ExecInstance(instanceName string, exec api.InstanceExecPost, args *InstanceExecArgs) (op Operation, err error)
 	ConsoleInstance(instanceName string, console api.InstanceConsolePost, args *InstanceConsoleArgs) (op Operation, err error)
@@ -149,6 +154,7 @@ type InstanceServer interface {
 	CreateInstanceBackup(instanceName string, backup api.InstanceBackupsPost) (op Operation, err error)
 	RenameInstanceBackup(instanceName string, name string, backup api.InstanceBackupPost) (op Operation, err error)
 	DeleteInstanceBackup(instanceName string, name string) (op Operation, err error)
+	VerifyInstanceBackup(instanceName string, name string, req api.BackupVerifyPost) (op Operation, err error)
 	GetInstanceBackupFile(instanceName string, name string, req *BackupFileRequest) (resp *BackupFileResponse, err error)
 	CreateInstanceFromBackup(args InstanceBackupArgs) (op Operation, err error)
 
@@ -156,6 +162,7 @@ type InstanceServer interface {
 	UpdateInstanceState(name string, state api.InstanceStatePut, ETag string) (op Operation, err error)
 
 	GetInstanceAccess(name string) (access api.Access, err error)
+	GetInstanceAttestation(name string, pcrs []int) (attestation *api.InstanceAttestation, err error)
 
 	GetInstanceLogfiles(name string) (logfiles []string, err error)
 	GetInstanceLogfile(name string, filename string) (content io.ReadCloser, err error)
@@ -176,6 +183,10 @@ type InstanceServer interface {
 	GetEventsAllProjects() (listener *EventListener, err error)
 	SendEvent(event api.Event) error
 
+	// Change tracking functions
+	GetChanges(since uint64) (changes *api.Changes, err error)
+	GetChangesAllProjects(since uint64) (changes *api.Changes, err error)
+
 	// Image functions
 	CreateImage(image api.ImagesPost, args *ImageCreateArgs) (op Operation, err error)
 	CopyImage(source ImageServer, image api.Image, args *ImageCopyArgs) (op RemoteOperation, err error)
@@ -236,6 +247,7 @@ type InstanceServer interface {
 	GetNetworkACLsAllProjects() (acls []api.NetworkACL, err error)
 	GetNetworkACL(name string) (acl *api.NetworkACL, ETag string, err error)
 	GetNetworkACLLogfile(name string) (log io.ReadCloser, err error)
+	GetNetworkACLState(name string) (state *api.NetworkACLState, err error)
 	CreateNetworkACL(acl api.NetworkACLsPost) (err error)
 	UpdateNetworkACL(name string, acl api.NetworkACLPut, ETag string) (err error)
 	RenameNetworkACL(name string, acl api.NetworkACLPost) (err error)
@@ -341,6 +353,7 @@ type InstanceServer interface {
 	// Storage bucket backup functions ("storage_bucket_backup" API extension)
 	CreateStoragePoolBucketBackup(poolName string, bucketName string, backup api.StorageBucketBackupsPost) (op Operation, err error)
 	DeleteStoragePoolBucketBackup(pool string, bucketName string, name string) (op Operation, err error)
+	VerifyStoragePoolBucketBackup(pool string, bucketName string, name string, req api.BackupVerifyPost) (op Operation, err error)
 	GetStoragePoolBucketBackupFile(pool string, bucketName string, name string, req *BackupFileRequest) (resp *BackupFileResponse, err error)
 	CreateStoragePoolBucketFromBackup(pool string, args StoragePoolBucketBackupArgs) (op Operation, err error)
 
@@ -377,6 +390,7 @@ type InstanceServer interface {
 	CreateStorageVolumeBackup(pool string, volName string, backup api.StorageVolumeBackupsPost) (op Operation, err error)
 	RenameStorageVolumeBackup(pool string, volName string, name string, backup api.StorageVolumeBackupPost) (op Operation, err error)
 	DeleteStorageVolumeBackup(pool string, volName string, name string) (op Operation, err error)
+	VerifyStorageVolumeBackup(pool string, volName string, name string, req api.BackupVerifyPost) (op Operation, err error)
 	GetStorageVolumeBackupFile(pool string, volName string, name string, req *BackupFileRequest) (resp *BackupFileResponse, err error)
 	CreateStoragePoolVolumeFromBackup(pool string, args StorageVolumeBackupArgs) (op Operation, err error)
 
@@ -403,6 +417,11 @@ type InstanceServer interface {
 	DeleteClusterGroup(name string) error
 	UpdateClusterGroup(name string, group api.ClusterGroupPut, ETag string) error
 	GetClusterGroup(name string) (*api.ClusterGroup, string, error)
+	GetClusterEnrollments() ([]api.ClusterEnrollment, error)
+	GetClusterEnrollment(id string) (*api.ClusterEnrollment, error)
+	CreateClusterEnrollment(enrollment api.ClusterEnrollmentsPost) (*api.ClusterEnrollment, error)
+	ApproveClusterEnrollment(id string) error
+	DeleteClusterEnrollment(id string) error
 
 	// Warning functions
 	GetWarningUUIDs() (uuids []string, err error)
@@ -419,13 +438,14 @@ type InstanceServer interface {
 
 // The ConnectionInfo struct represents general information for a connection.
 type ConnectionInfo struct {
-	Addresses   []string
-	Certificate string
-	Protocol    string
-	URL         string
-	SocketPath  string
-	Project     string
-	Target      string
+	Addresses    []string
+	Certificate  string
+	Protocol     string
+	URL          string
+	SocketPath   string
+	Project      string
+	Target       string
+	RegistryAuth *api.ImageRegistryAuth
 }
 
 // The BackupFileRequest struct is used for a backup download request.
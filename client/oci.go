@@ -3,6 +3,8 @@ package incus
 import (
 	"fmt"
 	"net/http"
+
+	"github.com/lxc/incus/v6/shared/api"
 )
 
 // ProtocolOCI implements an OCI registry API client.
@@ -12,6 +14,9 @@ type ProtocolOCI struct {
 	httpUserAgent   string
 	httpCertificate string
 
+	// Registry credentials and settings.
+	registryAuth *api.ImageRegistryAuth
+
 	// Cache for images.
 	cache map[string]ociInfo
 }
@@ -27,6 +32,7 @@ func (r *ProtocolOCI) GetConnectionInfo() (*ConnectionInfo, error) {
 	info.Certificate = r.httpCertificate
 	info.Protocol = "oci"
 	info.URL = r.httpHost
+	info.RegistryAuth = r.registryAuth
 
 	return &info, nil
 }
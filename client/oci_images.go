@@ -3,6 +3,7 @@ package incus
 import (
 	"compress/gzip"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -48,6 +49,111 @@ func (r *ProtocolOCI) getProxyHost() (*url.URL, error) {
 	return proxy, nil
 }
 
+// Parse a registry or mirror address, which may omit its scheme.
+func parseRegistryAddress(address string) (*url.URL, error) {
+	if !strings.Contains(address, "://") {
+		address = "https://" + address
+	}
+
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("Registry address must be a host with an optional http or https scheme and path")
+	}
+
+	return u, nil
+}
+
+// Get the image references to try for an image, mirrors first.
+func (r *ProtocolOCI) getImageReferences(name string) ([]string, error) {
+	hosts := []string{}
+	if r.registryAuth != nil {
+		hosts = append(hosts, r.registryAuth.Mirrors...)
+	}
+
+	hosts = append(hosts, r.httpHost)
+
+	refs := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		hostURL, err := parseRegistryAddress(host)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid registry address %q: %w", host, err)
+		}
+
+		refs = append(refs, fmt.Sprintf("docker://%s%s/%s", hostURL.Host, strings.TrimSuffix(hostURL.Path, "/"), name))
+	}
+
+	return refs, nil
+}
+
+// Get the skopeo arguments needed to access the registry.
+// Credentials and certificates are written to the provided private directory. The prefix
+// selects the variant of the arguments to use ("src-" when copying from the registry).
+func (r *ProtocolOCI) getRegistryArgs(path string, prefix string) ([]string, error) {
+	if r.registryAuth == nil {
+		return nil, nil
+	}
+
+	args := []string{}
+
+	// Credentials.
+	authFile := r.registryAuth.AuthFile
+	if authFile == "" && r.registryAuth.Username != "" {
+		auth := base64.StdEncoding.EncodeToString([]byte(r.registryAuth.Username + ":" + r.registryAuth.Password))
+
+		auths := map[string]map[string]string{}
+		for _, host := range append([]string{r.httpHost}, r.registryAuth.Mirrors...) {
+			hostURL, err := parseRegistryAddress(host)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid registry address %q: %w", host, err)
+			}
+
+			auths[hostURL.Host] = map[string]string{"auth": auth}
+		}
+
+		data, err := json.Marshal(map[string]any{"auths": auths})
+		if err != nil {
+			return nil, err
+		}
+
+		authFile = string(data)
+	}
+
+	if authFile != "" {
+		err := os.WriteFile(filepath.Join(path, "auth.json"), []byte(authFile), 0o600)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, fmt.Sprintf("--%sauthfile", prefix), filepath.Join(path, "auth.json"))
+	}
+
+	// Registry CA.
+	if r.registryAuth.CACertificate != "" {
+		err := os.Mkdir(filepath.Join(path, "certs"), 0o700)
+		if err != nil {
+			return nil, err
+		}
+
+		err = os.WriteFile(filepath.Join(path, "certs", "ca.crt"), []byte(r.registryAuth.CACertificate), 0o600)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, fmt.Sprintf("--%scert-dir", prefix), filepath.Join(path, "certs"))
+	}
+
+	// Insecure registries.
+	if r.registryAuth.Insecure {
+		args = append(args, fmt.Sprintf("--%stls-verify=false", prefix))
+	}
+
+	return args, nil
+}
+
 // Image handling functions
 
 // GetImages returns a list of available images as Image structs.
@@ -183,18 +289,30 @@ func (r *ProtocolOCI) GetImageFile(fingerprint string, req ImageFileRequest) (*I
 		req.ProgressHandler(ioprogress.ProgressData{Text: "Retrieving OCI image from registry"})
 	}
 
-	stdout, _, err := subprocess.RunCommandSplit(
-		ctx,
-		env,
-		nil,
-		"skopeo",
-		"--insecure-policy",
-		"copy",
-		"--remove-signatures",
-		fmt.Sprintf("%s/%s", strings.Replace(r.httpHost, "https://", "docker://", 1), info.Alias),
-		fmt.Sprintf("oci:%s:latest", filepath.Join(ociPath, "oci")))
+	registryArgs, err := r.getRegistryArgs(ociPath, "src-")
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := r.getImageReferences(info.Alias)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout string
+	for _, ref := range refs {
+		args := append([]string{"--insecure-policy", "copy", "--remove-signatures"}, registryArgs...)
+		args = append(args, ref, fmt.Sprintf("oci:%s:latest", filepath.Join(ociPath, "oci")))
+
+		stdout, _, err = subprocess.RunCommandSplit(ctx, env, nil, "skopeo", args...)
+		if err == nil {
+			break
+		}
+
+		logger.Debug("Error copying remote image to local", logger.Ctx{"image": ref, "stdout": stdout, "stderr": err})
+	}
+
 	if err != nil {
-		logger.Debug("Error copying remote image to local", logger.Ctx{"image": info.Alias, "stdout": stdout, "stderr": err})
 		return nil, err
 	}
 
@@ -360,16 +478,42 @@ func (r *ProtocolOCI) GetImageAlias(name string) (*api.ImageAliasesEntry, string
 		}
 	}
 
+	// Get the registry credentials.
+	var registryArgs []string
+	if r.registryAuth != nil {
+		authPath, err := os.MkdirTemp("", "incus-oci-")
+		if err != nil {
+			return nil, "", err
+		}
+
+		defer func() { _ = os.RemoveAll(authPath) }()
+
+		registryArgs, err = r.getRegistryArgs(authPath, "")
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
 	// Get the image information from skopeo.
-	stdout, _, err := subprocess.RunCommandSplit(
-		context.TODO(),
-		env,
-		nil,
-		"skopeo",
-		"inspect",
-		fmt.Sprintf("%s/%s", strings.Replace(r.httpHost, "https://", "docker://", 1), name))
+	refs, err := r.getImageReferences(name)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var stdout string
+	for _, ref := range refs {
+		args := append([]string{"inspect"}, registryArgs...)
+		args = append(args, ref)
+
+		stdout, _, err = subprocess.RunCommandSplit(context.TODO(), env, nil, "skopeo", args...)
+		if err == nil {
+			break
+		}
+
+		logger.Debug("Error getting image alias", logger.Ctx{"name": ref, "stdout": stdout, "stderr": err})
+	}
+
 	if err != nil {
-		logger.Debug("Error getting image alias", logger.Ctx{"name": name, "stdout": stdout, "stderr": err})
 		return nil, "", err
 	}
 
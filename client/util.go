@@ -2,7 +2,9 @@ package incus
 
 import (
 	"context"
+	"crypto"
 	"crypto/tls"
+	"encoding/pem"
 	"fmt"
 	"net"
 	"net/http"
@@ -15,16 +17,32 @@ import (
 )
 
 // tlsHTTPClient creates an HTTP client with a specified Transport Layer Security (TLS) configuration.
-// It takes in parameters for client certificates, keys, Certificate Authority, server certificates,
+// It takes in parameters for client certificates, keys (or a signer holding the key), Certificate Authority, server certificates,
 // a boolean for skipping verification, a proxy function, and a transport wrapper function.
 // It returns the HTTP client with the provided configurations and handles any errors that might occur during the setup process.
-func tlsHTTPClient(client *http.Client, tlsClientCert string, tlsClientKey string, tlsCA string, tlsServerCert string, insecureSkipVerify bool, proxyFunc func(req *http.Request) (*url.URL, error), transportWrapper func(t *http.Transport) HTTPTransporter) (*http.Client, error) {
+func tlsHTTPClient(client *http.Client, tlsClientCert string, tlsClientKey string, tlsClientSigner crypto.Signer, tlsCA string, tlsServerCert string, insecureSkipVerify bool, proxyFunc func(req *http.Request) (*url.URL, error), transportWrapper func(t *http.Transport) HTTPTransporter) (*http.Client, error) {
 	// Get the TLS configuration
 	tlsConfig, err := localtls.GetTLSConfigMem(tlsClientCert, tlsClientKey, tlsCA, tlsServerCert, insecureSkipVerify)
 	if err != nil {
 		return nil, err
 	}
 
+	// Use the signer for client authentication when the key isn't directly available.
+	if tlsClientSigner != nil && tlsClientCert != "" && tlsClientKey == "" {
+		certBlock, _ := pem.Decode([]byte(tlsClientCert))
+		if certBlock == nil {
+			return nil, fmt.Errorf("Invalid client certificate")
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{{
+			Certificate: [][]byte{certBlock.Bytes},
+			PrivateKey:  tlsClientSigner,
+		}}
+
+		// Resume TLS sessions so that the signer (possibly a slow hardware token) isn't needed for every connection.
+		tlsConfig.ClientSessionCache = tls.NewLRUClientSessionCache(0)
+	}
+
 	// Define the http transport
 	transport := &http.Transport{
 		TLSClientConfig:       tlsConfig,
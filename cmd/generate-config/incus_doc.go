@@ -280,6 +280,9 @@ func writeDocFile(inputJSONPath, outputTxtPath string) error {
 
 	specialChars := []string{"", "*", "_", "#", "+", "-", ".", "!", "no", "yes"}
 
+	// Fields only used by API clients and not rendered in the documentation.
+	jsonOnlyFields := []string{"allowedvalues", "deprecated"}
+
 	// read the JSON file which is the source of truth for the generation of the .txt file
 	jsonData, err := os.ReadFile(inputJSONPath)
 	if err != nil {
@@ -311,6 +314,10 @@ func writeDocFile(inputJSONPath, outputTxtPath string) error {
 					sortedConfigContentKeys := getSortedKeysFromMap(configContent.(map[string]any))
 					for _, configEntryContentKey := range sortedConfigContentKeys {
 						configContentValue := configContent.(map[string]any)[configEntryContentKey]
+						if slices.Contains(jsonOnlyFields, configEntryContentKey) {
+							continue
+						}
+
 						if configEntryContentKey == "longdesc" {
 							backticksCount = countMaxBackTicks(configContentValue.(string))
 							longDescContent = configContentValue.(string)
@@ -38,6 +38,8 @@ var api10 = []APIEndpoint{
 	operationWait,
 	sftpCmd,
 	stateCmd,
+	tpmCmd,
+	tpmQuoteCmd,
 }
 
 func api10Get(d *Daemon, r *http.Request) response.Response {
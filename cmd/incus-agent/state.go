@@ -44,6 +44,7 @@ func renderState() *api.InstanceState {
 		Pid:       1,
 		Processes: processesState(),
 		OSInfo:    osState(),
+		CloudInit: linux.CloudInitStatus("/"),
 	}
 }
 
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/lxc/incus/v6/internal/server/response"
+	agentAPI "github.com/lxc/incus/v6/shared/api/agent"
+	"github.com/lxc/incus/v6/shared/subprocess"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// tpmEKCertificateIndex is the NV index holding the RSA endorsement key certificate.
+const tpmEKCertificateIndex = "0x01c00002"
+
+// tpmEventLogPath is the path to the firmware event log exposed by the kernel.
+const tpmEventLogPath = "/sys/kernel/security/tpm0/binary_bios_measurements"
+
+// tpmPath is the directory holding the TPM contexts used for attestation.
+const tpmPath = "tpm"
+
+// tpmLock serializes the use of the attestation key.
+var tpmLock sync.Mutex
+
+var tpmCmd = APIEndpoint{
+	Name: "tpm",
+	Path: "tpm",
+
+	Get: APIEndpointAction{Handler: tpmGet},
+}
+
+var tpmQuoteCmd = APIEndpoint{
+	Name: "tpmQuote",
+	Path: "tpm/quote",
+
+	Post: APIEndpointAction{Handler: tpmQuotePost},
+}
+
+// tpmGet creates a new attestation key and returns it along with the endorsement key certificate.
+func tpmGet(d *Daemon, r *http.Request) response.Response {
+	tpmLock.Lock()
+	defer tpmLock.Unlock()
+
+	if !util.PathExists("/dev/tpmrm0") {
+		return response.NotFound(errors.New("No TPM resource manager available"))
+	}
+
+	err := os.MkdirAll(tpmPath, 0o700)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Create the endorsement and attestation keys.
+	_, err = subprocess.RunCommand("tpm2_createek", "-c", filepath.Join(tpmPath, "ek.ctx"), "-G", "rsa", "-u", filepath.Join(tpmPath, "ek.pub"))
+	if err != nil {
+		return response.InternalError(fmt.Errorf("Failed to create endorsement key: %w", err))
+	}
+
+	_, err = subprocess.RunCommand("tpm2_createak", "-C", filepath.Join(tpmPath, "ek.ctx"), "-c", filepath.Join(tpmPath, "ak.ctx"), "-G", "rsa", "-g", "sha256", "-s", "rsassa", "-u", filepath.Join(tpmPath, "ak.pub"))
+	if err != nil {
+		return response.InternalError(fmt.Errorf("Failed to create attestation key: %w", err))
+	}
+
+	akPublic, err := os.ReadFile(filepath.Join(tpmPath, "ak.pub"))
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Retrieve the endorsement key certificate.
+	certPath := filepath.Join(tpmPath, "ek.crt")
+	_, err = subprocess.RunCommand("tpm2_nvread", "-C", "o", "-o", certPath, tpmEKCertificateIndex)
+	if err != nil {
+		return response.InternalError(fmt.Errorf("Failed to read endorsement key certificate: %w", err))
+	}
+
+	cert, err := os.ReadFile(certPath)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// The NV index may be larger than the certificate.
+	var raw asn1.RawValue
+	_, err = asn1.Unmarshal(cert, &raw)
+	if err != nil {
+		return response.InternalError(fmt.Errorf("Failed to parse endorsement key certificate: %w", err))
+	}
+
+	return response.SyncResponse(true, agentAPI.TPM{
+		EKCertificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: raw.FullBytes})),
+		AKPublic:      akPublic,
+	})
+}
+
+// tpmQuotePost activates the provided credential and returns a quote of the requested PCRs.
+func tpmQuotePost(d *Daemon, r *http.Request) response.Response {
+	tpmLock.Lock()
+	defer tpmLock.Unlock()
+
+	req := agentAPI.TPMQuotePost{}
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if len(req.PCRs) == 0 {
+		return response.BadRequest(errors.New("No PCRs requested"))
+	}
+
+	pcrs := slices.Clone(req.PCRs)
+	slices.Sort(pcrs)
+	pcrs = slices.Compact(pcrs)
+
+	pcrList := make([]string, 0, len(pcrs))
+	for _, pcr := range pcrs {
+		if pcr < 0 || pcr > 23 {
+			return response.BadRequest(fmt.Errorf("Invalid PCR index %d", pcr))
+		}
+
+		pcrList = append(pcrList, strconv.Itoa(pcr))
+	}
+
+	akPath := filepath.Join(tpmPath, "ak.ctx")
+	if !util.PathExists(akPath) {
+		return response.BadRequest(errors.New("No attestation key available"))
+	}
+
+	// Recover the secret, proving that the attestation key lives in the same TPM as the endorsement key.
+	credPath := filepath.Join(tpmPath, "cred.in")
+	err = os.WriteFile(credPath, req.Credential, 0o600)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	sessionPath := filepath.Join(tpmPath, "session.ctx")
+	_, err = subprocess.RunCommand("tpm2_startauthsession", "--policy-session", "-S", sessionPath)
+	if err != nil {
+		return response.InternalError(fmt.Errorf("Failed to start policy session: %w", err))
+	}
+
+	defer func() { _, _ = subprocess.RunCommand("tpm2_flushcontext", sessionPath) }()
+
+	_, err = subprocess.RunCommand("tpm2_policysecret", "-S", sessionPath, "-c", "e")
+	if err != nil {
+		return response.InternalError(fmt.Errorf("Failed to satisfy endorsement policy: %w", err))
+	}
+
+	secretPath := filepath.Join(tpmPath, "secret.out")
+	_, err = subprocess.RunCommand("tpm2_activatecredential", "-c", akPath, "-C", filepath.Join(tpmPath, "ek.ctx"), "-i", credPath, "-o", secretPath, "-P", fmt.Sprintf("session:%s", sessionPath))
+	if err != nil {
+		return response.InternalError(fmt.Errorf("Failed to activate credential: %w", err))
+	}
+
+	secret, err := os.ReadFile(secretPath)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Quote the PCRs.
+	quotePath := filepath.Join(tpmPath, "quote.msg")
+	signaturePath := filepath.Join(tpmPath, "quote.sig")
+	pcrsPath := filepath.Join(tpmPath, "quote.pcrs")
+
+	_, err = subprocess.RunCommand("tpm2_quote", "-c", akPath, "-l", fmt.Sprintf("sha256:%s", strings.Join(pcrList, ",")), "-q", hex.EncodeToString(req.Nonce), "-g", "sha256", "-m", quotePath, "-s", signaturePath, "-f", "plain", "-o", pcrsPath)
+	if err != nil {
+		return response.InternalError(fmt.Errorf("Failed to quote PCRs: %w", err))
+	}
+
+	quote, err := os.ReadFile(quotePath)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	signature, err := os.ReadFile(signaturePath)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	values, err := os.ReadFile(pcrsPath)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if len(values) != len(pcrs)*sha256.Size {
+		return response.InternalError(fmt.Errorf("Unexpected PCR values size %d", len(values)))
+	}
+
+	resp := agentAPI.TPMQuote{
+		Secret:    secret,
+		Quote:     quote,
+		Signature: signature,
+		PCRs:      map[int][]byte{},
+	}
+
+	for i, pcr := range pcrs {
+		resp.PCRs[pcr] = values[i*sha256.Size : (i+1)*sha256.Size]
+	}
+
+	// The event log is optional as the kernel only exposes it when securityfs is mounted.
+	resp.EventLog, err = os.ReadFile(tpmEventLogPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, resp)
+}
@@ -36,6 +36,11 @@ type cmdAdminInit struct {
 	flagStorageLoopSize int
 	flagStoragePool     string
 
+	flagEnroll            string
+	flagEnrollFingerprint string
+	flagEnrollGroup       string
+	flagEnrollSecret      string
+
 	hostname string
 }
 
@@ -49,6 +54,8 @@ func (c *cmdAdminInit) Command() *cobra.Command {
   init --auto [--network-address=IP] [--network-port=8443] [--storage-backend=dir]
               [--storage-create-device=DEVICE] [--storage-create-loop=SIZE]
               [--storage-pool=POOL]
+  init --auto --network-address=IP --enroll=URL --enroll-group=GROUP
+              [--enroll-secret=SECRET] [--enroll-fingerprint=FINGERPRINT]
   init --preseed [preseed.yaml]
   init --dump
 `
@@ -64,6 +71,10 @@ func (c *cmdAdminInit) Command() *cobra.Command {
 	cmd.Flags().StringVar(&c.flagStorageDevice, "storage-create-device", "", i18n.G("Setup device based storage using DEVICE")+"``")
 	cmd.Flags().IntVar(&c.flagStorageLoopSize, "storage-create-loop", -1, i18n.G("Setup loop based storage with SIZE in GiB")+"``")
 	cmd.Flags().StringVar(&c.flagStoragePool, "storage-pool", "", i18n.G("Storage pool to use or create")+"``")
+	cmd.Flags().StringVar(&c.flagEnroll, "enroll", "", i18n.G("Enroll into the cluster at URL")+"``")
+	cmd.Flags().StringVar(&c.flagEnrollFingerprint, "enroll-fingerprint", "", i18n.G("Expected fingerprint of the cluster certificate")+"``")
+	cmd.Flags().StringVar(&c.flagEnrollGroup, "enroll-group", "", i18n.G("Cluster group to enroll into")+"``")
+	cmd.Flags().StringVar(&c.flagEnrollSecret, "enroll-secret", "", i18n.G("Enrollment secret of the cluster group")+"``")
 
 	return cmd
 }
@@ -89,6 +100,14 @@ func (c *cmdAdminInit) Run(cmd *cobra.Command, args []string) error {
 		return errors.New(i18n.G("Configuration flags require --auto"))
 	}
 
+	if c.flagEnroll == "" && (c.flagEnrollFingerprint != "" || c.flagEnrollGroup != "" || c.flagEnrollSecret != "") {
+		return errors.New(i18n.G("Enrollment flags require --enroll"))
+	}
+
+	if c.flagEnroll != "" && !c.flagAuto {
+		return errors.New(i18n.G("--enroll requires --auto"))
+	}
+
 	if c.flagDump && (c.flagAuto || c.flagMinimal ||
 		c.flagPreseed || c.flagNetworkAddress != "" ||
 		c.flagNetworkPort != -1 || c.flagStorageBackend != "" ||
@@ -137,6 +156,14 @@ func (c *cmdAdminInit) Run(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Enrollment mode
+	if c.flagEnroll != "" {
+		err = c.RunEnroll(config)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Interactive mode
 	if !c.flagAuto && !c.flagMinimal && !c.flagPreseed {
 		config, err = c.RunInteractive(cmd, d, server)
@@ -0,0 +1,113 @@
+//go:build linux
+
+package main
+
+import (
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	incus "github.com/lxc/incus/v6/client"
+	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/internal/ports"
+	internalUtil "github.com/lxc/incus/v6/internal/util"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+	localtls "github.com/lxc/incus/v6/shared/tls"
+)
+
+// RunEnroll requests to join an existing cluster and waits for the enrollment to be approved.
+// The local server certificate is used to authenticate the enrollment.
+func (c *cmdAdminInit) RunEnroll(config *api.InitPreseed) error {
+	// Quick checks.
+	if c.flagNetworkAddress == "" {
+		return errors.New(i18n.G("--enroll requires --network-address"))
+	}
+
+	if c.flagEnrollGroup == "" {
+		return errors.New(i18n.G("--enroll requires --enroll-group"))
+	}
+
+	serverURL := c.flagEnroll
+	if !strings.HasPrefix(serverURL, "https://") {
+		serverURL = fmt.Sprintf("https://%s", internalUtil.CanonicalNetworkAddress(serverURL, ports.HTTPSDefaultPort))
+	}
+
+	// Use the server certificate as the client certificate.
+	serverCert, err := os.ReadFile(internalUtil.VarPath("server.crt"))
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed to read the server certificate: %w"), err)
+	}
+
+	serverKey, err := os.ReadFile(internalUtil.VarPath("server.key"))
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed to read the server key: %w"), err)
+	}
+
+	args := &incus.ConnectionArgs{
+		TLSClientCert: string(serverCert),
+		TLSClientKey:  string(serverKey),
+		UserAgent:     version.UserAgent,
+	}
+
+	// Pin the cluster certificate if a fingerprint was provided, otherwise rely on the system CA.
+	if c.flagEnrollFingerprint != "" {
+		cert, err := localtls.GetRemoteCertificate(serverURL, version.UserAgent)
+		if err != nil {
+			return fmt.Errorf(i18n.G("Failed to get the cluster certificate: %w"), err)
+		}
+
+		if localtls.CertFingerprint(cert) != strings.ToLower(c.flagEnrollFingerprint) {
+			return errors.New(i18n.G("Certificate fingerprint mismatch with the cluster"))
+		}
+
+		args.TLSServerCert = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+	}
+
+	cluster, err := incus.ConnectIncus(serverURL, args)
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed to connect to the cluster: %w"), err)
+	}
+
+	enrollment, err := cluster.CreateClusterEnrollment(api.ClusterEnrollmentsPost{
+		ServerName: c.defaultHostname(),
+		Secret:     c.flagEnrollSecret,
+		Group:      c.flagEnrollGroup,
+	})
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed to enroll into the cluster: %w"), err)
+	}
+
+	// Wait for the enrollment to be approved.
+	if enrollment.Status != api.ClusterEnrollmentStatusApproved {
+		fmt.Printf(i18n.G("Waiting for enrollment %s to be approved")+"\n", enrollment.ID)
+	}
+
+	for enrollment.Status != api.ClusterEnrollmentStatusApproved {
+		time.Sleep(5 * time.Second)
+
+		enrollment, err = cluster.GetClusterEnrollment(enrollment.ID)
+		if err != nil {
+			if api.StatusErrorCheck(err, http.StatusNotFound) {
+				return errors.New(i18n.G("The enrollment was rejected or has expired"))
+			}
+
+			return fmt.Errorf(i18n.G("Failed to get the enrollment status: %w"), err)
+		}
+	}
+
+	config.Cluster = &api.InitClusterPreseed{}
+	config.Cluster.Enabled = true
+	config.Cluster.ServerAddress = internalUtil.CanonicalNetworkAddressFromAddressAndPort(c.flagNetworkAddress, c.flagNetworkPort, ports.HTTPSDefaultPort)
+	config.Cluster.ClusterToken = enrollment.JoinToken
+
+	// The cluster checks that the member configuration of the enrollment policy is applied
+	// and adds the new member to the enrollment group when accepting it.
+	config.Cluster.MemberConfig = enrollment.MemberConfig
+
+	return nil
+}
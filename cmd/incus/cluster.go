@@ -101,6 +101,9 @@ func (c *cmdCluster) Command() *cobra.Command {
 	cmdClusterRestore := cmdClusterRestore{global: c.global, cluster: c}
 	cmd.AddCommand(cmdClusterRestore.Command())
 
+	clusterEnrollmentCmd := cmdClusterEnrollment{global: c.global, cluster: c}
+	cmd.AddCommand(clusterEnrollmentCmd.Command())
+
 	clusterGroupCmd := cmdClusterGroup{global: c.global, cluster: c}
 	cmd.AddCommand(clusterGroupCmd.Command())
 
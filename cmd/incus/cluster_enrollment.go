@@ -0,0 +1,297 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+type cmdClusterEnrollment struct {
+	global  *cmdGlobal
+	cluster *cmdCluster
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdClusterEnrollment) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("enrollment")
+	cmd.Short = i18n.G("Manage cluster enrollments")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Manage cluster enrollments
+
+Enrollments are requests from new servers to join the cluster,
+matching the enrollment policy of a cluster group.`))
+
+	// Approve
+	clusterEnrollmentApproveCmd := cmdClusterEnrollmentApprove{global: c.global, cluster: c.cluster}
+	cmd.AddCommand(clusterEnrollmentApproveCmd.Command())
+
+	// List
+	clusterEnrollmentListCmd := cmdClusterEnrollmentList{global: c.global, cluster: c.cluster}
+	cmd.AddCommand(clusterEnrollmentListCmd.Command())
+
+	// Reject
+	clusterEnrollmentRejectCmd := cmdClusterEnrollmentReject{global: c.global, cluster: c.cluster}
+	cmd.AddCommand(clusterEnrollmentRejectCmd.Command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, _ []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// List.
+type cmdClusterEnrollmentList struct {
+	global  *cmdGlobal
+	cluster *cmdCluster
+
+	flagFormat  string
+	flagColumns string
+}
+
+type clusterEnrollmentColumn struct {
+	Name string
+	Data func(api.ClusterEnrollment) string
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdClusterEnrollmentList) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("list", i18n.G("[<remote>:]"))
+	cmd.Aliases = []string{"ls"}
+	cmd.Short = i18n.G("List pending and approved cluster enrollments")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`List pending and approved cluster enrollments
+
+Default column layout: ingasE
+
+== Columns ==
+The -c option takes a comma separated list of arguments that control
+which enrollment attributes to output when displaying in table or csv
+format.
+
+Commas between consecutive shorthand chars are optional.
+
+Pre-defined column shorthand chars:
+  i - ID
+  n - Server name
+  g - Cluster group
+  a - Address
+  f - Certificate fingerprint
+  s - Status
+  E - Expires At`))
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", i18n.G(`Format (csv|json|table|yaml|compact), use suffix ",noheader" to disable headers and ",header" to enable if demanded, e.g. csv,header`)+"``")
+	cmd.Flags().StringVarP(&c.flagColumns, "columns", "c", defaultClusterEnrollmentColumns, i18n.G("Columns")+"``")
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpRemotes(toComplete, false)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+const defaultClusterEnrollmentColumns = "ingasE"
+
+func (c *cmdClusterEnrollmentList) parseColumns() ([]clusterEnrollmentColumn, error) {
+	columnsShorthandMap := map[rune]clusterEnrollmentColumn{
+		'i': {i18n.G("ID"), func(e api.ClusterEnrollment) string { return e.ID }},
+		'n': {i18n.G("NAME"), func(e api.ClusterEnrollment) string { return e.ServerName }},
+		'g': {i18n.G("GROUP"), func(e api.ClusterEnrollment) string { return e.Group }},
+		'a': {i18n.G("ADDRESS"), func(e api.ClusterEnrollment) string { return e.Address }},
+		'f': {i18n.G("FINGERPRINT"), func(e api.ClusterEnrollment) string { return e.Fingerprint }},
+		's': {i18n.G("STATUS"), func(e api.ClusterEnrollment) string { return strings.ToUpper(e.Status) }},
+		'E': {i18n.G("EXPIRES AT"), func(e api.ClusterEnrollment) string { return e.ExpiresAt.Local().Format(dateLayout) }},
+	}
+
+	columnList := strings.Split(c.flagColumns, ",")
+	columns := []clusterEnrollmentColumn{}
+
+	for _, columnEntry := range columnList {
+		if columnEntry == "" {
+			return nil, fmt.Errorf(i18n.G("Empty column entry (redundant, leading or trailing command) in '%s'"), c.flagColumns)
+		}
+
+		for _, columnRune := range columnEntry {
+			column, ok := columnsShorthandMap[columnRune]
+			if !ok {
+				return nil, fmt.Errorf(i18n.G("Unknown column shorthand char '%c' in '%s'"), columnRune, columnEntry)
+			}
+
+			columns = append(columns, column)
+		}
+	}
+
+	return columns, nil
+}
+
+// Run runs the actual command logic.
+func (c *cmdClusterEnrollmentList) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 0, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	remote := ""
+	if len(args) == 1 {
+		remote = args[0]
+	}
+
+	resources, err := c.global.parseServers(remote)
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	// Parse column flags.
+	columns, err := c.parseColumns()
+	if err != nil {
+		return err
+	}
+
+	enrollments, err := resource.server.GetClusterEnrollments()
+	if err != nil {
+		return err
+	}
+
+	data := [][]string{}
+	for _, enrollment := range enrollments {
+		line := []string{}
+		for _, column := range columns {
+			line = append(line, column.Data(enrollment))
+		}
+
+		data = append(data, line)
+	}
+
+	sort.Sort(cli.SortColumnsNaturally(data))
+
+	header := []string{}
+	for _, column := range columns {
+		header = append(header, column.Name)
+	}
+
+	return cli.RenderTable(os.Stdout, c.flagFormat, header, data, enrollments)
+}
+
+// Approve.
+type cmdClusterEnrollmentApprove struct {
+	global  *cmdGlobal
+	cluster *cmdCluster
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdClusterEnrollmentApprove) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("approve", i18n.G("[<remote>:]<id>"))
+	cmd.Short = i18n.G("Approve a pending cluster enrollment")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Approve a pending cluster enrollment
+
+The enrolling server will then receive a join token and join the cluster.`))
+
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdClusterEnrollmentApprove) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing enrollment ID"))
+	}
+
+	err = resource.server.ApproveClusterEnrollment(resource.name)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Cluster enrollment %s approved")+"\n", resource.name)
+	}
+
+	return nil
+}
+
+// Reject.
+type cmdClusterEnrollmentReject struct {
+	global  *cmdGlobal
+	cluster *cmdCluster
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdClusterEnrollmentReject) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("reject", i18n.G("[<remote>:]<id>"))
+	cmd.Aliases = []string{"rm", "delete"}
+	cmd.Short = i18n.G("Reject a cluster enrollment")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Reject a cluster enrollment
+
+If the enrollment was already approved, its join token is revoked.`))
+
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdClusterEnrollmentReject) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing enrollment ID"))
+	}
+
+	err = resource.server.DeleteClusterEnrollment(resource.name)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Cluster enrollment %s rejected")+"\n", resource.name)
+	}
+
+	return nil
+}
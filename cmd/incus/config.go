@@ -194,6 +194,11 @@ func (c *cmdConfigEdit) Run(cmd *cobra.Command, args []string) error {
 					return err
 				}
 
+				err = configIssuesError(newConfigValidator(resource.server, "instance", "kernel").Validate(newdata.Config))
+				if err != nil {
+					return err
+				}
+
 				op, err = resource.server.UpdateInstance(resource.name, newdata, "")
 				if err != nil {
 					return err
@@ -247,6 +252,11 @@ func (c *cmdConfigEdit) Run(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
+		var validator *configValidator
+		if !isSnapshot {
+			validator = newConfigValidator(resource.server, "instance", "kernel")
+		}
+
 		for {
 			// Parse the text received from the editor
 			if isSnapshot {
@@ -263,6 +273,14 @@ func (c *cmdConfigEdit) Run(cmd *cobra.Command, args []string) error {
 			} else {
 				newdata := api.InstancePut{}
 				err = yaml.Unmarshal(content, &newdata)
+				if err == nil {
+					issues := validator.Validate(newdata.Config)
+					err = configIssuesError(issues)
+					if err != nil {
+						content = configIssuesAnnotate(content, issues)
+					}
+				}
+
 				if err == nil {
 					var op incus.Operation
 					op, err = resource.server.UpdateInstance(resource.name, newdata, etag)
@@ -318,6 +336,11 @@ func (c *cmdConfigEdit) Run(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
+		err = configIssuesError(newConfigValidator(resource.server, "server").Validate(newdata.Config))
+		if err != nil {
+			return err
+		}
+
 		return resource.server.UpdateServer(newdata, "")
 	}
 
@@ -339,10 +362,20 @@ func (c *cmdConfigEdit) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	validator := newConfigValidator(resource.server, "server")
+
 	for {
 		// Parse the text received from the editor
 		newdata := api.ServerPut{}
 		err = yaml.Unmarshal(content, &newdata)
+		if err == nil {
+			issues := validator.Validate(newdata.Config)
+			err = configIssuesError(issues)
+			if err != nil {
+				content = configIssuesAnnotate(content, issues)
+			}
+		}
+
 		if err == nil {
 			err = resource.server.UpdateServer(newdata, etag)
 		}
@@ -689,6 +722,13 @@ func (c *cmdConfigSet) Run(cmd *cobra.Command, args []string) error {
 			}
 		}
 
+		if cmd.Name() != "unset" && !c.flagIsProperty {
+			err = configIssuesError(newConfigValidator(resource.server, "instance", "kernel").Validate(keys))
+			if err != nil {
+				return err
+			}
+		}
+
 		op, err := resource.server.UpdateInstance(resource.name, writable, etag)
 		if err != nil {
 			return err
@@ -725,6 +765,13 @@ func (c *cmdConfigSet) Run(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if cmd.Name() != "unset" {
+		err = configIssuesError(newConfigValidator(resource.server, "server").Validate(keys))
+		if err != nil {
+			return err
+		}
+	}
+
 	if server.Config == nil {
 		server.Config = map[string]string{}
 	}
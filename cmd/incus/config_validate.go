@@ -0,0 +1,289 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	incus "github.com/lxc/incus/v6/client"
+	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// configValidatorMaxDistance is the maximum edit distance for a key to be suggested as a replacement.
+const configValidatorMaxDistance = 2
+
+// configIssue is a problem found with a configuration key.
+type configIssue struct {
+	Key     string
+	Message string
+	Warning bool
+}
+
+// String returns the issue in a human readable form.
+func (i configIssue) String() string {
+	if i.Warning {
+		return fmt.Sprintf(i18n.G("Warning: %s: %s"), i.Key, i.Message)
+	}
+
+	return fmt.Sprintf(i18n.G("Error: %s: %s"), i.Key, i.Message)
+}
+
+type configValidatorPattern struct {
+	regex *regexp.Regexp
+	key   api.MetadataConfigKey
+}
+
+// configValidator validates configuration keys against the metadata published by the server.
+type configValidator struct {
+	keys     map[string]api.MetadataConfigKey
+	patterns []configValidatorPattern
+}
+
+// newConfigValidator returns a validator for the keys of the given metadata entities.
+// It returns nil if the server doesn't publish the configuration metadata.
+func newConfigValidator(server incus.InstanceServer, entities ...string) *configValidator {
+	if !server.HasExtension("metadata_configuration_validation") {
+		return nil
+	}
+
+	meta, err := server.GetMetadataConfiguration()
+	if err != nil {
+		return nil
+	}
+
+	v := &configValidator{keys: map[string]api.MetadataConfigKey{}}
+
+	for _, entity := range entities {
+		for _, group := range meta.Config[api.MetadataConfigEntityName(entity)] {
+			for _, entry := range group.Keys {
+				for name, key := range entry {
+					v.addKey(name, key)
+				}
+			}
+		}
+	}
+
+	return v
+}
+
+// addKey records a key, turning its placeholders into a pattern if needed.
+func (v *configValidator) addKey(name string, key api.MetadataConfigKey) {
+	fields := strings.Split(name, ".")
+	isPattern := false
+
+	for i, field := range fields {
+		switch {
+		case field == "*":
+			fields[i] = ".+"
+			isPattern = true
+		case strings.HasPrefix(field, "<") && strings.HasSuffix(field, ">"),
+			strings.HasPrefix(field, "[") && strings.HasSuffix(field, "]"),
+			configValidatorPlaceholder.MatchString(field):
+			fields[i] = "[^.]+"
+			isPattern = true
+		default:
+			fields[i] = regexp.QuoteMeta(field)
+		}
+	}
+
+	if !isPattern {
+		v.keys[name] = key
+		return
+	}
+
+	v.patterns = append(v.patterns, configValidatorPattern{
+		regex: regexp.MustCompile("^" + strings.Join(fields, `\.`) + "$"),
+		key:   key,
+	})
+}
+
+// configValidatorPlaceholder matches upper case key segments such as "NAME" in "logging.NAME.target.address".
+var configValidatorPlaceholder = regexp.MustCompile(`^[A-Z][A-Z_]*$`)
+
+// lookup returns the metadata for a key.
+func (v *configValidator) lookup(name string) (api.MetadataConfigKey, bool) {
+	key, ok := v.keys[name]
+	if ok {
+		return key, true
+	}
+
+	for _, pattern := range v.patterns {
+		if pattern.regex.MatchString(name) {
+			return pattern.key, true
+		}
+	}
+
+	return api.MetadataConfigKey{}, false
+}
+
+// suggest returns the closest known key to an unknown one, if any is close enough.
+func (v *configValidator) suggest(name string) string {
+	best := ""
+	bestDistance := configValidatorMaxDistance + 1
+
+	for known := range v.keys {
+		distance := levenshteinDistance(name, known)
+		if distance < bestDistance || (distance == bestDistance && known < best) {
+			best = known
+			bestDistance = distance
+		}
+	}
+
+	return best
+}
+
+// Validate checks the configuration against the known keys and returns the issues sorted by key.
+// Unknown keys without a close match are left to the server to validate.
+func (v *configValidator) Validate(config map[string]string) []configIssue {
+	if v == nil {
+		return nil
+	}
+
+	issues := []configIssue{}
+
+	for name, value := range config {
+		key, ok := v.lookup(name)
+		if !ok {
+			suggestion := v.suggest(name)
+			if suggestion != "" {
+				issues = append(issues, configIssue{Key: name, Message: fmt.Sprintf(i18n.G("Unknown key, did you mean %q?"), suggestion)})
+			}
+
+			continue
+		}
+
+		if key.Deprecated != "" {
+			issues = append(issues, configIssue{Key: name, Message: fmt.Sprintf(i18n.G("Deprecated key. %s"), key.Deprecated), Warning: true})
+		}
+
+		// Empty values unset the key.
+		if value == "" {
+			continue
+		}
+
+		switch key.Type {
+		case "bool":
+			if !util.IsTrue(value) && !util.IsFalse(value) {
+				issues = append(issues, configIssue{Key: name, Message: fmt.Sprintf(i18n.G("Invalid value %q, must be a boolean"), value)})
+				continue
+			}
+
+		case "integer", "int":
+			_, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				issues = append(issues, configIssue{Key: name, Message: fmt.Sprintf(i18n.G("Invalid value %q, must be an integer"), value)})
+				continue
+			}
+		}
+
+		if key.AllowedValues != "" {
+			allowed := util.SplitNTrimSpace(key.AllowedValues, ",", -1, true)
+			if !slices.Contains(allowed, value) {
+				issues = append(issues, configIssue{Key: name, Message: fmt.Sprintf(i18n.G("Invalid value %q, must be one of: %s"), value, strings.Join(allowed, ", "))})
+			}
+		}
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].Key != issues[j].Key {
+			return issues[i].Key < issues[j].Key
+		}
+
+		return !issues[i].Warning && issues[j].Warning
+	})
+
+	return issues
+}
+
+// configIssuesError prints the warnings and returns an error listing the errors, if any.
+func configIssuesError(issues []configIssue) error {
+	errs := []string{}
+
+	for _, issue := range issues {
+		if issue.Warning {
+			fmt.Fprintln(os.Stderr, issue.String())
+			continue
+		}
+
+		errs = append(errs, issue.String())
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errors.New(strings.Join(errs, "\n"))
+}
+
+// configIssuesAnnotate adds comments above the offending keys of the top-level "config" section
+// of a YAML document. Comments added by a previous call are removed first.
+func configIssuesAnnotate(content []byte, issues []configIssue) []byte {
+	lines := strings.Split(string(content), "\n")
+	out := make([]string, 0, len(lines)+len(issues))
+	inConfig := false
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "# ERROR: ") || strings.HasPrefix(trimmed, "# WARNING: ") {
+			continue
+		}
+
+		if line != "" && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "#") {
+			inConfig = strings.HasPrefix(line, "config:")
+		} else if inConfig && strings.HasPrefix(line, "  ") && !strings.HasPrefix(line, "   ") {
+			name, _, _ := strings.Cut(trimmed, ":")
+			name = strings.Trim(name, `"'`)
+
+			for _, issue := range issues {
+				if issue.Key != name {
+					continue
+				}
+
+				prefix := "# ERROR: "
+				if issue.Warning {
+					prefix = "# WARNING: "
+				}
+
+				out = append(out, "  "+prefix+issue.Message)
+			}
+		}
+
+		out = append(out, line)
+	}
+
+	return []byte(strings.Join(out, "\n"))
+}
+
+// levenshteinDistance returns the edit distance between two strings.
+func levenshteinDistance(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
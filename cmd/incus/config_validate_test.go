@@ -0,0 +1,97 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+func newTestConfigValidator() *configValidator {
+	v := &configValidator{keys: map[string]api.MetadataConfigKey{}}
+	v.addKey("boot.autostart", api.MetadataConfigKey{Type: "bool"})
+	v.addKey("boot.autostart.delay", api.MetadataConfigKey{Type: "integer"})
+	v.addKey("limits.cpu", api.MetadataConfigKey{Type: "string"})
+	v.addKey("limits.memory.enforce", api.MetadataConfigKey{Type: "string", AllowedValues: "hard, soft"})
+	v.addKey("loki.api.url", api.MetadataConfigKey{Type: "string", Deprecated: "Use 'logging.*.target.address' instead"})
+	v.addKey("logging.NAME.target.retry", api.MetadataConfigKey{Type: "integer"})
+	v.addKey("user.*", api.MetadataConfigKey{Type: "string"})
+	v.addKey("volatile.<name>.hwaddr", api.MetadataConfigKey{Type: "string"})
+
+	return v
+}
+
+func TestConfigValidatorValidate(t *testing.T) {
+	v := newTestConfigValidator()
+
+	issues := v.Validate(map[string]string{
+		"boot.autostart":            "maybe",
+		"boot.autostart.delay":      "10s",
+		"limits.cpus":               "2",
+		"limits.memory.enforce":     "medium",
+		"loki.api.url":              "https://loki",
+		"logging.foo.target.retry":  "3",
+		"user.anything":             "value",
+		"volatile.eth0.hwaddr":      "00:16:3e:00:00:01",
+		"image.description":         "Debian",
+		"limits.memory.enforce.foo": "",
+	})
+
+	assert.Equal(t, []configIssue{
+		{Key: "boot.autostart", Message: `Invalid value "maybe", must be a boolean`},
+		{Key: "boot.autostart.delay", Message: `Invalid value "10s", must be an integer`},
+		{Key: "limits.cpus", Message: `Unknown key, did you mean "limits.cpu"?`},
+		{Key: "limits.memory.enforce", Message: `Invalid value "medium", must be one of: hard, soft`},
+		{Key: "loki.api.url", Message: "Deprecated key. Use 'logging.*.target.address' instead", Warning: true},
+	}, issues)
+}
+
+func TestConfigValidatorValidateEmpty(t *testing.T) {
+	v := newTestConfigValidator()
+
+	assert.Empty(t, v.Validate(map[string]string{"boot.autostart": "", "limits.memory.enforce": ""}))
+
+	var nilValidator *configValidator
+	assert.Nil(t, nilValidator.Validate(map[string]string{"limits.cpus": "2"}))
+}
+
+func TestConfigIssuesAnnotate(t *testing.T) {
+	content := `### Comment
+name: c1
+config:
+  # ERROR: stale comment
+  boot.autostart: maybe
+  limits.cpu: "2"
+devices:
+  eth0:
+    boot.autostart: maybe
+`
+
+	issues := []configIssue{
+		{Key: "boot.autostart", Message: `Invalid value "maybe", must be a boolean`},
+		{Key: "boot.autostart", Message: "Deprecated key", Warning: true},
+	}
+
+	expected := `### Comment
+name: c1
+config:
+  # ERROR: Invalid value "maybe", must be a boolean
+  # WARNING: Deprecated key
+  boot.autostart: maybe
+  limits.cpu: "2"
+devices:
+  eth0:
+    boot.autostart: maybe
+`
+
+	assert.Equal(t, expected, string(configIssuesAnnotate([]byte(content), issues)))
+	assert.Equal(t, expected, string(configIssuesAnnotate([]byte(expected), issues)))
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	assert.Equal(t, 0, levenshteinDistance("limits.cpu", "limits.cpu"))
+	assert.Equal(t, 1, levenshteinDistance("limits.cpus", "limits.cpu"))
+	assert.Equal(t, 2, levenshteinDistance("secuirty.nesting", "security.nesting"))
+	assert.Equal(t, 3, levenshteinDistance("", "abc"))
+}
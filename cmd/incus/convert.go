@@ -0,0 +1,266 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+type cmdConvert struct {
+	global *cmdGlobal
+
+	flagTo      string
+	flagImage   string
+	flagReplace bool
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdConvert) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("convert", i18n.G("[<remote>:]<instance> [<name>]"))
+	cmd.Short = i18n.G("Convert containers to virtual machines")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Convert containers to virtual machines
+
+A new virtual machine is created from the virtual-machine variant of the
+container's image (or the image provided with --image), which provides the
+kernel and bootloader. The container's filesystem is then copied into it and
+compatible configuration and devices are carried over.
+
+The container is left untouched until the conversion is confirmed, at which
+point it is deleted and the virtual machine takes over its name.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus convert c1
+    Convert the stopped container "c1" into a virtual machine, asking before replacing it.
+
+incus convert c1 c1-vm --image images:debian/12
+    Create the virtual machine "c1-vm" using the Debian 12 image for the kernel and bootloader, keeping "c1".`))
+
+	cmd.Flags().StringVar(&c.flagTo, "to", "virtual-machine", i18n.G("Type of the new instance")+"``")
+	cmd.Flags().StringVar(&c.flagImage, "image", "", i18n.G("Image to take the kernel and bootloader from")+"``")
+	cmd.Flags().BoolVar(&c.flagReplace, "replace", false, i18n.G("Replace the original instance without asking for confirmation"))
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpInstances(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdConvert) Run(cmd *cobra.Command, args []string) error {
+	conf := c.global.conf
+
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 2)
+	if exit {
+		return err
+	}
+
+	if c.flagTo != string(api.InstanceTypeVM) {
+		return fmt.Errorf(i18n.G("Conversion to %q isn't supported"), c.flagTo)
+	}
+
+	// Parse remote
+	remote, name, err := conf.ParseRemote(args[0])
+	if err != nil {
+		return err
+	}
+
+	if strings.Contains(name, instance.SnapshotDelimiter) {
+		return fmt.Errorf(i18n.G("Instance snapshots cannot be converted: %s"), name)
+	}
+
+	req := api.InstanceConvertPost{
+		Type: api.InstanceTypeVM,
+		Name: name + "-vm",
+	}
+
+	if len(args) == 2 {
+		req.Name = args[1]
+	}
+
+	d, err := conf.GetInstanceServer(remote)
+	if err != nil {
+		return err
+	}
+
+	progress := cli.ProgressRenderer{
+		Format: i18n.G("Converting instance: %s"),
+		Quiet:  c.global.flagQuiet,
+	}
+
+	var metadata map[string]any
+	if c.flagImage != "" {
+		iremote, image, err := conf.ParseRemote(c.flagImage)
+		if err != nil {
+			return err
+		}
+
+		iremote, image = guessImage(conf, d, remote, iremote, image)
+		imgRemote, imgInfo, err := getImgInfo(d, conf, iremote, remote, image, &req.Source)
+		if err != nil {
+			return err
+		}
+
+		if conf.Remotes[iremote].Protocol == "incus" && imgInfo.Type != string(api.InstanceTypeVM) {
+			return errors.New(i18n.G("The image must be a virtual-machine image"))
+		}
+
+		rop, err := d.ConvertInstanceFromImage(imgRemote, *imgInfo, name, req)
+		if err != nil {
+			return err
+		}
+
+		_, err = rop.AddHandler(progress.UpdateOp)
+		if err != nil {
+			progress.Done("")
+			return err
+		}
+
+		err = cli.CancelableWait(rop, &progress)
+		if err != nil {
+			progress.Done("")
+			return err
+		}
+
+		opAPI, err := rop.GetTarget()
+		if err != nil {
+			return err
+		}
+
+		metadata = opAPI.Metadata
+	} else {
+		op, err := d.ConvertInstance(name, req)
+		if err != nil {
+			return err
+		}
+
+		_, err = op.AddHandler(progress.UpdateOp)
+		if err != nil {
+			progress.Done("")
+			return err
+		}
+
+		err = cli.CancelableWait(op, &progress)
+		if err != nil {
+			progress.Done("")
+			return err
+		}
+
+		metadata = op.Get().Metadata
+	}
+
+	progress.Done("")
+
+	// Report what couldn't be carried over.
+	skipped, ok := metadata["skipped"].([]any)
+	if ok && len(skipped) > 0 && !c.global.flagQuiet {
+		fmt.Println(i18n.G("The following configuration wasn't carried over to the virtual machine:"))
+		for _, entry := range skipped {
+			fmt.Printf("  - %v\n", entry)
+		}
+	}
+
+	// Ask before replacing the original instance.
+	if !c.flagReplace {
+		if !c.global.flagQuiet {
+			fmt.Printf(i18n.G("Virtual machine %s created from %s")+"\n", req.Name, name)
+		}
+
+		replace, err := c.global.asker.AskBool(fmt.Sprintf(i18n.G("Delete %s and rename %s to %s?"), name, req.Name, name)+" (yes/no) [default=no]: ", "no")
+		if err != nil {
+			return err
+		}
+
+		if !replace {
+			return nil
+		}
+	}
+
+	// Record the MAC addresses of the container so the virtual machine can take them over once it's gone.
+	container, _, err := d.GetInstance(name)
+	if err != nil {
+		return err
+	}
+
+	op, err := d.DeleteInstance(name)
+	if err != nil {
+		return err
+	}
+
+	err = op.Wait()
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed deleting %s: %w"), name, err)
+	}
+
+	op, err = d.RenameInstance(req.Name, api.InstancePost{Name: name})
+	if err != nil {
+		return err
+	}
+
+	err = op.Wait()
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed renaming %s to %s: %w"), req.Name, name, err)
+	}
+
+	vm, etag, err := d.GetInstance(name)
+	if err != nil {
+		return err
+	}
+
+	hwaddrs := convertHwaddrs(container.Config, vm.ExpandedDevices)
+	if len(hwaddrs) == 0 {
+		return nil
+	}
+
+	for key, value := range hwaddrs {
+		vm.Config[key] = value
+	}
+
+	op, err = d.UpdateInstance(name, vm.Writable(), etag)
+	if err != nil {
+		return err
+	}
+
+	err = op.Wait()
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed restoring the MAC addresses of %s: %w"), name, err)
+	}
+
+	return nil
+}
+
+// convertHwaddrs returns the MAC addresses of the container's NICs which the virtual machine also has.
+func convertHwaddrs(config map[string]string, devices map[string]map[string]string) map[string]string {
+	hwaddrs := map[string]string{}
+
+	for key, value := range config {
+		fields := strings.SplitN(key, ".", 3)
+		if len(fields) != 3 || fields[0] != "volatile" || fields[2] != "hwaddr" {
+			continue
+		}
+
+		dev, ok := devices[fields[1]]
+		if !ok || dev["type"] != "nic" {
+			continue
+		}
+
+		hwaddrs[key] = value
+	}
+
+	return hwaddrs
+}
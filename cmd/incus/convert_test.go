@@ -0,0 +1,24 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertHwaddrs(t *testing.T) {
+	config := map[string]string{
+		"volatile.eth0.hwaddr":    "10:66:6a:00:00:01",
+		"volatile.eth1.hwaddr":    "10:66:6a:00:00:02",
+		"volatile.eth0.host_name": "veth1234",
+		"volatile.disk0.hwaddr":   "10:66:6a:00:00:03",
+		"user.hwaddr":             "10:66:6a:00:00:04",
+	}
+
+	devices := map[string]map[string]string{
+		"eth0":  {"type": "nic", "network": "incusbr0"},
+		"disk0": {"type": "disk", "path": "/mnt"},
+	}
+
+	assert.Equal(t, map[string]string{"volatile.eth0.hwaddr": "10:66:6a:00:00:01"}, convertHwaddrs(config, devices))
+}
@@ -32,6 +32,7 @@ type cmdCopy struct {
 	flagRefresh             bool
 	flagRefreshExcludeOlder bool
 	flagAllowInconsistent   bool
+	flagCheck               bool
 }
 
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
@@ -66,6 +67,7 @@ The pull transfer mode is the default as it is compatible with all server versio
 	cmd.Flags().BoolVar(&c.flagRefresh, "refresh", false, i18n.G("Perform an incremental copy"))
 	cmd.Flags().BoolVar(&c.flagRefreshExcludeOlder, "refresh-exclude-older", false, i18n.G("During incremental copy, exclude source snapshots earlier than latest target snapshot"))
 	cmd.Flags().BoolVar(&c.flagAllowInconsistent, "allow-inconsistent", false, i18n.G("Ignore copy errors for volatile files"))
+	cmd.Flags().BoolVar(&c.flagCheck, "check", false, i18n.G("Only check whether the instance can be copied"))
 
 	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		if len(args) == 0 {
@@ -476,6 +478,22 @@ func (c *cmdCopy) Run(cmd *cobra.Command, args []string) error {
 	keepVolatile := c.flagRefresh
 	instanceOnly := c.flagInstanceOnly
 
+	// Only check whether the instance can be copied.
+	if c.flagCheck {
+		destResource := args[0]
+		if len(args) == 2 {
+			destResource = args[1]
+		}
+
+		req := api.InstancePost{
+			Live:    stateful,
+			Pool:    c.flagStorage,
+			Project: c.flagTargetProject,
+		}
+
+		return checkMigration(conf, args[0], destResource, req, c.flagTarget, false)
+	}
+
 	// If target name is not specified, one will be chosen by the server
 	if len(args) < 2 {
 		return c.copyInstance(conf, args[0], "", keepVolatile, ephem, stateful, instanceOnly, mode, c.flagStorage, false)
@@ -1,20 +1,28 @@
 package main
 
 import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"net/url"
 	"os"
+	"os/exec"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	incus "github.com/lxc/incus/v6/client"
+	"github.com/lxc/incus/v6/internal/backupmanifest"
 	cli "github.com/lxc/incus/v6/internal/cmd"
 	"github.com/lxc/incus/v6/internal/i18n"
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/archive"
+	"github.com/lxc/incus/v6/shared/util"
 )
 
 type cmdExport struct {
@@ -23,6 +31,7 @@ type cmdExport struct {
 	flagInstanceOnly         bool
 	flagOptimizedStorage     bool
 	flagCompressionAlgorithm string
+	flagEncryptTo            []string
 }
 
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
@@ -34,7 +43,10 @@ func (c *cmdExport) Command() *cobra.Command {
 		`Export instances as backup tarballs.`))
 	cmd.Example = cli.FormatSection("", i18n.G(
 		`incus export u1 backup0.tar.gz
-    Download a backup tarball of the u1 instance.`))
+    Download a backup tarball of the u1 instance.
+
+incus export u1 --encrypt-to age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p
+    Download a backup of the u1 instance encrypted with age.`))
 
 	cmd.RunE = c.Run
 	cmd.Flags().BoolVar(&c.flagInstanceOnly, "instance-only", false,
@@ -42,6 +54,11 @@ func (c *cmdExport) Command() *cobra.Command {
 	cmd.Flags().BoolVar(&c.flagOptimizedStorage, "optimized-storage", false,
 		i18n.G("Use storage driver optimized format (can only be restored on a similar pool)"))
 	cmd.Flags().StringVar(&c.flagCompressionAlgorithm, "compression", "", i18n.G("Compression algorithm to use (none for uncompressed)")+"``")
+	cmd.Flags().StringArrayVar(&c.flagEncryptTo, "encrypt-to", nil, i18n.G("Encrypt the backup to this age, SSH or OpenPGP recipient (can be repeated)")+"``")
+
+	// Verify
+	exportVerifyCmd := cmdExportVerify{global: c.global}
+	cmd.AddCommand(exportVerifyCmd.Command())
 
 	return cmd
 }
@@ -75,6 +92,7 @@ func (c *cmdExport) Run(cmd *cobra.Command, args []string) error {
 		InstanceOnly:         instanceOnly,
 		OptimizedStorage:     c.flagOptimizedStorage,
 		CompressionAlgorithm: c.flagCompressionAlgorithm,
+		EncryptionRecipients: c.flagEncryptTo,
 	}
 
 	op, err := d.CreateInstanceBackup(name, req)
@@ -174,11 +192,29 @@ func (c *cmdExport) Run(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
-		_, ext, _, err := archive.DetectCompressionFile(target)
+		encryption, err := archive.DetectEncryptionFile(target)
 		if err != nil {
 			return err
 		}
 
+		_, err = target.Seek(0, io.SeekStart)
+		if err != nil {
+			return err
+		}
+
+		var ext string
+		switch encryption {
+		case archive.EncryptionAge:
+			ext = ".backup.age"
+		case archive.EncryptionOpenPGP:
+			ext = ".backup.gpg"
+		default:
+			_, ext, _, err = archive.DetectCompressionFile(target)
+			if err != nil {
+				return err
+			}
+		}
+
 		err = os.Rename(targetName, name+ext)
 		if err != nil {
 			return fmt.Errorf(i18n.G("Failed to rename export file: %w"), err)
@@ -193,3 +229,228 @@ func (c *cmdExport) Run(cmd *cobra.Command, args []string) error {
 	progress.Done(i18n.G("Backup exported successfully!"))
 	return nil
 }
+
+// Verify.
+type cmdExportVerify struct {
+	global *cmdGlobal
+
+	flagIdentity    string
+	flagFingerprint string
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdExportVerify) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("verify", i18n.G("<file>|[<remote>:]<instance>/<backup>"))
+	cmd.Short = i18n.G("Verify instance backups")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Verify instance backups
+
+Checks every entry of an existing backup against the signed manifest embedded
+in it, without restoring it. The backup is either a local file or a backup
+stored on the server.
+
+Local files are checked against the certificate fingerprint provided through
+--fingerprint. Encrypted files are decrypted first, using the provided age
+identity or the local OpenPGP keyring. Backups stored on the server are verified
+by the server itself, against the provided fingerprint or else the certificates
+of its cluster members.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus export verify u1.backup.age --identity ~/.config/age/key.txt --fingerprint 2d5f33c5eb2e...
+    Decrypt and verify a downloaded backup of the u1 instance.
+
+incus export verify u1/backup0
+    Have the server verify the backup0 backup of the u1 instance.`))
+
+	cmd.Flags().StringVar(&c.flagIdentity, "identity", "", i18n.G("age identity file used to decrypt the backup")+"``")
+	cmd.Flags().StringVar(&c.flagFingerprint, "fingerprint", "", i18n.G("Fingerprint of the certificate expected to have signed the backup")+"``")
+
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdExportVerify) Run(cmd *cobra.Command, args []string) error {
+	conf := c.global.conf
+
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Verify local files directly.
+	if util.PathExists(args[0]) {
+		return c.verifyFile(args[0])
+	}
+
+	// Parse remote.
+	remote, name, err := conf.ParseRemote(args[0])
+	if err != nil {
+		return err
+	}
+
+	instanceName, backupName, found := strings.Cut(name, "/")
+	if !found || instanceName == "" || backupName == "" {
+		return fmt.Errorf(i18n.G("Invalid backup name %q, expected <instance>/<backup>"), name)
+	}
+
+	d, err := conf.GetInstanceServer(remote)
+	if err != nil {
+		return err
+	}
+
+	op, err := d.VerifyInstanceBackup(instanceName, backupName, api.BackupVerifyPost{Fingerprint: c.flagFingerprint})
+	if err != nil {
+		return err
+	}
+
+	progress := cli.ProgressRenderer{
+		Format: i18n.G("Verifying backup: %s"),
+		Quiet:  c.global.flagQuiet,
+	}
+
+	_, err = op.AddHandler(progress.UpdateOp)
+	if err != nil {
+		progress.Done("")
+		return err
+	}
+
+	err = cli.CancelableWait(op, &progress)
+	if err != nil {
+		progress.Done("")
+		return err
+	}
+
+	progress.Done("")
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Backup verified successfully (%v files signed by %v)")+"\n", op.Get().Metadata["files"], op.Get().Metadata["fingerprint"])
+	}
+
+	return nil
+}
+
+// verifyFile decrypts and decompresses a local backup file and checks it against its manifest.
+func (c *cmdExportVerify) verifyFile(path string) error {
+	// The certificate embedded in the manifest proves nothing on its own.
+	if c.flagFingerprint == "" {
+		return errors.New(i18n.G("The fingerprint of the certificate expected to have signed the backup must be provided with --fingerprint"))
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = f.Close() }()
+
+	encryption, err := archive.DetectEncryptionFile(f)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	var waits []func() error
+	var r io.Reader = f
+
+	// Decrypt the backup.
+	switch encryption {
+	case archive.EncryptionAge:
+		if c.flagIdentity == "" {
+			return errors.New(i18n.G("The backup is encrypted with age, an identity must be provided with --identity"))
+		}
+
+		r, err = c.pipeCommand(r, &waits, "age", "--decrypt", "--identity", c.flagIdentity)
+	case archive.EncryptionOpenPGP:
+		r, err = c.pipeCommand(r, &waits, "gpg", "--quiet", "--decrypt")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// Wait for the helper processes, their errors explain any truncated or corrupted stream.
+	finish := func(err error) error {
+		_, _ = io.Copy(io.Discard, r)
+
+		for i := len(waits) - 1; i >= 0; i-- {
+			waitErr := waits[i]()
+			if waitErr != nil {
+				return waitErr
+			}
+		}
+
+		return err
+	}
+
+	// Decompress the backup.
+	br := bufio.NewReaderSize(r, 512)
+	header, err := br.Peek(263)
+	if err != nil && err != io.EOF {
+		return finish(err)
+	}
+
+	_, _, unpacker, err := archive.DetectCompressionFile(bytes.NewReader(header))
+	if err != nil {
+		return finish(err)
+	}
+
+	r = br
+	if len(unpacker) > 0 {
+		r, err = c.pipeCommand(r, &waits, unpacker[0], unpacker[1:]...)
+		if err != nil {
+			return finish(err)
+		}
+	}
+
+	result, err := backupmanifest.Verify(tar.NewReader(r))
+	err = finish(err)
+	if err != nil {
+		return err
+	}
+
+	err = backupmanifest.CheckSigner(result, []string{c.flagFingerprint})
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Backup verified successfully (%d files signed by %s)")+"\n", result.Files, result.Fingerprint)
+	}
+
+	return nil
+}
+
+// pipeCommand starts the command reading from r and returns its output.
+func (c *cmdExportVerify) pipeCommand(r io.Reader, waits *[]func() error, name string, args ...string) (io.Reader, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = r
+	cmd.Stderr = os.Stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	err = cmd.Start()
+	if err != nil {
+		return nil, fmt.Errorf(i18n.G("Failed to run %q: %w"), name, err)
+	}
+
+	*waits = append(*waits, func() error {
+		err := cmd.Wait()
+		if err != nil {
+			return fmt.Errorf(i18n.G("Failed to run %q: %w"), name, err)
+		}
+
+		return nil
+	})
+
+	return stdout, nil
+}
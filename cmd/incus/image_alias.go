@@ -44,6 +44,10 @@ func (c *cmdImageAlias) Command() *cobra.Command {
 	imageAliasListCmd := cmdImageAliasList{global: c.global, image: c.image, imageAlias: c}
 	cmd.AddCommand(imageAliasListCmd.Command())
 
+	// Promote
+	imageAliasPromoteCmd := cmdImageAliasPromote{global: c.global, image: c.image, imageAlias: c}
+	cmd.AddCommand(imageAliasPromoteCmd.Command())
+
 	// Rename
 	imageAliasRenameCmd := cmdImageAliasRename{global: c.global, image: c.image, imageAlias: c}
 	cmd.AddCommand(imageAliasRenameCmd.Command())
@@ -422,3 +426,82 @@ func (c *cmdImageAliasRename) Run(cmd *cobra.Command, args []string) error {
 	// Rename the alias
 	return resource.server.RenameImageAlias(resource.name, api.ImageAliasesEntryPost{Name: args[1]})
 }
+
+// Promote.
+type cmdImageAliasPromote struct {
+	global     *cmdGlobal
+	image      *cmdImage
+	imageAlias *cmdImageAlias
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdImageAliasPromote) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("promote", i18n.G("[<remote>:]<alias> <from-channel> <to-channel>"))
+	cmd.Short = i18n.G("Promote an image between alias channels")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Promote an image between alias channels
+
+Release channels of an alias are aliases named <alias>@<channel>.
+Promoting points the alias of the target channel to the image currently in the source channel,
+creating it if needed.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus image alias promote debian/12 candidate stable
+    Make the image in the candidate channel of debian/12 the stable one.`))
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		return c.global.cmpImages(toComplete)
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdImageAliasPromote) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 3, 3)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.parseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return errors.New(i18n.G("Alias name missing"))
+	}
+
+	if strings.Contains(resource.name, "@") {
+		return errors.New(i18n.G("The alias name must not include a channel"))
+	}
+
+	if !resource.server.HasExtension("image_channels") {
+		return errors.New(i18n.G("The server doesn't support image alias channels"))
+	}
+
+	fromName := resource.name + "@" + args[1]
+	toName := resource.name + "@" + args[2]
+
+	// Have the server point the target channel to the image in the source channel.
+	err = resource.server.RenameImageAlias(fromName, api.ImageAliasesEntryPost{Name: toName, Promote: true})
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed to promote alias %q: %w"), fromName, err)
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Image promoted from %s to %s")+"\n", fromName, toName)
+	}
+
+	return nil
+}
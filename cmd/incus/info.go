@@ -675,6 +675,10 @@ func (c *cmdInfo) instanceInfo(d incus.InstanceServer, name string, showLog bool
 			fmt.Printf(i18n.G("Started: %s")+"\n", inst.State.StartedAt.Local().Format(dateLayout))
 		}
 
+		if inst.State.CloudInit != "" {
+			fmt.Printf(i18n.G("Cloud-init: %s")+"\n", inst.State.CloudInit)
+		}
+
 		// Operating System info
 		if inst.State.OSInfo != nil {
 			fmt.Println("\n" + i18n.G("Operating System:"))
@@ -39,6 +39,7 @@ type cmdGlobal struct {
 	flagLogDebug   bool
 	flagLogVerbose bool
 	flagProject    string
+	flagPriority   string
 	flagQuiet      bool
 	flagVersion    bool
 	flagSubCmds    bool
@@ -118,6 +119,7 @@ Custom commands can be defined through aliases, use "incus alias" to control tho
 	app.PersistentFlags().BoolVarP(&globalCmd.flagHelp, "help", "h", false, i18n.G("Print help"))
 	app.PersistentFlags().BoolVar(&globalCmd.flagForceLocal, "force-local", false, i18n.G("Force using the local unix socket"))
 	app.PersistentFlags().StringVar(&globalCmd.flagProject, "project", "", i18n.G("Override the source project")+"``")
+	app.PersistentFlags().StringVar(&globalCmd.flagPriority, "priority", "", i18n.G("Scheduling priority of the resulting operations (bulk, normal or interactive)")+"``")
 	app.PersistentFlags().BoolVar(&globalCmd.flagLogDebug, "debug", false, i18n.G("Show all debug messages"))
 	app.PersistentFlags().BoolVarP(&globalCmd.flagLogVerbose, "verbose", "v", false, i18n.G("Show all information messages"))
 	app.PersistentFlags().BoolVarP(&globalCmd.flagQuiet, "quiet", "q", false, i18n.G("Don't show progress information"))
@@ -155,6 +157,10 @@ Custom commands can be defined through aliases, use "incus alias" to control tho
 	createCmd := cmdCreate{global: &globalCmd}
 	app.AddCommand(createCmd.Command())
 
+	// convert sub-command
+	convertCmd := cmdConvert{global: &globalCmd}
+	app.AddCommand(convertCmd.Command())
+
 	// copy sub-command
 	copyCmd := cmdCopy{global: &globalCmd}
 	app.AddCommand(copyCmd.Command())
@@ -279,6 +285,14 @@ Custom commands can be defined through aliases, use "incus alias" to control tho
 	topCmd := cmdTop{global: &globalCmd}
 	app.AddCommand(topCmd.Command())
 
+	// tui sub-command
+	tuiCmd := cmdTui{global: &globalCmd}
+	app.AddCommand(tuiCmd.Command())
+
+	// wait sub-command
+	waitCmd := cmdWait{global: &globalCmd}
+	app.AddCommand(waitCmd.Command())
+
 	// warning sub-command
 	warningCmd := cmdWarning{global: &globalCmd}
 	app.AddCommand(warningCmd.Command())
@@ -422,6 +436,17 @@ func (c *cmdGlobal) PreRun(cmd *cobra.Command, _ []string) error {
 		c.conf.ProjectOverride = os.Getenv("INCUS_PROJECT")
 	}
 
+	// Override the operation priority
+	if c.flagPriority != "" {
+		c.conf.PriorityOverride = c.flagPriority
+	} else {
+		c.conf.PriorityOverride = os.Getenv("INCUS_PRIORITY")
+	}
+
+	if c.conf.PriorityOverride != "" && !slices.Contains([]string{"bulk", "normal", "interactive"}, c.conf.PriorityOverride) {
+		return fmt.Errorf(i18n.G("Invalid priority %q, must be one of: bulk, normal, interactive"), c.conf.PriorityOverride)
+	}
+
 	// Setup password helper
 	c.conf.PromptPassword = func(filename string) (string, error) {
 		return c.asker.AskPasswordOnce(fmt.Sprintf(i18n.G("Password for %s: "), filename)), nil
@@ -3,13 +3,17 @@ package main
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	incus "github.com/lxc/incus/v6/client"
 	cli "github.com/lxc/incus/v6/internal/cmd"
 	"github.com/lxc/incus/v6/internal/i18n"
+	localMigration "github.com/lxc/incus/v6/internal/migration"
 	"github.com/lxc/incus/v6/shared/api"
+	config "github.com/lxc/incus/v6/shared/cliconfig"
 )
 
 type cmdMove struct {
@@ -26,6 +30,7 @@ type cmdMove struct {
 	flagTarget            string
 	flagTargetProject     string
 	flagAllowInconsistent bool
+	flagCheck             bool
 }
 
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
@@ -52,7 +57,10 @@ incus move <old name> <new name> [--instance-only]
     Rename a local instance.
 
 incus move <instance>/<old snapshot name> <instance>/<new snapshot name>
-    Rename a snapshot.`))
+    Rename a snapshot.
+
+incus move <instance> --target <member> --check
+    Check whether the instance can be moved to another cluster member without moving it.`))
 
 	cmd.RunE = c.Run
 	cmd.Flags().StringArrayVarP(&c.flagConfig, "config", "c", nil, i18n.G("Config key/value to apply to the target instance")+"``")
@@ -66,6 +74,7 @@ incus move <instance>/<old snapshot name> <instance>/<new snapshot name>
 	cmd.Flags().StringVar(&c.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
 	cmd.Flags().StringVar(&c.flagTargetProject, "target-project", "", i18n.G("Copy to a project different from the source")+"``")
 	cmd.Flags().BoolVar(&c.flagAllowInconsistent, "allow-inconsistent", false, i18n.G("Ignore copy errors for volatile files"))
+	cmd.Flags().BoolVar(&c.flagCheck, "check", false, i18n.G("Only check whether the instance can be moved"))
 
 	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		if len(args) == 0 {
@@ -120,6 +129,26 @@ func (c *cmdMove) Run(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Only check whether the instance can be moved.
+	if c.flagCheck {
+		if sourceRemote == destRemote && c.flagTarget == "" && c.flagStorage == "" && c.flagTargetProject == "" {
+			return errors.New(i18n.G("--check can only be used when moving to another server, cluster member, storage pool or project"))
+		}
+
+		destResource := args[0]
+		if len(args) == 2 {
+			destResource = args[1]
+		}
+
+		req := api.InstancePost{
+			Live:    !c.flagStateless,
+			Pool:    c.flagStorage,
+			Project: c.flagTargetProject,
+		}
+
+		return checkMigration(conf, args[0], destResource, req, c.flagTarget, sourceRemote == destRemote)
+	}
+
 	// As an optimization, if the source and destination are the same, do
 	// this via a simple rename. This only works for instances that aren't
 	// running, instances that are running should be live migrated (of
@@ -365,5 +394,169 @@ func (c *cmdMove) moveInstance(sourceResource string, destResource string, state
 	return nil
 }
 
+// checkMigration checks whether the instance can be migrated to the destination and prints the problems found.
+// Server-side migrations are checked by the server alone, otherwise the checks against the destination are run
+// locally from the information both servers expose.
+func checkMigration(conf *config.Config, sourceResource string, destResource string, req api.InstancePost, target string, serverSide bool) error {
+	sourceRemote, sourceName, err := conf.ParseRemote(sourceResource)
+	if err != nil {
+		return err
+	}
+
+	destRemote, _, err := conf.ParseRemote(destResource)
+	if err != nil {
+		return err
+	}
+
+	if sourceName == "" {
+		return errors.New(i18n.G("You must specify a source instance name"))
+	}
+
+	source, err := conf.GetInstanceServer(sourceRemote)
+	if err != nil {
+		return err
+	}
+
+	req.Migration = true
+
+	var check *api.InstanceMigrationCheck
+	if serverSide {
+		if target != "" {
+			if !source.IsClustered() {
+				return errors.New(i18n.G("--target can only be used with clusters"))
+			}
+
+			source = source.UseTarget(target)
+		}
+
+		check, err = source.CheckMigrateInstance(sourceName, req)
+		if err != nil {
+			return err
+		}
+	} else {
+		// Run the checks which only depend on the source.
+		check, err = source.CheckMigrateInstance(sourceName, api.InstancePost{Migration: true, Live: req.Live})
+		if err != nil {
+			return err
+		}
+
+		// Run the checks against the destination.
+		dest, err := conf.GetInstanceServer(destRemote)
+		if err != nil {
+			return err
+		}
+
+		if req.Project != "" {
+			dest = dest.UseProject(req.Project)
+		}
+
+		if target != "" {
+			if !dest.IsClustered() {
+				return errors.New(i18n.G("--target can only be used with clusters"))
+			}
+
+			dest = dest.UseTarget(target)
+		}
+
+		destCheck, err := checkMigrationDestination(source, sourceName, dest, req)
+		if err != nil {
+			return err
+		}
+
+		// Merge the results, skipping the problems already reported by the source.
+		for _, entry := range destCheck.Blockers {
+			if !slices.Contains(check.Blockers, entry) {
+				check.Blockers = append(check.Blockers, entry)
+			}
+		}
+
+		for _, entry := range destCheck.Warnings {
+			if !slices.Contains(check.Warnings, entry) {
+				check.Warnings = append(check.Warnings, entry)
+			}
+		}
+	}
+
+	if len(check.Blockers) == 0 && len(check.Warnings) == 0 {
+		fmt.Println(i18n.G("No problems found"))
+		return nil
+	}
+
+	if len(check.Blockers) > 0 {
+		fmt.Println(i18n.G("Blockers:"))
+		for _, entry := range check.Blockers {
+			fmt.Printf("  - [%s] %s\n", entry.Type, entry.Description)
+		}
+	}
+
+	if len(check.Warnings) > 0 {
+		fmt.Println(i18n.G("Warnings:"))
+		for _, entry := range check.Warnings {
+			fmt.Printf("  - [%s] %s\n", entry.Type, entry.Description)
+		}
+	}
+
+	if len(check.Blockers) > 0 {
+		return errors.New(i18n.G("The instance can't be migrated"))
+	}
+
+	return nil
+}
+
+// checkMigrationDestination checks the instance against what the destination server provides.
+func checkMigrationDestination(source incus.InstanceServer, sourceName string, dest incus.InstanceServer, req api.InstancePost) (*api.InstanceMigrationCheck, error) {
+	inst, _, err := source.GetInstance(sourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	// A root disk inherited from profiles comes from the destination's own profiles.
+	if req.Pool == "" {
+		for devName, dev := range inst.ExpandedDevices {
+			_, isLocal := inst.Devices[devName]
+			if dev["type"] == "disk" && dev["path"] == "/" && !isLocal {
+				delete(inst.ExpandedDevices, devName)
+			}
+		}
+	}
+
+	sourceServer, _, err := source.GetServer()
+	if err != nil {
+		return nil, err
+	}
+
+	args := localMigration.CheckArgs{
+		Instance:             inst,
+		Live:                 req.Live,
+		Pool:                 req.Pool,
+		SourceDriverVersions: localMigration.DriverVersions(sourceServer.Environment),
+	}
+
+	// The source resources may not be accessible to the user, the CPU flags check is skipped then.
+	args.SourceResources, _ = source.GetServerResources()
+
+	args.Target, _, err = dest.GetServer()
+	if err != nil {
+		return nil, err
+	}
+
+	args.TargetResources, err = dest.GetServerResources()
+	if err != nil {
+		return nil, err
+	}
+
+	args.TargetPools, err = dest.GetStoragePools()
+	if err != nil {
+		return nil, err
+	}
+
+	args.TargetNetworks, err = dest.GetNetworks()
+	if err != nil {
+		return nil, err
+	}
+
+	return localMigration.Check(args), nil
+}
+
 // Default migration mode when moving an instance.
 const moveDefaultMode = "pull"
@@ -1061,6 +1061,15 @@ func (c *cmdNetworkInfo) Run(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  %s: %d\n", i18n.G("VLAN ID"), state.VLAN.VID)
 	}
 
+	// Gateway information.
+	if state.Gateway != nil {
+		fmt.Println("")
+		fmt.Println(i18n.G("Gateway:"))
+		fmt.Printf("  %s: %s\n", i18n.G("Mode"), state.Gateway.Mode)
+		fmt.Printf("  %s: %s\n", i18n.G("Owner"), state.Gateway.Owner)
+		fmt.Printf("  %s: %v\n", i18n.G("Active"), state.Gateway.Active)
+	}
+
 	// OVN information.
 	if state.OVN != nil {
 		fmt.Println("")
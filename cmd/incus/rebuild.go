@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/kballard/go-shellquote"
 	"github.com/spf13/cobra"
 
 	cli "github.com/lxc/incus/v6/internal/cmd"
@@ -16,9 +17,12 @@ import (
 
 // Rebuild.
 type cmdRebuild struct {
-	global    *cmdGlobal
-	flagEmpty bool
-	flagForce bool
+	global                 *cmdGlobal
+	flagEmpty              bool
+	flagForce              bool
+	flagUpgrade            bool
+	flagHealthCheck        string
+	flagHealthCheckTimeout int
 }
 
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
@@ -27,11 +31,25 @@ func (c *cmdRebuild) Command() *cobra.Command {
 	cmd.Use = usage("rebuild", i18n.G("[<remote>:]<image> [<remote>:]<instance>"))
 	cmd.Short = i18n.G("Rebuild instances")
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
-		`Wipe the instance root disk and re-initialize with a new image (or empty volume).`))
+		`Wipe the instance root disk and re-initialize with a new image (or empty volume).
+
+With --upgrade, the instance is snapshotted before being rebuilt and then started
+to run a health check. Should the health check fail, the instance is restored
+from the snapshot. The image may be omitted to use the most recent version of
+the image the instance was created from.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus rebuild images:debian/12 c1
+    Rebuild the instance "c1" using the Debian 12 image.
+
+incus rebuild c1 --upgrade --health-check "systemctl is-system-running --wait"
+    Upgrade "c1" to the latest version of its image, rolling back if the system doesn't come up cleanly.`))
 
 	cmd.RunE = c.Run
 	cmd.Flags().BoolVar(&c.flagEmpty, "empty", false, i18n.G("Rebuild as an empty instance"))
 	cmd.Flags().BoolVarP(&c.flagForce, "force", "f", false, i18n.G("If an instance is running, stop it and then rebuild it"))
+	cmd.Flags().BoolVar(&c.flagUpgrade, "upgrade", false, i18n.G("Snapshot the instance and roll back if the post-upgrade health check fails"))
+	cmd.Flags().StringVar(&c.flagHealthCheck, "health-check", "", i18n.G("Command to run inside the instance to check its health after an upgrade")+"``")
+	cmd.Flags().IntVar(&c.flagHealthCheckTimeout, "health-check-timeout", 0, i18n.G("Time to wait for the health check to pass (in seconds)")+"``")
 
 	return cmd
 }
@@ -65,6 +83,14 @@ func (c *cmdRebuild) rebuild(conf *config.Config, args []string) error {
 		if len(args) > 1 {
 			return errors.New(i18n.G("--empty cannot be combined with an image name"))
 		}
+
+		if c.flagUpgrade {
+			return errors.New(i18n.G("--empty cannot be combined with --upgrade"))
+		}
+	}
+
+	if !c.flagUpgrade && (c.flagHealthCheck != "" || c.flagHealthCheckTimeout != 0) {
+		return errors.New(i18n.G("Health checks can only be used with --upgrade"))
 	}
 
 	d, err := conf.GetInstanceServer(remote)
@@ -114,10 +140,48 @@ func (c *cmdRebuild) rebuild(conf *config.Config, args []string) error {
 
 	// Base request
 	req := api.InstanceRebuildPost{
-		Source: api.InstanceSource{},
+		Source:  api.InstanceSource{},
+		Upgrade: c.flagUpgrade,
 	}
 
-	if !c.flagEmpty {
+	if c.flagUpgrade && (c.flagHealthCheck != "" || c.flagHealthCheckTimeout != 0) {
+		req.HealthCheck = &api.InstanceRebuildHealthCheck{
+			Timeout: c.flagHealthCheckTimeout,
+		}
+
+		if c.flagHealthCheck != "" {
+			req.HealthCheck.Command, err = shellquote.Split(c.flagHealthCheck)
+			if err != nil {
+				return fmt.Errorf(i18n.G("Invalid health check command: %w"), err)
+			}
+		}
+	}
+
+	if c.flagUpgrade && image == "" && iremote == "" {
+		// Upgrade using the update source of the instance's current image.
+		op, err := d.RebuildInstance(name, req)
+		if err != nil {
+			return err
+		}
+
+		progress := cli.ProgressRenderer{
+			Quiet: c.global.flagQuiet,
+		}
+
+		_, err = op.AddHandler(progress.UpdateOp)
+		if err != nil {
+			progress.Done("")
+			return err
+		}
+
+		err = cli.CancelableWait(op, &progress)
+		if err != nil {
+			progress.Done("")
+			return err
+		}
+
+		progress.Done("")
+	} else if !c.flagEmpty {
 		if image == "" && iremote == "" {
 			return errors.New(i18n.G("You need to specify an image name or use --empty"))
 		}
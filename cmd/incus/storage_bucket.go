@@ -1445,6 +1445,7 @@ type cmdStorageBucketExport struct {
 	storageBucket *cmdStorageBucket
 
 	flagCompressionAlgorithm string
+	flagEncryptTo            []string
 }
 
 // Command generates the command definition.
@@ -1459,6 +1460,7 @@ func (c *cmdStorageBucketExport) Command() *cobra.Command {
     Download a backup tarball of the b1 storage bucket from the default pool.`))
 
 	cmd.Flags().StringVar(&c.flagCompressionAlgorithm, "compression", "", i18n.G("Define a compression algorithm: for backup or none")+"``")
+	cmd.Flags().StringArrayVar(&c.flagEncryptTo, "encrypt-to", nil, i18n.G("Encrypt the backup to this age, SSH or OpenPGP recipient (can be repeated)")+"``")
 	cmd.Flags().StringVar(&c.storageBucket.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
 
 	cmd.RunE = c.Run
@@ -1501,6 +1503,7 @@ func (c *cmdStorageBucketExport) Run(cmd *cobra.Command, args []string) error {
 		Name:                 "",
 		ExpiresAt:            time.Now().Add(23 * time.Hour),
 		CompressionAlgorithm: c.flagCompressionAlgorithm,
+		EncryptionRecipients: c.flagEncryptTo,
 	}
 
 	op, err := s.CreateStoragePoolBucketBackup(pool.name, bucketName, req)
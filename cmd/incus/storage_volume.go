@@ -1469,6 +1469,14 @@ func (c *cmdStorageVolumeInfo) Run(cmd *cobra.Command, args []string) error {
 		if volState.Usage.Total > 0 {
 			fmt.Printf(i18n.G("Total: %s")+"\n", units.GetByteSizeStringIEC(int64(volState.Usage.Total), 2))
 		}
+
+		if volState.Usage.Referenced > 0 {
+			fmt.Printf(i18n.G("Referenced: %s")+"\n", units.GetByteSizeStringIEC(int64(volState.Usage.Referenced), 2))
+		}
+
+		if volState.Usage.UsedBySnapshots > 0 {
+			fmt.Printf(i18n.G("Used by snapshots: %s")+"\n", units.GetByteSizeStringIEC(int64(volState.Usage.UsedBySnapshots), 2))
+		}
 	}
 
 	if !vol.CreatedAt.IsZero() {
@@ -1480,6 +1488,12 @@ func (c *cmdStorageVolumeInfo) Run(cmd *cobra.Command, args []string) error {
 	if len(volSnapshots) > 0 {
 		snapData := [][]string{}
 
+		// Only show the space freed by deleting each snapshot when reported by the server.
+		var snapUsages map[string]api.StorageVolumeStateUsageSnapshot
+		if volState != nil && volState.Usage != nil {
+			snapUsages = volState.Usage.Snapshots
+		}
+
 		for _, snap := range volSnapshots {
 			if firstSnapshot {
 				fmt.Println("\n" + i18n.G("Snapshots:"))
@@ -1497,6 +1511,15 @@ func (c *cmdStorageVolumeInfo) Run(cmd *cobra.Command, args []string) error {
 				row = append(row, " ")
 			}
 
+			if snapUsages != nil {
+				snapUsage, ok := snapUsages[fields[len(fields)-1]]
+				if ok && snapUsage.Freed >= 0 {
+					row = append(row, units.GetByteSizeStringIEC(snapUsage.Freed, 2))
+				} else {
+					row = append(row, " ")
+				}
+			}
+
 			firstSnapshot = false
 			snapData = append(snapData, row)
 		}
@@ -1508,6 +1531,10 @@ func (c *cmdStorageVolumeInfo) Run(cmd *cobra.Command, args []string) error {
 			i18n.G("Expires at"),
 		}
 
+		if snapUsages != nil {
+			snapHeader = append(snapHeader, i18n.G("Freed if deleted"))
+		}
+
 		_ = cli.RenderTable(os.Stdout, cli.TableFormatTable, snapHeader, snapData, volSnapshots)
 	}
 
@@ -3055,6 +3082,7 @@ type cmdStorageVolumeExport struct {
 	flagVolumeOnly           bool
 	flagOptimizedStorage     bool
 	flagCompressionAlgorithm string
+	flagEncryptTo            []string
 }
 
 // Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
@@ -3069,6 +3097,7 @@ func (c *cmdStorageVolumeExport) Command() *cobra.Command {
 	cmd.Flags().BoolVar(&c.flagOptimizedStorage, "optimized-storage", false,
 		i18n.G("Use storage driver optimized format (can only be restored on a similar pool)"))
 	cmd.Flags().StringVar(&c.flagCompressionAlgorithm, "compression", "", i18n.G("Define a compression algorithm: for backup or none")+"``")
+	cmd.Flags().StringArrayVar(&c.flagEncryptTo, "encrypt-to", nil, i18n.G("Encrypt the backup to this age, SSH or OpenPGP recipient (can be repeated)")+"``")
 	cmd.Flags().StringVar(&c.storage.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
 	cmd.RunE = c.Run
 
@@ -3126,6 +3155,7 @@ func (c *cmdStorageVolumeExport) Run(cmd *cobra.Command, args []string) error {
 		VolumeOnly:           volumeOnly,
 		OptimizedStorage:     c.flagOptimizedStorage,
 		CompressionAlgorithm: c.flagCompressionAlgorithm,
+		EncryptionRecipients: c.flagEncryptTo,
 	}
 
 	op, err := d.CreateStorageVolumeBackup(name, volName, req)
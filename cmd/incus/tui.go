@@ -0,0 +1,1181 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gorilla/websocket"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	incus "github.com/lxc/incus/v6/client"
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/termios"
+)
+
+type cmdTui struct {
+	global *cmdGlobal
+}
+
+// Command is a method of the cmdTui structure that returns a new cobra Command for the terminal interface.
+func (c *cmdTui) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("tui", i18n.G("[<remote>:]"))
+	cmd.Short = i18n.G("Full-screen terminal interface")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Full-screen terminal interface
+
+Browse the remotes, projects, instances, networks, storage pools and volumes,
+operations and events of the server using the keyboard.
+
+== Keys ==
+  1-7, Tab    Switch between views
+  Up, Down    Select an entry (also j and k)
+  Enter       Use the selected remote or project
+  r           Refresh the current view
+  e           Edit the selected entry in the text editor
+  q           Quit
+
+== Instance keys ==
+  s           Start the instance
+  S           Stop the instance
+  n           Create a snapshot of the instance
+  x           Open a shell in the instance (in a split pane)
+  c           Attach to the instance console (in a split pane, detach with <ctrl>+a q)`))
+
+	cmd.RunE = c.Run
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdTui) Run(cmd *cobra.Command, args []string) error {
+	conf := c.global.conf
+
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 0, 1)
+	if exit {
+		return err
+	}
+
+	if !termios.IsTerminal(getStdinFd()) || !termios.IsTerminal(getStdoutFd()) {
+		return errors.New(i18n.G("The terminal interface requires a terminal"))
+	}
+
+	remoteInput := ""
+	if len(args) > 0 {
+		remoteInput = args[0]
+	}
+
+	remote, _, err := conf.ParseRemote(remoteInput)
+	if err != nil {
+		return err
+	}
+
+	t := &tuiState{
+		global:  c.global,
+		out:     getStdout(),
+		keys:    make(chan []byte),
+		resume:  make(chan struct{}),
+		events:  make(chan api.Event, 100),
+		results: make(chan string, 10),
+		loaded:  make(chan tuiLoad, 1),
+	}
+
+	err = t.connect(remote, "")
+	if err != nil {
+		return err
+	}
+
+	defer t.disconnect()
+
+	return t.run()
+}
+
+const (
+	tuiViewInstances = iota
+	tuiViewProjects
+	tuiViewNetworks
+	tuiViewStorage
+	tuiViewOperations
+	tuiViewEvents
+	tuiViewRemotes
+)
+
+// tuiMaxEvents is the number of events kept for the events view.
+const tuiMaxEvents = 500
+
+// tuiStateInterval is how often the instance state is refreshed, as it changes without lifecycle events.
+const tuiStateInterval = 5 * time.Second
+
+// tuiRow is an entry of the current view.
+type tuiRow struct {
+	cells []string
+
+	name    string
+	pool    string
+	volType string
+}
+
+// tuiLoad is the result of loading the entries of a view.
+type tuiLoad struct {
+	view    int
+	server  incus.InstanceServer
+	columns []string
+	rows    []tuiRow
+	err     error
+}
+
+// tuiSession is an exec or console session running in the lower pane.
+type tuiSession struct {
+	title   string
+	console bool
+	escape  bool
+
+	stdin      *io.PipeWriter
+	disconnect chan bool
+	ended      chan struct{}
+	done       chan error
+}
+
+// tuiState holds the state of the terminal interface.
+type tuiState struct {
+	global *cmdGlobal
+	out    io.Writer
+
+	remote   string
+	project  string
+	server   incus.InstanceServer
+	listener *incus.EventListener
+
+	// Connection used to fetch the instance state, bypassing the response cache.
+	stateServer incus.InstanceServer
+
+	width  int
+	height int
+
+	view     int
+	columns  []string
+	rows     []tuiRow
+	selected int
+	offset   int
+	dirty    bool
+	status   string
+
+	// Whether entries are being loaded and whether to load them again once done.
+	loading       bool
+	reloadPending bool
+	loadedAt      time.Time
+
+	eventLog []api.Event
+	session  *tuiSession
+
+	// Terminal state to restore when handing the terminal over to the text editor.
+	termState *termios.State
+
+	keys    chan []byte
+	resume  chan struct{}
+	events  chan api.Event
+	results chan string
+	loaded  chan tuiLoad
+}
+
+// tuiViewNames returns the names of the views.
+func tuiViewNames() []string {
+	return []string{
+		i18n.G("Instances"),
+		i18n.G("Projects"),
+		i18n.G("Networks"),
+		i18n.G("Storage"),
+		i18n.G("Operations"),
+		i18n.G("Events"),
+		i18n.G("Remotes"),
+	}
+}
+
+// connect connects to a remote (and project) and starts listening to its events.
+func (t *tuiState) connect(remote string, project string) error {
+	// Only fetch the entries again from the server once they changed.
+	t.global.conf.ResponseCache = true
+	d, err := t.global.conf.GetInstanceServer(remote)
+	t.global.conf.ResponseCache = false
+	if err != nil {
+		return err
+	}
+
+	stateServer, err := t.global.conf.GetInstanceServer(remote)
+	if err != nil {
+		return err
+	}
+
+	if project != "" {
+		d = d.UseProject(project)
+	}
+
+	info, err := d.GetConnectionInfo()
+	if err != nil {
+		return err
+	}
+
+	t.disconnect()
+
+	t.remote = remote
+	t.project = info.Project
+	t.server = d.UseProject(info.Project)
+	t.stateServer = stateServer.UseProject(info.Project)
+	t.eventLog = nil
+
+	listener, err := t.server.GetEvents()
+	if err != nil {
+		t.status = fmt.Sprintf(i18n.G("Failed to listen for events: %v"), err)
+		return nil
+	}
+
+	_, err = listener.AddHandler([]string{api.EventTypeLifecycle, api.EventTypeOperation}, func(event api.Event) {
+		select {
+		case t.events <- event:
+		default:
+		}
+	})
+	if err != nil {
+		listener.Disconnect()
+		return err
+	}
+
+	t.listener = listener
+
+	return nil
+}
+
+// disconnect stops listening to the events of the current remote.
+func (t *tuiState) disconnect() {
+	if t.listener != nil {
+		t.listener.Disconnect()
+		t.listener = nil
+	}
+}
+
+// run sets up the terminal and processes the keyboard input and events until the user quits.
+func (t *tuiState) run() error {
+	fd := getStdinFd()
+
+	oldState, err := termios.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = termios.Restore(fd, oldState) }()
+
+	t.termState = oldState
+
+	// Switch to the alternate screen and hide the cursor.
+	_, _ = fmt.Fprint(t.out, "\x1b[?1049h\x1b[?25l")
+	defer func() { _, _ = fmt.Fprint(t.out, "\x1b[?25h\x1b[?1049l") }()
+
+	// Read the keyboard input, waiting for each key to be handled before reading the next one
+	// so that the terminal can be handed over to the text editor.
+	go func() {
+		buf := make([]byte, 128)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if err != nil {
+				close(t.keys)
+				return
+			}
+
+			t.keys <- slices.Clone(buf[:n])
+			<-t.resume
+		}
+	}()
+
+	t.updateSize()
+	t.reload()
+
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+
+	var sessionDone chan error
+	for {
+		if t.session == nil {
+			t.render()
+		}
+
+		select {
+		case key, ok := <-t.keys:
+			if !ok {
+				return nil
+			}
+
+			if t.session != nil {
+				t.sessionInput(key)
+				t.resume <- struct{}{}
+				continue
+			}
+
+			quit := t.handleKey(string(key))
+			if quit {
+				return nil
+			}
+
+			if t.session != nil {
+				sessionDone = t.session.done
+			}
+
+			t.resume <- struct{}{}
+
+		case err := <-sessionDone:
+			t.endSession(err)
+			sessionDone = nil
+
+		case event := <-t.events:
+			t.eventLog = append(t.eventLog, event)
+			if len(t.eventLog) > tuiMaxEvents {
+				t.eventLog = t.eventLog[len(t.eventLog)-tuiMaxEvents:]
+			}
+
+			if event.Type == api.EventTypeLifecycle || t.view == tuiViewOperations || t.view == tuiViewEvents {
+				t.dirty = true
+			}
+
+			if t.session != nil {
+				continue
+			}
+
+		case msg := <-t.results:
+			t.status = msg
+			t.dirty = true
+
+		case result := <-t.loaded:
+			t.applyLoad(result)
+
+		case <-ticker.C:
+			// The pane keeps its size until the session ends.
+			if t.session != nil {
+				continue
+			}
+
+			if t.stateRefreshDue(time.Now()) {
+				t.dirty = true
+			}
+
+			resized := t.updateSize()
+			if t.dirty {
+				t.reload()
+			} else if !resized {
+				continue
+			}
+		}
+	}
+}
+
+// stateRefreshDue returns whether the instance state shown in the current view should be refreshed.
+func (t *tuiState) stateRefreshDue(now time.Time) bool {
+	return t.view == tuiViewInstances && !t.loading && now.Sub(t.loadedAt) >= tuiStateInterval
+}
+
+// updateSize records the size of the terminal, returning whether it changed.
+func (t *tuiState) updateSize() bool {
+	width, height, err := termios.GetSize(getStdoutFd())
+	if err != nil || (width == t.width && height == t.height) {
+		return false
+	}
+
+	t.width = width
+	t.height = height
+
+	return true
+}
+
+// paneHeight returns the height of the lower pane used by exec and console sessions.
+func (t *tuiState) paneHeight() int {
+	return max(t.height-t.height/3-1, 1)
+}
+
+// handleKey processes a key press, returning whether to quit.
+func (t *tuiState) handleKey(key string) bool {
+	switch key {
+	case "q", "\x03":
+		return true
+	case "\t":
+		t.switchView((t.view + 1) % len(tuiViewNames()))
+	case "\x1b[Z":
+		t.switchView((t.view + len(tuiViewNames()) - 1) % len(tuiViewNames()))
+	case "\x1b[A", "\x1bOA", "k":
+		t.selected--
+	case "\x1b[B", "\x1bOB", "j":
+		t.selected++
+	case "\x1b[5~":
+		t.selected -= t.listHeight()
+	case "\x1b[6~":
+		t.selected += t.listHeight()
+	case "\x1b[H", "\x1bOH", "\x1b[1~", "g":
+		t.selected = 0
+	case "\x1b[F", "\x1bOF", "\x1b[4~", "G":
+		t.selected = len(t.rows) - 1
+	case "r":
+		t.reload()
+	case "\r":
+		t.use()
+	case "e":
+		t.edit()
+	case "s", "S", "n", "x", "c":
+		t.instanceAction(key)
+	default:
+		index, err := strconv.Atoi(key)
+		if err == nil && index >= 1 && index <= len(tuiViewNames()) {
+			t.switchView(index - 1)
+		}
+	}
+
+	t.selected = max(min(t.selected, len(t.rows)-1), 0)
+
+	return false
+}
+
+// switchView shows another view.
+func (t *tuiState) switchView(view int) {
+	if view == t.view {
+		return
+	}
+
+	t.view = view
+	t.columns = nil
+	t.rows = nil
+	t.selected = 0
+	t.offset = 0
+	t.reload()
+}
+
+// selectedRow returns the currently selected entry.
+func (t *tuiState) selectedRow() *tuiRow {
+	if t.selected < 0 || t.selected >= len(t.rows) {
+		return nil
+	}
+
+	return &t.rows[t.selected]
+}
+
+// reload fetches the entries of the current view in the background.
+// Reloads requested while loading are coalesced into a single one once the current load is done.
+func (t *tuiState) reload() {
+	t.dirty = false
+
+	if t.loading {
+		t.reloadPending = true
+		return
+	}
+
+	t.loading = true
+
+	// Load from a copy of the state as the interface keeps changing it.
+	state := *t
+	state.eventLog = slices.Clone(t.eventLog)
+
+	go func() {
+		columns, rows, err := state.load()
+		t.loaded <- tuiLoad{view: state.view, server: state.server, columns: columns, rows: rows, err: err}
+	}()
+}
+
+// applyLoad shows the loaded entries, unless the view or remote changed in the meantime.
+func (t *tuiState) applyLoad(result tuiLoad) {
+	t.loading = false
+
+	if t.reloadPending {
+		t.reloadPending = false
+		t.reload()
+	}
+
+	if result.view != t.view || result.server != t.server {
+		return
+	}
+
+	t.loadedAt = time.Now()
+
+	if result.err != nil {
+		t.status = result.err.Error()
+		return
+	}
+
+	var selectedName string
+	row := t.selectedRow()
+	if row != nil {
+		selectedName = row.pool + "/" + row.name
+	}
+
+	t.columns = result.columns
+	t.rows = result.rows
+
+	// Keep the same entry selected.
+	for i, row := range t.rows {
+		if row.pool+"/"+row.name == selectedName {
+			t.selected = i
+			break
+		}
+	}
+
+	t.selected = max(min(t.selected, len(t.rows)-1), 0)
+}
+
+// load returns the columns and entries of the current view.
+func (t *tuiState) load() ([]string, []tuiRow, error) {
+	switch t.view {
+	case tuiViewInstances:
+		instances, err := t.stateServer.GetInstancesFull(api.InstanceTypeAny)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		rows := []tuiRow{}
+		for _, inst := range instances {
+			rows = append(rows, tuiRow{name: inst.Name, cells: []string{inst.Name, strings.ToUpper(inst.Status), inst.Type, tuiInstanceIPv4(inst), strconv.Itoa(len(inst.Snapshots)), inst.Location}})
+		}
+
+		return []string{i18n.G("NAME"), i18n.G("STATE"), i18n.G("TYPE"), i18n.G("IPV4"), i18n.G("SNAPSHOTS"), i18n.G("LOCATION")}, rows, nil
+
+	case tuiViewProjects:
+		projects, err := t.server.GetProjects()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		rows := []tuiRow{}
+		for _, project := range projects {
+			name := project.Name
+			if name == t.project {
+				name = fmt.Sprintf(i18n.G("%s (current)"), name)
+			}
+
+			rows = append(rows, tuiRow{name: project.Name, cells: []string{name, project.Description, strconv.Itoa(len(project.UsedBy))}})
+		}
+
+		return []string{i18n.G("NAME"), i18n.G("DESCRIPTION"), i18n.G("USED BY")}, rows, nil
+
+	case tuiViewNetworks:
+		networks, err := t.server.GetNetworks()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		rows := []tuiRow{}
+		for _, network := range networks {
+			managed := i18n.G("NO")
+			if network.Managed {
+				managed = i18n.G("YES")
+			}
+
+			rows = append(rows, tuiRow{name: network.Name, cells: []string{network.Name, network.Type, managed, strings.ToUpper(network.Status), strconv.Itoa(len(network.UsedBy))}})
+		}
+
+		return []string{i18n.G("NAME"), i18n.G("TYPE"), i18n.G("MANAGED"), i18n.G("STATE"), i18n.G("USED BY")}, rows, nil
+
+	case tuiViewStorage:
+		pools, err := t.server.GetStoragePools()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		rows := []tuiRow{}
+		for _, pool := range pools {
+			rows = append(rows, tuiRow{name: pool.Name, cells: []string{pool.Name, pool.Driver, strings.ToUpper(pool.Status), "", "", ""}})
+
+			volumes, err := t.server.GetStoragePoolVolumes(pool.Name)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			for _, volume := range volumes {
+				if volume.Type != "custom" {
+					continue
+				}
+
+				rows = append(rows, tuiRow{pool: pool.Name, name: volume.Name, volType: volume.Type, cells: []string{"", "", "", volume.Name, volume.ContentType, volume.Location}})
+			}
+		}
+
+		return []string{i18n.G("POOL"), i18n.G("DRIVER"), i18n.G("STATE"), i18n.G("VOLUME"), i18n.G("CONTENT-TYPE"), i18n.G("LOCATION")}, rows, nil
+
+	case tuiViewOperations:
+		operations, err := t.server.GetOperations()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		sort.Slice(operations, func(i, j int) bool {
+			return operations[i].CreatedAt.After(operations[j].CreatedAt)
+		})
+
+		rows := []tuiRow{}
+		for _, op := range operations {
+			rows = append(rows, tuiRow{name: op.ID, cells: []string{op.ID, strings.ToUpper(op.Class), op.Description, strings.ToUpper(op.Status), op.CreatedAt.Local().Format(time.DateTime)}})
+		}
+
+		return []string{i18n.G("ID"), i18n.G("TYPE"), i18n.G("DESCRIPTION"), i18n.G("STATUS"), i18n.G("CREATED")}, rows, nil
+
+	case tuiViewEvents:
+		rows := []tuiRow{}
+		for i := len(t.eventLog) - 1; i >= 0; i-- {
+			event := t.eventLog[i]
+			rows = append(rows, tuiRow{cells: []string{event.Timestamp.Local().Format(time.DateTime), event.Type, event.Location, event.Project, tuiEventDetails(event)}})
+		}
+
+		return []string{i18n.G("TIME"), i18n.G("TYPE"), i18n.G("LOCATION"), i18n.G("PROJECT"), i18n.G("DETAILS")}, rows, nil
+
+	case tuiViewRemotes:
+		names := []string{}
+		for name, remote := range t.global.conf.Remotes {
+			if remote.Public || (remote.Protocol != "" && remote.Protocol != "incus") {
+				continue
+			}
+
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		rows := []tuiRow{}
+		for _, name := range names {
+			label := name
+			if name == t.remote {
+				label = fmt.Sprintf(i18n.G("%s (current)"), name)
+			}
+
+			rows = append(rows, tuiRow{name: name, cells: []string{label, t.global.conf.Remotes[name].Addr}})
+		}
+
+		return []string{i18n.G("NAME"), i18n.G("URL")}, rows, nil
+	}
+
+	return nil, nil, nil
+}
+
+// tuiInstanceIPv4 returns the first global IPv4 address of an instance.
+func tuiInstanceIPv4(inst api.InstanceFull) string {
+	if inst.State == nil {
+		return ""
+	}
+
+	names := make([]string, 0, len(inst.State.Network))
+	for name := range inst.State.Network {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, addr := range inst.State.Network[name].Addresses {
+			if addr.Family == "inet" && addr.Scope == "global" {
+				return fmt.Sprintf("%s (%s)", addr.Address, name)
+			}
+		}
+	}
+
+	return ""
+}
+
+// tuiEventDetails returns a one line description of an event.
+func tuiEventDetails(event api.Event) string {
+	switch event.Type {
+	case api.EventTypeLifecycle:
+		lifecycle := api.EventLifecycle{}
+		err := json.Unmarshal(event.Metadata, &lifecycle)
+		if err == nil {
+			return fmt.Sprintf("%s %s", lifecycle.Action, lifecycle.Source)
+		}
+
+	case api.EventTypeOperation:
+		op := api.Operation{}
+		err := json.Unmarshal(event.Metadata, &op)
+		if err == nil {
+			return fmt.Sprintf("%s: %s", op.Description, op.Status)
+		}
+	}
+
+	return ""
+}
+
+// use switches to the selected remote or project.
+func (t *tuiState) use() {
+	row := t.selectedRow()
+	if row == nil {
+		return
+	}
+
+	var err error
+
+	switch t.view {
+	case tuiViewRemotes:
+		err = t.connect(row.name, "")
+	case tuiViewProjects:
+		err = t.connect(t.remote, row.name)
+	default:
+		return
+	}
+
+	if err != nil {
+		t.status = err.Error()
+		return
+	}
+
+	t.status = fmt.Sprintf(i18n.G("Using project %q on remote %q"), t.project, t.remote)
+	t.switchView(tuiViewInstances)
+}
+
+// background runs an action without blocking the interface, reporting its result in the status line.
+func (t *tuiState) background(description string, action func() error) {
+	t.status = description + "..."
+
+	go func() {
+		err := action()
+		if err != nil {
+			t.results <- fmt.Sprintf("%s: %v", description, err)
+			return
+		}
+
+		t.results <- fmt.Sprintf(i18n.G("%s: done"), description)
+	}()
+}
+
+// instanceAction runs an action on the selected instance.
+func (t *tuiState) instanceAction(key string) {
+	row := t.selectedRow()
+	if t.view != tuiViewInstances || row == nil {
+		return
+	}
+
+	d := t.server
+	name := row.name
+
+	changeState := func(action string) func() error {
+		return func() error {
+			op, err := d.UpdateInstanceState(name, api.InstanceStatePut{Action: action, Timeout: -1}, "")
+			if err != nil {
+				return err
+			}
+
+			return op.Wait()
+		}
+	}
+
+	switch key {
+	case "s":
+		t.background(fmt.Sprintf(i18n.G("Starting %s"), name), changeState("start"))
+	case "S":
+		t.background(fmt.Sprintf(i18n.G("Stopping %s"), name), changeState("stop"))
+	case "n":
+		t.background(fmt.Sprintf(i18n.G("Creating a snapshot of %s"), name), func() error {
+			op, err := d.CreateInstanceSnapshot(name, api.InstanceSnapshotsPost{})
+			if err != nil {
+				return err
+			}
+
+			return op.Wait()
+		})
+
+	case "x":
+		t.startSession(name, false)
+	case "c":
+		t.startSession(name, true)
+	}
+}
+
+// startSession opens an exec or console session to an instance in the lower pane.
+func (t *tuiState) startSession(name string, console bool) {
+	stdinReader, stdinWriter := io.Pipe()
+
+	session := &tuiSession{
+		console:    console,
+		stdin:      stdinWriter,
+		disconnect: make(chan bool),
+		ended:      make(chan struct{}),
+		done:       make(chan error, 1),
+	}
+
+	session.title = fmt.Sprintf(i18n.G("Shell in %s (exit the shell to return)"), name)
+	if console {
+		session.title = fmt.Sprintf(i18n.G("Console of %s (detach with <ctrl>+a q)"), name)
+	}
+
+	// Close the control connection once the session is over.
+	control := func(conn *websocket.Conn) {
+		<-session.ended
+
+		closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+		_ = conn.WriteMessage(websocket.CloseMessage, closeMsg)
+	}
+
+	// Draw the interface in the upper part of the screen and restrict the output to the lower pane.
+	t.session = session
+	t.render()
+
+	_, _ = fmt.Fprintf(t.out, "\x1b[%d;%dr\x1b[?6h\x1b[H\x1b[J\x1b[?25h", t.height-t.paneHeight()+1, t.height)
+
+	d := t.server
+	width := t.width
+	height := t.paneHeight()
+
+	go func() {
+		var op incus.Operation
+		var err error
+
+		if console {
+			op, err = d.ConsoleInstance(name, api.InstanceConsolePost{Width: width, Height: height, Type: "console"}, &incus.InstanceConsoleArgs{
+				Terminal:          &readWriteCloser{stdinReader, getStdout()},
+				Control:           control,
+				ConsoleDisconnect: session.disconnect,
+			})
+		} else {
+			term := os.Getenv("TERM")
+			if term == "" {
+				term = "xterm"
+			}
+
+			execArgs := &incus.InstanceExecArgs{
+				Stdin:    stdinReader,
+				Stdout:   getStdout(),
+				Stderr:   getStdout(),
+				Control:  control,
+				DataDone: make(chan bool),
+			}
+
+			op, err = d.ExecInstance(name, api.InstanceExecPost{
+				Command:     []string{"su", "-l"},
+				WaitForWS:   true,
+				Interactive: true,
+				Environment: map[string]string{"TERM": term},
+				Width:       width,
+				Height:      height,
+			}, execArgs)
+			if err == nil {
+				err = op.Wait()
+				<-execArgs.DataDone
+			}
+		}
+
+		if console && err == nil {
+			err = op.Wait()
+		}
+
+		// Unblock any pending input.
+		_ = stdinReader.Close()
+
+		session.done <- err
+	}()
+}
+
+// sessionInput forwards the keyboard input to the running session.
+func (t *tuiState) sessionInput(key []byte) {
+	session := t.session
+
+	// Look for the console detach sequence (<ctrl>+a q).
+	data := make([]byte, 0, len(key))
+	for _, b := range key {
+		if session.console && session.escape {
+			session.escape = false
+
+			if b == 'q' {
+				select {
+				case <-session.disconnect:
+				default:
+					close(session.disconnect)
+				}
+
+				return
+			}
+		} else if session.console && b == '\x01' {
+			session.escape = true
+			continue
+		}
+
+		data = append(data, b)
+	}
+
+	if len(data) > 0 {
+		_, _ = session.stdin.Write(data)
+	}
+}
+
+// endSession restores the interface once the session is over.
+func (t *tuiState) endSession(err error) {
+	session := t.session
+	t.session = nil
+
+	_ = session.stdin.Close()
+	close(session.ended)
+
+	_, _ = fmt.Fprint(t.out, "\x1b[?6l\x1b[r\x1b[?25l\x1b[H\x1b[J")
+
+	if err != nil {
+		t.status = err.Error()
+	} else {
+		t.status = i18n.G("Session ended")
+	}
+
+	t.reload()
+}
+
+// edit opens the selected entry in the text editor and applies the changes.
+func (t *tuiState) edit() {
+	row := t.selectedRow()
+	if row == nil {
+		return
+	}
+
+	d := t.server
+	name := row.name
+
+	var err error
+
+	switch {
+	case t.view == tuiViewInstances:
+		inst, etag, errGet := d.GetInstance(name)
+		if errGet != nil {
+			err = errGet
+			break
+		}
+
+		newData := api.InstancePut{}
+		err = t.editYAML(inst.Writable(), &newData, func() error {
+			op, err := d.UpdateInstance(name, newData, etag)
+			if err != nil {
+				return err
+			}
+
+			return op.Wait()
+		})
+
+	case t.view == tuiViewProjects:
+		project, etag, errGet := d.GetProject(name)
+		if errGet != nil {
+			err = errGet
+			break
+		}
+
+		newData := api.ProjectPut{}
+		err = t.editYAML(project.Writable(), &newData, func() error {
+			return d.UpdateProject(name, newData, etag)
+		})
+
+	case t.view == tuiViewNetworks:
+		network, etag, errGet := d.GetNetwork(name)
+		if errGet != nil {
+			err = errGet
+			break
+		}
+
+		newData := api.NetworkPut{}
+		err = t.editYAML(network.Writable(), &newData, func() error {
+			return d.UpdateNetwork(name, newData, etag)
+		})
+
+	case t.view == tuiViewStorage && row.pool == "":
+		pool, etag, errGet := d.GetStoragePool(name)
+		if errGet != nil {
+			err = errGet
+			break
+		}
+
+		newData := api.StoragePoolPut{}
+		err = t.editYAML(pool.Writable(), &newData, func() error {
+			return d.UpdateStoragePool(name, newData, etag)
+		})
+
+	case t.view == tuiViewStorage:
+		volume, etag, errGet := d.GetStoragePoolVolume(row.pool, row.volType, name)
+		if errGet != nil {
+			err = errGet
+			break
+		}
+
+		newData := api.StorageVolumePut{}
+		err = t.editYAML(volume.Writable(), &newData, func() error {
+			return d.UpdateStoragePoolVolume(row.pool, row.volType, name, newData, etag)
+		})
+
+	default:
+		return
+	}
+
+	if err != nil {
+		t.status = err.Error()
+		return
+	}
+
+	t.reload()
+}
+
+// editYAML hands the terminal over to the text editor to modify the YAML representation of an entity.
+func (t *tuiState) editYAML(current any, target any, update func() error) error {
+	data, err := yaml.Marshal(current)
+	if err != nil {
+		return err
+	}
+
+	// Leave the alternate screen and restore the terminal for the editor.
+	fd := getStdinFd()
+
+	rawState, err := termios.GetState(fd)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprint(t.out, "\x1b[?25h\x1b[?1049l")
+
+	err = termios.Restore(fd, t.termState)
+	if err != nil {
+		return err
+	}
+
+	content, err := textEditor("", data)
+
+	_ = termios.Restore(fd, rawState)
+	_, _ = fmt.Fprint(t.out, "\x1b[?1049h\x1b[?25l")
+	t.width = 0
+	t.updateSize()
+
+	if err != nil {
+		return err
+	}
+
+	if bytes.Equal(content, data) {
+		t.status = i18n.G("No changes")
+		return nil
+	}
+
+	err = yaml.Unmarshal(content, target)
+	if err != nil {
+		return err
+	}
+
+	err = update()
+	if err != nil {
+		return err
+	}
+
+	t.status = i18n.G("Changes applied")
+
+	return nil
+}
+
+// listHeight returns the number of entries visible at once.
+func (t *tuiState) listHeight() int {
+	if t.session != nil {
+		return max(t.height-t.paneHeight()-4, 1)
+	}
+
+	return max(t.height-5, 1)
+}
+
+// render draws the interface (only the upper part while a session is running).
+func (t *tuiState) render() {
+	lines := []string{}
+
+	// Title and views.
+	lines = append(lines, "\x1b[7m"+tuiFit(" "+fmt.Sprintf(i18n.G("Incus - remote: %s - project: %s"), t.remote, t.project), t.width)+"\x1b[0m")
+
+	tabs := ""
+	for i, name := range tuiViewNames() {
+		tab := fmt.Sprintf(" %d %s ", i+1, name)
+		if i == t.view {
+			tab = "\x1b[1;7m" + tab + "\x1b[0m"
+		}
+
+		tabs += tab
+	}
+
+	lines = append(lines, tabs+"\x1b[K")
+
+	// Entries.
+	widths := make([]int, len(t.columns))
+	for i, column := range t.columns {
+		widths[i] = utf8.RuneCountInString(column)
+	}
+
+	for _, row := range t.rows {
+		for i, cell := range row.cells {
+			if i < len(widths) {
+				widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+			}
+		}
+	}
+
+	formatRow := func(cells []string) string {
+		line := ""
+		for i, cell := range cells {
+			if i < len(widths) {
+				line += " " + cell + strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)) + " "
+			}
+		}
+
+		return tuiFit(line, t.width)
+	}
+
+	lines = append(lines, "\x1b[1m"+formatRow(t.columns)+"\x1b[0m")
+
+	height := t.listHeight()
+	if t.selected < t.offset {
+		t.offset = t.selected
+	} else if t.selected >= t.offset+height {
+		t.offset = t.selected - height + 1
+	}
+
+	for i := t.offset; i < t.offset+height; i++ {
+		if i >= len(t.rows) {
+			lines = append(lines, "\x1b[K")
+			continue
+		}
+
+		line := formatRow(t.rows[i].cells)
+		if i == t.selected {
+			line = "\x1b[7m" + line + "\x1b[0m"
+		}
+
+		lines = append(lines, line)
+	}
+
+	// Session title or status and keys.
+	if t.session != nil {
+		lines = append(lines, "\x1b[7m"+tuiFit(" "+t.session.title, t.width)+"\x1b[0m")
+	} else {
+		lines = append(lines, tuiFit(" "+t.status, t.width))
+
+		keys := i18n.G("1-7/Tab: views  Up/Down: select  r: refresh  q: quit")
+		switch t.view {
+		case tuiViewInstances:
+			keys = i18n.G("s: start  S: stop  n: snapshot  x: shell  c: console  e: edit") + "  " + keys
+		case tuiViewProjects:
+			keys = i18n.G("Enter: use  e: edit") + "  " + keys
+		case tuiViewNetworks, tuiViewStorage:
+			keys = i18n.G("e: edit") + "  " + keys
+		case tuiViewRemotes:
+			keys = i18n.G("Enter: use") + "  " + keys
+		}
+
+		lines = append(lines, "\x1b[7m"+tuiFit(" "+keys, t.width)+"\x1b[0m")
+	}
+
+	_, _ = fmt.Fprint(t.out, "\x1b[H"+strings.Join(lines, "\r\n"))
+}
+
+// tuiFit truncates or pads a line to the width of the terminal.
+func tuiFit(line string, width int) string {
+	line = strings.NewReplacer("\r", " ", "\n", " ", "\t", " ").Replace(line)
+
+	length := utf8.RuneCountInString(line)
+	if length > width {
+		return string([]rune(line)[:width])
+	}
+
+	return line + strings.Repeat(" ", width-length)
+}
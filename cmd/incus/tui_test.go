@@ -0,0 +1,170 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// tuiTestState returns a terminal interface state showing the given rows.
+func tuiTestState(rows int) *tuiState {
+	t := &tuiState{height: 15, loaded: make(chan tuiLoad, 1)}
+	for range rows {
+		t.rows = append(t.rows, tuiRow{})
+	}
+
+	return t
+}
+
+// tuiWaitLoad waits for entries to be loaded in the background and shows them.
+func tuiWaitLoad(t *testing.T, state *tuiState) {
+	t.Helper()
+
+	select {
+	case result := <-state.loaded:
+		state.applyLoad(result)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the entries to load")
+	}
+}
+
+func TestTuiFit(t *testing.T) {
+	assert.Equal(t, "abc  ", tuiFit("abc", 5))
+	assert.Equal(t, "abcde", tuiFit("abcdefgh", 5))
+	assert.Equal(t, "ééé", tuiFit("éééé", 3))
+	assert.Equal(t, "a b c", tuiFit("a\tb\nc", 5))
+	assert.Equal(t, "", tuiFit("abc", 0))
+}
+
+func TestTuiInstanceIPv4(t *testing.T) {
+	assert.Empty(t, tuiInstanceIPv4(api.InstanceFull{}))
+
+	inst := api.InstanceFull{State: &api.InstanceState{Network: map[string]api.InstanceStateNetwork{
+		"lo": {Addresses: []api.InstanceStateNetworkAddress{{Family: "inet", Address: "127.0.0.1", Scope: "local"}}},
+		"eth1": {Addresses: []api.InstanceStateNetworkAddress{
+			{Family: "inet6", Address: "fd42::10", Scope: "global"},
+			{Family: "inet", Address: "10.0.1.10", Scope: "global"},
+		}},
+		"eth0": {Addresses: []api.InstanceStateNetworkAddress{
+			{Family: "inet", Address: "169.254.0.10", Scope: "link"},
+			{Family: "inet", Address: "10.0.0.10", Scope: "global"},
+		}},
+	}}}
+
+	// The first global address of the interfaces sorted by name is used.
+	assert.Equal(t, "10.0.0.10 (eth0)", tuiInstanceIPv4(inst))
+
+	delete(inst.State.Network, "eth0")
+	assert.Equal(t, "10.0.1.10 (eth1)", tuiInstanceIPv4(inst))
+
+	delete(inst.State.Network, "eth1")
+	assert.Empty(t, tuiInstanceIPv4(inst))
+}
+
+func TestTuiEventDetails(t *testing.T) {
+	lifecycle, err := json.Marshal(api.EventLifecycle{Action: "instance-started", Source: "/1.0/instances/c1"})
+	require.NoError(t, err)
+
+	operation, err := json.Marshal(api.Operation{Description: "Creating instance", Status: "Running"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "instance-started /1.0/instances/c1", tuiEventDetails(api.Event{Type: api.EventTypeLifecycle, Metadata: lifecycle}))
+	assert.Equal(t, "Creating instance: Running", tuiEventDetails(api.Event{Type: api.EventTypeOperation, Metadata: operation}))
+	assert.Empty(t, tuiEventDetails(api.Event{Type: api.EventTypeLifecycle, Metadata: json.RawMessage(`"invalid"`)}))
+	assert.Empty(t, tuiEventDetails(api.Event{Type: api.EventTypeLogging, Metadata: lifecycle}))
+}
+
+func TestTuiHandleKeyClamping(t *testing.T) {
+	state := tuiTestState(20)
+
+	// The selection stays within the entries.
+	state.handleKey("k")
+	assert.Equal(t, 0, state.selected)
+
+	state.handleKey("j")
+	assert.Equal(t, 1, state.selected)
+
+	state.handleKey("\x1b[6~")
+	assert.Equal(t, 11, state.selected)
+
+	state.handleKey("\x1b[6~")
+	assert.Equal(t, 19, state.selected)
+
+	state.handleKey("j")
+	assert.Equal(t, 19, state.selected)
+
+	state.handleKey("\x1b[5~")
+	assert.Equal(t, 9, state.selected)
+
+	state.handleKey("g")
+	assert.Equal(t, 0, state.selected)
+
+	state.handleKey("G")
+	assert.Equal(t, 19, state.selected)
+
+	// Without entries, the selection stays on the first line.
+	state = tuiTestState(0)
+	for _, key := range []string{"j", "G", "\x1b[6~", "k"} {
+		state.handleKey(key)
+		assert.Equal(t, 0, state.selected)
+	}
+}
+
+func TestTuiReload(t *testing.T) {
+	state := tuiTestState(0)
+	state.view = tuiViewEvents
+	state.eventLog = []api.Event{{Type: api.EventTypeLifecycle}, {Type: api.EventTypeOperation}}
+	state.dirty = true
+
+	// Reloads requested while loading are coalesced into a single one.
+	state.reload()
+	state.reload()
+	state.reload()
+	assert.True(t, state.loading)
+	assert.True(t, state.reloadPending)
+	assert.False(t, state.dirty)
+
+	tuiWaitLoad(t, state)
+	assert.Len(t, state.rows, 2)
+	assert.True(t, state.loading)
+	assert.False(t, state.reloadPending)
+
+	tuiWaitLoad(t, state)
+	assert.False(t, state.loading)
+	assert.Empty(t, state.loaded)
+
+	// The selected entry is kept and results for another view are dropped.
+	state.selected = 1
+	state.eventLog = append(state.eventLog, api.Event{Type: api.EventTypeLogging})
+	state.reload()
+	state.view = tuiViewOperations
+
+	tuiWaitLoad(t, state)
+	assert.Len(t, state.rows, 2)
+	assert.Equal(t, 1, state.selected)
+	assert.False(t, state.loading)
+}
+
+func TestTuiStateRefreshDue(t *testing.T) {
+	state := tuiTestState(0)
+	now := time.Now()
+	state.loadedAt = now
+
+	// The instance state is refreshed periodically.
+	assert.False(t, state.stateRefreshDue(now.Add(time.Second)))
+	assert.True(t, state.stateRefreshDue(now.Add(tuiStateInterval)))
+
+	// But not while loading.
+	state.loading = true
+	assert.False(t, state.stateRefreshDue(now.Add(tuiStateInterval)))
+
+	// And only in the instances view.
+	state.loading = false
+	state.view = tuiViewNetworks
+	assert.False(t, state.stateRefreshDue(now.Add(tuiStateInterval)))
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+type cmdWait struct {
+	global *cmdGlobal
+
+	flagFor     string
+	flagTimeout int
+}
+
+// Command returns a cobra.Command for use with (*cobra.Command).AddCommand.
+func (c *cmdWait) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("wait", i18n.G("[<remote>:]<instance>"))
+	cmd.Short = i18n.G("Wait for an instance to reach a condition")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Wait for an instance to reach a condition
+
+The supported conditions are:
+ - running: The instance is running
+ - agent: The instance is running and, for virtual machines, its agent is available
+ - ip: The instance has a global IP address on one of its interfaces
+ - cloud-init: cloud-init has completed successfully in the instance
+ - healthy: The instance is running, its agent is available, it has a global IP address and
+   cloud-init (if used) has completed successfully
+ - stopped: The instance is stopped
+
+The command fails if the condition isn't met before the timeout.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus wait c1 --for=cloud-init --timeout=300
+    Wait up to 5 minutes for cloud-init to complete in instance "c1".
+
+incus wait v1 --for=agent
+    Wait for the agent of virtual machine "v1" to be available.`))
+
+	cmd.RunE = c.Run
+	cmd.Flags().StringVar(&c.flagFor, "for", "running", i18n.G("Condition to wait for (running, agent, ip, cloud-init, healthy or stopped)")+"``")
+	cmd.Flags().IntVar(&c.flagTimeout, "timeout", 0, i18n.G("Time to wait for the condition (in seconds, 0 to wait forever)")+"``")
+
+	cmd.ValidArgsFunction = func(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpInstances(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run runs the actual command logic.
+func (c *cmdWait) Run(cmd *cobra.Command, args []string) error {
+	conf := c.global.conf
+
+	// Quick checks.
+	exit, err := c.global.checkArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	if c.flagTimeout < 0 {
+		return errors.New(i18n.G("The timeout can't be negative"))
+	}
+
+	// Parse remote.
+	remote, name, err := conf.ParseRemote(args[0])
+	if err != nil {
+		return err
+	}
+
+	if strings.Contains(name, instance.SnapshotDelimiter) {
+		return fmt.Errorf(i18n.G("Invalid instance name: %s"), name)
+	}
+
+	d, err := conf.GetInstanceServer(remote)
+	if err != nil {
+		return err
+	}
+
+	op, err := d.WaitInstance(name, api.InstanceWaitPost{
+		For:     c.flagFor,
+		Timeout: c.flagTimeout,
+	})
+	if err != nil {
+		return err
+	}
+
+	return cli.CancelableWait(op, nil)
+}
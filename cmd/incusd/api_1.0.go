@@ -20,6 +20,7 @@ import (
 	instanceDrivers "github.com/lxc/incus/v6/internal/server/instance/drivers"
 	"github.com/lxc/incus/v6/internal/server/lifecycle"
 	"github.com/lxc/incus/v6/internal/server/node"
+	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
 	scriptletLoad "github.com/lxc/incus/v6/internal/server/scriptlet/load"
@@ -44,7 +45,10 @@ var api10 = []APIEndpoint{
 	api10ResourcesCmd,
 	certificateCmd,
 	certificatesCmd,
+	changesCmd,
 	clusterCmd,
+	clusterEnrollmentCmd,
+	clusterEnrollmentsCmd,
 	clusterGroupCmd,
 	clusterGroupsCmd,
 	clusterNodeCmd,
@@ -53,6 +57,7 @@ var api10 = []APIEndpoint{
 	clusterCertificateCmd,
 	instanceBackupCmd,
 	instanceBackupExportCmd,
+	instanceBackupVerifyCmd,
 	instanceBackupsCmd,
 	instanceCmd,
 	instanceConsoleCmd,
@@ -66,12 +71,15 @@ var api10 = []APIEndpoint{
 	instanceMetadataTemplatesCmd,
 	instancesCmd,
 	instanceRebuildCmd,
+	instanceConvertCmd,
+	instanceWaitCmd,
 	instanceSFTPCmd,
 	instanceSnapshotCmd,
 	instanceSnapshotsCmd,
 	instanceStateCmd,
 	instanceAccessCmd,
 	instanceDebugMemoryCmd,
+	instanceAttestationCmd,
 	eventsCmd,
 	imageAliasCmd,
 	imageAliasesCmd,
@@ -88,6 +96,7 @@ var api10 = []APIEndpoint{
 	networkACLCmd,
 	networkACLsCmd,
 	networkACLLogCmd,
+	networkACLStateCmd,
 	networkAddressSetCmd,
 	networkAddressSetsCmd,
 	networkAllocationsCmd,
@@ -124,6 +133,7 @@ var api10 = []APIEndpoint{
 	storagePoolBucketBackupsCmd,
 	storagePoolBucketBackupCmd,
 	storagePoolBucketBackupsExportCmd,
+	storagePoolBucketBackupVerifyCmd,
 	storagePoolVolumesCmd,
 	storagePoolVolumeSnapshotsTypeCmd,
 	storagePoolVolumeSnapshotTypeCmd,
@@ -132,6 +142,7 @@ var api10 = []APIEndpoint{
 	storagePoolVolumeTypeCustomBackupsCmd,
 	storagePoolVolumeTypeCustomBackupCmd,
 	storagePoolVolumeTypeCustomBackupExportCmd,
+	storagePoolVolumeTypeCustomBackupVerifyCmd,
 	storagePoolVolumeTypeStateCmd,
 	warningsCmd,
 	warningCmd,
@@ -799,6 +810,7 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 	linstorChanged := false
 	ovsChanged := false
 	syslogChanged := false
+	operationsChanged := false
 	loggingChanges := map[string]struct{}{}
 
 	for key := range clusterChanged {
@@ -868,6 +880,9 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 
 		case "network.ovs.connection":
 			ovsChanged = true
+
+		case "operations.concurrency.image_unpack", "operations.concurrency.volume_copy", "operations.concurrency.migration", "operations.concurrency.backup":
+			operationsChanged = true
 		}
 	}
 
@@ -1012,6 +1027,12 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 		}
 	}
 
+	if operationsChanged {
+		for _, workload := range operations.Workloads {
+			operations.SetConcurrencyLimit(workload, int(nodeConfig.OperationsConcurrency(workload)))
+		}
+	}
+
 	if linstorChanged {
 		err := d.setupLinstor()
 		if err != nil {
@@ -1280,9 +1280,22 @@ func clusterNodesPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(fmt.Errorf("This server is not clustered"))
 	}
 
+	op, err := clusterJoinTokenCreate(s, r, req.ServerName, nil)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	s.Events.SendLifecycle(request.ProjectParam(r), lifecycle.ClusterTokenCreated.Event("members", op.Requestor(), nil))
+
+	return operations.OperationResponse(op)
+}
+
+// clusterJoinTokenCreate creates a join token operation for a new cluster member, replacing any existing one.
+// The extra metadata is stored alongside the token and the returned operation still needs to be started.
+func clusterJoinTokenCreate(s *state.State, r *http.Request, serverName string, extraMeta map[string]any) (*operations.Operation, error) {
 	expiry, err := internalInstance.GetExpiry(time.Now(), s.GlobalConfig.ClusterJoinTokenExpiry())
 	if err != nil {
-		return response.BadRequest(err)
+		return nil, api.StatusErrorf(http.StatusBadRequest, "%w", err)
 	}
 
 	// Get target addresses for existing online members, so that it can be encoded into the join token so that
@@ -1301,8 +1314,8 @@ func clusterNodesPost(d *Daemon, r *http.Request) response.Response {
 		// Filter to online members.
 		for _, member := range members {
 			// Verify if a node with the same name already exists in the cluster.
-			if member.Name == req.ServerName {
-				return fmt.Errorf("The cluster already has a member with name: %s", req.ServerName)
+			if member.Name == serverName {
+				return fmt.Errorf("The cluster already has a member with name: %s", serverName)
 			}
 
 			if member.State == db.ClusterMemberStateEvacuated || member.IsOffline(s.GlobalConfig.OfflineThreshold()) {
@@ -1315,11 +1328,11 @@ func clusterNodesPost(d *Daemon, r *http.Request) response.Response {
 		return nil
 	})
 	if err != nil {
-		return response.SmartError(err)
+		return nil, err
 	}
 
 	if len(onlineNodeAddresses) < 1 {
-		return response.InternalError(fmt.Errorf("There are no online cluster members"))
+		return nil, fmt.Errorf("There are no online cluster members")
 	}
 
 	// Lock to prevent concurrent requests racing the operationsGetByType function and creating duplicates.
@@ -1332,7 +1345,7 @@ func clusterNodesPost(d *Daemon, r *http.Request) response.Response {
 	// This also ensures any historically unused (but potentially published) join tokens are removed.
 	ops, err := operationsGetByType(s, r, api.ProjectDefaultName, operationtype.ClusterJoinToken)
 	if err != nil {
-		return response.InternalError(fmt.Errorf("Failed getting cluster join token operations: %w", err))
+		return nil, fmt.Errorf("Failed getting cluster join token operations: %w", err)
 	}
 
 	for _, op := range ops {
@@ -1345,12 +1358,12 @@ func clusterNodesPost(d *Daemon, r *http.Request) response.Response {
 			continue
 		}
 
-		if opServerName == req.ServerName {
+		if opServerName == serverName {
 			// Join token operation matches requested server name, so lets cancel it.
 			logger.Warn("Cancelling duplicate join token operation", logger.Ctx{"operation": op.ID, "serverName": opServerName})
 			err = operationCancel(s, r, api.ProjectDefaultName, op)
 			if err != nil {
-				return response.InternalError(fmt.Errorf("Failed to cancel operation %q: %w", op.ID, err))
+				return nil, fmt.Errorf("Failed to cancel operation %q: %w", op.ID, err)
 			}
 		}
 	}
@@ -1360,35 +1373,37 @@ func clusterNodesPost(d *Daemon, r *http.Request) response.Response {
 	// operation in order to validate the requested joining server name is correct and authorised.
 	joinSecret, err := internalUtil.RandomHexString(32)
 	if err != nil {
-		return response.InternalError(err)
+		return nil, err
 	}
 
 	// Generate fingerprint of network certificate so joining member can automatically trust the correct
 	// certificate when it is presented during the join process.
 	fingerprint, err := localtls.CertFingerprintStr(string(s.Endpoints.NetworkPublicKey()))
 	if err != nil {
-		return response.InternalError(err)
+		return nil, err
 	}
 
 	meta := map[string]any{
-		"serverName":  req.ServerName, // Add server name to allow validation of name during join process.
+		"serverName":  serverName, // Add server name to allow validation of name during join process.
 		"secret":      joinSecret,
 		"fingerprint": fingerprint,
 		"addresses":   onlineNodeAddresses,
 		"expiresAt":   expiry,
 	}
 
+	for k, v := range extraMeta {
+		meta[k] = v
+	}
+
 	resources := map[string][]api.URL{}
 	resources["cluster"] = []api.URL{}
 
 	op, err := operations.OperationCreate(s, api.ProjectDefaultName, operations.OperationClassToken, operationtype.ClusterJoinToken, resources, meta, nil, nil, nil, r)
 	if err != nil {
-		return response.InternalError(err)
+		return nil, err
 	}
 
-	s.Events.SendLifecycle(request.ProjectParam(r), lifecycle.ClusterTokenCreated.Event("members", op.Requestor(), nil))
-
-	return operations.OperationResponse(op)
+	return op, nil
 }
 
 // swagger:operation GET /1.0/cluster/members/{name} cluster cluster_member_get
@@ -1774,6 +1789,7 @@ func clusterValidateConfig(config map[string]string) error {
 		// {ref}`clustering-instance-placement` for more information.
 		// ---
 		//  type: string
+		//  allowedvalues: all, group, manual
 		//  defaultdesc: `all`
 		//  shortdesc: Controls how instances are scheduled to run on this member
 		"scheduler.instance": validate.Optional(validate.IsOneOf("all", "group", "manual")),
@@ -2201,11 +2217,46 @@ func internalClusterPostAccept(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	// Apply the enrollment policy for enrolled servers.
+	enrollmentEntry, err := clusterEnrollmentJoiningLoad(s, r, req.Name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if enrollmentEntry != nil {
+		err = clusterEnrollmentCheckMemberConfig(enrollmentEntry.enrollment, req.StoragePools, req.Networks)
+		if err != nil {
+			return response.BadRequest(err)
+		}
+	}
+
 	nodes, err := cluster.Accept(s, d.gateway, req.Name, req.Address, req.Schema, req.API, req.Architecture)
 	if err != nil {
 		return response.BadRequest(err)
 	}
 
+	if enrollmentEntry != nil {
+		// Add the new member to the group it enrolled into.
+		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+			groupID, err := dbCluster.GetClusterGroupID(ctx, tx.Tx(), enrollmentEntry.enrollment.Group)
+			if err != nil {
+				return err
+			}
+
+			_, err = dbCluster.CreateNodeClusterGroup(ctx, tx.Tx(), dbCluster.NodeClusterGroup{GroupID: int(groupID), Node: req.Name})
+
+			return err
+		})
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed adding member to cluster group %q: %w", enrollmentEntry.enrollment.Group, err))
+		}
+
+		err = operationCancel(s, r, api.ProjectDefaultName, enrollmentEntry.op)
+		if err != nil {
+			logger.Warn("Failed to cancel enrollment operation", logger.Ctx{"operation": enrollmentEntry.op.ID, "err": err})
+		}
+	}
+
 	accepted := internalClusterPostAcceptResponse{
 		RaftNodes:  make([]internalRaftNode, len(nodes)),
 		PrivateKey: s.Endpoints.NetworkPrivateKey(),
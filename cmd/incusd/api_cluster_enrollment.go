@@ -0,0 +1,764 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/cluster/enrollment"
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	"github.com/lxc/incus/v6/internal/server/lifecycle"
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+	localtls "github.com/lxc/incus/v6/shared/tls"
+)
+
+var clusterEnrollmentsCmd = APIEndpoint{
+	Path: "cluster/enrollments",
+
+	Get:  APIEndpointAction{Handler: clusterEnrollmentsGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanView)},
+	Post: APIEndpointAction{Handler: clusterEnrollmentsPost, AllowUntrusted: true},
+}
+
+var clusterEnrollmentCmd = APIEndpoint{
+	Path: "cluster/enrollments/{id}",
+
+	Get:    APIEndpointAction{Handler: clusterEnrollmentGet, AllowUntrusted: true},
+	Post:   APIEndpointAction{Handler: clusterEnrollmentPost, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+	Delete: APIEndpointAction{Handler: clusterEnrollmentDelete, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
+var clusterEnrollmentsPostMu sync.Mutex // Used to prevent races when creating enrollments.
+
+// swagger:operation GET /1.0/cluster/enrollments cluster cluster_enrollments_get
+//
+//	Get the cluster enrollments
+//
+//	Returns a list of pending, approved and joining cluster enrollments.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of cluster enrollments
+//	          items:
+//	            $ref: "#/definitions/ClusterEnrollment"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func clusterEnrollmentsGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	if !s.ServerClustered {
+		return response.BadRequest(fmt.Errorf("This server is not clustered"))
+	}
+
+	enrollments, err := clusterEnrollmentsLoad(s, r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	result := make([]api.ClusterEnrollment, 0, len(enrollments))
+	for _, entry := range enrollments {
+		// Join tokens are only handed out to the enrolling server.
+		entry.enrollment.JoinToken = ""
+		result = append(result, *entry.enrollment)
+	}
+
+	return response.SyncResponse(true, result)
+}
+
+// swagger:operation POST /1.0/cluster/enrollments cluster cluster_enrollments_post
+//
+//	Request to join the cluster
+//
+//	Submits an enrollment request on behalf of a new server.
+//	The request must be made using the server certificate of the new server and must match
+//	the enrollment policy of the requested cluster group.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: enrollment
+//	    description: Enrollment request
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/ClusterEnrollmentsPost"
+//	responses:
+//	  "200":
+//	    description: Enrollment
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/ClusterEnrollment"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func clusterEnrollmentsPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	if !s.ServerClustered {
+		return response.BadRequest(fmt.Errorf("This server is not clustered"))
+	}
+
+	req := api.ClusterEnrollmentsPost{}
+
+	// Parse the request.
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if req.ServerName == "" {
+		return response.BadRequest(fmt.Errorf("No server name provided"))
+	}
+
+	if req.Group == "" {
+		return response.BadRequest(fmt.Errorf("No cluster group provided"))
+	}
+
+	// The enrolling server is identified by its certificate.
+	if r.TLS == nil || len(r.TLS.PeerCertificates) < 1 {
+		return response.BadRequest(fmt.Errorf("No client certificate provided"))
+	}
+
+	cert := r.TLS.PeerCertificates[0]
+	fingerprint := localtls.CertFingerprint(cert)
+
+	remoteHost, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	// Check the request against the enrollment policy of the group.
+	policy, err := clusterEnrollmentPolicy(r.Context(), s, req.Group)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if policy == nil {
+		logger.Warn("Rejecting enrollment into group without enrollment policy", logger.Ctx{"group": req.Group, "serverName": req.ServerName, "ip": r.RemoteAddr})
+		return response.Forbidden(nil)
+	}
+
+	err = policy.Check(req.ServerName, req.Secret, cert, net.ParseIP(remoteHost))
+	if err != nil {
+		logger.Warn("Rejecting enrollment request", logger.Ctx{"group": req.Group, "serverName": req.ServerName, "ip": r.RemoteAddr, "err": err})
+
+		if errors.Is(err, enrollment.ErrTooManyRequests) {
+			return response.Unavailable(err)
+		}
+
+		return response.Forbidden(nil)
+	}
+
+	expiry, err := internalInstance.GetExpiry(time.Now(), s.GlobalConfig.ClusterJoinTokenExpiry())
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	clusterEnrollmentsPostMu.Lock()
+	defer clusterEnrollmentsPostMu.Unlock()
+
+	// Only keep the most recent enrollment for each server.
+	enrollments, err := clusterEnrollmentsLoad(s, r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	for _, entry := range enrollments {
+		if entry.enrollment.ServerName != req.ServerName {
+			continue
+		}
+
+		logger.Warn("Cancelling duplicate enrollment operation", logger.Ctx{"operation": entry.op.ID, "serverName": req.ServerName})
+		err = operationCancel(s, r, api.ProjectDefaultName, entry.op)
+		if err != nil {
+			return response.InternalError(fmt.Errorf("Failed to cancel operation %q: %w", entry.op.ID, err))
+		}
+	}
+
+	meta := map[string]any{
+		"enrollment":  uuid.New().String(),
+		"serverName":  req.ServerName,
+		"address":     remoteHost,
+		"fingerprint": fingerprint,
+		"group":       req.Group,
+	}
+
+	requestor := request.CreateRequestor(r)
+	lcCtx := map[string]any{"server_name": req.ServerName}
+
+	var op *operations.Operation
+	if policy.Approval == enrollment.ApprovalManual {
+		meta["expiresAt"] = expiry
+
+		resources := map[string][]api.URL{}
+		resources["cluster"] = []api.URL{}
+
+		op, err = operations.OperationCreate(s, api.ProjectDefaultName, operations.OperationClassToken, operationtype.ClusterEnrollment, resources, meta, nil, nil, nil, r)
+		if err != nil {
+			return response.InternalError(err)
+		}
+	} else {
+		op, err = clusterJoinTokenCreate(s, r, req.ServerName, meta)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
+	err = op.Start()
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	_, apiOp, err := op.Render()
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	result, err := clusterEnrollmentFromOperation(apiOp, policy)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	lc := lifecycle.ClusterEnrollmentCreated.Event(result.ID, requestor, lcCtx)
+	s.Events.SendLifecycle(api.ProjectDefaultName, lc)
+
+	if result.Status == api.ClusterEnrollmentStatusApproved {
+		s.Events.SendLifecycle(api.ProjectDefaultName, lifecycle.ClusterEnrollmentApproved.Event(result.ID, requestor, lcCtx))
+	}
+
+	return response.SyncResponseLocation(true, result, lc.Source)
+}
+
+// swagger:operation GET /1.0/cluster/enrollments/{id} cluster cluster_enrollment_get
+//
+//	Get the cluster enrollment
+//
+//	Gets a specific cluster enrollment.
+//	This can be used by the enrolling server (authenticated by its certificate) to wait for approval
+//	and retrieve its join token.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: Enrollment
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/ClusterEnrollment"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func clusterEnrollmentGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	if !s.ServerClustered {
+		return response.BadRequest(fmt.Errorf("This server is not clustered"))
+	}
+
+	id, err := url.PathUnescape(mux.Vars(r)["id"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Trusted users may look at any enrollment, the enrolling server only at its own.
+	trusted, _, _, err := d.Authenticate(nil, r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if trusted {
+		resp := allowPermission(auth.ObjectTypeServer, auth.EntitlementCanView)(d, r)
+		if resp != response.EmptySyncResponse {
+			trusted = false
+		}
+	}
+
+	var fingerprint string
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		fingerprint = localtls.CertFingerprint(r.TLS.PeerCertificates[0])
+	}
+
+	if !trusted && fingerprint == "" {
+		return response.Forbidden(nil)
+	}
+
+	entry, err := clusterEnrollmentLoad(s, r, id)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if entry.enrollment.Fingerprint != fingerprint {
+		if !trusted {
+			return response.NotFound(nil)
+		}
+
+		// Only the enrolling server gets to see the join token.
+		entry.enrollment.JoinToken = ""
+	}
+
+	return response.SyncResponse(true, entry.enrollment)
+}
+
+// swagger:operation POST /1.0/cluster/enrollments/{id} cluster cluster_enrollment_post
+//
+//	Approve the cluster enrollment
+//
+//	Approves a pending cluster enrollment, allowing the server to join the cluster.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func clusterEnrollmentPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	if !s.ServerClustered {
+		return response.BadRequest(fmt.Errorf("This server is not clustered"))
+	}
+
+	id, err := url.PathUnescape(mux.Vars(r)["id"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	clusterEnrollmentsPostMu.Lock()
+	defer clusterEnrollmentsPostMu.Unlock()
+
+	entry, err := clusterEnrollmentLoad(s, r, id)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if entry.enrollment.Status != api.ClusterEnrollmentStatusPending {
+		return response.BadRequest(fmt.Errorf("Enrollment %q isn't pending approval", id))
+	}
+
+	meta := map[string]any{
+		"enrollment":  entry.enrollment.ID,
+		"serverName":  entry.enrollment.ServerName,
+		"address":     entry.enrollment.Address,
+		"fingerprint": entry.enrollment.Fingerprint,
+		"group":       entry.enrollment.Group,
+	}
+
+	op, err := clusterJoinTokenCreate(s, r, entry.enrollment.ServerName, meta)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = op.Start()
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	// The pending enrollment is now replaced by the join token.
+	err = operationCancel(s, r, api.ProjectDefaultName, entry.op)
+	if err != nil {
+		return response.InternalError(fmt.Errorf("Failed to cancel operation %q: %w", entry.op.ID, err))
+	}
+
+	requestor := request.CreateRequestor(r)
+	lc := lifecycle.ClusterEnrollmentApproved.Event(id, requestor, map[string]any{"server_name": entry.enrollment.ServerName})
+	s.Events.SendLifecycle(api.ProjectDefaultName, lc)
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation DELETE /1.0/cluster/enrollments/{id} cluster cluster_enrollment_delete
+//
+//	Reject the cluster enrollment
+//
+//	Rejects a cluster enrollment, revoking its join token if it was already approved.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func clusterEnrollmentDelete(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	if !s.ServerClustered {
+		return response.BadRequest(fmt.Errorf("This server is not clustered"))
+	}
+
+	id, err := url.PathUnescape(mux.Vars(r)["id"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	entry, err := clusterEnrollmentLoad(s, r, id)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = operationCancel(s, r, api.ProjectDefaultName, entry.op)
+	if err != nil {
+		return response.InternalError(fmt.Errorf("Failed to cancel operation %q: %w", entry.op.ID, err))
+	}
+
+	requestor := request.CreateRequestor(r)
+	lc := lifecycle.ClusterEnrollmentRejected.Event(id, requestor, nil)
+	s.Events.SendLifecycle(api.ProjectDefaultName, lc)
+
+	return response.EmptySyncResponse
+}
+
+// clusterEnrollmentEntry ties an enrollment to the operation backing it.
+type clusterEnrollmentEntry struct {
+	enrollment *api.ClusterEnrollment
+	op         *api.Operation
+}
+
+// clusterEnrollmentPolicy returns the enrollment policy of a cluster group (nil if enrollment is disabled).
+func clusterEnrollmentPolicy(ctx context.Context, s *state.State, groupName string) (*enrollment.Policy, error) {
+	var group *api.ClusterGroup
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		dbGroup, err := dbCluster.GetClusterGroup(ctx, tx.Tx(), groupName)
+		if err != nil {
+			return err
+		}
+
+		group, err = dbGroup.ToAPI(ctx, tx.Tx())
+
+		return err
+	})
+	if err != nil {
+		if api.StatusErrorCheck(err, http.StatusNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return enrollment.ParsePolicy(group.Config)
+}
+
+// clusterEnrollmentsLoad returns all pending, approved and joining enrollments across the cluster.
+// Pending and joining enrollments are backed by enrollment operations while approved ones are backed by join tokens.
+func clusterEnrollmentsLoad(s *state.State, r *http.Request) ([]clusterEnrollmentEntry, error) {
+	entries := []clusterEnrollmentEntry{}
+	policies := map[string]*enrollment.Policy{}
+
+	for _, opType := range []operationtype.Type{operationtype.ClusterEnrollment, operationtype.ClusterJoinToken} {
+		ops, err := operationsGetByType(s, r, api.ProjectDefaultName, opType)
+		if err != nil {
+			return nil, fmt.Errorf("Failed getting enrollment operations: %w", err)
+		}
+
+		for _, op := range ops {
+			if op.StatusCode != api.Running {
+				continue
+			}
+
+			if op.Metadata["enrollment"] == nil {
+				continue // Regular join token.
+			}
+
+			// Local and remote operations use different metadata types, normalize them.
+			op, err = clusterEnrollmentOperationNormalize(op)
+			if err != nil {
+				return nil, err
+			}
+
+			groupName, _ := op.Metadata["group"].(string)
+			policy, ok := policies[groupName]
+			if !ok {
+				policy, err = clusterEnrollmentPolicy(r.Context(), s, groupName)
+				if err != nil {
+					return nil, err
+				}
+
+				policies[groupName] = policy
+			}
+
+			result, err := clusterEnrollmentFromOperation(op, policy)
+			if err != nil {
+				logger.Warn("Skipping invalid enrollment operation", logger.Ctx{"operation": op.ID, "err": err})
+				continue
+			}
+
+			entries = append(entries, clusterEnrollmentEntry{enrollment: result, op: op})
+		}
+	}
+
+	return entries, nil
+}
+
+// clusterEnrollmentLoad returns a specific enrollment.
+func clusterEnrollmentLoad(s *state.State, r *http.Request, id string) (*clusterEnrollmentEntry, error) {
+	entries, err := clusterEnrollmentsLoad(s, r)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.enrollment.ID == id {
+			return &entry, nil
+		}
+	}
+
+	return nil, api.StatusErrorf(http.StatusNotFound, "Enrollment not found")
+}
+
+// clusterEnrollmentOperationNormalize converts the operation metadata to its JSON representation.
+func clusterEnrollmentOperationNormalize(op *api.Operation) (*api.Operation, error) {
+	data, err := json.Marshal(op)
+	if err != nil {
+		return nil, err
+	}
+
+	result := api.Operation{}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// clusterEnrollmentFromOperation builds the enrollment from its backing operation.
+func clusterEnrollmentFromOperation(op *api.Operation, policy *enrollment.Policy) (*api.ClusterEnrollment, error) {
+	op, err := clusterEnrollmentOperationNormalize(op)
+	if err != nil {
+		return nil, err
+	}
+
+	result := api.ClusterEnrollment{
+		CreatedAt:    op.CreatedAt,
+		MemberConfig: []api.ClusterMemberConfigKey{},
+	}
+
+	fields := map[string]*string{
+		"enrollment":  &result.ID,
+		"serverName":  &result.ServerName,
+		"address":     &result.Address,
+		"fingerprint": &result.Fingerprint,
+		"group":       &result.Group,
+	}
+
+	for key, field := range fields {
+		value, ok := op.Metadata[key].(string)
+		if !ok {
+			return nil, fmt.Errorf("Operation %s is type %T not string", key, op.Metadata[key])
+		}
+
+		*field = value
+	}
+
+	expiresAt, ok := op.Metadata["expiresAt"].(string)
+	if ok {
+		result.ExpiresAt, err = time.Parse(time.RFC3339Nano, expiresAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if policy != nil {
+		result.MemberConfig = policy.MemberConfig
+	}
+
+	// Approved enrollments are backed by a join token.
+	result.Status = api.ClusterEnrollmentStatusPending
+	if op.Metadata["joining"] == true {
+		result.Status = api.ClusterEnrollmentStatusJoining
+	} else if op.Metadata["secret"] != nil {
+		joinToken, err := op.ToClusterJoinToken()
+		if err != nil {
+			return nil, err
+		}
+
+		result.Status = api.ClusterEnrollmentStatusApproved
+		result.JoinToken = joinToken.String()
+	}
+
+	return &result, nil
+}
+
+// clusterEnrollmentJoining records that the join token of an enrollment was used.
+// The enrollment is kept until the server is accepted into the cluster, so the enrollment
+// policy can be applied then.
+func clusterEnrollmentJoining(s *state.State, r *http.Request, joinOp *api.Operation) error {
+	joinOp, err := clusterEnrollmentOperationNormalize(joinOp)
+	if err != nil {
+		return err
+	}
+
+	expiry, err := internalInstance.GetExpiry(time.Now(), s.GlobalConfig.ClusterJoinTokenExpiry())
+	if err != nil {
+		return err
+	}
+
+	meta := map[string]any{
+		"joining":   true,
+		"expiresAt": expiry,
+	}
+
+	for _, key := range []string{"enrollment", "serverName", "address", "fingerprint", "group"} {
+		meta[key] = joinOp.Metadata[key]
+	}
+
+	resources := map[string][]api.URL{}
+	resources["cluster"] = []api.URL{}
+
+	op, err := operations.OperationCreate(s, api.ProjectDefaultName, operations.OperationClassToken, operationtype.ClusterEnrollment, resources, meta, nil, nil, nil, r)
+	if err != nil {
+		return err
+	}
+
+	return op.Start()
+}
+
+// clusterEnrollmentJoiningLoad returns the enrollment of a server whose join token was used (nil if none).
+func clusterEnrollmentJoiningLoad(s *state.State, r *http.Request, serverName string) (*clusterEnrollmentEntry, error) {
+	entries, err := clusterEnrollmentsLoad(s, r)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.enrollment.Status == api.ClusterEnrollmentStatusJoining && entry.enrollment.ServerName == serverName {
+			return &entry, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// clusterEnrollmentCheckMemberConfig checks that the member specific configuration of the joining server
+// matches the one required by the enrollment policy.
+func clusterEnrollmentCheckMemberConfig(e *api.ClusterEnrollment, pools []api.StoragePool, networks []api.InitNetworksProjectPost) error {
+	for _, key := range e.MemberConfig {
+		var config map[string]string
+
+		switch key.Entity {
+		case "storage-pool":
+			for _, pool := range pools {
+				if pool.Name == key.Name {
+					config = pool.Config
+					break
+				}
+			}
+
+		case "network":
+			for _, network := range networks {
+				if network.Name == key.Name {
+					config = network.Config
+					break
+				}
+			}
+		}
+
+		value, ok := config[key.Key]
+		if key.Key == "source" && config["volatile.initial_source"] != "" {
+			value, ok = config["volatile.initial_source"], true
+		}
+
+		if !ok || value != key.Value {
+			return fmt.Errorf("The %q configuration of %s %q doesn't match the enrollment policy of cluster group %q", key.Key, key.Entity, key.Name, e.Group)
+		}
+	}
+
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/cluster/enrollment"
 	"github.com/lxc/incus/v6/internal/server/db"
 	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
 	instanceDrivers "github.com/lxc/incus/v6/internal/server/instance/drivers"
@@ -912,6 +913,51 @@ func clusterGroupValidate(config map[string]string) error {
 		configKeys[fmt.Sprintf("instances.vm.cpu.%s.flags", arch)] = validate.Optional(validate.IsListOf(validate.IsAny))
 	}
 
+	// gendoc:generate(entity=cluster_group, group=common, key=enrollment.approval)
+	// Possible values are `auto` (servers matching the policy join immediately) and `manual` (enrollments must be approved with `incus cluster enrollment approve`).
+	// ---
+	//  type: string
+	//  defaultdesc: `auto`
+	//  shortdesc: Approval mode for enrollment requests
+	configKeys["enrollment.approval"] = validate.Optional(validate.IsOneOf(enrollment.ApprovalAuto, enrollment.ApprovalManual))
+
+	// gendoc:generate(entity=cluster_group, group=common, key=enrollment.hostnames)
+	// Comma-separated list of shell-style patterns that the name of enrolling servers must match.
+	// ---
+	//  type: string
+	//  shortdesc: Server names allowed to enroll
+	configKeys["enrollment.hostnames"] = validate.Optional(validate.IsListOf(enrollment.ValidateHostname))
+
+	// gendoc:generate(entity=cluster_group, group=common, key=enrollment.member_config)
+	// YAML list of member-specific configuration keys applied to enrolling servers, using the same format as the `member_config` section of a preseed file.
+	// ---
+	//  type: string
+	//  shortdesc: Member configuration for enrolling servers
+	configKeys["enrollment.member_config"] = validate.Optional(enrollment.ValidateMemberConfig)
+
+	// gendoc:generate(entity=cluster_group, group=common, key=enrollment.secret)
+	// Setting this key or `enrollment.trusted_ca` enables enrollment into the group.
+	// Only a hash of the secret is stored and shown in the group configuration.
+	// ---
+	//  type: string
+	//  shortdesc: Shared secret for enrolling servers
+	configKeys["enrollment.secret"] = validate.Optional(validate.IsAny)
+
+	// gendoc:generate(entity=cluster_group, group=common, key=enrollment.subnets)
+	// Comma-separated list of subnets that enrollment requests must originate from.
+	// ---
+	//  type: string
+	//  shortdesc: Subnets allowed to enroll
+	configKeys["enrollment.subnets"] = validate.Optional(validate.IsListOf(validate.IsNetwork))
+
+	// gendoc:generate(entity=cluster_group, group=common, key=enrollment.trusted_ca)
+	// PEM encoded CA certificate which must have issued the server certificate of enrolling servers.
+	// Setting this key or `enrollment.secret` enables enrollment into the group.
+	// ---
+	//  type: string
+	//  shortdesc: CA for enrolling servers
+	configKeys["enrollment.trusted_ca"] = validate.Optional(enrollment.ValidateTrustedCA)
+
 	for k, v := range config {
 		// User keys are free for all.
 
@@ -945,6 +991,16 @@ func clusterGroupFill(ctx context.Context, s *state.State, servers []string, req
 		return nil
 	}
 
+	// Only keep a hash of the enrollment secret.
+	secret, err := enrollment.HashSecret(req.Config["enrollment.secret"])
+	if err != nil {
+		return err
+	}
+
+	if secret != "" {
+		req.Config["enrollment.secret"] = secret
+	}
+
 	for _, arch := range osarch.SupportedArchitectures() {
 		baseline := req.Config[fmt.Sprintf("instances.vm.cpu.%s.baseline", arch)]
 		flags := req.Config[fmt.Sprintf("instances.vm.cpu.%s.flags", arch)]
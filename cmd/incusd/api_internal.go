@@ -61,6 +61,7 @@ var apiInternal = []APIEndpoint{
 	internalGarbageCollectorCmd,
 	internalImageOptimizeCmd,
 	internalImageRefreshCmd,
+	internalInstanceOnLimitsCmd,
 	internalRAFTSnapshotCmd,
 	internalRebalanceLoadCmd,
 	internalReadyCmd,
@@ -159,6 +160,13 @@ var internalVirtualMachineOnResizeCmd = APIEndpoint{
 	Get: APIEndpointAction{Handler: internalVirtualMachineOnResize, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
 }
 
+// Instance hooks.
+var internalInstanceOnLimitsCmd = APIEndpoint{
+	Path: "instances/{instanceRef}/onlimits",
+
+	Get: APIEndpointAction{Handler: internalInstanceOnLimits, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
 // Debugging.
 var internalBGPStateCmd = APIEndpoint{
 	Path: "debug/bgp",
@@ -500,6 +508,44 @@ func internalVirtualMachineOnResize(d *Daemon, r *http.Request) response.Respons
 	return response.EmptySyncResponse
 }
 
+// internalInstanceOnLimits applies the changed I/O limits of the storage volumes used by some devices of a
+// local instance.
+func internalInstanceOnLimits(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// Get the instance ID.
+	instanceID, err := strconv.Atoi(mux.Vars(r)["instanceRef"])
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	// Get the devices list.
+	devices := request.QueryParam(r, "devices")
+	if devices == "" {
+		return response.BadRequest(fmt.Errorf("Limits hook requires a list of devices"))
+	}
+
+	// Load by ID.
+	inst, err := instance.LoadByID(s, instanceID)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// The limits get applied on the next start.
+	if !inst.IsRunning() {
+		return response.EmptySyncResponse
+	}
+
+	for _, devName := range strings.Split(devices, ",") {
+		err = inst.ReloadDevice(devName)
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed applying I/O limits to device %q: %w", devName, err))
+		}
+	}
+
+	return response.EmptySyncResponse
+}
+
 // Perform a database dump.
 func internalSQLGet(d *Daemon, r *http.Request) response.Response {
 	s := d.State()
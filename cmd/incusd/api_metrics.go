@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"runtime"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -19,6 +20,8 @@ import (
 	instanceDrivers "github.com/lxc/incus/v6/internal/server/instance/drivers"
 	"github.com/lxc/incus/v6/internal/server/locking"
 	"github.com/lxc/incus/v6/internal/server/metrics"
+	"github.com/lxc/incus/v6/internal/server/network/acl"
+	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
 	"github.com/lxc/incus/v6/internal/server/state"
@@ -128,6 +131,9 @@ func metricsGet(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	// Add network ACL metrics.
+	metricSet.Merge(networkACLMetrics(r.Context(), s, projectNames))
+
 	// invalidProjectFilters returns project filters which are either not in cache or have expired.
 	invalidProjectFilters := func(projectNames []string) []dbCluster.InstanceFilter {
 		metricsCacheLock.Lock()
@@ -314,6 +320,62 @@ func getFilteredMetrics(s *state.State, r *http.Request, compress bool, metricSe
 	return response.SyncResponsePlain(true, compress, metricSet.String())
 }
 
+// networkACLMetrics returns the hit counters of the network ACL rules of the provided projects on this server.
+func networkACLMetrics(ctx context.Context, s *state.State, projectNames []string) *metrics.MetricSet {
+	out := metrics.NewMetricSet(nil)
+
+	counters, err := acl.LocalCounters(s)
+	if err != nil {
+		logger.Warn("Failed to get network ACL counters", logger.Ctx{"err": err})
+		return out
+	}
+
+	if len(counters) == 0 {
+		return out
+	}
+
+	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		projectACLs, err := tx.GetNetworkACLsAllProjects(ctx)
+		if err != nil {
+			return err
+		}
+
+		for projectName, aclNames := range projectACLs {
+			if !slices.Contains(projectNames, projectName) {
+				continue
+			}
+
+			for _, aclName := range aclNames {
+				aclID, aclInfo, err := tx.GetNetworkACL(ctx, projectName, aclName)
+				if err != nil {
+					return err
+				}
+
+				aclState := acl.RuleCounters(aclID, aclInfo, counters)
+
+				addSamples := func(direction string, ruleCounters []api.NetworkACLRuleCounters) {
+					for i, counter := range ruleCounters {
+						labels := map[string]string{"project": projectName, "acl": aclName, "direction": direction, "rule": strconv.Itoa(i)}
+
+						out.AddSamples(metrics.NetworkACLRuleBytesTotal, metrics.Sample{Value: float64(counter.Bytes), Labels: labels})
+						out.AddSamples(metrics.NetworkACLRulePacketsTotal, metrics.Sample{Value: float64(counter.Packets), Labels: labels})
+					}
+				}
+
+				addSamples("ingress", aclState.Ingress)
+				addSamples("egress", aclState.Egress)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Warn("Failed to get network ACLs", logger.Ctx{"err": err})
+	}
+
+	return out
+}
+
 func internalMetrics(ctx context.Context, daemonStartTime time.Time, tx *db.ClusterTx) *metrics.MetricSet {
 	out := metrics.NewMetricSet(nil)
 
@@ -325,12 +387,22 @@ func internalMetrics(ctx context.Context, daemonStartTime time.Time, tx *db.Clus
 		out.AddSamples(metrics.WarningsTotal, metrics.Sample{Value: float64(len(warnings))})
 	}
 
-	operations, err := dbCluster.GetOperations(ctx, tx.Tx())
+	ops, err := dbCluster.GetOperations(ctx, tx.Tx())
 	if err != nil {
 		logger.Warn("Failed to get operations", logger.Ctx{"err": err})
 	} else {
 		// Total number of operations
-		out.AddSamples(metrics.OperationsTotal, metrics.Sample{Value: float64(len(operations))})
+		out.AddSamples(metrics.OperationsTotal, metrics.Sample{Value: float64(len(ops))})
+	}
+
+	// Operation queues
+	for _, stats := range operations.GetWorkloadStats() {
+		labels := map[string]string{"workload": stats.Workload}
+
+		out.AddSamples(metrics.OperationsWorkloadLimit, metrics.Sample{Value: float64(stats.Limit), Labels: labels})
+		out.AddSamples(metrics.OperationsWorkloadQueued, metrics.Sample{Value: float64(stats.Queued), Labels: labels})
+		out.AddSamples(metrics.OperationsWorkloadRunning, metrics.Sample{Value: float64(stats.Running), Labels: labels})
+		out.AddSamples(metrics.OperationsWorkloadWaitSecondsTotal, metrics.Sample{Value: stats.WaitSeconds, Labels: labels})
 	}
 
 	// Daemon uptime
@@ -18,11 +18,13 @@ import (
 	"github.com/lxc/incus/v6/internal/filter"
 	"github.com/lxc/incus/v6/internal/jmap"
 	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/backup"
 	"github.com/lxc/incus/v6/internal/server/db"
 	"github.com/lxc/incus/v6/internal/server/db/cluster"
 	"github.com/lxc/incus/v6/internal/server/db/operationtype"
 	"github.com/lxc/incus/v6/internal/server/lifecycle"
 	"github.com/lxc/incus/v6/internal/server/network"
+	"github.com/lxc/incus/v6/internal/server/network/acl"
 	"github.com/lxc/incus/v6/internal/server/operations"
 	projecthelpers "github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/request"
@@ -161,6 +163,12 @@ var projectAccessCmd = APIEndpoint{
 func projectsGet(d *Daemon, r *http.Request) response.Response {
 	s := d.State()
 
+	// Skip loading the collection if the client already has its current content.
+	etag := changesETag(s, r)
+	if response.ETagMatch(r, etag) {
+		return response.SyncResponseConditional(true, nil, etag)
+	}
+
 	recursion := localUtil.IsRecursionRequest(r)
 
 	// Parse filter value.
@@ -218,7 +226,7 @@ func projectsGet(d *Daemon, r *http.Request) response.Response {
 	}
 
 	if recursion {
-		return response.SyncResponse(true, filtered)
+		return response.SyncResponseConditional(true, filtered, etag)
 	}
 
 	urls := make([]string, len(filtered))
@@ -226,7 +234,7 @@ func projectsGet(d *Daemon, r *http.Request) response.Response {
 		urls[i] = p.URL(version.APIVersion).String()
 	}
 
-	return response.SyncResponse(true, urls)
+	return response.SyncResponseConditional(true, urls, etag)
 }
 
 // projectUsedBy returns a list of URLs for all instances, images, profiles,
@@ -363,6 +371,11 @@ func projectsPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
+	err = projectValidateMandatoryACLs(s, &api.Project{Name: project.Name, ProjectPut: project.ProjectPut})
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
 	var id int64
 	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 		id, err = cluster.CreateProject(ctx, tx.Tx(), cluster.Project{Description: project.Description, Name: project.Name})
@@ -530,6 +543,19 @@ func projectPut(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	if isClusterNotification(r) {
+		// In this case the ProjectPut request payload contains information about the old project, since
+		// the new one has already been saved in the database.
+		old := api.ProjectPut{}
+		err := json.NewDecoder(r.Body).Decode(&old)
+		if err != nil {
+			return response.BadRequest(err)
+		}
+
+		err = projectUpdateMandatoryACLs(r.Context(), s, name, old)
+		return response.SmartError(err)
+	}
+
 	// Get the current data
 	var project *api.Project
 	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
@@ -754,6 +780,13 @@ func projectChange(ctx context.Context, s *state.State, project *api.Project, re
 		return response.BadRequest(err)
 	}
 
+	if slices.Contains(configChanged, "restricted.networks.acls.mandatory") || slices.Contains(configChanged, "features.networks") {
+		err = projectValidateMandatoryACLs(s, &api.Project{Name: project.Name, ProjectPut: req})
+		if err != nil {
+			return response.BadRequest(err)
+		}
+	}
+
 	// Update the database entry.
 	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
 		err := projecthelpers.AllowProjectUpdate(tx, project.Name, req.Config, configChanged)
@@ -794,6 +827,19 @@ func projectChange(ctx context.Context, s *state.State, project *api.Project, re
 		return response.SmartError(err)
 	}
 
+	// Apply the changed mandatory network ACLs to the running instances.
+	if slices.Contains(configChanged, "restricted.networks.acls.mandatory") {
+		err = projectUpdateMandatoryACLs(ctx, s, project.Name, project.ProjectPut)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		err = projectNotifyMandatoryACLs(s, project.Name, project.ProjectPut)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
 	return response.EmptySyncResponse
 }
 
@@ -1346,6 +1392,14 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		//  shortdesc: Compression algorithm to use for backups
 		"backups.compression_algorithm": validate.IsCompressionAlgorithm,
 
+		// gendoc:generate(entity=project, group=specific, key=backups.encryption_recipients)
+		// Comma-separated list of recipients that backups in this project are encrypted to, unless the request specifies its own.
+		// Recipients are either age or SSH public keys (encrypted with `age`), or OpenPGP key fingerprints (encrypted with `gpg`, the keys must be in the server's keyring).
+		// ---
+		//  type: string
+		//  shortdesc: Recipients to encrypt backups to
+		"backups.encryption_recipients": backup.ValidateEncryptionRecipients,
+
 		// gendoc:generate(entity=project, group=features, key=features.profiles)
 		//
 		// ---
@@ -1492,6 +1546,46 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		//  shortdesc: Maximum number of networks that the project can have
 		"limits.networks": validate.Optional(validate.IsUint32),
 
+		// gendoc:generate(entity=project, group=limits, key=limits.snapshots)
+		// This value is the maximum number of instance snapshots and custom volume snapshots that can exist in the project.
+		// Snapshots copied, migrated or imported along with an instance or volume count towards the limit.
+		// Scheduled snapshots aren't taken once the limit is reached.
+		// ---
+		//  type: integer
+		//  shortdesc: Maximum number of snapshots that the project can have
+		"limits.snapshots": validate.Optional(validate.IsUint32),
+
+		// gendoc:generate(entity=project, group=limits, key=limits.backups)
+		// This value is the maximum number of instance, custom volume and storage bucket backups that can exist in the project.
+		// ---
+		//  type: integer
+		//  shortdesc: Maximum number of backups that the project can have
+		"limits.backups": validate.Optional(validate.IsUint32),
+
+		// gendoc:generate(entity=project, group=limits, key=limits.buckets)
+		//
+		// ---
+		//  type: integer
+		//  shortdesc: Maximum number of storage buckets that the project can have
+		"limits.buckets": validate.Optional(validate.IsUint32),
+
+		// gendoc:generate(entity=project, group=limits, key=limits.images)
+		// This value is the maximum number of images that can exist in the project, including the images cached when creating instances.
+		// If {config:option}`project-features:features.images` is disabled, the limit of the `default` project applies instead.
+		// ---
+		//  type: integer
+		//  shortdesc: Maximum number of images that the project can have
+		"limits.images": validate.Optional(validate.IsUint32),
+
+		// gendoc:generate(entity=project, group=limits, key=limits.images.size)
+		// This value is the maximum value of the aggregate size of the images of the project.
+		// It applies in addition to {config:option}`project-limits:limits.disk`.
+		// If {config:option}`project-features:features.images` is disabled, the limit of the `default` project applies instead.
+		// ---
+		//  type: string
+		//  shortdesc: Maximum disk space used by the images of the project
+		"limits.images.size": validate.Optional(validate.IsSize),
+
 		// gendoc:generate(entity=project, group=restricted, key=restricted)
 		// This option must be enabled to allow the `restricted.*` keys to take effect.
 		// To temporarily remove the restrictions, you can disable this option instead of clearing the related keys.
@@ -1505,6 +1599,7 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		// Possible values are `allow` or `block`.
 		// ---
 		//  type: string
+		//  allowedvalues: block, allow
 		//  defaultdesc: `block`
 		//  shortdesc: Whether to prevent creating instance or volume backups
 		"restricted.backups": isEitherAllowOrBlock,
@@ -1554,6 +1649,7 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		// When set to `allow`, this option allows targeting of cluster members (either directly or via a group) when creating or moving instances.
 		// ---
 		//  type: string
+		//  allowedvalues: block, allow
 		//  defaultdesc: `block`
 		//  shortdesc: Whether to prevent targeting of cluster members
 		"restricted.cluster.target": isEitherAllowOrBlock,
@@ -1564,6 +1660,7 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		// File system mounting remains blocked.
 		// ---
 		//  type: string
+		//  allowedvalues: allow, block, full
 		//  defaultdesc: `block`
 		//  shortdesc: Whether to prevent using system call interception options
 		"restricted.containers.interception": validate.Optional(validate.IsOneOf("allow", "block", "full")),
@@ -1573,6 +1670,7 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		// When set to `allow`, {config:option}`instance-security:security.nesting` can be set to `true` for an instance.
 		// ---
 		//  type: string
+		//  allowedvalues: block, allow
 		//  defaultdesc: `block`
 		//  shortdesc: Whether to prevent running nested Incus
 		"restricted.containers.nesting": isEitherAllowOrBlock,
@@ -1582,6 +1680,7 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		// When set to `allow`, low-level container options like {config:option}`instance-raw:raw.lxc`, {config:option}`instance-raw:raw.idmap`, `volatile.*`, etc. can be used.
 		// ---
 		//  type: string
+		//  allowedvalues: block, allow
 		//  defaultdesc: `block`
 		//  shortdesc: Whether to prevent using low-level container options
 		"restricted.containers.lowlevel": isEitherAllowOrBlock,
@@ -1594,6 +1693,7 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		// - When set to `allow`, there is no restriction.
 		// ---
 		//  type: string
+		//  allowedvalues: allow, unprivileged, isolated
 		//  defaultdesc: `unprivileged`
 		//  shortdesc: Which settings for privileged containers to prevent
 		"restricted.containers.privilege": validate.Optional(validate.IsOneOf("allow", "unprivileged", "isolated")),
@@ -1603,6 +1703,7 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		// When set to `allow`, low-level VM options like {config:option}`instance-raw:raw.qemu`, `volatile.*`, etc. can be used.
 		// ---
 		//  type: string
+		//  allowedvalues: block, allow
 		//  defaultdesc: `block`
 		//  shortdesc: Whether to prevent using low-level VM options
 		"restricted.virtual-machines.lowlevel": isEitherAllowOrBlock,
@@ -1611,6 +1712,7 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		// Possible values are `allow` or `block`.
 		// ---
 		//  type: string
+		//  allowedvalues: block, allow
 		//  defaultdesc: `block`
 		//  shortdesc: Whether to prevent using devices of type `unix-char`
 		"restricted.devices.unix-char": isEitherAllowOrBlock,
@@ -1619,6 +1721,7 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		// Possible values are `allow` or `block`.
 		// ---
 		//  type: string
+		//  allowedvalues: block, allow
 		//  defaultdesc: `block`
 		//  shortdesc: Whether to prevent using devices of type `unix-block`
 		"restricted.devices.unix-block": isEitherAllowOrBlock,
@@ -1627,6 +1730,7 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		// Possible values are `allow` or `block`.
 		// ---
 		//  type: string
+		//  allowedvalues: block, allow
 		//  defaultdesc: `block`
 		//  shortdesc: Whether to prevent using devices of type `unix-hotplug`
 		"restricted.devices.unix-hotplug": isEitherAllowOrBlock,
@@ -1635,6 +1739,7 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		// Possible values are `allow` or `block`.
 		// ---
 		//  type: string
+		//  allowedvalues: block, allow
 		//  defaultdesc: `block`
 		//  shortdesc: Whether to prevent using devices of type `infiniband`
 		"restricted.devices.infiniband": isEitherAllowOrBlock,
@@ -1643,6 +1748,7 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		// Possible values are `allow` or `block`.
 		// ---
 		//  type: string
+		//  allowedvalues: block, allow
 		//  defaultdesc: `block`
 		//  shortdesc: Whether to prevent using devices of type `gpu`
 		"restricted.devices.gpu": isEitherAllowOrBlock,
@@ -1651,6 +1757,7 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		// Possible values are `allow` or `block`.
 		// ---
 		//  type: string
+		//  allowedvalues: block, allow
 		//  defaultdesc: `block`
 		//  shortdesc: Whether to prevent using devices of type `usb`
 		"restricted.devices.usb": isEitherAllowOrBlock,
@@ -1659,6 +1766,7 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		// Possible values are `allow` or `block`.
 		// ---
 		//  type: string
+		//  allowedvalues: block, allow
 		//  defaultdesc: `block`
 		//  shortdesc: Whether to prevent using devices of type `pci`
 		"restricted.devices.pci": isEitherAllowOrBlock,
@@ -1667,6 +1775,7 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		// Possible values are `allow` or `block`.
 		// ---
 		//  type: string
+		//  allowedvalues: block, allow
 		//  defaultdesc: `block`
 		//  shortdesc: Whether to prevent using devices of type `proxy`
 		"restricted.devices.proxy": isEitherAllowOrBlock,
@@ -1679,6 +1788,7 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		// - When set to `allow`, there is no restriction on which network devices can be used.
 		// ---
 		//  type: string
+		//  allowedvalues: block, allow, managed
 		//  defaultdesc: `managed`
 		//  shortdesc: Which network devices can be used
 		"restricted.devices.nic": isEitherAllowOrBlockOrManaged,
@@ -1691,6 +1801,7 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		// - When set to `allow`, there is no restriction on which disk devices can be used.
 		// ---
 		//  type: string
+		//  allowedvalues: block, allow, managed
 		//  defaultdesc: `managed`
 		//  shortdesc: Which disk devices can be used
 		"restricted.devices.disk": isEitherAllowOrBlockOrManaged,
@@ -1728,6 +1839,15 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		//  shortdesc: Which network names are allowed for use in this project
 		"restricted.networks.access": validate.Optional(validate.IsListOf(validate.IsAny)),
 
+		// gendoc:generate(entity=project, group=restricted, key=restricted.networks.acls.mandatory)
+		// Specify a comma-delimited list of network ACLs that are applied first to every NIC connected to a bridge or OVN network in this project.
+		// Project users can't remove them from the NICs, and they show up in the expanded configuration of the instances.
+		// This applies whether the project is restricted or not.
+		// ---
+		//  type: string
+		//  shortdesc: Which network ACLs are always applied to NICs in this project
+		"restricted.networks.acls.mandatory": validate.Optional(validate.IsListOf(acl.ValidName)),
+
 		// gendoc:generate(entity=project, group=restricted, key=restricted.networks.integrations)
 		// Specify a comma-delimited list of network integrations that can be used by networks in this project.
 		// ---
@@ -1765,6 +1885,7 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		//
 		// ---
 		//  type: string
+		//  allowedvalues: block, allow
 		//  defaultdesc: `block`
 		//  shortdesc: Whether to prevent creating instance or volume snapshots
 		"restricted.snapshots": isEitherAllowOrBlock,
@@ -1812,6 +1933,22 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 			continue
 		}
 
+		// gendoc:generate(entity=project, group=specific, key=images.pin.*)
+		// Pins an image alias (for example, `images.pin.debian/12`) to either a specific image by setting its fingerprint, or to one of the alias' release channels by setting `@<channel>` (for example, `@stable`).
+		// Creating instances from the local alias then uses the pinned image or channel, and images pinned by fingerprint aren't automatically updated.
+		// ---
+		//  type: string
+		//  shortdesc: Image or channel to use for an image alias
+		_, isImagePin := projecthelpers.ImagePinAlias(key)
+		if isImagePin {
+			err := projectValidateImagePin(v)
+			if err != nil {
+				return fmt.Errorf("Invalid project configuration key %q value: %w", k, err)
+			}
+
+			continue
+		}
+
 		// Then validate.
 		validator, ok := projectConfigKeys[key]
 		if !ok {
@@ -1834,6 +1971,24 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 	return nil
 }
 
+// projectValidateImagePin validates the value of an image alias pin, either an image fingerprint or a channel.
+func projectValidateImagePin(value string) error {
+	channel, isChannel := strings.CutPrefix(value, "@")
+	if isChannel {
+		return imageValidateChannel(channel)
+	}
+
+	if len(value) < 12 || len(value) > 64 {
+		return fmt.Errorf("Image fingerprint must be between 12 and 64 characters long")
+	}
+
+	if strings.Trim(value, "0123456789abcdef") != "" {
+		return fmt.Errorf("Invalid image fingerprint %q", value)
+	}
+
+	return nil
+}
+
 func projectValidateName(name string) error {
 	if name == "" {
 		return fmt.Errorf("No name provided")
@@ -1929,6 +2084,16 @@ func projectValidateRestrictedSubnets(s *state.State, value string) error {
 	return nil
 }
 
+// projectValidateMandatoryACLs checks that the mandatory network ACLs of the project exist.
+func projectValidateMandatoryACLs(s *state.State, p *api.Project) error {
+	aclNames := util.SplitNTrimSpace(p.Config["restricted.networks.acls.mandatory"], ",", -1, true)
+	if len(aclNames) == 0 {
+		return nil
+	}
+
+	return acl.Exists(s, projecthelpers.NetworkProjectFromRecord(p), aclNames...)
+}
+
 // swagger:operation GET /1.0/projects/{name}/access projects project_access
 //
 //	Get who has access to a project
@@ -6,13 +6,16 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"time"
 
 	"gopkg.in/yaml.v2"
 
+	"github.com/lxc/incus/v6/internal/backupmanifest"
 	"github.com/lxc/incus/v6/internal/instancewriter"
 	"github.com/lxc/incus/v6/internal/server/backup"
+	"github.com/lxc/incus/v6/internal/server/certificate"
 	"github.com/lxc/incus/v6/internal/server/db"
 	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
 	"github.com/lxc/incus/v6/internal/server/db/operationtype"
@@ -26,6 +29,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/task"
 	internalUtil "github.com/lxc/incus/v6/internal/util"
 	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/archive"
 	"github.com/lxc/incus/v6/shared/idmap"
 	"github.com/lxc/incus/v6/shared/ioprogress"
 	"github.com/lxc/incus/v6/shared/logger"
@@ -106,6 +110,12 @@ func backupCreate(s *state.State, args db.InstanceBackup, sourceInst instance.In
 		}
 	}
 
+	// Get the encryption recipients.
+	recipients, err := backupEncryptionRecipients(s, sourceInst.Project().Name, args.EncryptionRecipients)
+	if err != nil {
+		return err
+	}
+
 	// Create the target path if needed.
 	backupsPath := internalUtil.VarPath("backups", "instances", project.Instance(sourceInst.Project().Name, sourceInst.Name()))
 	if !util.PathExists(backupsPath) {
@@ -166,9 +176,9 @@ func backupCreate(s *state.State, args db.InstanceBackup, sourceInst instance.In
 	go func(resCh chan<- error) {
 		l.Debug("Started backup tarball writer")
 		defer l.Debug("Finished backup tarball writer")
-		if compress != "none" {
+		if compress != "none" || len(recipients) > 0 {
 			backupProgressWriter.WriteCloser = tarFileWriter
-			compressErr = compressFile(compress, tarPipeReader, backupProgressWriter)
+			compressErr = backupCompressAndEncrypt(compress, recipients, tarPipeReader, backupProgressWriter)
 
 			// If a compression error occurred, close the tarPipeWriter to end the export.
 			if compressErr != nil {
@@ -201,6 +211,12 @@ func backupCreate(s *state.State, args db.InstanceBackup, sourceInst instance.In
 		return fmt.Errorf("Backup create: %w", err)
 	}
 
+	// Write the signed manifest.
+	err = backupWriteManifest(s, tarWriter)
+	if err != nil {
+		return fmt.Errorf("Error writing backup manifest: %w", err)
+	}
+
 	// Close off the tarball file.
 	err = tarWriter.Close()
 	if err != nil {
@@ -223,12 +239,219 @@ func backupCreate(s *state.State, args db.InstanceBackup, sourceInst instance.In
 		return fmt.Errorf("Error closing tar file: %w", err)
 	}
 
+	// Encrypted backups can't be verified by the server through their embedded manifest, sign the file itself.
+	if len(recipients) > 0 {
+		err = backupWriteDetachedManifest(s, target)
+		if err != nil {
+			return fmt.Errorf("Error writing backup manifest: %w", err)
+		}
+
+		reverter.Add(func() { _ = os.Remove(target + backupmanifest.DetachedSuffix) })
+	}
+
 	reverter.Success()
 	s.Events.SendLifecycle(sourceInst.Project().Name, lifecycle.InstanceBackupCreated.Event(args.Name, b.Instance(), nil))
 
 	return nil
 }
 
+// backupEncryptionRecipients returns the recipients to encrypt a backup to, using the project's
+// default recipients if none were requested.
+func backupEncryptionRecipients(s *state.State, projectName string, recipients []string) ([]string, error) {
+	if len(recipients) == 0 {
+		var p *api.Project
+		err := s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			project, err := dbCluster.GetProject(ctx, tx.Tx(), projectName)
+			if err != nil {
+				return err
+			}
+
+			p, err = project.ToAPI(ctx, tx.Tx())
+
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		recipients = util.SplitNTrimSpace(p.Config["backups.encryption_recipients"], ",", -1, true)
+	}
+
+	if len(recipients) > 0 {
+		err := backup.CheckEncryption(recipients)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return recipients, nil
+}
+
+// backupCompressAndEncrypt compresses the tarball read from infile and, if recipients are provided,
+// encrypts it before writing it to outfile.
+func backupCompressAndEncrypt(compress string, recipients []string, infile io.Reader, outfile io.Writer) error {
+	if len(recipients) == 0 {
+		if compress == "none" {
+			_, err := io.Copy(outfile, infile)
+			return err
+		}
+
+		return compressFile(compress, infile, outfile)
+	}
+
+	encryptReader, encryptWriter := io.Pipe()
+	encryptRes := make(chan error, 1)
+
+	go func() {
+		err := backup.Encrypt(recipients, encryptReader, outfile)
+
+		// Unblock the compression if encryption failed.
+		_ = encryptReader.CloseWithError(err)
+		encryptRes <- err
+	}()
+
+	err := backupCompressAndEncrypt(compress, nil, infile, encryptWriter)
+	_ = encryptWriter.CloseWithError(err)
+
+	encryptErr := <-encryptRes
+	if err != nil {
+		return err
+	}
+
+	return encryptErr
+}
+
+// backupWriteManifest signs the entries written so far and adds the manifest to the backup tarball.
+func backupWriteManifest(s *state.State, tarWriter *instancewriter.InstanceTarWriter) error {
+	manifest, signature, err := backupmanifest.Sign(tarWriter.Entries(), s.Endpoints.NetworkCert())
+	if err != nil {
+		return err
+	}
+
+	files := []struct {
+		path string
+		data []byte
+	}{
+		{path: backupmanifest.ManifestPath, data: manifest},
+		{path: backupmanifest.SignaturePath, data: signature},
+	}
+
+	for _, file := range files {
+		fileInfo := instancewriter.FileInfo{
+			FileName:    file.path,
+			FileSize:    int64(len(file.data)),
+			FileMode:    0o644,
+			FileModTime: time.Now(),
+		}
+
+		err = tarWriter.WriteFileFromReader(bytes.NewReader(file.data), &fileInfo)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// backupWriteDetachedManifest signs the backup file at path and stores the detached manifest next to it.
+func backupWriteDetachedManifest(s *state.State, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = f.Close() }()
+
+	detached, err := backupmanifest.SignFile(f, s.Endpoints.NetworkCert())
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path+backupmanifest.DetachedSuffix, detached, 0o600)
+}
+
+// backupVerifyFingerprints returns the fingerprints of the certificates trusted to have signed a backup.
+// Unless the request provides the expected fingerprint, those are the current server certificate and the
+// certificates of the cluster members.
+func backupVerifyFingerprints(d *Daemon, req api.BackupVerifyPost) []string {
+	if req.Fingerprint != "" {
+		return []string{req.Fingerprint}
+	}
+
+	fingerprints := []string{d.endpoints.NetworkCert().Fingerprint()}
+	for fingerprint := range d.clientCerts.GetCertificates()[certificate.TypeServer] {
+		fingerprints = append(fingerprints, fingerprint)
+	}
+
+	return fingerprints
+}
+
+// backupVerify checks the backup at path against its signed manifest without restoring it.
+// Encrypted backups are checked against their detached manifest instead.
+func backupVerify(s *state.State, path string, fingerprints []string, op *operations.Operation) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("Error opening backup tarball %q: %w", path, err)
+	}
+
+	defer func() { _ = f.Close() }()
+
+	encryption, err := archive.DetectEncryptionFile(f)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	var result *backupmanifest.Result
+	if encryption != "" {
+		detached, err := os.ReadFile(path + backupmanifest.DetachedSuffix)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return backupmanifest.ErrNoManifest
+			}
+
+			return err
+		}
+
+		result, err = backupmanifest.VerifyFile(f, detached)
+		if err != nil {
+			return err
+		}
+	} else {
+		tr, cancelFunc, err := backup.TarReader(f, s.OS, path)
+		if err != nil {
+			return err
+		}
+
+		defer cancelFunc()
+
+		result, err = backupmanifest.Verify(tr)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = backupmanifest.CheckSigner(result, fingerprints)
+	if err != nil {
+		return err
+	}
+
+	meta := op.Metadata()
+	if meta == nil {
+		meta = make(map[string]any)
+	}
+
+	meta["files"] = result.Files
+	meta["fingerprint"] = result.Fingerprint
+	meta["encrypted"] = encryption != ""
+
+	return op.UpdateMetadata(meta)
+}
+
 // backupWriteIndex generates an index.yaml file and then writes it to the root of the backup tarball.
 func backupWriteIndex(sourceInst instance.Instance, pool storagePools.Pool, optimized bool, snapshots bool, tarWriter *instancewriter.InstanceTarWriter) error {
 	// Indicate whether the driver will include a driver-specific optimized header.
@@ -447,6 +670,12 @@ func volumeBackupCreate(s *state.State, args db.StoragePoolVolumeBackup, project
 		compress = s.GlobalConfig.BackupsCompressionAlgorithm()
 	}
 
+	// Get the encryption recipients.
+	recipients, err := backupEncryptionRecipients(s, projectName, args.EncryptionRecipients)
+	if err != nil {
+		return err
+	}
+
 	// Create the target path if needed.
 	backupsPath := internalUtil.VarPath("backups", "custom", pool.Name(), project.StorageVolume(projectName, volumeName))
 	if !util.PathExists(backupsPath) {
@@ -482,8 +711,8 @@ func volumeBackupCreate(s *state.State, args db.StoragePoolVolumeBackup, project
 	go func(resCh chan<- error) {
 		l.Debug("Started backup tarball writer")
 		defer l.Debug("Finished backup tarball writer")
-		if compress != "none" {
-			compressErr = compressFile(compress, tarPipeReader, tarFileWriter)
+		if compress != "none" || len(recipients) > 0 {
+			compressErr = backupCompressAndEncrypt(compress, recipients, tarPipeReader, tarFileWriter)
 
 			// If a compression error occurred, close the tarPipeWriter to end the export.
 			if compressErr != nil {
@@ -515,6 +744,12 @@ func volumeBackupCreate(s *state.State, args db.StoragePoolVolumeBackup, project
 		return fmt.Errorf("Backup create: %w", err)
 	}
 
+	// Write the signed manifest.
+	err = backupWriteManifest(s, tarWriter)
+	if err != nil {
+		return fmt.Errorf("Error writing backup manifest: %w", err)
+	}
+
 	// Close off the tarball file.
 	err = tarWriter.Close()
 	if err != nil {
@@ -537,6 +772,16 @@ func volumeBackupCreate(s *state.State, args db.StoragePoolVolumeBackup, project
 		return fmt.Errorf("Error closing tar file: %w", err)
 	}
 
+	// Encrypted backups can't be verified by the server through their embedded manifest, sign the file itself.
+	if len(recipients) > 0 {
+		err = backupWriteDetachedManifest(s, target)
+		if err != nil {
+			return fmt.Errorf("Error writing backup manifest: %w", err)
+		}
+
+		reverter.Add(func() { _ = os.Remove(target + backupmanifest.DetachedSuffix) })
+	}
+
 	reverter.Success()
 	return nil
 }
@@ -693,6 +938,12 @@ func bucketBackupCreate(s *state.State, args db.StoragePoolBucketBackup, project
 		compress = s.GlobalConfig.BackupsCompressionAlgorithm()
 	}
 
+	// Get the encryption recipients.
+	recipients, err := backupEncryptionRecipients(s, projectName, args.EncryptionRecipients)
+	if err != nil {
+		return err
+	}
+
 	// Create the target path if needed.
 	backupsPath := internalUtil.VarPath("backups", "buckets", pool.Name(), project.StorageBucket(projectName, bucketName))
 	if !util.PathExists(backupsPath) {
@@ -728,8 +979,8 @@ func bucketBackupCreate(s *state.State, args db.StoragePoolBucketBackup, project
 	go func(resCh chan<- error) {
 		l.Debug("Started backup tarball writer")
 		defer l.Debug("Finished backup tarball writer")
-		if compress != "none" {
-			compressErr = compressFile(compress, tarPipeReader, tarFileWriter)
+		if compress != "none" || len(recipients) > 0 {
+			compressErr = backupCompressAndEncrypt(compress, recipients, tarPipeReader, tarFileWriter)
 
 			// If a compression error occurred, close the tarPipeWriter to end the export.
 			if compressErr != nil {
@@ -761,6 +1012,12 @@ func bucketBackupCreate(s *state.State, args db.StoragePoolBucketBackup, project
 		return fmt.Errorf("Backup create: %w", err)
 	}
 
+	// Write the signed manifest.
+	err = backupWriteManifest(s, tarWriter)
+	if err != nil {
+		return fmt.Errorf("Error writing backup manifest: %w", err)
+	}
+
 	// Close off the tarball file.
 	err = tarWriter.Close()
 	if err != nil {
@@ -783,6 +1040,16 @@ func bucketBackupCreate(s *state.State, args db.StoragePoolBucketBackup, project
 		return fmt.Errorf("Error closing tar file: %w", err)
 	}
 
+	// Encrypted backups can't be verified by the server through their embedded manifest, sign the file itself.
+	if len(recipients) > 0 {
+		err = backupWriteDetachedManifest(s, target)
+		if err != nil {
+			return fmt.Errorf("Error writing backup manifest: %w", err)
+		}
+
+		reverter.Add(func() { _ = os.Remove(target + backupmanifest.DetachedSuffix) })
+	}
+
 	reverter.Success()
 	return nil
 }
@@ -605,6 +605,14 @@ func certificatesPost(d *Daemon, r *http.Request) response.Response {
 			if joinOp == nil {
 				return response.Forbidden(fmt.Errorf("No matching cluster join operation found"))
 			}
+
+			// Keep track of enrolled servers until they join, so the enrollment policy can be applied.
+			if joinOp.Metadata["enrollment"] != nil {
+				err = clusterEnrollmentJoining(s, r, joinOp)
+				if err != nil {
+					return response.InternalError(fmt.Errorf("Failed recording enrollment join: %w", err))
+				}
+			}
 		} else {
 			// Check if certificate add token supplied as token.
 			joinToken, err := localtls.CertificateTokenDecode(req.TrustToken)
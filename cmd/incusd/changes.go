@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+var changesCmd = APIEndpoint{
+	Path: "changes",
+
+	Get: APIEndpointAction{Handler: changesGet, AccessHandler: allowAuthenticated},
+}
+
+// swagger:operation GET /1.0/changes server changes_get
+//
+//	Get the modified entities
+//
+//	Returns the entities modified since the provided generation.
+//
+//	Generations are tracked in memory by each server, the epoch changes whenever the server restarts
+//	and generations from a different epoch must not be compared.
+//	When some of the changes since the provided generation are no longer known, the reset flag is set
+//	and the client should reload all the entities it's interested in.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: since
+//	    description: Generation to list the changes from
+//	    type: integer
+//	    example: 1024
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: query
+//	    name: all-projects
+//	    description: Retrieve changes from all projects
+//	    type: boolean
+//	responses:
+//	  "200":
+//	    description: Modified entities
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/Changes"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func changesGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	var since uint64
+	sinceStr := request.QueryParam(r, "since")
+	if sinceStr != "" {
+		var err error
+		since, err = strconv.ParseUint(sinceStr, 10, 64)
+		if err != nil {
+			return response.BadRequest(fmt.Errorf("Invalid generation %q: %w", sinceStr, err))
+		}
+	}
+
+	// Detect project mode.
+	projectName := request.QueryParam(r, "project")
+	allProjects := util.IsTrue(request.QueryParam(r, "all-projects"))
+
+	if allProjects && projectName != "" {
+		return response.BadRequest(fmt.Errorf("Cannot specify a project when requesting all projects"))
+	} else if !allProjects && projectName == "" {
+		projectName = api.ProjectDefaultName
+	}
+
+	if !allProjects && projectName != api.ProjectDefaultName {
+		_, err := s.DB.GetProject(context.Background(), projectName)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
+	projectPermissionFunc := func(auth.Object) bool { return true }
+	if allProjects {
+		var err error
+		projectPermissionFunc, err = s.Authorizer.GetPermissionChecker(r.Context(), r, auth.EntitlementCanViewEvents, auth.ObjectTypeProject)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	} else {
+		err := s.Authorizer.CheckPermission(r.Context(), r, auth.ObjectProject(projectName), auth.EntitlementCanViewEvents)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
+	changes := s.Events.Changes().Since(since, func(entity api.ChangesEntity) bool {
+		// Entities outside of projects are always visible (same as their events).
+		if entity.Project == "" {
+			return true
+		}
+
+		if !allProjects && entity.Project != projectName {
+			return false
+		}
+
+		return projectPermissionFunc(auth.ObjectProject(entity.Project))
+	})
+
+	return response.SyncResponse(true, changes)
+}
+
+// changesETag returns the ETag of a collection, derived from the generation of the change log.
+// When URLs are provided, only the changes to the entities at or below them are considered.
+// It changes with every lifecycle event (creation, update, deletion, status change, ...)
+// but not with changes to the instance state (addresses, usage counters, ...).
+func changesETag(s *state.State, r *http.Request, urls ...string) []any {
+	epoch, generation := s.Events.Changes().Generation()
+	if len(urls) > 0 {
+		generation = s.Events.Changes().EntityGeneration(urls...)
+	}
+
+	return []any{epoch, generation, r.URL.RequestURI()}
+}
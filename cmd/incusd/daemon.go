@@ -55,6 +55,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/network/ovs"
 	networkZone "github.com/lxc/incus/v6/internal/server/network/zone"
 	"github.com/lxc/incus/v6/internal/server/node"
+	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
@@ -795,6 +796,9 @@ func (d *Daemon) createCmd(restAPI *mux.Router, version string, c APIEndpoint) {
 			resp = response.NotFound(fmt.Errorf("Method %q not found", r.Method))
 		}
 
+		// Skip sending the response back if the client already has it.
+		resp = response.ConditionalResponse(r, resp)
+
 		// If sending out Forbidden, make sure we have OIDC headers.
 		if resp.Code() == http.StatusForbidden && d.oidcVerifier != nil {
 			_ = d.oidcVerifier.WriteHeaders(w)
@@ -1444,6 +1448,11 @@ func (d *Daemon) init() error {
 		return err
 	}
 
+	// Apply the operation concurrency limits.
+	for _, workload := range operations.Workloads {
+		operations.SetConcurrencyLimit(workload, int(d.localConfig.OperationsConcurrency(workload)))
+	}
+
 	// Setup syslog listener.
 	if syslogSocketEnabled {
 		err = d.setupSyslogSocket(true)
@@ -2529,6 +2538,13 @@ func (d *Daemon) nodeRefreshTask(heartbeatData *cluster.APIHeartbeat, isLeader b
 		updateCertificateCache(d)
 	}
 
+	// Renew the gateway leases of highly available networks and move the gateways which changed hands.
+	err = networkHandleHeartbeat(s, heartbeatData)
+	if err != nil {
+		stateChangeTaskFailure = true
+		logger.Error("Error handling heartbeat for networks", logger.Ctx{"err": err})
+	}
+
 	// Refresh event listeners from heartbeat members (after certificates refreshed if needed).
 	// Run asynchronously so that connecting to remote members doesn't delay other heartbeat tasks.
 	wg := sync.WaitGroup{}
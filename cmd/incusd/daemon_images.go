@@ -17,6 +17,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/db/cluster"
 	"github.com/lxc/incus/v6/internal/server/locking"
 	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/response"
 	"github.com/lxc/incus/v6/internal/server/state"
 	localUtil "github.com/lxc/incus/v6/internal/server/util"
@@ -46,6 +47,8 @@ type ImageDownloadArgs struct {
 	StoragePool       string
 	Budget            int64
 	SourceProjectName string
+	RegistryAuth      *api.ImageRegistryAuth
+	SkipLimits        bool
 }
 
 // imageOperationLock acquires a lock for operating on an image and returns the unlock function.
@@ -100,6 +103,8 @@ func ImageDownload(ctx context.Context, r *http.Request, s *state.State, op *ope
 			}
 		} else if protocol == "oci" {
 			// Setup OCI client
+			clientArgs.RegistryAuth = args.RegistryAuth
+
 			remote, err = incus.ConnectOCI(args.Server, clientArgs)
 			if err != nil {
 				return nil, false, fmt.Errorf("Failed to connect to oci server %q: %w", args.Server, err)
@@ -216,6 +221,14 @@ func ImageDownload(ctx context.Context, r *http.Request, s *state.State, op *ope
 					return fmt.Errorf("Locate image %q in the cluster: %w", imgInfo.Fingerprint, err)
 				}
 
+				// Check that the project allows for the image before adding it.
+				if !args.SkipLimits {
+					err = project.AllowImageCreation(tx, args.ProjectName, imgInfo.Size)
+					if err != nil {
+						return err
+					}
+				}
+
 				// We need to insert the database entry for this project, including the node ID entry.
 				err = tx.CreateImage(ctx, args.ProjectName, imgInfo.Fingerprint, imgInfo.Filename, imgInfo.Size, args.Public, imgInfo.AutoUpdate, imgInfo.Architecture, imgInfo.CreatedAt, imgInfo.ExpiresAt, imgInfo.Properties, imgInfo.Type, nil)
 				if err != nil {
@@ -237,7 +250,7 @@ func ImageDownload(ctx context.Context, r *http.Request, s *state.State, op *ope
 					return err
 				}
 
-				return tx.CreateImageSource(ctx, id, args.Server, args.Protocol, args.Certificate, alias)
+				return tx.CreateImageSource(ctx, id, args.Server, args.Protocol, args.Certificate, alias, args.RegistryAuth)
 			})
 			if err != nil {
 				return nil, false, err
@@ -304,6 +317,21 @@ func ImageDownload(ctx context.Context, r *http.Request, s *state.State, op *ope
 		return info, false, nil
 	}
 
+	// Check that the project allows for another image before downloading it.
+	if !args.SkipLimits {
+		size := int64(-1)
+		if info != nil {
+			size = info.Size
+		}
+
+		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			return project.AllowImageCreation(tx, args.ProjectName, size)
+		})
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
 	// Begin downloading
 	if op == nil {
 		ctxMap = logger.Ctx{"alias": alias, "server": args.Server}
@@ -555,6 +583,14 @@ func ImageDownload(ctx context.Context, r *http.Request, s *state.State, op *ope
 	}
 
 	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		// Check the project limits again now that the size is known.
+		if !args.SkipLimits {
+			err := project.AllowImageCreation(tx, args.ProjectName, info.Size)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Create the database entry
 		return tx.CreateImage(ctx, args.ProjectName, info.Fingerprint, info.Filename, info.Size, info.Public, info.AutoUpdate, info.Architecture, info.CreatedAt, info.ExpiresAt, info.Properties, info.Type, nil)
 	})
@@ -589,7 +625,7 @@ func ImageDownload(ctx context.Context, r *http.Request, s *state.State, op *ope
 				return err
 			}
 
-			return tx.CreateImageSource(ctx, id, args.Server, protocol, args.Certificate, alias)
+			return tx.CreateImageSource(ctx, id, args.Server, protocol, args.Certificate, alias, args.RegistryAuth)
 		})
 		if err != nil {
 			return nil, false, err
@@ -59,6 +59,7 @@ import (
 	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/osarch"
 	"github.com/lxc/incus/v6/shared/util"
+	"github.com/lxc/incus/v6/shared/validate"
 )
 
 var imagesCmd = APIEndpoint{
@@ -440,6 +441,7 @@ func imgPostRemoteInfo(ctx context.Context, s *state.State, r *http.Request, req
 		ProjectName:       project,
 		Budget:            budget,
 		SourceProjectName: req.Source.Project,
+		RegistryAuth:      req.Source.RegistryAuth,
 	})
 	if err != nil {
 		return nil, err
@@ -1060,10 +1062,15 @@ func imagesPost(d *Daemon, r *http.Request) response.Response {
 		return response.InternalError(err)
 	}
 
-	// Possibly set a quota on the amount of disk space this project is
-	// allowed to use.
+	// Check that the project allows for another image and possibly set a
+	// quota on the amount of disk space this project is allowed to use.
 	var budget int64
 	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		err := projectutils.AllowImageCreation(tx, projectName, -1)
+		if err != nil {
+			return err
+		}
+
 		budget, err = projectutils.GetImageSpaceBudget(tx, projectName)
 		return err
 	})
@@ -1116,6 +1123,12 @@ func imagesPost(d *Daemon, r *http.Request) response.Response {
 		return response.InternalError(fmt.Errorf("Invalid images JSON"))
 	}
 
+	err = imageValidateRegistryAuth(req.Source.RegistryAuth)
+	if err != nil {
+		cleanup(builddir, post)
+		return response.BadRequest(err)
+	}
+
 	/* Forward requests for containers on other nodes */
 	if !imageUpload && slices.Contains([]string{"container", "instance", "virtual-machine", "snapshot"}, req.Source.Type) {
 		name := req.Source.Name
@@ -1704,6 +1717,13 @@ func imagesGet(d *Daemon, r *http.Request) response.Response {
 
 	public := d.checkTrustedClient(r) != nil || authorizationErr != nil
 
+	// Skip loading the collection if the client already has its current content.
+	// Untrusted clients don't get to see when the server changes.
+	etag := changesETag(s, r)
+	if !public && response.ETagMatch(r, etag) {
+		return response.SyncResponseConditional(true, nil, etag)
+	}
+
 	clauses, err := filter.Parse(filterStr, filter.QueryOperatorSet())
 	if err != nil {
 		return response.SmartError(fmt.Errorf("Invalid filter: %w", err))
@@ -1722,7 +1742,11 @@ func imagesGet(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
-	return response.SyncResponse(true, result)
+	if public {
+		return response.SyncResponse(true, result)
+	}
+
+	return response.SyncResponseConditional(true, result, etag)
 }
 
 func autoUpdateImagesTask(d *Daemon) (task.Func, task.Schedule) {
@@ -2117,31 +2141,86 @@ func distributeImage(ctx context.Context, s *state.State, nodes []string, oldFin
 	return nil
 }
 
+// imageValidateRegistryAuth validates the OCI registry credentials and settings of an image source.
+func imageValidateRegistryAuth(auth *api.ImageRegistryAuth) error {
+	if auth == nil {
+		return nil
+	}
+
+	if auth.AuthFile != "" && (auth.Username != "" || auth.Password != "") {
+		return fmt.Errorf("Registry auth file and username or password can't be used together")
+	}
+
+	if auth.Password != "" && auth.Username == "" {
+		return fmt.Errorf("Registry password requires a username")
+	}
+
+	if auth.AuthFile != "" && !json.Valid([]byte(auth.AuthFile)) {
+		return fmt.Errorf("Registry auth file isn't valid JSON")
+	}
+
+	for _, mirror := range auth.Mirrors {
+		err := validate.IsRegistryAddress(mirror)
+		if err != nil {
+			return fmt.Errorf("Invalid registry mirror %q: %w", mirror, err)
+		}
+	}
+
+	return nil
+}
+
 // Update a single image.  The operation can be nil, if no progress tracking is needed.
 // Returns whether the image has been updated.
 func autoUpdateImage(ctx context.Context, s *state.State, op *operations.Operation, id int, info *api.Image, projectName string, manual bool) (*api.Image, error) {
 	fingerprint := info.Fingerprint
 	var source api.ImageSource
+	var registryAuth *api.ImageRegistryAuth
 
-	if !manual {
-		var interval int64
+	var p *api.Project
+	var pinned bool
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		dbProjects, err := dbCluster.GetProjects(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
 
-		var project *api.Project
-		err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
-			p, err := dbCluster.GetProject(ctx, tx.Tx(), projectName)
+		// Look for pins of the image in all the projects using it.
+		for _, dbProject := range dbProjects {
+			apiProject, err := dbProject.ToAPI(ctx, tx.Tx())
 			if err != nil {
 				return err
 			}
 
-			project, err = p.ToAPI(ctx, tx.Tx())
-			return err
-		})
-		if err != nil {
-			return nil, err
+			if apiProject.Name == projectName {
+				p = apiProject
+			}
+
+			if projectutils.ImageProjectFromRecord(apiProject) == projectName && projectutils.ImagePinned(apiProject.Config, fingerprint) {
+				pinned = true
+			}
+		}
+
+		if p == nil {
+			return api.StatusErrorf(http.StatusNotFound, "Project %q not found", projectName)
 		}
 
-		if project.Config["images.auto_update_interval"] != "" {
-			interval, err = strconv.ParseInt(project.Config["images.auto_update_interval"], 10, 64)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Replacing a pinned image would break the pin.
+	if pinned {
+		logger.Debug("Skipping update of pinned image", logger.Ctx{"fingerprint": fingerprint, "project": projectName})
+		return nil, nil
+	}
+
+	if !manual {
+		var interval int64
+
+		if p.Config["images.auto_update_interval"] != "" {
+			interval, err = strconv.ParseInt(p.Config["images.auto_update_interval"], 10, 64)
 			if err != nil {
 				return nil, fmt.Errorf("Unable to fetch project configuration: %w", err)
 			}
@@ -2163,7 +2242,7 @@ func autoUpdateImage(ctx context.Context, s *state.State, op *operations.Operati
 
 	var poolNames []string
 
-	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
 		var err error
 		_, source, err = tx.GetImageSource(ctx, id)
 		if err != nil {
@@ -2171,6 +2250,12 @@ func autoUpdateImage(ctx context.Context, s *state.State, op *operations.Operati
 			return err
 		}
 
+		registryAuth, err = tx.GetImageSourceRegistryAuth(ctx, id)
+		if err != nil {
+			logger.Error("Error getting source image registry credentials", logger.Ctx{"err": err, "fingerprint": fingerprint})
+			return err
+		}
+
 		// Get the IDs of all storage pools on which a storage volume
 		// for the requested image currently exists.
 		poolIDs, err := tx.GetPoolsWithImage(ctx, fingerprint)
@@ -2226,16 +2311,18 @@ func autoUpdateImage(ctx context.Context, s *state.State, op *operations.Operati
 		}
 
 		newInfo, _, err = ImageDownload(ctx, nil, s, op, &ImageDownloadArgs{
-			Server:      source.Server,
-			Protocol:    source.Protocol,
-			Certificate: source.Certificate,
-			Alias:       source.Alias,
-			Type:        info.Type,
-			AutoUpdate:  true,
-			Public:      info.Public,
-			StoragePool: poolName,
-			ProjectName: projectName,
-			Budget:      -1,
+			Server:       source.Server,
+			Protocol:     source.Protocol,
+			Certificate:  source.Certificate,
+			Alias:        source.Alias,
+			Type:         info.Type,
+			AutoUpdate:   true,
+			Public:       info.Public,
+			StoragePool:  poolName,
+			ProjectName:  projectName,
+			Budget:       -1,
+			RegistryAuth: registryAuth,
+			SkipLimits:   true,
 		})
 		if err != nil {
 			logger.Error("Failed to update the image", logger.Ctx{"err": err, "fingerprint": fingerprint})
@@ -3367,6 +3454,11 @@ func imageAliasesPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(fmt.Errorf("name and target are required"))
 	}
 
+	err = imageAliasValidateName(req.Name)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
 	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 		// This is just to see if the alias name already exists.
 		_, _, err = tx.GetImageAlias(ctx, projectName, req.Name, true)
@@ -3504,6 +3596,13 @@ func imageAliasesGet(d *Daemon, r *http.Request) response.Response {
 	recursion := localUtil.IsRecursionRequest(r)
 
 	s := d.State()
+
+	// Skip loading the collection if the client already has its current content.
+	etag := changesETag(s, r)
+	if response.ETagMatch(r, etag) {
+		return response.SyncResponseConditional(true, nil, etag)
+	}
+
 	userHasPermission, err := s.Authorizer.GetPermissionChecker(r.Context(), r, auth.EntitlementCanView, auth.ObjectTypeImageAlias)
 	if err != nil {
 		return response.InternalError(fmt.Errorf("Failed to get a permission checker: %w", err))
@@ -3547,10 +3646,10 @@ func imageAliasesGet(d *Daemon, r *http.Request) response.Response {
 	}
 
 	if !recursion {
-		return response.SyncResponse(true, responseStr)
+		return response.SyncResponseConditional(true, responseStr, etag)
 	}
 
-	return response.SyncResponse(true, responseMap)
+	return response.SyncResponseConditional(true, responseMap, etag)
 }
 
 // swagger:operation GET /1.0/images/aliases/{name}?public images image_alias_get_untrusted
@@ -3930,7 +4029,7 @@ func imageAliasPatch(d *Daemon, r *http.Request) response.Response {
 //
 //	Rename the image alias
 //
-//	Renames an existing image alias.
+//	Renames an existing image alias, or points another alias to the same image when promoting.
 //
 //	---
 //	consumes:
@@ -3973,6 +4072,15 @@ func imageAliasPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
+	err = imageAliasValidateName(req.Name)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if req.Promote {
+		return imageAliasPromote(s, r, projectName, name, req.Name)
+	}
+
 	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 		// This is just to see if the alias name already exists.
 		_, _, err := tx.GetImageAlias(ctx, projectName, req.Name, true)
@@ -4008,6 +4116,88 @@ func imageAliasPost(d *Daemon, r *http.Request) response.Response {
 	return response.SyncResponseLocation(true, nil, lc.Source)
 }
 
+// imageAliasPromote points the alias newName to the target of the alias name, creating it if needed.
+func imageAliasPromote(s *state.State, r *http.Request, projectName string, name string, newName string) response.Response {
+	// Check that the alias can be created or edited.
+	var exists bool
+	err := s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		_, _, err := tx.GetImageAlias(ctx, projectName, newName, true)
+		if err != nil && !response.IsNotFoundError(err) {
+			return err
+		}
+
+		exists = err == nil
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if exists {
+		err = s.Authorizer.CheckPermission(r.Context(), r, auth.ObjectImageAlias(projectName, newName), auth.EntitlementCanEdit)
+	} else {
+		err = s.Authorizer.CheckPermission(r.Context(), r, auth.ObjectProject(projectName), auth.EntitlementCanCreateImageAliases)
+	}
+
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	var alias api.ImageAliasesEntry
+	var created bool
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		_, source, err := tx.GetImageAlias(ctx, projectName, name, true)
+		if err != nil {
+			return err
+		}
+
+		imageID, _, err := tx.GetImage(ctx, source.Target, dbCluster.ImageFilter{Project: &projectName})
+		if err != nil {
+			return err
+		}
+
+		aliasID, alias, err := tx.GetImageAlias(ctx, projectName, newName, true)
+		if err != nil {
+			if !response.IsNotFoundError(err) {
+				return err
+			}
+
+			created = true
+			alias = api.ImageAliasesEntry{Name: newName, Type: source.Type}
+			alias.Description = source.Description
+			alias.Target = source.Target
+
+			return tx.CreateImageAlias(ctx, projectName, newName, imageID, alias.Description)
+		}
+
+		alias.Target = source.Target
+
+		return tx.UpdateImageAlias(ctx, aliasID, imageID, alias.Description)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	requestor := request.CreateRequestor(r)
+
+	var lc api.EventLifecycle
+	if created {
+		err = s.Authorizer.AddImageAlias(r.Context(), projectName, newName)
+		if err != nil {
+			logger.Error("Failed to add image alias to authorizer", logger.Ctx{"name": newName, "project": projectName, "error": err})
+		}
+
+		lc = lifecycle.ImageAliasCreated.Event(newName, projectName, requestor, logger.Ctx{"target": alias.Target})
+	} else {
+		lc = lifecycle.ImageAliasUpdated.Event(newName, projectName, requestor, logger.Ctx{"target": alias.Target})
+	}
+
+	s.Events.SendLifecycle(projectName, lc)
+
+	return response.SyncResponseLocation(true, nil, lc.Source)
+}
+
 // swagger:operation GET /1.0/images/{fingerprint}/export?public images image_export_get_untrusted
 //
 //  Get the raw image file(s)
@@ -4795,3 +4985,36 @@ func createTokenResponse(s *state.State, r *http.Request, projectName string, fi
 
 	return operations.OperationResponse(op)
 }
+
+// imageAliasValidateName validates an image alias name.
+// Names of the form "<alias>@<channel>" refer to a release channel of the alias.
+func imageAliasValidateName(name string) error {
+	aliasName, channel, hasChannel := strings.Cut(name, "@")
+	if !hasChannel {
+		return nil
+	}
+
+	if aliasName == "" {
+		return fmt.Errorf("Image alias name is missing before channel %q", channel)
+	}
+
+	return imageValidateChannel(channel)
+}
+
+// imageValidateChannel validates the name of an image alias release channel.
+func imageValidateChannel(channel string) error {
+	if channel == "" {
+		return fmt.Errorf("Image alias channel name is missing")
+	}
+
+	if strings.Contains(channel, "@") {
+		return fmt.Errorf("Image alias channel %q cannot contain \"@\"", channel)
+	}
+
+	err := validate.IsURLSegmentSafe(channel)
+	if err != nil {
+		return fmt.Errorf("Invalid image alias channel %q: %w", channel, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+func TestImageValidateRegistryAuth(t *testing.T) {
+	tests := []struct {
+		name  string
+		auth  *api.ImageRegistryAuth
+		valid bool
+	}{
+		{"No credentials", nil, true},
+		{"Username and password", &api.ImageRegistryAuth{Username: "user", Password: "secret"}, true},
+		{"Auth file", &api.ImageRegistryAuth{AuthFile: `{"auths": {}}`}, true},
+		{"Auth file with username", &api.ImageRegistryAuth{AuthFile: `{"auths": {}}`, Username: "user"}, false},
+		{"Invalid auth file", &api.ImageRegistryAuth{AuthFile: "user:secret"}, false},
+		{"Password without username", &api.ImageRegistryAuth{Password: "secret"}, false},
+		{"Mirrors", &api.ImageRegistryAuth{Mirrors: []string{"https://mirror.example.com", "mirror.example.net:5000/team"}}, true},
+		{"Invalid mirror scheme", &api.ImageRegistryAuth{Mirrors: []string{"ftp://mirror.example.com"}}, false},
+		{"Empty mirror", &api.ImageRegistryAuth{Mirrors: []string{""}}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := imageValidateRegistryAuth(test.auth)
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
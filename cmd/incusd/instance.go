@@ -278,7 +278,7 @@ func instanceCreateFromImage(ctx context.Context, s *state.State, img *api.Image
 	return nil
 }
 
-func instanceRebuildFromImage(ctx context.Context, s *state.State, r *http.Request, inst instance.Instance, img *api.Image, op *operations.Operation) error {
+func instanceRebuildFromImage(ctx context.Context, s *state.State, r *http.Request, inst instance.Instance, img *api.Image, keepSnapshots bool, op *operations.Operation) error {
 	// Validate the type of the image matches the type of the instance.
 	imgType, err := instancetype.New(img.Type)
 	if err != nil {
@@ -294,7 +294,7 @@ func instanceRebuildFromImage(ctx context.Context, s *state.State, r *http.Reque
 		return err
 	}
 
-	err = inst.Rebuild(img, op)
+	err = inst.Rebuild(img, keepSnapshots, op)
 	if err != nil {
 		return fmt.Errorf("Failed rebuilding instance from image: %w", err)
 	}
@@ -303,7 +303,7 @@ func instanceRebuildFromImage(ctx context.Context, s *state.State, r *http.Reque
 }
 
 func instanceRebuildFromEmpty(inst instance.Instance, op *operations.Operation) error {
-	err := inst.Rebuild(nil, op) // Rebuild as empty.
+	err := inst.Rebuild(nil, false, op) // Rebuild as empty.
 	if err != nil {
 		return fmt.Errorf("Failed rebuilding as an empty instance: %w", err)
 	}
@@ -576,6 +576,24 @@ func autoCreateInstanceSnapshots(ctx context.Context, s *state.State, instances
 			return err
 		}
 
+		// Make room for the new snapshot if the instance has reached snapshots.max.
+		err = instance.PruneSnapshots(inst)
+		if err != nil {
+			l.Error("Error pruning snapshots", logger.Ctx{"err": err})
+			return err
+		}
+
+		// Check the project limits again as other cluster members may have created snapshots since scheduling.
+		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			p := inst.Project()
+
+			return project.AllowSnapshotCreation(tx, &p)
+		})
+		if err != nil {
+			l.Warn("Skipping scheduled snapshot", logger.Ctx{"err": err})
+			continue
+		}
+
 		err = inst.Snapshot(snapshotName, expiry, false)
 		if err != nil {
 			l.Error("Error creating snapshot", logger.Ctx{"snapshot": snapshotName, "err": err})
@@ -693,13 +711,11 @@ func pruneExpiredAndAutoCreateInstanceSnapshotsTask(d *Daemon) (task.Func, task.
 		// Get list of instances on the local member that are due to have snapshots creating.
 		filter := dbCluster.InstanceFilter{Node: &s.ServerName}
 
+		// Keep track of the snapshots scheduled per project so the batch doesn't go above the project limits.
+		scheduled := map[string]int{}
+
 		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
 			return tx.InstanceList(ctx, func(dbInst db.InstanceArgs, p api.Project) error {
-				err = project.AllowSnapshotCreation(&p)
-				if err != nil {
-					return nil
-				}
-
 				inst, err := instance.Load(s, dbInst, p)
 				if err != nil {
 					return fmt.Errorf("Failed loading instance %q (project %q) for snapshot task: %w", dbInst.Name, dbInst.Project, err)
@@ -721,8 +737,15 @@ func pruneExpiredAndAutoCreateInstanceSnapshotsTask(d *Daemon) (task.Func, task.
 					return nil
 				}
 
+				// Check that the project allows for the snapshot on top of those already scheduled.
+				err = project.AllowSnapshotsCreation(tx, &p, scheduled[p.Name]+1)
+				if err != nil {
+					return nil
+				}
+
 				logger.Debug("Scheduling auto instance snapshot", logger.Ctx{"instance": inst.Name(), "project": inst.Project().Name})
 				instances = append(instances, inst)
+				scheduled[p.Name]++
 
 				return nil
 			}, filter)
@@ -834,6 +857,45 @@ func getSourceImageFromInstanceSource(ctx context.Context, s *state.State, tx *d
 	return sourceImage, nil
 }
 
+// instanceBaseImageSource returns the source an instance's current image was downloaded from.
+func instanceBaseImageSource(ctx context.Context, tx *db.ClusterTx, projectName string, fingerprint string) (api.InstanceSource, error) {
+	if fingerprint == "" {
+		return api.InstanceSource{}, api.StatusErrorf(http.StatusBadRequest, "Instance has no base image, an image must be provided")
+	}
+
+	imageID, _, err := tx.GetImage(ctx, fingerprint, dbCluster.ImageFilter{Project: &projectName})
+	if err != nil {
+		if api.StatusErrorCheck(err, http.StatusNotFound) {
+			return api.InstanceSource{}, api.StatusErrorf(http.StatusBadRequest, "Instance's image %q no longer exists, an image must be provided", fingerprint)
+		}
+
+		return api.InstanceSource{}, fmt.Errorf("Failed loading image %q: %w", fingerprint, err)
+	}
+
+	_, source, err := tx.GetImageSource(ctx, imageID)
+	if err != nil {
+		if api.StatusErrorCheck(err, http.StatusNotFound) {
+			return api.InstanceSource{}, api.StatusErrorf(http.StatusBadRequest, "Instance's image %q has no update source, an image must be provided", fingerprint)
+		}
+
+		return api.InstanceSource{}, fmt.Errorf("Failed loading source of image %q: %w", fingerprint, err)
+	}
+
+	registryAuth, err := tx.GetImageSourceRegistryAuth(ctx, imageID)
+	if err != nil {
+		return api.InstanceSource{}, fmt.Errorf("Failed loading registry credentials of image %q: %w", fingerprint, err)
+	}
+
+	return api.InstanceSource{
+		Type:         "image",
+		Server:       source.Server,
+		Protocol:     source.Protocol,
+		Certificate:  source.Certificate,
+		Alias:        source.Alias,
+		RegistryAuth: registryAuth,
+	}, nil
+}
+
 // instanceOperationLock acquires a lock for operating on an instance and returns the unlock function.
 func instanceOperationLock(ctx context.Context, projectName string, instanceName string) (locking.UnlockFunc, error) {
 	l := logger.AddContext(logger.Ctx{"project": projectName, "instance": instanceName})
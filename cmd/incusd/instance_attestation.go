@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/server/attestation"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/response"
+)
+
+// swagger:operation GET /1.0/instances/{name}/attestation instances instance_attestation_get
+//
+//	Get the measured boot attestation of an instance
+//
+//	Retrieves a quote of the TPM PCRs through the instance agent, verifies it against the
+//	endorsement key of the TPM and compares the measurements to the reference policy
+//	(`security.attestation.pcrs`).
+//	Only supported for running virtual machines with a `tpm` device.
+//	Failed attestations aren't read-only: they create an instance warning and emit an
+//	`instance-attestation-failed` lifecycle event (the warning is resolved by the next successful attestation).
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: query
+//	    name: pcrs
+//	    description: Comma separated list of PCRs to quote (defaults to those of the reference policy)
+//	    type: string
+//	    example: 0,7
+//	responses:
+//	  "200":
+//	    description: Attestation result
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/InstanceAttestation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceAttestationGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	projectName := request.ProjectParam(r)
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if internalInstance.IsSnapshot(name) {
+		return response.BadRequest(fmt.Errorf("Invalid instance name"))
+	}
+
+	pcrs := []int{}
+	pcrsStr := request.QueryParam(r, "pcrs")
+	if pcrsStr != "" {
+		for _, entry := range strings.Split(pcrsStr, ",") {
+			pcr, err := attestation.ParsePCR(entry)
+			if err != nil {
+				return response.BadRequest(err)
+			}
+
+			pcrs = append(pcrs, pcr)
+		}
+	}
+
+	// Handle requests targeted to an instance on a different node.
+	resp, err := forwardedResponseIfInstanceIsRemote(s, r, projectName, name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if resp != nil {
+		return resp
+	}
+
+	inst, err := instance.LoadByProjectAndName(s, projectName, name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if inst.Type() != instancetype.VM {
+		return response.BadRequest(fmt.Errorf("Attestation is only supported for virtual machines"))
+	}
+
+	v, ok := inst.(instance.VM)
+	if !ok {
+		return response.InternalError(fmt.Errorf("Failed to cast inst to VM"))
+	}
+
+	result, err := v.Attest(pcrs)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, result)
+}
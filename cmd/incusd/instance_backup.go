@@ -3,7 +3,9 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
@@ -13,6 +15,7 @@ import (
 
 	internalInstance "github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/jmap"
+	"github.com/lxc/incus/v6/internal/server/backup"
 	"github.com/lxc/incus/v6/internal/server/db"
 	"github.com/lxc/incus/v6/internal/server/db/operationtype"
 	"github.com/lxc/incus/v6/internal/server/instance"
@@ -305,6 +308,14 @@ func instanceBackupsPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(fmt.Errorf("Backup names may not contain slashes"))
 	}
 
+	// Validate the encryption recipients.
+	if len(req.EncryptionRecipients) > 0 {
+		_, err = backup.EncryptionType(req.EncryptionRecipients)
+		if err != nil {
+			return response.BadRequest(err)
+		}
+	}
+
 	fullName := name + internalInstance.SnapshotDelimiter + req.Name
 	instanceOnly := req.InstanceOnly
 
@@ -317,6 +328,7 @@ func instanceBackupsPost(d *Daemon, r *http.Request) response.Response {
 			InstanceOnly:         instanceOnly,
 			OptimizedStorage:     req.OptimizedStorage,
 			CompressionAlgorithm: req.CompressionAlgorithm,
+			EncryptionRecipients: req.EncryptionRecipients,
 		}
 
 		err := backupCreate(s, args, inst, op)
@@ -662,3 +674,97 @@ func instanceBackupExportGet(d *Daemon, r *http.Request) response.Response {
 
 	return response.FileResponse(r, []response.FileResponseEntry{ent}, nil)
 }
+
+// swagger:operation POST /1.0/instances/{name}/backups/{backup}/verify instances instance_backup_verify
+//
+//	Verify the backup
+//
+//	Checks the backup against its signed manifest without restoring it.
+//	Encrypted backups are checked against the detached manifest stored along with them.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: backup
+//	    description: Backup verification options
+//	    required: false
+//	    schema:
+//	      $ref: "#/definitions/BackupVerifyPost"
+//	responses:
+//	  "202":
+//	    $ref: "#/responses/Operation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceBackupVerifyPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	projectName := request.ProjectParam(r)
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if internalInstance.IsSnapshot(name) {
+		return response.BadRequest(fmt.Errorf("Invalid instance name"))
+	}
+
+	backupName, err := url.PathUnescape(mux.Vars(r)["backupName"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Handle requests targeted to a container on a different node
+	resp, err := forwardedResponseIfInstanceIsRemote(s, r, projectName, name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if resp != nil {
+		return resp
+	}
+
+	fullName := name + internalInstance.SnapshotDelimiter + backupName
+	backup, err := instance.BackupLoadByName(s, projectName, fullName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	req := api.BackupVerifyPost{}
+
+	// The request body is optional.
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return response.BadRequest(err)
+	}
+
+	fingerprints := backupVerifyFingerprints(d, req)
+
+	verify := func(op *operations.Operation) error {
+		return backupVerify(s, internalUtil.VarPath("backups", "instances", project.Instance(projectName, backup.Name())), fingerprints, op)
+	}
+
+	resources := map[string][]api.URL{}
+	resources["instances"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instances", name)}
+	resources["backups"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instances", name, "backups", backupName)}
+
+	op, err := operations.OperationCreate(s, projectName, operations.OperationClassTask,
+		operationtype.BackupVerify, resources, nil, verify, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return operations.OperationResponse(op)
+}
@@ -0,0 +1,547 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"golang.org/x/sys/unix"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/rsync"
+	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/instance/operationlock"
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/state"
+	storagePools "github.com/lxc/incus/v6/internal/server/storage"
+	internalUtil "github.com/lxc/incus/v6/internal/util"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/idmap"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/osarch"
+	"github.com/lxc/incus/v6/shared/revert"
+	"github.com/lxc/incus/v6/shared/subprocess"
+)
+
+// instanceConvertMinRootSize is the smallest root disk given to a converted virtual machine.
+const instanceConvertMinRootSize = 10 * 1024 * 1024 * 1024
+
+// instanceConvertRsyncExcludes are the paths kept from the virtual-machine image when copying the container's filesystem.
+var instanceConvertRsyncExcludes = []string{
+	"/boot/",
+	"/efi/",
+	"/etc/fstab",
+	"/lib/firmware/",
+	"/lib/modules/",
+	"/usr/lib/firmware/",
+	"/usr/lib/modules/",
+	"*incus-agent*",
+	"/dev/*",
+	"/proc/*",
+	"/run/*",
+	"/sys/*",
+}
+
+// swagger:operation POST /1.0/instances/{name}/convert instances instance_convert_post
+//
+//	Convert an instance
+//
+//	Creates a new virtual machine out of a stopped container.
+//	The container's filesystem is copied on top of a virtual-machine image which provides the kernel
+//	and bootloader. The original container is left untouched.
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: instance
+//	    description: InstanceConvert request
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/InstanceConvertPost"
+//	responses:
+//	  "202":
+//	    $ref: "#/responses/Operation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceConvertPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	projectName := request.ProjectParam(r)
+
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if internalInstance.IsSnapshot(name) {
+		return response.BadRequest(fmt.Errorf("Invalid instance name"))
+	}
+
+	// Handle requests targeted to an instance on a different node.
+	resp, err := forwardedResponseIfInstanceIsRemote(s, r, projectName, name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if resp != nil {
+		return resp
+	}
+
+	// Parse the request.
+	req := api.InstanceConvertPost{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if req.Type == "" {
+		req.Type = api.InstanceTypeVM
+	}
+
+	if req.Type != api.InstanceTypeVM {
+		return response.BadRequest(fmt.Errorf("Only conversion to %q is supported", api.InstanceTypeVM))
+	}
+
+	if req.Name == "" {
+		req.Name = name + "-vm"
+	}
+
+	err = instance.ValidName(req.Name, false)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	// The new instance is created in the same project, check that the caller is allowed to do so.
+	err = s.Authorizer.CheckPermission(r.Context(), r, auth.ObjectProject(projectName), auth.EntitlementCanCreateInstances)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	inst, err := instance.LoadByProjectAndName(s, projectName, name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if inst.Type() != instancetype.Container {
+		return response.BadRequest(fmt.Errorf("Only containers can be converted"))
+	}
+
+	if inst.IsRunning() {
+		return response.BadRequest(fmt.Errorf("Instance must be stopped to be converted"))
+	}
+
+	var targetProject *api.Project
+	var sourceImage *api.Image
+	var sourceImageRef string
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		dbProject, err := dbCluster.GetProject(ctx, tx.Tx(), projectName)
+		if err != nil {
+			return fmt.Errorf("Failed loading project: %w", err)
+		}
+
+		targetProject, err = dbProject.ToAPI(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		// Default to the virtual-machine variant of the image the container was created from.
+		if req.Source.Fingerprint == "" && req.Source.Alias == "" && req.Source.Properties == nil {
+			req.Source, err = instanceBaseImageSource(ctx, tx, projectName, inst.LocalConfig()["volatile.base_image"])
+			if err != nil {
+				return err
+			}
+		}
+
+		sourceImage, err = getSourceImageFromInstanceSource(ctx, s, tx, projectName, req.Source, &sourceImageRef, string(api.InstanceTypeVM))
+		if err != nil && !api.StatusErrorCheck(err, http.StatusNotFound) {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	run := func(op *operations.Operation) error {
+		if req.Source.Server != "" {
+			sourceImage, err = ensureDownloadedImageFitWithinBudget(context.TODO(), s, r, op, *targetProject, sourceImageRef, req.Source, string(api.InstanceTypeVM), true)
+			if err != nil {
+				return err
+			}
+		}
+
+		if sourceImage == nil {
+			return fmt.Errorf("No virtual-machine image available for the conversion")
+		}
+
+		if req.Source.Server == "" {
+			err := ensureImageIsLocallyAvailable(context.TODO(), s, r, sourceImage, projectName)
+			if err != nil {
+				return err
+			}
+		}
+
+		return instanceConvertToVM(s, inst, req.Name, sourceImage, op)
+	}
+
+	resources := map[string][]api.URL{}
+	resources["instances"] = []api.URL{
+		*api.NewURL().Path(version.APIVersion, "instances", name),
+		*api.NewURL().Path(version.APIVersion, "instances", req.Name),
+	}
+
+	op, err := operations.OperationCreate(s, projectName, operations.OperationClassTask, operationtype.InstanceConvert, resources, nil, run, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return operations.OperationResponse(op)
+}
+
+// instanceConvertToVM creates a new virtual machine from the image provided and copies the container's filesystem into it.
+func instanceConvertToVM(s *state.State, inst instance.Instance, name string, img *api.Image, op *operations.Operation) error {
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	if img.Type != string(api.InstanceTypeVM) {
+		return fmt.Errorf("Image %q isn't a virtual-machine image", img.Fingerprint)
+	}
+
+	archName, err := osarch.ArchitectureName(inst.Architecture())
+	if err != nil {
+		return err
+	}
+
+	if img.Architecture != archName {
+		return fmt.Errorf("Image architecture %q doesn't match instance architecture %q", img.Architecture, archName)
+	}
+
+	// Keep the container from being started or modified while its filesystem is being copied.
+	opl, err := operationlock.Create(inst.Project().Name, inst.Name(), op, operationlock.ActionConvert, false, false)
+	if err != nil {
+		return err
+	}
+
+	defer opl.Done(nil)
+
+	if inst.IsRunning() {
+		return fmt.Errorf("Instance must be stopped to be converted")
+	}
+
+	pool, err := storagePools.LoadByInstance(s, inst)
+	if err != nil {
+		return err
+	}
+
+	config, devices, skipped := instanceConvertConfig(inst)
+
+	// Make sure the root disk is large enough to hold the container's data.
+	rootName, rootConfig, err := internalInstance.GetRootDiskDevice(inst.ExpandedDevices().CloneNative())
+	if err != nil {
+		return err
+	}
+
+	if rootConfig["size"] == "" {
+		usage, err := pool.GetInstanceUsage(inst)
+		if err == nil && usage.Used*3/2 > instanceConvertMinRootSize {
+			root := deviceConfig.Device{}
+			for k, v := range rootConfig {
+				root[k] = v
+			}
+
+			root["size"] = fmt.Sprintf("%dB", usage.Used*3/2)
+			devices[rootName] = root
+		}
+	}
+
+	args := db.InstanceArgs{
+		Project:      inst.Project().Name,
+		Architecture: inst.Architecture(),
+		Config:       config,
+		Type:         instancetype.VM,
+		Description:  inst.Description(),
+		Devices:      devices,
+		Name:         name,
+		Profiles:     inst.Profiles(),
+	}
+
+	err = instanceCreateFromImage(context.TODO(), s, img, args, op)
+	if err != nil {
+		return err
+	}
+
+	vm, err := instance.LoadByProjectAndName(s, args.Project, args.Name)
+	if err != nil {
+		return err
+	}
+
+	reverter.Add(func() { _ = vm.Delete(true) })
+
+	vmPool, err := storagePools.LoadByInstance(s, vm)
+	if err != nil {
+		return err
+	}
+
+	// Mount both instances and copy the container's filesystem into the virtual machine's root partition.
+	_, err = pool.MountInstance(inst, op)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = pool.UnmountInstance(inst, op) }()
+
+	mountInfo, err := vmPool.MountInstance(vm, op)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = vmPool.UnmountInstance(vm, op) }()
+
+	var diskIdmap *idmap.Set
+	container, ok := inst.(instance.Container)
+	if ok {
+		diskIdmap, err = container.DiskIdmap()
+		if err != nil {
+			return err
+		}
+	}
+
+	err = instanceConvertRootfs(inst.RootfsPath(), mountInfo.DiskPath, diskIdmap)
+	if err != nil {
+		return fmt.Errorf("Failed copying container filesystem: %w", err)
+	}
+
+	if len(skipped) > 0 {
+		err = op.ExtendMetadata(map[string]any{"skipped": skipped})
+		if err != nil {
+			return err
+		}
+	}
+
+	reverter.Success()
+	return nil
+}
+
+// instanceConvertConfig returns the container's configuration and devices which can be used by a virtual machine,
+// along with the configuration keys and devices which had to be left out.
+func instanceConvertConfig(inst instance.Instance) (map[string]string, deviceConfig.Devices, []string) {
+	config := map[string]string{}
+	devices := deviceConfig.Devices{}
+	skipped := []string{}
+
+	for devName, dev := range inst.LocalDevices() {
+		switch dev["type"] {
+		case "nic":
+			if dev["nictype"] == "ipvlan" {
+				skipped = append(skipped, "devices."+devName)
+				continue
+			}
+
+		case "disk":
+			dev = dev.Clone()
+			delete(dev, "shift")
+
+		case "proxy":
+			if dev["nat"] != "true" {
+				skipped = append(skipped, "devices."+devName)
+				continue
+			}
+
+		case "gpu", "pci", "tpm", "usb", "none":
+		default:
+			skipped = append(skipped, "devices."+devName)
+			continue
+		}
+
+		devices[devName] = dev
+	}
+
+	for key, value := range inst.LocalConfig() {
+		// The container is kept, so the virtual machine gets its own MAC addresses and other volatile state.
+		if strings.HasPrefix(key, "volatile.") {
+			continue
+		}
+
+		// Image properties come from the virtual-machine image.
+		if strings.HasPrefix(key, "image.") {
+			continue
+		}
+
+		_, err := internalInstance.ConfigKeyChecker(key, api.InstanceTypeVM)
+		if err != nil {
+			skipped = append(skipped, key)
+			continue
+		}
+
+		config[key] = value
+	}
+
+	sort.Strings(skipped)
+
+	return config, devices, skipped
+}
+
+// instanceConvertRootfs copies a container root filesystem into the root partition of a virtual machine disk.
+func instanceConvertRootfs(rootfsPath string, diskPath string, diskIdmap *idmap.Set) error {
+	loopDev, err := instanceConvertAttachDisk(diskPath)
+	if err != nil {
+		return err
+	}
+
+	partDev, partNum, fsType, last, err := instanceConvertRootPartition(loopDev)
+	if err != nil {
+		_, _ = subprocess.RunCommand("losetup", "-d", loopDev)
+		return err
+	}
+
+	// Grow the root partition to the size of the disk when possible.
+	if last {
+		_, _ = subprocess.RunCommand("losetup", "-d", loopDev)
+
+		err = subprocess.RunCommandWithFds(context.TODO(), strings.NewReader(",+\n"), nil, "sfdisk", "--no-reread", "--no-tell-kernel", "-N", strconv.Itoa(partNum), diskPath)
+		if err != nil {
+			return fmt.Errorf("Failed growing root partition: %w", err)
+		}
+
+		loopDev, err = instanceConvertAttachDisk(diskPath)
+		if err != nil {
+			return err
+		}
+
+		partDev = fmt.Sprintf("%sp%d", loopDev, partNum)
+	}
+
+	defer func() { _, _ = subprocess.RunCommand("losetup", "-d", loopDev) }()
+
+	mountPath, err := os.MkdirTemp(internalUtil.VarPath(), "incus_convert_")
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = os.Remove(mountPath) }()
+
+	err = unix.Mount(partDev, mountPath, fsType, 0, "")
+	if err != nil {
+		return fmt.Errorf("Failed mounting root partition %q: %w", partDev, err)
+	}
+
+	defer func() { _ = unix.Unmount(mountPath, unix.MNT_DETACH) }()
+
+	if last {
+		switch fsType {
+		case "ext4":
+			_, err = subprocess.RunCommand("resize2fs", partDev)
+		case "xfs":
+			_, err = subprocess.RunCommand("xfs_growfs", mountPath)
+		case "btrfs":
+			_, err = subprocess.RunCommand("btrfs", "filesystem", "resize", "max", mountPath)
+		}
+
+		if err != nil {
+			return fmt.Errorf("Failed growing root filesystem: %w", err)
+		}
+	}
+
+	rsyncArgs := []string{}
+	for _, exclude := range instanceConvertRsyncExcludes {
+		rsyncArgs = append(rsyncArgs, "--exclude", exclude)
+	}
+
+	_, err = rsync.LocalCopy(rootfsPath, mountPath, "", true, rsyncArgs...)
+	if err != nil {
+		return err
+	}
+
+	// Files of containers without idmapped storage are shifted on disk, restore their original ownership.
+	if diskIdmap != nil {
+		err = diskIdmap.UnshiftPath(mountPath, nil)
+		if err != nil {
+			return fmt.Errorf("Failed unshifting root filesystem: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// instanceConvertAttachDisk attaches a disk to a loop device and scans its partitions.
+func instanceConvertAttachDisk(diskPath string) (string, error) {
+	out, err := subprocess.RunCommand("losetup", "--find", "--show", "--partscan", diskPath)
+	if err != nil {
+		return "", fmt.Errorf("Failed attaching disk %q: %w", diskPath, err)
+	}
+
+	return strings.TrimSpace(out), nil
+}
+
+// instanceConvertRootPartition finds the root partition of the disk attached to the loop device.
+// It returns the partition device, its number, filesystem and whether it is the last partition of the disk.
+func instanceConvertRootPartition(loopDev string) (string, int, string, bool, error) {
+	partitions, err := filepath.Glob(loopDev + "p*")
+	if err != nil {
+		return "", -1, "", false, err
+	}
+
+	numbers := []int{}
+	for _, partition := range partitions {
+		number, err := strconv.Atoi(strings.TrimPrefix(partition, loopDev+"p"))
+		if err != nil {
+			continue
+		}
+
+		numbers = append(numbers, number)
+	}
+
+	slices.Sort(numbers)
+
+	for i := len(numbers) - 1; i >= 0; i-- {
+		partDev := fmt.Sprintf("%sp%d", loopDev, numbers[i])
+
+		out, err := subprocess.RunCommand("blkid", "-o", "value", "-s", "TYPE", partDev)
+		if err != nil {
+			continue
+		}
+
+		fsType := strings.TrimSpace(out)
+		if !slices.Contains([]string{"ext4", "xfs", "btrfs"}, fsType) {
+			logger.Debug("Skipping partition during conversion", logger.Ctx{"partition": partDev, "fstype": fsType})
+			continue
+		}
+
+		return partDev, numbers[i], fsType, i == len(numbers)-1, nil
+	}
+
+	return "", -1, "", false, fmt.Errorf("Couldn't find a root partition in the virtual-machine image")
+}
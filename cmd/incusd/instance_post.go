@@ -6,12 +6,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 
 	"github.com/gorilla/mux"
 
 	incus "github.com/lxc/incus/v6/client"
 	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/migration"
 	"github.com/lxc/incus/v6/internal/server/auth"
 	"github.com/lxc/incus/v6/internal/server/cluster"
 	clusterRequest "github.com/lxc/incus/v6/internal/server/cluster/request"
@@ -20,10 +22,12 @@ import (
 	"github.com/lxc/incus/v6/internal/server/db/operationtype"
 	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
 	"github.com/lxc/incus/v6/internal/server/instance"
+	instanceDrivers "github.com/lxc/incus/v6/internal/server/instance/drivers"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
 	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/resources"
 	"github.com/lxc/incus/v6/internal/server/response"
 	"github.com/lxc/incus/v6/internal/server/scriptlet"
 	"github.com/lxc/incus/v6/internal/server/state"
@@ -32,6 +36,8 @@ import (
 	"github.com/lxc/incus/v6/shared/api"
 	apiScriptlet "github.com/lxc/incus/v6/shared/api/scriptlet"
 	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/osarch"
+	"github.com/lxc/incus/v6/shared/util"
 )
 
 // swagger:operation POST /1.0/instances/{name} instances instance_post
@@ -46,6 +52,9 @@ import (
 //	operation with progress data, for the pull case, it will be a websocket
 //	operation with a number of secrets to be passed to the target server.
 //
+//	When check_only is set, nothing is migrated and the problems which would prevent
+//	or affect the migration are returned instead.
+//
 //	---
 //	consumes:
 //	  - application/json
@@ -63,6 +72,26 @@ import (
 //	    schema:
 //	      $ref: "#/definitions/InstancePost"
 //	responses:
+//	  "200":
+//	    description: Migration check
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/InstanceMigrationCheck"
 //	  "202":
 //	    $ref: "#/responses/Operation"
 //	  "400":
@@ -223,33 +252,60 @@ func instancePost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(fmt.Errorf("Instance snapshots cannot be moved on their own"))
 	}
 
+	// Problems found while validating the request are reported as blockers rather than returned in check-only mode.
+	checkBlockers := []api.InstanceMigrationCheckEntry{}
+	checkBlocker := func(checkType string, err error) response.Response {
+		if !req.CheckOnly {
+			return response.BadRequest(err)
+		}
+
+		checkBlockers = append(checkBlockers, api.InstanceMigrationCheckEntry{Type: checkType, Description: err.Error()})
+
+		return nil
+	}
+
 	// Checks for running instances.
 	if inst.IsRunning() {
 		if req.Pool != "" || req.Project != "" || target != "" {
 			// Stateless migrations need the instance stopped.
 			if !req.Live {
-				return response.BadRequest(fmt.Errorf("Instance must be stopped to be moved statelessly"))
+				resp := checkBlocker(migration.CheckTypeInstance, fmt.Errorf("Instance must be stopped to be moved statelessly"))
+				if resp != nil {
+					return resp
+				}
 			}
 
 			// Storage pool changes require a target flag.
 			if req.Pool != "" {
 				if inst.Type() != instancetype.VM {
-					return response.BadRequest(fmt.Errorf("Storage pool change supported only by virtual-machines"))
+					resp := checkBlocker(migration.CheckTypeStorage, fmt.Errorf("Storage pool change supported only by virtual-machines"))
+					if resp != nil {
+						return resp
+					}
 				}
 
 				if target == "" {
-					return response.BadRequest(fmt.Errorf("Storage pool can be specified only together with target flag"))
+					resp := checkBlocker(migration.CheckTypeStorage, fmt.Errorf("Storage pool can be specified only together with target flag"))
+					if resp != nil {
+						return resp
+					}
 				}
 			}
 
 			// Project changes require a stopped instance.
 			if req.Project != "" {
-				return response.BadRequest(fmt.Errorf("Instance must be stopped to be moved across projects"))
+				resp := checkBlocker(migration.CheckTypeInstance, fmt.Errorf("Instance must be stopped to be moved across projects"))
+				if resp != nil {
+					return resp
+				}
 			}
 
 			// Name changes require a stopped instance.
 			if req.Name != "" {
-				return response.BadRequest(fmt.Errorf("Instance must be stopped to change their names"))
+				resp := checkBlocker(migration.CheckTypeInstance, fmt.Errorf("Instance must be stopped to change their names"))
+				if resp != nil {
+					return resp
+				}
 			}
 		}
 	} else {
@@ -259,7 +315,10 @@ func instancePost(d *Daemon, r *http.Request) response.Response {
 
 	// Check for offline sources.
 	if sourceMemberInfo != nil && sourceMemberInfo.IsOffline(s.GlobalConfig.OfflineThreshold()) && (req.Pool != "" || req.Project != "" || req.Name != "") {
-		return response.BadRequest(fmt.Errorf("Instance server is currently offline"))
+		resp := checkBlocker(migration.CheckTypeInstance, fmt.Errorf("Instance server is currently offline"))
+		if resp != nil {
+			return resp
+		}
 	}
 
 	// When in a cluster, default to keeping current location.
@@ -270,6 +329,7 @@ func instancePost(d *Daemon, r *http.Request) response.Response {
 	// If clustered, consider a new location for the instance.
 	var targetMemberInfo *db.NodeInfo
 	var targetCandidates []db.NodeInfo
+	var targetOffline bool
 	if s.ServerClustered && (target != "" || req.Project != "") {
 		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 			var targetGroupName string
@@ -439,7 +499,13 @@ func instancePost(d *Daemon, r *http.Request) response.Response {
 		}
 
 		if targetMemberInfo.IsOffline(s.GlobalConfig.OfflineThreshold()) {
-			return response.BadRequest(fmt.Errorf("Target cluster member is offline"))
+			resp := checkBlocker(migration.CheckTypeInstance, fmt.Errorf("Target cluster member is offline"))
+			if resp != nil {
+				return resp
+			}
+
+			// The offline target can't be queried for the remaining checks.
+			targetOffline = true
 		}
 	}
 
@@ -451,7 +517,10 @@ func instancePost(d *Daemon, r *http.Request) response.Response {
 
 	// Check that we're not requested to move to the same location we're currently on.
 	if target != "" && targetMemberInfo.Name == inst.Location() {
-		return response.BadRequest(fmt.Errorf("Requested target server is the same as current server"))
+		resp := checkBlocker(migration.CheckTypeInstance, fmt.Errorf("Requested target server is the same as current server"))
+		if resp != nil {
+			return resp
+		}
 	}
 
 	// If the instance needs to move, make sure it doesn't have backups.
@@ -468,8 +537,28 @@ func instancePost(d *Daemon, r *http.Request) response.Response {
 		}
 
 		if len(backups) > 0 {
-			return response.BadRequest(fmt.Errorf("Instances with backups cannot be moved"))
+			resp := checkBlocker(migration.CheckTypeInstance, fmt.Errorf("Instances with backups cannot be moved"))
+			if resp != nil {
+				return resp
+			}
+		}
+	}
+
+	// Only report whether the migration can be performed.
+	if req.CheckOnly {
+		// Clear targetMemberInfo if no target change required or if it can't be queried.
+		if targetMemberInfo != nil && (inst.Location() == targetMemberInfo.Name || targetOffline) {
+			targetMemberInfo = nil
 		}
+
+		check, err := instanceMigrationCheck(r, s, inst, req, targetMemberInfo)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		check.Blockers = append(checkBlockers, check.Blockers...)
+
+		return response.SyncResponse(true, check)
 	}
 
 	// Server-side instance migration.
@@ -531,6 +620,153 @@ func instancePost(d *Daemon, r *http.Request) response.Response {
 	return operations.OperationResponse(op)
 }
 
+// instanceMigrationCheck runs the migration pre-flight checks for the instance.
+// When moving within a cluster, the target member is queried so the checks cover its resources, storage pools
+// and networks. Otherwise only the checks which don't depend on the target are run.
+func instanceMigrationCheck(r *http.Request, s *state.State, inst instance.Instance, req api.InstancePost, targetMemberInfo *db.NodeInfo) (*api.InstanceMigrationCheck, error) {
+	apiInst, _, err := inst.Render()
+	if err != nil {
+		return nil, err
+	}
+
+	args := migration.CheckArgs{
+		Instance:             apiInst.(*api.Instance),
+		Live:                 req.Live,
+		Pool:                 req.Pool,
+		NonMigratableDevices: inst.NonMigratableDevices(),
+		SourceDriverVersions: map[string]string{},
+	}
+
+	for _, driver := range instanceDrivers.DriverStatuses() {
+		if driver.Supported {
+			args.SourceDriverVersions[driver.Info.Name] = driver.Info.Version
+		}
+	}
+
+	if targetMemberInfo == nil {
+		return migration.Check(args), nil
+	}
+
+	args.SourceResources, err = resources.GetResources()
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting local resources: %w", err)
+	}
+
+	// Get the CPU flags the instance relies on.
+	var groupWarning string
+	if inst.Type() == instancetype.VM && util.IsTrue(inst.ExpandedConfig()["migration.stateful"]) {
+		args.CPUFlags, groupWarning, err = instanceMigrationCPUFlags(r.Context(), s, inst, targetMemberInfo)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Query the target member.
+	client, err := cluster.Connect(targetMemberInfo.Address, s.Endpoints.NetworkCert(), s.ServerCert(), r, true)
+	if err != nil {
+		return nil, fmt.Errorf("Failed connecting to target member %q: %w", targetMemberInfo.Name, err)
+	}
+
+	client = client.UseProject(inst.Project().Name)
+	if req.Project != "" {
+		client = client.UseProject(req.Project)
+	}
+
+	args.Target, _, err = client.GetServer()
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting target member %q: %w", targetMemberInfo.Name, err)
+	}
+
+	args.TargetResources, err = client.GetServerResources()
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting resources of target member %q: %w", targetMemberInfo.Name, err)
+	}
+
+	args.TargetPools, err = client.GetStoragePools()
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting storage pools of target member %q: %w", targetMemberInfo.Name, err)
+	}
+
+	args.TargetNetworks, err = client.GetNetworks()
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting networks of target member %q: %w", targetMemberInfo.Name, err)
+	}
+
+	check := migration.Check(args)
+
+	if groupWarning != "" {
+		entry := api.InstanceMigrationCheckEntry{Type: migration.CheckTypeCPU, Description: groupWarning}
+		if req.Live {
+			check.Blockers = append(check.Blockers, entry)
+		} else {
+			check.Warnings = append(check.Warnings, entry)
+		}
+	}
+
+	return check, nil
+}
+
+// instanceMigrationCPUFlags returns the CPU flags a clustered virtual machine relies on, based on its cluster group
+// configuration. A problem description is also returned if the target member isn't part of that cluster group.
+func instanceMigrationCPUFlags(ctx context.Context, s *state.State, inst instance.Instance, targetMemberInfo *db.NodeInfo) ([]string, string, error) {
+	archName, err := osarch.ArchitectureName(inst.Architecture())
+	if err != nil {
+		return nil, "", err
+	}
+
+	clusterGroupName := inst.LocalConfig()["volatile.cluster.group"]
+	if clusterGroupName == "" {
+		clusterGroupName = "default"
+	}
+
+	var groupConfig map[string]string
+	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		group, err := dbCluster.GetClusterGroup(ctx, tx.Tx(), clusterGroupName)
+		if err != nil {
+			return err
+		}
+
+		groupConfig, err = dbCluster.GetClusterGroupConfig(ctx, tx.Tx(), group.ID)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil && !api.StatusErrorCheck(err, http.StatusNotFound) {
+		return nil, "", fmt.Errorf("Failed loading cluster group %q: %w", clusterGroupName, err)
+	}
+
+	var groupWarning string
+	if groupConfig != nil && !slices.Contains(targetMemberInfo.Groups, clusterGroupName) {
+		groupWarning = fmt.Sprintf("Target member %q isn't part of cluster group %q which defines the instance CPU baseline", targetMemberInfo.Name, clusterGroupName)
+	}
+
+	if groupConfig[fmt.Sprintf("instances.vm.cpu.%s.baseline", archName)] != "" {
+		cpuFlags := []string{}
+		for _, flag := range util.SplitNTrimSpace(groupConfig[fmt.Sprintf("instances.vm.cpu.%s.flags", archName)], ",", -1, true) {
+			if strings.HasPrefix(flag, "-") {
+				continue
+			}
+
+			cpuFlags = append(cpuFlags, strings.TrimPrefix(flag, "+"))
+		}
+
+		return cpuFlags, groupWarning, nil
+	}
+
+	if inst.Architecture() == osarch.ARCH_64BIT_INTEL_X86 {
+		cpuFlags, err := instanceDrivers.GetClusterCPUFlags(ctx, s, nil, archName)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return cpuFlags, groupWarning, nil
+	}
+
+	return nil, groupWarning, nil
+}
+
 // Perform the server-side migration.
 func migrateInstance(ctx context.Context, s *state.State, inst instance.Instance, req api.InstancePost, sourceMemberInfo *db.NodeInfo, targetMemberInfo *db.NodeInfo, targetGroupName string, op *operations.Operation) error {
 	// Load the instance storage pool.
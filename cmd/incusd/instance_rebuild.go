@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	"golang.org/x/sys/unix"
 
 	internalInstance "github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/server/db"
@@ -17,15 +20,26 @@ import (
 	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/state"
 	"github.com/lxc/incus/v6/internal/version"
 	"github.com/lxc/incus/v6/shared/api"
 )
 
+// instanceRebuildHealthCheckTimeout is the default time given to an upgraded instance to become healthy.
+const instanceRebuildHealthCheckTimeout = 5 * time.Minute
+
+// instanceRebuildHealthCheckInterval is the time between two attempts at the health check.
+const instanceRebuildHealthCheckInterval = 5 * time.Second
+
+// instanceRebuildShutdownTimeout is the time given to an upgraded instance to shut down cleanly.
+const instanceRebuildShutdownTimeout = 2 * time.Minute
+
 // swagger:operation POST /1.0/instances/{name}/rebuild instances instance_rebuild_post
 //
 //	Rebuild an instance
 //
 //	Rebuild an instance using an alternate image or as empty.
+//	When upgrading, a snapshot is taken first and restored should the post-upgrade health check fail.
 //	---
 //	consumes:
 //	  - application/octet-stream
@@ -87,10 +101,18 @@ func instanceRebuildPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
+	if req.Upgrade && req.Source.Type == "none" {
+		return response.BadRequest(fmt.Errorf("Upgrades require an image source"))
+	}
+
+	// When upgrading without an explicit image, use the update source of the current image.
+	upgradeFromBaseImage := req.Upgrade && req.Source.Fingerprint == "" && req.Source.Alias == "" && req.Source.Properties == nil
+
 	var targetProject *api.Project
 	var sourceImage *api.Image
 	var inst instance.Instance
 	var sourceImageRef string
+	var baseImage string
 	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 		dbProject, err := dbCluster.GetProject(ctx, tx.Tx(), targetProjectName)
 		if err != nil {
@@ -107,6 +129,22 @@ func instanceRebuildPost(d *Daemon, r *http.Request) response.Response {
 			return fmt.Errorf("Failed loading instance: %w", err)
 		}
 
+		if req.Upgrade {
+			config, err := dbCluster.GetInstanceConfig(ctx, tx.Tx(), dbInst.ID)
+			if err != nil {
+				return fmt.Errorf("Failed loading instance config: %w", err)
+			}
+
+			baseImage = config["volatile.base_image"]
+		}
+
+		if upgradeFromBaseImage {
+			req.Source, err = instanceBaseImageSource(ctx, tx, targetProject.Name, baseImage)
+			if err != nil {
+				return err
+			}
+		}
+
 		if req.Source.Type != "none" {
 			sourceImage, err = getSourceImageFromInstanceSource(ctx, s, tx, targetProject.Name, req.Source, &sourceImageRef, dbInst.Type.String())
 			if err != nil && !api.StatusErrorCheck(err, http.StatusNotFound) {
@@ -135,7 +173,8 @@ func instanceRebuildPost(d *Daemon, r *http.Request) response.Response {
 		}
 
 		if req.Source.Server != "" {
-			sourceImage, err = ensureDownloadedImageFitWithinBudget(context.TODO(), s, r, op, *targetProject, sourceImageRef, req.Source, inst.Type().String())
+			// Skip cached images when upgrading so that the most recent version gets picked up.
+			sourceImage, err = ensureDownloadedImageFitWithinBudget(context.TODO(), s, r, op, *targetProject, sourceImageRef, req.Source, inst.Type().String(), !req.Upgrade)
 			if err != nil {
 				return err
 			}
@@ -145,7 +184,15 @@ func instanceRebuildPost(d *Daemon, r *http.Request) response.Response {
 			return fmt.Errorf("Image not provided for instance rebuild")
 		}
 
-		return instanceRebuildFromImage(context.TODO(), s, r, inst, sourceImage, op)
+		if req.Upgrade {
+			if sourceImage.Fingerprint == baseImage {
+				return fmt.Errorf("Instance is already using the most recent image")
+			}
+
+			return instanceRebuildUpgrade(context.TODO(), s, r, inst, sourceImage, req.HealthCheck, op)
+		}
+
+		return instanceRebuildFromImage(context.TODO(), s, r, inst, sourceImage, false, op)
 	}
 
 	resources := map[string][]api.URL{}
@@ -158,3 +205,193 @@ func instanceRebuildPost(d *Daemon, r *http.Request) response.Response {
 
 	return operations.OperationResponse(op)
 }
+
+// instanceRebuildUpgrade rebuilds a stopped instance onto a newer image.
+// A snapshot is taken beforehand and the instance is restored from it if the rebuild or the
+// post-upgrade health check fails. On success, the instance is stopped again and the snapshot kept.
+func instanceRebuildUpgrade(ctx context.Context, s *state.State, r *http.Request, inst instance.Instance, img *api.Image, check *api.InstanceRebuildHealthCheck, op *operations.Operation) error {
+	projectName := inst.Project().Name
+	name := inst.Name()
+
+	snapName, err := instance.NextSnapshotName(s, inst, "upgrade%d")
+	if err != nil {
+		return err
+	}
+
+	expiry, err := internalInstance.GetExpiry(time.Now(), inst.ExpandedConfig()["snapshots.expiry"])
+	if err != nil {
+		return err
+	}
+
+	err = inst.Snapshot(snapName, expiry, false)
+	if err != nil {
+		return fmt.Errorf("Failed creating pre-upgrade snapshot: %w", err)
+	}
+
+	err = op.ExtendMetadata(map[string]any{"snapshot": snapName})
+	if err != nil {
+		return err
+	}
+
+	steps := instanceRebuildUpgradeSteps{
+		snapshot: snapName,
+		upgrade: func() error {
+			err := instanceRebuildFromImage(ctx, s, r, inst, img, true, op)
+			if err != nil {
+				return err
+			}
+
+			// Reload the instance to pick up the new image configuration.
+			inst, err = instance.LoadByProjectAndName(s, projectName, name)
+			if err != nil {
+				return err
+			}
+
+			// Have cloud-init run again against the new root filesystem.
+			err = inst.VolatileSet(map[string]string{"volatile.cloud-init.instance-id": uuid.New().String()})
+			if err != nil {
+				return err
+			}
+
+			err = inst.Start(false)
+			if err != nil {
+				return fmt.Errorf("Failed starting upgraded instance: %w", err)
+			}
+
+			return instanceRebuildHealthCheck(inst, check)
+		},
+		isRunning: func() bool { return inst.IsRunning() },
+		stop:      func() error { return inst.Stop(false) },
+		shutdown:  func() error { return inst.Shutdown(instanceRebuildShutdownTimeout) },
+		restore: func() error {
+			return instanceSnapRestore(s, projectName, name, snapName, false, op)
+		},
+	}
+
+	return steps.run()
+}
+
+// instanceRebuildUpgradeSteps holds the actions an upgrade is made of, once its snapshot is taken.
+type instanceRebuildUpgradeSteps struct {
+	snapshot  string
+	upgrade   func() error
+	isRunning func() bool
+	stop      func() error
+	shutdown  func() error
+	restore   func() error
+}
+
+// run performs the upgrade, restoring the snapshot if it fails.
+// On success, the instance is returned to the stopped state it was in before the rebuild.
+func (u instanceRebuildUpgradeSteps) run() error {
+	err := u.upgrade()
+	if err != nil {
+		if u.isRunning() {
+			stopErr := u.stop()
+			if stopErr != nil {
+				return fmt.Errorf("Failed stopping instance for rollback after failed upgrade (%v): %w", err, stopErr)
+			}
+		}
+
+		restoreErr := u.restore()
+		if restoreErr != nil {
+			return fmt.Errorf("Failed restoring snapshot %q after failed upgrade (%v): %w", u.snapshot, err, restoreErr)
+		}
+
+		return fmt.Errorf("Upgrade failed, instance restored from snapshot %q: %w", u.snapshot, err)
+	}
+
+	err = u.shutdown()
+	if err != nil {
+		err = u.stop()
+		if err != nil {
+			return fmt.Errorf("Failed stopping upgraded instance: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// instanceRebuildHealthCheck waits for the upgraded instance to pass its health check.
+// Without a specific command, the instance is considered healthy once a process can be run in it,
+// which for virtual machines means that the agent is responsive.
+func instanceRebuildHealthCheck(inst instance.Instance, check *api.InstanceRebuildHealthCheck) error {
+	timeout := instanceRebuildHealthCheckTimeout
+	command := []string{"true"}
+	if check != nil {
+		if check.Timeout > 0 {
+			timeout = time.Duration(check.Timeout) * time.Second
+		}
+
+		if len(check.Command) > 0 {
+			command = check.Command
+		}
+	}
+
+	probe := func(deadline time.Time) error {
+		if !inst.IsRunning() {
+			return fmt.Errorf("Instance isn't running")
+		}
+
+		cmd, err := inst.Exec(api.InstanceExecPost{
+			Command:     command,
+			Environment: map[string]string{"PATH": "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"},
+			Cwd:         "/",
+		}, nil, nil, nil)
+		if err != nil {
+			return err
+		}
+
+		return instanceRebuildWaitCommand(cmd, time.Until(deadline))
+	}
+
+	return instanceRebuildPoll(probe, timeout, instanceRebuildHealthCheckInterval)
+}
+
+// instanceRebuildWaitCommand waits for a health check command to succeed, killing it once the timeout is reached.
+func instanceRebuildWaitCommand(cmd instance.Cmd, timeout time.Duration) error {
+	type result struct {
+		status int
+		err    error
+	}
+
+	chResult := make(chan result, 1)
+	go func() {
+		status, err := cmd.Wait()
+		chResult <- result{status: status, err: err}
+	}()
+
+	select {
+	case res := <-chResult:
+		if res.err != nil {
+			return res.err
+		}
+
+		if res.status != 0 {
+			return fmt.Errorf("Health check command exited with status %d", res.status)
+		}
+
+		return nil
+	case <-time.After(timeout):
+		_ = cmd.Signal(unix.SIGKILL)
+		return fmt.Errorf("Health check command didn't complete in time")
+	}
+}
+
+// instanceRebuildPoll runs the probe until it succeeds or the timeout is reached.
+// The probe is given the deadline it must complete by.
+func instanceRebuildPoll(probe func(deadline time.Time) error, timeout time.Duration, interval time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		err := probe(deadline)
+		if err == nil {
+			return nil
+		}
+
+		if !time.Now().Add(interval).Before(deadline) {
+			return fmt.Errorf("Health check didn't pass within %s: %w", timeout, err)
+		}
+
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+)
+
+// upgradeStepsRecorder records the upgrade actions being run.
+type upgradeStepsRecorder struct {
+	actions []string
+	running bool
+}
+
+func (u *upgradeStepsRecorder) steps(upgradeErr error, shutdownErr error) instanceRebuildUpgradeSteps {
+	record := func(action string, err error) func() error {
+		return func() error {
+			u.actions = append(u.actions, action)
+			return err
+		}
+	}
+
+	return instanceRebuildUpgradeSteps{
+		snapshot: "upgrade0",
+		upgrade: func() error {
+			u.actions = append(u.actions, "upgrade")
+			u.running = true
+			return upgradeErr
+		},
+		isRunning: func() bool { return u.running },
+		stop:      record("stop", nil),
+		shutdown:  record("shutdown", shutdownErr),
+		restore:   record("restore", nil),
+	}
+}
+
+func TestInstanceRebuildUpgradeSteps(t *testing.T) {
+	// A successful upgrade leaves the instance stopped and doesn't restore the snapshot.
+	u := &upgradeStepsRecorder{}
+	assert.NoError(t, u.steps(nil, nil).run())
+	assert.Equal(t, []string{"upgrade", "shutdown"}, u.actions)
+
+	// The instance is forcefully stopped when it doesn't shut down.
+	u = &upgradeStepsRecorder{}
+	assert.NoError(t, u.steps(nil, errors.New("timeout")).run())
+	assert.Equal(t, []string{"upgrade", "shutdown", "stop"}, u.actions)
+
+	// A failed health check stops the instance and restores the snapshot.
+	u = &upgradeStepsRecorder{}
+	err := u.steps(errors.New("unhealthy"), nil).run()
+	assert.ErrorContains(t, err, `restored from snapshot "upgrade0": unhealthy`)
+	assert.Equal(t, []string{"upgrade", "stop", "restore"}, u.actions)
+
+	// A failure before the instance got started only restores the snapshot.
+	u = &upgradeStepsRecorder{}
+	steps := u.steps(errors.New("rebuild failed"), nil)
+	steps.isRunning = func() bool { return false }
+	assert.Error(t, steps.run())
+	assert.Equal(t, []string{"upgrade", "restore"}, u.actions)
+
+	// Restore failures are reported.
+	u = &upgradeStepsRecorder{}
+	steps = u.steps(errors.New("unhealthy"), nil)
+	steps.restore = func() error { return errors.New("restore failed") }
+	err = steps.run()
+	assert.ErrorContains(t, err, `Failed restoring snapshot "upgrade0"`)
+	assert.ErrorContains(t, err, "restore failed")
+}
+
+// healthCheckCmd is a health check command exiting with the given status after the given delay.
+type healthCheckCmd struct {
+	status  int
+	delay   time.Duration
+	killed  chan struct{}
+	signals []unix.Signal
+}
+
+func (c *healthCheckCmd) Wait() (int, error) {
+	select {
+	case <-time.After(c.delay):
+		return c.status, nil
+	case <-c.killed:
+		return -1, nil
+	}
+}
+
+func (c *healthCheckCmd) PID() int { return 1 }
+
+func (c *healthCheckCmd) Signal(s unix.Signal) error {
+	c.signals = append(c.signals, s)
+	close(c.killed)
+	return nil
+}
+
+func (c *healthCheckCmd) WindowResize(fd, winchWidth, winchHeight int) error { return nil }
+
+func TestInstanceRebuildWaitCommand(t *testing.T) {
+	cmd := &healthCheckCmd{killed: make(chan struct{})}
+	assert.NoError(t, instanceRebuildWaitCommand(cmd, time.Second))
+
+	cmd = &healthCheckCmd{status: 1, killed: make(chan struct{})}
+	assert.ErrorContains(t, instanceRebuildWaitCommand(cmd, time.Second), "exited with status 1")
+
+	// Commands not completing in time get killed.
+	cmd = &healthCheckCmd{delay: time.Minute, killed: make(chan struct{})}
+	assert.ErrorContains(t, instanceRebuildWaitCommand(cmd, 10*time.Millisecond), "didn't complete in time")
+	assert.Equal(t, []unix.Signal{unix.SIGKILL}, cmd.signals)
+}
+
+func TestInstanceRebuildPoll(t *testing.T) {
+	// The probe is retried until it passes.
+	attempts := 0
+	err := instanceRebuildPoll(func(deadline time.Time) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("not ready")
+		}
+
+		return nil
+	}, time.Second, time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+
+	// The last error is reported once the timeout is reached.
+	err = instanceRebuildPoll(func(deadline time.Time) error {
+		return errors.New("not ready")
+	}, 20*time.Millisecond, 5*time.Millisecond)
+	assert.ErrorContains(t, err, "not ready")
+}
@@ -256,7 +256,7 @@ func instanceSnapshotsPost(d *Daemon, r *http.Request) response.Response {
 			return err
 		}
 
-		err = project.AllowSnapshotCreation(p)
+		err = project.AllowSnapshotCreation(tx, p)
 		if err != nil {
 			return err
 		}
@@ -288,6 +288,11 @@ func instanceSnapshotsPost(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	err = instance.CheckSnapshotsMax(inst)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
 	req := api.InstanceSnapshotsPost{}
 	err = json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
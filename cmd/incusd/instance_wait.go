@@ -0,0 +1,289 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/linux"
+	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// instanceWaitConditions lists the conditions which can be waited for.
+var instanceWaitConditions = []string{"running", "agent", "ip", "cloud-init", "healthy", "stopped"}
+
+// instanceWaitPollInterval is how often the conditions which don't come with an event get checked.
+const instanceWaitPollInterval = 2 * time.Second
+
+// swagger:operation POST /1.0/instances/{name}/wait instances instance_wait_post
+//
+//	Wait for an instance condition
+//
+//	Waits for the instance to reach a condition, such as being running, having an IP address or having
+//	completed its cloud-init run. The returned operation completes once the condition is met and fails
+//	if the timeout is reached first.
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: wait
+//	    description: Wait request
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/InstanceWaitPost"
+//	responses:
+//	  "202":
+//	    $ref: "#/responses/Operation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceWaitPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	projectName := request.ProjectParam(r)
+
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if internalInstance.IsSnapshot(name) {
+		return response.BadRequest(fmt.Errorf("Invalid instance name"))
+	}
+
+	// Handle requests targeted to an instance on a different node.
+	resp, err := forwardedResponseIfInstanceIsRemote(s, r, projectName, name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if resp != nil {
+		return resp
+	}
+
+	// Parse the request.
+	req := api.InstanceWaitPost{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if !slices.Contains(instanceWaitConditions, req.For) {
+		return response.BadRequest(fmt.Errorf("Invalid condition %q (must be one of %s)", req.For, strings.Join(instanceWaitConditions, ", ")))
+	}
+
+	if req.Timeout < 0 {
+		return response.BadRequest(fmt.Errorf("Invalid timeout %d", req.Timeout))
+	}
+
+	inst, err := instance.LoadByProjectAndName(s, projectName, name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+
+	// If timeout is 0, wait indefinitely (or until the operation is cancelled).
+	if req.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), time.Duration(req.Timeout)*time.Second)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+
+	run := func(op *operations.Operation) error {
+		defer cancel()
+
+		return instanceWait(ctx, d, inst.Project().Name, inst.Name(), req.For)
+	}
+
+	onCancel := func(op *operations.Operation) error {
+		cancel()
+
+		return nil
+	}
+
+	resources := map[string][]api.URL{}
+	resources["instances"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instances", name)}
+
+	op, err := operations.OperationCreate(s, projectName, operations.OperationClassTask, operationtype.InstanceWait, resources, nil, run, onCancel, nil, r)
+	if err != nil {
+		cancel()
+		return response.InternalError(err)
+	}
+
+	return operations.OperationResponse(op)
+}
+
+// instanceWait blocks until the instance meets the condition or the context is done.
+// The condition is checked again on every lifecycle event of the instance as well as periodically
+// for those conditions which aren't tied to an event.
+func instanceWait(ctx context.Context, d *Daemon, projectName string, name string, condition string) error {
+	events := make(chan struct{}, 1)
+
+	instURL := api.NewURL().Path(version.APIVersion, "instances", name).Project(projectName).String()
+
+	handlerName := fmt.Sprintf("instance-wait-%s", uuid.New().String())
+	d.internalListener.AddHandler(handlerName, func(event api.Event) {
+		if event.Type != api.EventTypeLifecycle || event.Project != projectName {
+			return
+		}
+
+		lifecycleEvent := api.EventLifecycle{}
+		err := json.Unmarshal(event.Metadata, &lifecycleEvent)
+		if err != nil || lifecycleEvent.Source != instURL {
+			return
+		}
+
+		select {
+		case events <- struct{}{}:
+		default:
+		}
+	})
+
+	defer d.internalListener.RemoveHandler(handlerName)
+
+	ticker := time.NewTicker(instanceWaitPollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Reload the instance every time as its configuration changes as it starts.
+		inst, err := instance.LoadByProjectAndName(d.State(), projectName, name)
+		if err != nil {
+			return err
+		}
+
+		done, err := instanceWaitCheck(inst, condition)
+		if err != nil {
+			return err
+		}
+
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return fmt.Errorf("Timed out waiting for instance %q to reach condition %q", name, condition)
+			}
+
+			return fmt.Errorf("Stopped waiting for instance %q to reach condition %q", name, condition)
+		case <-events:
+		case <-ticker.C:
+		}
+	}
+}
+
+// instanceWaitCheck returns whether the instance currently meets the condition.
+// An error is returned for conditions which can't be met anymore.
+func instanceWaitCheck(inst instance.Instance, condition string) (bool, error) {
+	if condition == "stopped" {
+		return !inst.IsRunning(), nil
+	}
+
+	if !inst.IsRunning() {
+		return false, nil
+	}
+
+	if condition == "running" {
+		return true, nil
+	}
+
+	// Containers don't have an agent, so they have one as soon as they're running.
+	agentRunning := true
+	if inst.Type() == instancetype.VM {
+		vm, ok := inst.(instance.VM)
+		agentRunning = ok && vm.AgentRunning()
+	}
+
+	if !agentRunning {
+		return false, nil
+	}
+
+	if condition == "agent" {
+		return true, nil
+	}
+
+	hostInterfaces, _ := net.Interfaces()
+
+	state, err := inst.RenderState(hostInterfaces)
+	if err != nil {
+		return false, err
+	}
+
+	switch condition {
+	case "ip":
+		return instanceWaitHasAddress(state), nil
+	case "cloud-init":
+		switch state.CloudInit {
+		case linux.CloudInitStatusDone:
+			return true, nil
+		case linux.CloudInitStatusError:
+			return false, fmt.Errorf("cloud-init reported errors in instance %q", inst.Name())
+		case linux.CloudInitStatusDisabled:
+			return false, fmt.Errorf("cloud-init is disabled in instance %q", inst.Name())
+		}
+
+		return false, nil
+	case "healthy":
+		// Instances without cloud-init (or with it disabled) only need to be reachable.
+		switch state.CloudInit {
+		case linux.CloudInitStatusError:
+			return false, fmt.Errorf("cloud-init reported errors in instance %q", inst.Name())
+		case linux.CloudInitStatusPending, linux.CloudInitStatusRunning:
+			return false, nil
+		}
+
+		return instanceWaitHasAddress(state), nil
+	}
+
+	return false, fmt.Errorf("Unknown condition %q", condition)
+}
+
+// instanceWaitHasAddress returns whether the instance has a global IP address on one of its interfaces.
+func instanceWaitHasAddress(state *api.InstanceState) bool {
+	for ifaceName, iface := range state.Network {
+		if ifaceName == "lo" {
+			continue
+		}
+
+		for _, addr := range iface.Addresses {
+			if addr.Scope == "global" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
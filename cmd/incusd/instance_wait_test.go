@@ -0,0 +1,92 @@
+package main
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/v6/internal/linux"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// waitTestInstance is an instance reporting the given state.
+type waitTestInstance struct {
+	instance.VM
+
+	instType     instancetype.Type
+	running      bool
+	agentRunning bool
+	state        api.InstanceState
+}
+
+func (i *waitTestInstance) Name() string            { return "c1" }
+func (i *waitTestInstance) Type() instancetype.Type { return i.instType }
+func (i *waitTestInstance) IsRunning() bool         { return i.running }
+func (i *waitTestInstance) AgentRunning() bool      { return i.agentRunning }
+
+func (i *waitTestInstance) RenderState(hostInterfaces []net.Interface) (*api.InstanceState, error) {
+	return &i.state, nil
+}
+
+func TestInstanceWaitCheck(t *testing.T) {
+	addresses := func(scope string) map[string]api.InstanceStateNetwork {
+		return map[string]api.InstanceStateNetwork{
+			"lo":   {Addresses: []api.InstanceStateNetworkAddress{{Family: "inet", Address: "127.0.0.1", Scope: "local"}}},
+			"eth0": {Addresses: []api.InstanceStateNetworkAddress{{Family: "inet", Address: "10.0.0.10", Scope: scope}}},
+		}
+	}
+
+	stopped := &waitTestInstance{instType: instancetype.Container}
+	booting := &waitTestInstance{instType: instancetype.VM, running: true}
+	container := &waitTestInstance{instType: instancetype.Container, running: true, state: api.InstanceState{Network: addresses("link")}}
+	configured := &waitTestInstance{instType: instancetype.VM, running: true, agentRunning: true, state: api.InstanceState{Network: addresses("global"), CloudInit: linux.CloudInitStatusDone}}
+	initializing := &waitTestInstance{instType: instancetype.VM, running: true, agentRunning: true, state: api.InstanceState{Network: addresses("global"), CloudInit: linux.CloudInitStatusRunning}}
+	failed := &waitTestInstance{instType: instancetype.Container, running: true, state: api.InstanceState{Network: addresses("global"), CloudInit: linux.CloudInitStatusError}}
+	withoutCloudInit := &waitTestInstance{instType: instancetype.Container, running: true, state: api.InstanceState{Network: addresses("global")}}
+	disabled := &waitTestInstance{instType: instancetype.Container, running: true, state: api.InstanceState{CloudInit: linux.CloudInitStatusDisabled}}
+
+	tests := []struct {
+		name      string
+		inst      instance.Instance
+		condition string
+		done      bool
+		err       string
+	}{
+		{name: "Stopped instance is stopped", inst: stopped, condition: "stopped", done: true},
+		{name: "Stopped instance isn't running", inst: stopped, condition: "running"},
+		{name: "Stopped instance isn't healthy", inst: stopped, condition: "healthy"},
+		{name: "Booting VM is running", inst: booting, condition: "running", done: true},
+		{name: "Booting VM isn't stopped", inst: booting, condition: "stopped"},
+		{name: "Booting VM has no agent", inst: booting, condition: "agent"},
+		{name: "Booting VM has no address", inst: booting, condition: "ip"},
+		{name: "Container has an agent", inst: container, condition: "agent", done: true},
+		{name: "Link-local addresses don't count", inst: container, condition: "ip"},
+		{name: "Configured VM has an address", inst: configured, condition: "ip", done: true},
+		{name: "Configured VM completed cloud-init", inst: configured, condition: "cloud-init", done: true},
+		{name: "Configured VM is healthy", inst: configured, condition: "healthy", done: true},
+		{name: "Initializing VM didn't complete cloud-init", inst: initializing, condition: "cloud-init"},
+		{name: "Initializing VM isn't healthy", inst: initializing, condition: "healthy"},
+		{name: "Failed cloud-init", inst: failed, condition: "cloud-init", err: `cloud-init reported errors in instance "c1"`},
+		{name: "Failed cloud-init isn't healthy", inst: failed, condition: "healthy", err: `cloud-init reported errors in instance "c1"`},
+		{name: "Instance without cloud-init can't complete it", inst: disabled, condition: "cloud-init", err: `cloud-init is disabled in instance "c1"`},
+		{name: "Instance without cloud-init is healthy", inst: withoutCloudInit, condition: "healthy", done: true},
+		{name: "Instance without address isn't healthy", inst: disabled, condition: "healthy"},
+		{name: "Unknown condition", inst: configured, condition: "ready", err: `Unknown condition "ready"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			done, err := instanceWaitCheck(test.inst, test.condition)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.done, done)
+		})
+	}
+}
@@ -56,6 +56,20 @@ var instanceRebuildCmd = APIEndpoint{
 	Post: APIEndpointAction{Handler: instanceRebuildPost, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanEdit, "name")},
 }
 
+var instanceConvertCmd = APIEndpoint{
+	Name: "instanceConvert",
+	Path: "instances/{name}/convert",
+
+	Post: APIEndpointAction{Handler: instanceConvertPost, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanEdit, "name")},
+}
+
+var instanceWaitCmd = APIEndpoint{
+	Name: "instanceWait",
+	Path: "instances/{name}/wait",
+
+	Post: APIEndpointAction{Handler: instanceWaitPost, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanView, "name")},
+}
+
 var instanceStateCmd = APIEndpoint{
 	Name: "instanceState",
 	Path: "instances/{name}/state",
@@ -158,6 +172,13 @@ var instanceBackupExportCmd = APIEndpoint{
 	Get: APIEndpointAction{Handler: instanceBackupExportGet, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanManageBackups, "name")},
 }
 
+var instanceBackupVerifyCmd = APIEndpoint{
+	Name: "instanceBackupVerify",
+	Path: "instances/{name}/backups/{backupName}/verify",
+
+	Post: APIEndpointAction{Handler: instanceBackupVerifyPost, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanManageBackups, "name")},
+}
+
 var instanceAccessCmd = APIEndpoint{
 	Name: "access",
 	Path: "instances/{name}/access",
@@ -172,6 +193,13 @@ var instanceDebugMemoryCmd = APIEndpoint{
 	Get: APIEndpointAction{Handler: instanceDebugMemoryGet, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanEdit, "name")},
 }
 
+var instanceAttestationCmd = APIEndpoint{
+	Name: "instanceAttestation",
+	Path: "instances/{name}/attestation",
+
+	Get: APIEndpointAction{Handler: instanceAttestationGet, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanView, "name")},
+}
+
 type instanceAutostartList []instance.Instance
 
 func (slice instanceAutostartList) Len() int {
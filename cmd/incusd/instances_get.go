@@ -211,6 +211,18 @@ func instancesGet(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(fmt.Errorf("Invalid filter: %w", err))
 	}
 
+	// Skip loading the instances if the client already has their current state.
+	// Instances are affected by changes to their profiles (expanded configuration).
+	// The instance state (addresses, usage, ...) changes without lifecycle events, so responses including it
+	// (or filtered on it) don't get an ETag.
+	var etag any
+	if recursion < 2 && (clauses == nil || len(clauses.Clauses) == 0) {
+		etag = changesETag(s, r, "/1.0/instances", "/1.0/profiles")
+		if response.ETagMatch(r, etag) {
+			return response.SyncResponseConditional(true, nil, etag)
+		}
+	}
+
 	mustLoadObjects := recursion > 0 || (recursion == 0 && clauses != nil && len(clauses.Clauses) > 0)
 
 	// Detect project mode.
@@ -464,7 +476,7 @@ func instancesGet(d *Daemon, r *http.Request) response.Response {
 			resultList = append(resultList, url.String())
 		}
 
-		return response.SyncResponse(true, resultList)
+		return response.SyncResponseConditional(true, resultList, etag)
 	}
 
 	if recursion == 1 {
@@ -473,7 +485,7 @@ func instancesGet(d *Daemon, r *http.Request) response.Response {
 			resultList = append(resultList, &resultFullList[i].Instance)
 		}
 
-		return response.SyncResponse(true, resultList)
+		return response.SyncResponseConditional(true, resultList, etag)
 	}
 
 	return response.SyncResponse(true, resultFullList)
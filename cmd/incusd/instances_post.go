@@ -43,7 +43,7 @@ import (
 	"github.com/lxc/incus/v6/shared/util"
 )
 
-func ensureDownloadedImageFitWithinBudget(ctx context.Context, s *state.State, r *http.Request, op *operations.Operation, p api.Project, imgAlias string, source api.InstanceSource, imgType string) (*api.Image, error) {
+func ensureDownloadedImageFitWithinBudget(ctx context.Context, s *state.State, r *http.Request, op *operations.Operation, p api.Project, imgAlias string, source api.InstanceSource, imgType string, preferCached bool) (*api.Image, error) {
 	var autoUpdate bool
 	var err error
 	if p.Config["images.auto_update_cached"] != "" {
@@ -71,9 +71,10 @@ func ensureDownloadedImageFitWithinBudget(ctx context.Context, s *state.State, r
 		Type:         imgType,
 		AutoUpdate:   autoUpdate,
 		Public:       false,
-		PreferCached: true,
+		PreferCached: preferCached,
 		ProjectName:  p.Name,
 		Budget:       budget,
+		RegistryAuth: source.RegistryAuth,
 	})
 	if err != nil {
 		return nil, err
@@ -117,7 +118,7 @@ func createFromImage(s *state.State, r *http.Request, p api.Project, profiles []
 		}
 
 		if req.Source.Server != "" {
-			img, err = ensureDownloadedImageFitWithinBudget(context.TODO(), s, r, op, p, imgAlias, req.Source, string(req.Type))
+			img, err = ensureDownloadedImageFitWithinBudget(context.TODO(), s, r, op, p, imgAlias, req.Source, string(req.Type), true)
 			if err != nil {
 				return err
 			}
@@ -532,6 +533,19 @@ func createFromCopy(ctx context.Context, s *state.State, r *http.Request, projec
 		}
 	}
 
+	// Check that the target project allows for the snapshots copied along with the instance.
+	if !req.Source.InstanceOnly {
+		count, err := instanceCopySnapshotsCount(s, source, targetProject, req.Name, req.Source.Refresh)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		err = allowSnapshotsCreation(r.Context(), s, targetProject, count)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
 	// Config override
 	sourceConfig := source.LocalConfig()
 	if req.Config == nil {
@@ -632,6 +646,67 @@ func createFromCopy(ctx context.Context, s *state.State, r *http.Request, projec
 	return operations.OperationResponse(op)
 }
 
+// instanceCopySnapshotsCount returns the number of snapshots created by copying the source instance with its
+// snapshots. When refreshing an existing instance, the snapshots it already has aren't counted.
+func instanceCopySnapshotsCount(s *state.State, source instance.Instance, targetProject string, targetName string, refresh bool) (int, error) {
+	sourceSnapshots, err := source.Snapshots()
+	if err != nil {
+		return -1, err
+	}
+
+	if !refresh {
+		return len(sourceSnapshots), nil
+	}
+
+	target, err := instance.LoadByProjectAndName(s, targetProject, targetName)
+	if err != nil {
+		if response.IsNotFoundError(err) {
+			return len(sourceSnapshots), nil
+		}
+
+		return -1, err
+	}
+
+	targetSnapshots, err := target.Snapshots()
+	if err != nil {
+		return -1, err
+	}
+
+	targetSnapshotNames := make([]string, 0, len(targetSnapshots))
+	for _, snap := range targetSnapshots {
+		_, snapName, _ := api.GetParentAndSnapshotName(snap.Name())
+		targetSnapshotNames = append(targetSnapshotNames, snapName)
+	}
+
+	count := 0
+	for _, snap := range sourceSnapshots {
+		_, snapName, _ := api.GetParentAndSnapshotName(snap.Name())
+		if !slices.Contains(targetSnapshotNames, snapName) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// allowSnapshotsCreation checks that the project allows for the given number of snapshots created along
+// with an instance or custom volume.
+func allowSnapshotsCreation(ctx context.Context, s *state.State, projectName string, count int) error {
+	return s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		dbProject, err := dbCluster.GetProject(ctx, tx.Tx(), projectName)
+		if err != nil {
+			return err
+		}
+
+		p, err := dbProject.ToAPI(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		return project.AllowSnapshotsCreation(tx, p, count)
+	})
+}
+
 func createFromBackup(s *state.State, r *http.Request, projectName string, data io.Reader, pool string, instanceName string) response.Response {
 	reverter := revert.New()
 	defer reverter.Fail()
@@ -720,6 +795,11 @@ func createFromBackup(s *state.State, r *http.Request, projectName string, data
 		return response.SmartError(err)
 	}
 
+	err = allowSnapshotsCreation(r.Context(), s, projectName, len(bInfo.Snapshots))
+	if err != nil {
+		return response.SmartError(err)
+	}
+
 	bInfo.Project = projectName
 
 	// Override pool.
@@ -912,6 +992,11 @@ func instancesPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
+	err = imageValidateRegistryAuth(req.Source.RegistryAuth)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
 	// Set type from URL if missing
 	if req.Type == "" {
 		req.Type = api.InstanceTypeContainer // Default to container if not specified.
@@ -449,6 +449,16 @@ func (c *migrationSink) DoStorage(state *state.State, projectName string, poolNa
 		offerHeader.SnapshotNames = syncSnapshotNames
 	}
 
+	// Check that the project allows for the received snapshots. Cluster moves don't add any as the
+	// source volume and its snapshots are removed afterwards.
+	if !c.volumeOnly && !clusterMove {
+		err = allowSnapshotsCreation(ctx, state, projectName, len(respHeader.Snapshots))
+		if err != nil {
+			c.sendControl(err)
+			return err
+		}
+	}
+
 	err = c.send(respHeader)
 	if err != nil {
 		logger.Errorf("Failed to send storage volume migration header")
@@ -50,6 +50,12 @@ var networkACLLogCmd = APIEndpoint{
 	Get: APIEndpointAction{Handler: networkACLLogGet, AccessHandler: allowPermission(auth.ObjectTypeNetworkACL, auth.EntitlementCanView, "name")},
 }
 
+var networkACLStateCmd = APIEndpoint{
+	Path: "network-acls/{name}/state",
+
+	Get: APIEndpointAction{Handler: networkACLStateGet, AccessHandler: allowPermission(auth.ObjectTypeNetworkACL, auth.EntitlementCanView, "name")},
+}
+
 // API endpoints.
 
 // swagger:operation GET /1.0/network-acls network-acls network_acls_get
@@ -716,3 +722,70 @@ func networkACLLogGet(d *Daemon, r *http.Request) response.Response {
 
 	return response.FileResponse(r, []response.FileResponseEntry{ent}, nil)
 }
+
+// swagger:operation GET /1.0/network-acls/{name}/state network-acls network_acl_state_get
+//
+//	Get the network ACL state
+//
+//	Gets the hit counters of the rules of a specific network ACL, summed across the cluster members.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: ACL state
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/NetworkACLState"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func networkACLStateGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	projectName, _, err := project.NetworkProject(s.DB.Cluster, request.ProjectParam(r))
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	aclName, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	netACL, err := acl.LoadByName(s, projectName, aclName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	clientType := clusterRequest.UserAgentClientType(r.Header.Get("User-Agent"))
+	aclState, err := netACL.GetState(clientType)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, aclState)
+}
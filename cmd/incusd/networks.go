@@ -192,6 +192,12 @@ var networkStateCmd = APIEndpoint{
 func networksGet(d *Daemon, r *http.Request) response.Response {
 	s := d.State()
 
+	// Skip loading the collection if the client already has its current content.
+	etag := changesETag(s, r)
+	if response.ETagMatch(r, etag) {
+		return response.SyncResponseConditional(true, nil, etag)
+	}
+
 	projectName, reqProject, err := project.NetworkProject(s.DB.Cluster, request.ProjectParam(r))
 	if err != nil {
 		return response.SmartError(err)
@@ -298,10 +304,10 @@ func networksGet(d *Daemon, r *http.Request) response.Response {
 	}
 
 	if !recursion {
-		return response.SyncResponse(true, linkResults)
+		return response.SyncResponseConditional(true, linkResults, etag)
 	}
 
-	return response.SyncResponse(true, fullResults)
+	return response.SyncResponseConditional(true, fullResults, etag)
 }
 
 // swagger:operation POST /1.0/networks networks networks_post
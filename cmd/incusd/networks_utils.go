@@ -1,9 +1,14 @@
 package main
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/lxc/incus/v6/internal/server/cluster"
 	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/network"
 	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/logger"
 )
 
@@ -39,3 +44,46 @@ func networkUpdateOVNChassis(s *state.State, heartbeatData *cluster.APIHeartbeat
 	networkOVNChassis = &runChassis
 	return nil
 }
+
+// networkHandleHeartbeat gets called on each full state heartbeat to let the local networks react to member changes.
+func networkHandleHeartbeat(s *state.State, heartbeatData *cluster.APIHeartbeat) error {
+	var projectNetworks map[string]map[int64]api.Network
+
+	err := s.DB.Cluster.Transaction(s.ShutdownCtx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		projectNetworks, err = tx.GetCreatedNetworks(ctx)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to load networks: %w", err)
+	}
+
+	var failed bool
+	for projectName, networks := range projectNetworks {
+		for _, netInfo := range networks {
+			// Only bridges with a highly available gateway need to react to heartbeats.
+			if netInfo.Type != "bridge" || netInfo.Config["bridge.gateway.mode"] != "ha" {
+				continue
+			}
+
+			n, err := network.LoadByName(s, projectName, netInfo.Name)
+			if err != nil {
+				return fmt.Errorf("Failed to load network %q in project %q: %w", netInfo.Name, projectName, err)
+			}
+
+			err = n.HandleHeartbeat(heartbeatData)
+			if err != nil {
+				failed = true
+				logger.Error("Failed handling heartbeat for network", logger.Ctx{"project": projectName, "network": netInfo.Name, "err": err})
+			}
+		}
+	}
+
+	if failed {
+		return fmt.Errorf("Failed handling heartbeat for some networks")
+	}
+
+	return nil
+}
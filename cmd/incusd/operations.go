@@ -82,7 +82,7 @@ func waitForOperations(ctx context.Context, cluster *db.Cluster, consoleShutdown
 
 		var runningOps, execConsoleOps int
 		for _, op := range ops {
-			if op.Status() != api.Running || op.Class() == operations.OperationClassToken {
+			if (op.Status() != api.Running && op.Status() != api.Queued) || op.Class() == operations.OperationClassToken {
 				continue
 			}
 
@@ -337,7 +337,7 @@ func operationCancel(s *state.State, r *http.Request, projectName string, op *ap
 	// Check if operation is local and if so, cancel it.
 	localOp, _ := operations.OperationGetInternal(op.ID)
 	if localOp != nil {
-		if localOp.Status() == api.Running {
+		if localOp.Status() == api.Running || localOp.Status() == api.Queued {
 			_, err := localOp.Cancel()
 			if err != nil {
 				return fmt.Errorf("Failed to cancel local operation %q: %w", op.ID, err)
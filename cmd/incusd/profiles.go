@@ -168,6 +168,12 @@ var profileCmd = APIEndpoint{
 func profilesGet(d *Daemon, r *http.Request) response.Response {
 	s := d.State()
 
+	// Skip loading the collection if the client already has its current content.
+	etag := changesETag(s, r)
+	if response.ETagMatch(r, etag) {
+		return response.SyncResponseConditional(true, nil, etag)
+	}
+
 	p, err := project.ProfileProject(s.DB.Cluster, request.ProjectParam(r))
 	if err != nil {
 		return response.SmartError(err)
@@ -272,10 +278,10 @@ func profilesGet(d *Daemon, r *http.Request) response.Response {
 	}
 
 	if recursion {
-		return response.SyncResponse(true, fullResults)
+		return response.SyncResponseConditional(true, fullResults, etag)
 	}
 
-	return response.SyncResponse(true, linkResults)
+	return response.SyncResponseConditional(true, linkResults, etag)
 }
 
 // profileUsedBy returns all the instance URLs that are using the given profile.
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	incus "github.com/lxc/incus/v6/client"
+	"github.com/lxc/incus/v6/internal/server/cluster"
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// projectUpdateMandatoryACLs applies the changed mandatory network ACLs of the project to the NICs of the running
+// instances on this member. As the project has already been updated in the database by this point, the instances
+// are loaded using the old project config, so that their update detects the changed NICs and applies them.
+func projectUpdateMandatoryACLs(ctx context.Context, s *state.State, projectName string, old api.ProjectPut) error {
+	var instances map[int]db.InstanceArgs
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		dbInstances, err := dbCluster.GetInstances(ctx, tx.Tx(), dbCluster.InstanceFilter{Project: &projectName, Node: &s.ServerName})
+		if err != nil {
+			return err
+		}
+
+		instances, err = tx.InstancesToInstanceArgs(ctx, true, dbInstances...)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to fetch instances of project %q: %w", projectName, err)
+	}
+
+	failures := map[string]error{}
+	for _, args := range instances {
+		inst, err := instance.Load(s, args, api.Project{Name: projectName, ProjectPut: old})
+		if err != nil {
+			failures[args.Name] = err
+			continue
+		}
+
+		// Stopped instances get the mandatory network ACLs applied when started.
+		if !inst.IsRunning() {
+			continue
+		}
+
+		// Update will internally load the new project config and detect the changes to apply.
+		err = inst.Update(db.InstanceArgs{
+			Architecture: inst.Architecture(),
+			Config:       inst.LocalConfig(),
+			Description:  inst.Description(),
+			Devices:      inst.LocalDevices(),
+			Ephemeral:    inst.IsEphemeral(),
+			Profiles:     inst.Profiles(),
+			Project:      inst.Project().Name,
+			Type:         inst.Type(),
+			Snapshot:     inst.IsSnapshot(),
+			ExpiryDate:   inst.ExpiryDate(),
+		}, true)
+		if err != nil {
+			failures[args.Name] = err
+		}
+	}
+
+	if len(failures) != 0 {
+		msg := "The following instances failed to update (project change still saved):\n"
+		for instName, err := range failures {
+			msg += fmt.Sprintf(" - Instance: %s: %v\n", instName, err)
+		}
+
+		return fmt.Errorf("%s", msg)
+	}
+
+	return nil
+}
+
+// projectNotifyMandatoryACLs notifies the other cluster members to apply the changed mandatory network ACLs of the
+// project to their running instances. The notification carries the old project config.
+func projectNotifyMandatoryACLs(s *state.State, projectName string, old api.ProjectPut) error {
+	// Notify all other members. If a member is down, it will be ignored.
+	notifier, err := cluster.NewNotifier(s, s.Endpoints.NetworkCert(), s.ServerCert(), cluster.NotifyAlive)
+	if err != nil {
+		return err
+	}
+
+	return notifier(func(client incus.InstanceServer) error {
+		return client.UpdateProject(projectName, old, "")
+	})
+}
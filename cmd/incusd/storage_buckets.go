@@ -441,6 +441,14 @@ func storagePoolBucketsPost(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	// Check that the project allows for the bucket.
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return project.AllowBucketCreation(tx, bucketProjectName)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
 	if r.Header.Get("Content-Type") == "application/octet-stream" {
 		return createStoragePoolBucketFromBackup(s, r, request.ProjectParam(r), bucketProjectName, r.Body, poolName, r.Header.Get("X-Incus-name"))
 	}
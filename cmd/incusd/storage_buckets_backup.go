@@ -3,7 +3,9 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
@@ -50,6 +52,12 @@ var storagePoolBucketBackupsExportCmd = APIEndpoint{
 	Get: APIEndpointAction{Handler: storagePoolBucketBackupExportGet, AccessHandler: allowPermission(auth.ObjectTypeStorageBucket, auth.EntitlementCanView, "poolName", "bucketName", "location")},
 }
 
+var storagePoolBucketBackupVerifyCmd = APIEndpoint{
+	Path: "storage-pools/{poolName}/buckets/{bucketName}/backups/{backupName}/verify",
+
+	Post: APIEndpointAction{Handler: storagePoolBucketBackupVerifyPost, AccessHandler: allowPermission(auth.ObjectTypeStorageBucket, auth.EntitlementCanManageBackups, "poolName", "bucketName", "location")},
+}
+
 // swagger:operation GET /1.0/storage-pools/{poolName}/buckets/{bucketName}/backups storage storage_pool_buckets_backups_get
 //
 //  Get the storage bucket backups
@@ -359,14 +367,23 @@ func storagePoolBucketBackupsPost(d *Daemon, r *http.Request) response.Response
 		return response.BadRequest(fmt.Errorf("Backup names may not contain slashes"))
 	}
 
+	// Validate the encryption recipients.
+	if len(req.EncryptionRecipients) > 0 {
+		_, err = backup.EncryptionType(req.EncryptionRecipients)
+		if err != nil {
+			return response.BadRequest(err)
+		}
+	}
+
 	fullName := bucketName + internalInstance.SnapshotDelimiter + req.Name
 
 	do := func(op *operations.Operation) error {
 		args := db.StoragePoolBucketBackup{
-			Name:         fullName,
-			BucketID:     bucket.ID,
-			CreationDate: time.Now(),
-			ExpiryDate:   req.ExpiresAt,
+			Name:                 fullName,
+			BucketID:             bucket.ID,
+			CreationDate:         time.Now(),
+			ExpiryDate:           req.ExpiresAt,
+			EncryptionRecipients: req.EncryptionRecipients,
 		}
 
 		err := bucketBackupCreate(s, args, projectName, poolName, bucketName)
@@ -803,3 +820,116 @@ func storagePoolBucketBackupLoadByName(ctx context.Context, s *state.State, proj
 
 	return entry, nil
 }
+
+// swagger:operation POST /1.0/storage-pools/{poolName}/buckets/{bucketName}/backups/{backupName}/verify storage storage_pool_buckets_backup_verify_post
+//
+//	Verify the backup
+//
+//	Checks the backup against its signed manifest without restoring it.
+//	Encrypted backups are checked against the detached manifest stored along with them.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	  - in: body
+//	    name: backup
+//	    description: Backup verification options
+//	    required: false
+//	    schema:
+//	      $ref: "#/definitions/BackupVerifyPost"
+//	responses:
+//	  "202":
+//	    $ref: "#/responses/Operation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func storagePoolBucketBackupVerifyPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	projectName, err := project.StorageBucketProject(r.Context(), s.DB.Cluster, request.ProjectParam(r))
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	poolName, err := url.PathUnescape(mux.Vars(r)["poolName"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	pool, err := storagePools.LoadByName(s, poolName)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed loading storage pool: %w", err))
+	}
+
+	if !pool.Driver().Info().Buckets {
+		return response.BadRequest(fmt.Errorf("Storage pool does not support buckets"))
+	}
+
+	bucketName, err := url.PathUnescape(mux.Vars(r)["bucketName"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Get backup name.
+	backupName, err := url.PathUnescape(mux.Vars(r)["backupName"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	fullName := bucketName + internalInstance.SnapshotDelimiter + backupName
+
+	// Ensure the backup exists.
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		_, err = tx.GetStoragePoolBucketBackup(ctx, projectName, poolName, fullName)
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	req := api.BackupVerifyPost{}
+
+	// The request body is optional.
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return response.BadRequest(err)
+	}
+
+	fingerprints := backupVerifyFingerprints(d, req)
+
+	verify := func(op *operations.Operation) error {
+		return backupVerify(s, internalUtil.VarPath("backups", "buckets", poolName, project.StorageBucket(projectName, fullName)), fingerprints, op)
+	}
+
+	resources := map[string][]api.URL{}
+	resources["storage_buckets"] = []api.URL{*api.NewURL().Path(version.APIVersion, "storage-pools", poolName, "buckets", bucketName)}
+	resources["backups"] = []api.URL{*api.NewURL().Path(version.APIVersion, "storage-pools", poolName, "buckets", bucketName, "backups", backupName)}
+
+	op, err := operations.OperationCreate(s, request.ProjectParam(r), operations.OperationClassTask, operationtype.BucketBackupVerify, resources, nil, verify, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return operations.OperationResponse(op)
+}
@@ -157,6 +157,12 @@ var storagePoolCmd = APIEndpoint{
 func storagePoolsGet(d *Daemon, r *http.Request) response.Response {
 	s := d.State()
 
+	// Skip loading the collection if the client already has its current content.
+	etag := changesETag(s, r)
+	if response.ETagMatch(r, etag) {
+		return response.SyncResponseConditional(true, nil, etag)
+	}
+
 	recursion := localUtil.IsRecursionRequest(r)
 
 	// Parse filter value.
@@ -253,10 +259,10 @@ func storagePoolsGet(d *Daemon, r *http.Request) response.Response {
 	}
 
 	if !recursion {
-		return response.SyncResponse(true, linkResults)
+		return response.SyncResponseConditional(true, linkResults, etag)
 	}
 
-	return response.SyncResponse(true, fullResults)
+	return response.SyncResponseConditional(true, fullResults, etag)
 }
 
 // swagger:operation POST /1.0/storage-pools storage storage_pools_post
@@ -36,6 +36,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/response"
 	"github.com/lxc/incus/v6/internal/server/state"
 	storagePools "github.com/lxc/incus/v6/internal/server/storage"
+	storageDrivers "github.com/lxc/incus/v6/internal/server/storage/drivers"
 	localUtil "github.com/lxc/incus/v6/internal/server/util"
 	internalUtil "github.com/lxc/incus/v6/internal/util"
 	"github.com/lxc/incus/v6/internal/version"
@@ -296,6 +297,12 @@ func storagePoolVolumesGet(d *Daemon, r *http.Request) response.Response {
 		return resp
 	}
 
+	// Skip loading the collection if the client already has its current content.
+	etag := changesETag(s, r)
+	if response.ETagMatch(r, etag) {
+		return response.SyncResponseConditional(true, nil, etag)
+	}
+
 	targetMember := request.QueryParam(r, "target")
 	memberSpecific := targetMember != ""
 
@@ -484,7 +491,7 @@ func storagePoolVolumesGet(d *Daemon, r *http.Request) response.Response {
 			volumes = append(volumes, vol)
 		}
 
-		return response.SyncResponse(true, volumes)
+		return response.SyncResponseConditional(true, volumes, etag)
 	}
 
 	urls := make([]string, 0, len(dbVolumes))
@@ -503,7 +510,7 @@ func storagePoolVolumesGet(d *Daemon, r *http.Request) response.Response {
 		urls = append(urls, dbVol.StorageVolume.URL(version.APIVersion, poolName).String())
 	}
 
-	return response.SyncResponse(true, urls)
+	return response.SyncResponseConditional(true, urls, etag)
 }
 
 // filterVolumes returns a filtered list of volumes that match the given clauses.
@@ -821,6 +828,19 @@ func doCustomVolumeRefresh(s *state.State, r *http.Request, requestProjectName s
 		}
 	}
 
+	// Check that the project allows for the snapshots copied along with the volume.
+	if req.Source.Name != "" && !req.Source.VolumeOnly {
+		count, err := customVolumeCopySnapshotsCount(s, pool, projectName, srcProjectName, req, true)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		err = allowSnapshotsCreation(r.Context(), s, projectName, count)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
 	run := func(op *operations.Operation) error {
 		reverter := revert.New()
 		defer reverter.Fail()
@@ -846,6 +866,54 @@ func doCustomVolumeRefresh(s *state.State, r *http.Request, requestProjectName s
 	return operations.OperationResponse(op)
 }
 
+// customVolumeCopySnapshotsCount returns the number of snapshots created by copying the source volume with its
+// snapshots. When refreshing an existing volume, the snapshots it already has aren't counted.
+func customVolumeCopySnapshotsCount(s *state.State, pool storagePools.Pool, projectName string, srcProjectName string, req *api.StorageVolumesPost, refresh bool) (int, error) {
+	srcPool := pool
+	if req.Source.Pool != "" && req.Source.Pool != pool.Name() {
+		var err error
+
+		srcPool, err = storagePools.LoadByName(s, req.Source.Pool)
+		if err != nil {
+			return -1, err
+		}
+	}
+
+	if srcProjectName == "" {
+		srcProjectName = projectName
+	}
+
+	sourceSnapshots, err := storagePools.VolumeDBSnapshotsGet(srcPool, srcProjectName, req.Source.Name, storageDrivers.VolumeTypeCustom)
+	if err != nil {
+		return -1, err
+	}
+
+	if !refresh {
+		return len(sourceSnapshots), nil
+	}
+
+	targetSnapshots, err := storagePools.VolumeDBSnapshotsGet(pool, projectName, req.Name, storageDrivers.VolumeTypeCustom)
+	if err != nil {
+		return -1, err
+	}
+
+	targetSnapshotNames := make([]string, 0, len(targetSnapshots))
+	for _, snap := range targetSnapshots {
+		_, snapName, _ := api.GetParentAndSnapshotName(snap.Name)
+		targetSnapshotNames = append(targetSnapshotNames, snapName)
+	}
+
+	count := 0
+	for _, snap := range sourceSnapshots {
+		_, snapName, _ := api.GetParentAndSnapshotName(snap.Name)
+		if !slices.Contains(targetSnapshotNames, snapName) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
 func doVolumeCreateOrCopy(s *state.State, r *http.Request, requestProjectName string, projectName string, poolName string, req *api.StorageVolumesPost) response.Response {
 	pool, err := storagePools.LoadByName(s, poolName)
 	if err != nil {
@@ -870,6 +938,19 @@ func doVolumeCreateOrCopy(s *state.State, r *http.Request, requestProjectName st
 		return response.SmartError(err)
 	}
 
+	// Check that the project allows for the snapshots copied along with the volume.
+	if req.Source.Name != "" && !req.Source.VolumeOnly {
+		count, err := customVolumeCopySnapshotsCount(s, pool, projectName, srcProjectName, req, false)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		err = allowSnapshotsCreation(r.Context(), s, projectName, count)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
 	run := func(op *operations.Operation) error {
 		if req.Source.Name == "" {
 			// Use an empty operation for this sync response to pass the requestor
@@ -2358,6 +2439,12 @@ func createStoragePoolVolumeFromBackup(s *state.State, r *http.Request, requestP
 		bInfo.Name = volName
 	}
 
+	// Check that the project allows for the snapshots in the backup.
+	err = allowSnapshotsCreation(r.Context(), s, projectName, len(bInfo.Snapshots))
+	if err != nil {
+		return response.SmartError(err)
+	}
+
 	logger.Debug("Backup file info loaded", logger.Ctx{
 		"type":      bInfo.Type,
 		"name":      bInfo.Name,
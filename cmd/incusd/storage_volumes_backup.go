@@ -3,7 +3,9 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
@@ -51,6 +53,12 @@ var storagePoolVolumeTypeCustomBackupExportCmd = APIEndpoint{
 	Get: APIEndpointAction{Handler: storagePoolVolumeTypeCustomBackupExportGet, AccessHandler: allowPermission(auth.ObjectTypeStorageVolume, auth.EntitlementCanView, "poolName", "type", "volumeName", "location")},
 }
 
+var storagePoolVolumeTypeCustomBackupVerifyCmd = APIEndpoint{
+	Path: "storage-pools/{poolName}/volumes/{type}/{volumeName}/backups/{backupName}/verify",
+
+	Post: APIEndpointAction{Handler: storagePoolVolumeTypeCustomBackupVerifyPost, AccessHandler: allowPermission(auth.ObjectTypeStorageVolume, auth.EntitlementCanManageBackups, "poolName", "type", "volumeName", "location")},
+}
+
 // swagger:operation GET /1.0/storage-pools/{poolName}/volumes/{type}/{volumeName}/backups storage storage_pool_volumes_type_backups_get
 //
 //  Get the storage volume backups
@@ -428,6 +436,14 @@ func storagePoolVolumeTypeCustomBackupsPost(d *Daemon, r *http.Request) response
 		return response.BadRequest(fmt.Errorf("Backup names may not contain slashes"))
 	}
 
+	// Validate the encryption recipients.
+	if len(req.EncryptionRecipients) > 0 {
+		_, err = backup.EncryptionType(req.EncryptionRecipients)
+		if err != nil {
+			return response.BadRequest(err)
+		}
+	}
+
 	fullName := volumeName + internalInstance.SnapshotDelimiter + req.Name
 	volumeOnly := req.VolumeOnly
 
@@ -440,6 +456,7 @@ func storagePoolVolumeTypeCustomBackupsPost(d *Daemon, r *http.Request) response
 			VolumeOnly:           volumeOnly,
 			OptimizedStorage:     req.OptimizedStorage,
 			CompressionAlgorithm: req.CompressionAlgorithm,
+			EncryptionRecipients: req.EncryptionRecipients,
 		}
 
 		err := volumeBackupCreate(s, args, projectName, poolName, volumeName)
@@ -915,3 +932,128 @@ func storagePoolVolumeTypeCustomBackupExportGet(d *Daemon, r *http.Request) resp
 
 	return response.FileResponse(r, []response.FileResponseEntry{ent}, nil)
 }
+
+// swagger:operation POST /1.0/storage-pools/{poolName}/volumes/{type}/{volumeName}/backups/{backupName}/verify storage storage_pool_volumes_type_backup_verify_post
+//
+//	Verify the backup
+//
+//	Checks the backup against its signed manifest without restoring it.
+//	Encrypted backups are checked against the detached manifest stored along with them.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	  - in: body
+//	    name: backup
+//	    description: Backup verification options
+//	    required: false
+//	    schema:
+//	      $ref: "#/definitions/BackupVerifyPost"
+//	responses:
+//	  "202":
+//	    $ref: "#/responses/Operation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func storagePoolVolumeTypeCustomBackupVerifyPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// Get the name of the storage volume.
+	volumeName, err := url.PathUnescape(mux.Vars(r)["volumeName"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Get the name of the storage pool the volume is supposed to be attached to.
+	poolName, err := url.PathUnescape(mux.Vars(r)["poolName"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Get the volume type.
+	volumeTypeName, err := url.PathUnescape(mux.Vars(r)["type"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Get backup name.
+	backupName, err := url.PathUnescape(mux.Vars(r)["backupName"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Convert the volume type name to our internal integer representation.
+	volumeType, err := storagePools.VolumeTypeNameToDBType(volumeTypeName)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	// Check that the storage volume type is valid.
+	if volumeType != db.StoragePoolVolumeTypeCustom {
+		return response.BadRequest(fmt.Errorf("Invalid storage volume type %q", volumeTypeName))
+	}
+
+	projectName, err := project.StorageVolumeProject(s.DB.Cluster, request.ProjectParam(r), db.StoragePoolVolumeTypeCustom)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	resp = forwardedResponseIfVolumeIsRemote(s, r, poolName, projectName, volumeName, db.StoragePoolVolumeTypeCustom)
+	if resp != nil {
+		return resp
+	}
+
+	fullName := volumeName + internalInstance.SnapshotDelimiter + backupName
+
+	// Ensure the backup exists.
+	_, err = storagePoolVolumeBackupLoadByName(r.Context(), s, projectName, poolName, fullName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	req := api.BackupVerifyPost{}
+
+	// The request body is optional.
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return response.BadRequest(err)
+	}
+
+	fingerprints := backupVerifyFingerprints(d, req)
+
+	verify := func(op *operations.Operation) error {
+		return backupVerify(s, internalUtil.VarPath("backups", "custom", poolName, project.StorageVolume(projectName, fullName)), fingerprints, op)
+	}
+
+	resources := map[string][]api.URL{}
+	resources["storage_volumes"] = []api.URL{*api.NewURL().Path(version.APIVersion, "storage-pools", poolName, "volumes", volumeTypeName, volumeName)}
+	resources["backups"] = []api.URL{*api.NewURL().Path(version.APIVersion, "storage-pools", poolName, "volumes", volumeTypeName, volumeName, "backups", backupName)}
+
+	op, err := operations.OperationCreate(s, request.ProjectParam(r), operations.OperationClassTask, operationtype.CustomVolumeBackupVerify, resources, nil, verify, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return operations.OperationResponse(op)
+}
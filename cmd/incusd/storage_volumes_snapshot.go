@@ -138,7 +138,7 @@ func storagePoolVolumeSnapshotsTypePost(d *Daemon, r *http.Request) response.Res
 			return err
 		}
 
-		err = project.AllowSnapshotCreation(p)
+		err = project.AllowSnapshotCreation(tx, p)
 		if err != nil {
 			return err
 		}
@@ -185,6 +185,7 @@ func storagePoolVolumeSnapshotsTypePost(d *Daemon, r *http.Request) response.Res
 
 	// Get the parent volume.
 	var parentDBVolume *db.StorageVolume
+	var snapshots []db.StorageVolumeArgs
 	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 		// Get the parent volume so we can get the config.
 		parentDBVolume, err = tx.GetStoragePoolVolume(ctx, pool.ID(), projectName, volumeType, volumeName, true)
@@ -192,12 +193,27 @@ func storagePoolVolumeSnapshotsTypePost(d *Daemon, r *http.Request) response.Res
 			return err
 		}
 
+		snapshots, err = tx.GetLocalStoragePoolVolumeSnapshotsWithType(ctx, projectName, volumeName, volumeType, pool.ID())
+		if err != nil {
+			return err
+		}
+
 		return nil
 	})
 	if err != nil {
 		return response.SmartError(err)
 	}
 
+	// Check the volume snapshot limit.
+	snapshotsMax, err := internalInstance.GetSnapshotsMax(parentDBVolume.Config)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if snapshotsMax >= 0 && len(snapshots) >= snapshotsMax {
+		return response.BadRequest(fmt.Errorf("Volume %q already has the maximum number of snapshots (%d)", volumeName, snapshotsMax))
+	}
+
 	// Get the snapshot pattern.
 	pattern := parentDBVolume.Config["snapshots.pattern"]
 	if pattern == "" {
@@ -1232,12 +1248,10 @@ func pruneExpiredAndAutoCreateCustomVolumeSnapshotsTask(d *Daemon) (task.Func, t
 				return fmt.Errorf("Failed getting volumes for auto custom volume snapshot task: %w", err)
 			}
 
-			for _, v := range allVolumes {
-				err = project.AllowSnapshotCreation(projects[v.ProjectName])
-				if err != nil {
-					continue
-				}
+			// Keep track of the snapshots scheduled per project so the batch doesn't go above the project limits.
+			scheduled := map[string]int{}
 
+			for _, v := range allVolumes {
 				schedule, ok := v.Config["snapshots.schedule"]
 				if !ok || schedule == "" {
 					continue
@@ -1248,6 +1262,14 @@ func pruneExpiredAndAutoCreateCustomVolumeSnapshotsTask(d *Daemon) (task.Func, t
 					continue
 				}
 
+				// Check that the project allows for the snapshot on top of those already scheduled.
+				err = project.AllowSnapshotsCreation(tx, projects[v.ProjectName], scheduled[v.ProjectName]+1)
+				if err != nil {
+					continue
+				}
+
+				scheduled[v.ProjectName]++
+
 				if v.NodeID < 0 {
 					// Keep a separate list of remote volumes in order to select a member to
 					// perform the snapshot later.
@@ -1469,6 +1491,31 @@ func autoCreateCustomVolumeSnapshots(ctx context.Context, s *state.State, volume
 			return fmt.Errorf("Error loading pool for volume %q (project %q, pool %q): %w", v.Name, v.ProjectName, v.PoolName, err)
 		}
 
+		// Make room for the new snapshot if the volume has reached snapshots.max.
+		err = pruneCustomVolumeSnapshots(ctx, s, pool, v)
+		if err != nil {
+			return fmt.Errorf("Error pruning snapshots of volume %q (project %q, pool %q): %w", v.Name, v.ProjectName, v.PoolName, err)
+		}
+
+		// Check the project limits again as other cluster members may have created snapshots since scheduling.
+		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			dbProject, err := dbCluster.GetProject(ctx, tx.Tx(), v.ProjectName)
+			if err != nil {
+				return err
+			}
+
+			p, err := dbProject.ToAPI(ctx, tx.Tx())
+			if err != nil {
+				return err
+			}
+
+			return project.AllowSnapshotCreation(tx, p)
+		})
+		if err != nil {
+			logger.Warn("Skipping scheduled custom volume snapshot", logger.Ctx{"volName": v.Name, "project": v.ProjectName, "pool": v.PoolName, "err": err})
+			continue
+		}
+
 		err = pool.CreateCustomVolumeSnapshot(v.ProjectName, v.Name, snapshotName, expiry, nil)
 		if err != nil {
 			return fmt.Errorf("Error creating snapshot for volume %q (project %q, pool %q): %w", v.Name, v.ProjectName, v.PoolName, err)
@@ -1478,6 +1525,41 @@ func autoCreateCustomVolumeSnapshots(ctx context.Context, s *state.State, volume
 	return nil
 }
 
+// pruneCustomVolumeSnapshots deletes the oldest snapshots of a custom volume so that a new one can be created
+// without going above snapshots.max.
+func pruneCustomVolumeSnapshots(ctx context.Context, s *state.State, pool storagePools.Pool, volume db.StorageVolumeArgs) error {
+	snapshotsMax, err := internalInstance.GetSnapshotsMax(volume.Config)
+	if err != nil {
+		return err
+	}
+
+	if snapshotsMax < 0 {
+		return nil
+	}
+
+	var snapshots []db.StorageVolumeArgs
+	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		snapshots, err = tx.GetLocalStoragePoolVolumeSnapshotsWithType(ctx, volume.ProjectName, volume.Name, db.StoragePoolVolumeTypeCustom, pool.ID())
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// Snapshots are sorted from oldest to newest.
+	for len(snapshots) >= snapshotsMax {
+		err = pool.DeleteCustomVolumeSnapshot(volume.ProjectName, snapshots[0].Name, nil)
+		if err != nil {
+			return err
+		}
+
+		snapshots = snapshots[1:]
+	}
+
+	return nil
+}
+
 func volumeDetermineNextSnapshotName(ctx context.Context, s *state.State, volume db.StorageVolumeArgs, defaultPattern string) (string, error) {
 	var err error
 
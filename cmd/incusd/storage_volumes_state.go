@@ -18,6 +18,7 @@ import (
 	storagePools "github.com/lxc/incus/v6/internal/server/storage"
 	storageDrivers "github.com/lxc/incus/v6/internal/server/storage/drivers"
 	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
 )
 
 var storagePoolVolumeTypeStateCmd = APIEndpoint{
@@ -117,12 +118,19 @@ func storagePoolVolumeTypeStateGet(d *Daemon, r *http.Request) response.Response
 
 	// Fetch the current usage.
 	var usage *storagePools.VolumeUsage
+	var spaceUsage *storageDrivers.VolumeSpaceUsage
 	if volumeType == db.StoragePoolVolumeTypeCustom {
 		// Custom volumes.
 		usage, err = pool.GetCustomVolumeUsage(projectName, volumeName)
 		if err != nil && !errors.Is(err, storageDrivers.ErrNotSupported) {
 			return response.SmartError(err)
 		}
+
+		// The space breakdown is optional, don't fail the request over it.
+		spaceUsage, err = pool.GetCustomVolumeSpaceUsage(projectName, volumeName)
+		if err != nil && !errors.Is(err, storageDrivers.ErrNotSupported) {
+			logger.Warn("Failed getting volume space usage", logger.Ctx{"project": projectName, "pool": poolName, "volume": volumeName, "err": err})
+		}
 	} else {
 		resp, err := forwardedResponseIfInstanceIsRemote(s, r, projectName, volumeName)
 		if err != nil {
@@ -143,6 +151,12 @@ func storagePoolVolumeTypeStateGet(d *Daemon, r *http.Request) response.Response
 		if err != nil && !errors.Is(err, storageDrivers.ErrNotSupported) {
 			return response.SmartError(err)
 		}
+
+		// The space breakdown is optional, don't fail the request over it.
+		spaceUsage, err = pool.GetInstanceSpaceUsage(inst)
+		if err != nil && !errors.Is(err, storageDrivers.ErrNotSupported) {
+			logger.Warn("Failed getting volume space usage", logger.Ctx{"project": projectName, "pool": poolName, "volume": volumeName, "err": err})
+		}
 	}
 
 	// Prepare the state struct.
@@ -160,6 +174,27 @@ func storagePoolVolumeTypeStateGet(d *Daemon, r *http.Request) response.Response
 		if usage.Total >= 0 {
 			state.Usage.Total = usage.Total
 		}
+
+		// Add the space breakdown when supported by the driver.
+		if spaceUsage != nil {
+			if spaceUsage.Referenced >= 0 {
+				state.Usage.Referenced = uint64(spaceUsage.Referenced)
+			}
+
+			if spaceUsage.Snapshots >= 0 {
+				state.Usage.UsedBySnapshots = uint64(spaceUsage.Snapshots)
+			}
+
+			if len(spaceUsage.Snapshot) > 0 {
+				state.Usage.Snapshots = make(map[string]api.StorageVolumeStateUsageSnapshot, len(spaceUsage.Snapshot))
+				for snapName, snapUsage := range spaceUsage.Snapshot {
+					state.Usage.Snapshots[snapName] = api.StorageVolumeStateUsageSnapshot{
+						Referenced: snapUsage.Referenced,
+						Freed:      snapUsage.Freed,
+					}
+				}
+			}
+		}
 	}
 
 	return response.SyncResponse(true, state)
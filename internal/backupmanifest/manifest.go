@@ -0,0 +1,367 @@
+package backupmanifest
+
+import (
+	"archive/tar"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	localtls "github.com/lxc/incus/v6/shared/tls"
+)
+
+// ManifestPath is the path of the manifest within the backup tarball.
+const ManifestPath = "backup/manifest.yaml"
+
+// SignaturePath is the path of the manifest signature within the backup tarball.
+const SignaturePath = "backup/manifest.yaml.sig"
+
+// DetachedSuffix is appended to the path of encrypted backups to store their detached manifest.
+const DetachedSuffix = ".manifest"
+
+// DetachedFile is the name under which the backup file is listed in detached manifests.
+const DetachedFile = "backup"
+
+// ErrNoManifest is returned when verifying a backup which doesn't include a manifest.
+var ErrNoManifest = errors.New("Backup doesn't include a manifest")
+
+// Manifest lists every entry included in a backup tarball.
+type Manifest struct {
+	Algorithm   string           `yaml:"algorithm"`
+	Certificate string           `yaml:"certificate"`
+	Files       map[string]Entry `yaml:"files"`
+}
+
+// Entry describes an entry of a backup tarball, including the checksum of the content of regular files.
+type Entry struct {
+	Type     string            `yaml:"type"`
+	Mode     int64             `yaml:"mode"`
+	UID      int               `yaml:"uid"`
+	GID      int               `yaml:"gid"`
+	Link     string            `yaml:"link,omitempty"`
+	Xattrs   map[string]string `yaml:"xattrs,omitempty"`
+	Checksum string            `yaml:"checksum,omitempty"`
+}
+
+// entryTypes maps the tar entry types to their name in the manifest.
+var entryTypes = map[byte]string{
+	tar.TypeReg:     "file",
+	tar.TypeLink:    "hardlink",
+	tar.TypeSymlink: "symlink",
+	tar.TypeChar:    "char",
+	tar.TypeBlock:   "block",
+	tar.TypeDir:     "directory",
+	tar.TypeFifo:    "fifo",
+}
+
+// NewEntry returns the manifest entry for a tar header. The checksum of regular files is added separately.
+// The extended attributes (such as file capabilities) are included, hex encoded.
+func NewEntry(hdr *tar.Header) Entry {
+	entryType, ok := entryTypes[hdr.Typeflag]
+	if !ok {
+		entryType = fmt.Sprintf("%q", hdr.Typeflag)
+	}
+
+	entry := Entry{
+		Type: entryType,
+		Mode: hdr.Mode & 0o7777,
+		UID:  hdr.Uid,
+		GID:  hdr.Gid,
+		Link: hdr.Linkname,
+	}
+
+	for key, value := range hdr.PAXRecords {
+		name, ok := strings.CutPrefix(key, "SCHILY.xattr.")
+		if !ok {
+			continue
+		}
+
+		if entry.Xattrs == nil {
+			entry.Xattrs = map[string]string{}
+		}
+
+		entry.Xattrs[name] = hex.EncodeToString([]byte(value))
+	}
+
+	return entry
+}
+
+// problems returns the differences of the actual entry from the one listed in the manifest.
+func (e Entry) problems(name string, actual Entry) []string {
+	var problems []string
+
+	if actual.Type != e.Type {
+		problems = append(problems, fmt.Sprintf("%q isn't a %s", name, e.Type))
+	} else if actual.Checksum != e.Checksum {
+		problems = append(problems, fmt.Sprintf("%q doesn't match its checksum", name))
+	}
+
+	if actual.Mode != e.Mode {
+		problems = append(problems, fmt.Sprintf("%q doesn't match its mode", name))
+	}
+
+	if actual.UID != e.UID || actual.GID != e.GID {
+		problems = append(problems, fmt.Sprintf("%q doesn't match its ownership", name))
+	}
+
+	if actual.Link != e.Link {
+		problems = append(problems, fmt.Sprintf("%q doesn't match its link target", name))
+	}
+
+	if !maps.Equal(actual.Xattrs, e.Xattrs) {
+		problems = append(problems, fmt.Sprintf("%q doesn't match its extended attributes", name))
+	}
+
+	return problems
+}
+
+// Detached is a signed manifest stored alongside a backup file rather than within it.
+type Detached struct {
+	Manifest  string `yaml:"manifest"`
+	Signature []byte `yaml:"signature"`
+}
+
+// Result represents the outcome of a successful verification.
+type Result struct {
+	Files       int
+	Certificate *x509.Certificate
+	Fingerprint string
+}
+
+// Sign generates the manifest for the provided entries and signs it with the provided certificate.
+// It returns the encoded manifest and its signature.
+func Sign(entries map[string]Entry, cert *localtls.CertInfo) ([]byte, []byte, error) {
+	manifest := Manifest{
+		Algorithm:   "sha256",
+		Certificate: string(cert.PublicKey()),
+		Files:       entries,
+	}
+
+	data, err := yaml.Marshal(&manifest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signer, ok := cert.KeyPair().PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("Unsupported private key type %T", cert.KeyPair().PrivateKey)
+	}
+
+	var signature []byte
+	switch signer.(type) {
+	case ed25519.PrivateKey:
+		signature, err = signer.Sign(rand.Reader, data, crypto.Hash(0))
+	default:
+		digest := sha256.Sum256(data)
+		signature, err = signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed signing backup manifest: %w", err)
+	}
+
+	return data, signature, nil
+}
+
+// Verify reads the whole (uncompressed and unencrypted) backup tarball and checks every entry (type, mode,
+// ownership, link target, extended attributes and content) against the embedded manifest and the manifest
+// against its signature.
+func Verify(tr *tar.Reader) (*Result, error) {
+	var manifestData []byte
+	var signature []byte
+	entries := map[string]Entry{}
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("Failed reading backup tarball: %w", err)
+		}
+
+		switch hdr.Name {
+		case ManifestPath:
+			manifestData, err = io.ReadAll(tr)
+			if err != nil {
+				return nil, fmt.Errorf("Failed reading backup manifest: %w", err)
+			}
+
+			continue
+		case SignaturePath:
+			signature, err = io.ReadAll(tr)
+			if err != nil {
+				return nil, fmt.Errorf("Failed reading backup manifest signature: %w", err)
+			}
+
+			continue
+		}
+
+		entry := NewEntry(hdr)
+		if hdr.Typeflag == tar.TypeReg {
+			h := sha256.New()
+			_, err = io.Copy(h, tr)
+			if err != nil {
+				return nil, fmt.Errorf("Failed reading %q from backup tarball: %w", hdr.Name, err)
+			}
+
+			entry.Checksum = hex.EncodeToString(h.Sum(nil))
+		}
+
+		entries[hdr.Name] = entry
+	}
+
+	if manifestData == nil {
+		return nil, ErrNoManifest
+	}
+
+	return verifyManifest(manifestData, signature, entries)
+}
+
+// SignFile generates a detached manifest for the content of a file (such as an encrypted backup,
+// whose content can't be verified without decrypting it), signed with the provided certificate.
+func SignFile(r io.Reader, cert *localtls.CertInfo) ([]byte, error) {
+	entry, err := fileEntry(r)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, signature, err := Sign(map[string]Entry{DetachedFile: *entry}, cert)
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(&Detached{Manifest: string(manifest), Signature: signature})
+}
+
+// VerifyFile checks the content of a file against its detached manifest.
+func VerifyFile(r io.Reader, detached []byte) (*Result, error) {
+	d := Detached{}
+	err := yaml.Unmarshal(detached, &d)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing detached backup manifest: %w", err)
+	}
+
+	if d.Manifest == "" {
+		return nil, ErrNoManifest
+	}
+
+	entry, err := fileEntry(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return verifyManifest([]byte(d.Manifest), d.Signature, map[string]Entry{DetachedFile: *entry})
+}
+
+// CheckSigner checks that the manifest was signed by one of the certificates with the provided fingerprints.
+func CheckSigner(result *Result, fingerprints []string) error {
+	for _, fingerprint := range fingerprints {
+		if strings.EqualFold(fingerprint, result.Fingerprint) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Backup manifest was signed by an untrusted certificate %q", result.Fingerprint)
+}
+
+// fileEntry returns the entry of a detached manifest for the content of r.
+func fileEntry(r io.Reader) (*Entry, error) {
+	h := sha256.New()
+	_, err := io.Copy(h, r)
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading backup: %w", err)
+	}
+
+	return &Entry{Type: entryTypes[tar.TypeReg], Checksum: hex.EncodeToString(h.Sum(nil))}, nil
+}
+
+// verifyManifest checks the manifest against its signature and the entries against the manifest.
+func verifyManifest(manifestData []byte, signature []byte, entries map[string]Entry) (*Result, error) {
+	if signature == nil {
+		return nil, errors.New("Backup manifest isn't signed")
+	}
+
+	manifest := Manifest{}
+	err := yaml.Unmarshal(manifestData, &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing backup manifest: %w", err)
+	}
+
+	if manifest.Algorithm != "sha256" {
+		return nil, fmt.Errorf("Unsupported backup manifest checksum algorithm %q", manifest.Algorithm)
+	}
+
+	// Check the signature.
+	block, _ := pem.Decode([]byte(manifest.Certificate))
+	if block == nil {
+		return nil, errors.New("Backup manifest doesn't include a valid certificate")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing backup manifest certificate: %w", err)
+	}
+
+	err = checkSignature(cert, manifestData, signature)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid backup manifest signature: %w", err)
+	}
+
+	// Compare the entries.
+	var problems []string
+	for name, expected := range manifest.Files {
+		actual, ok := entries[name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%q is missing", name))
+		} else {
+			problems = append(problems, expected.problems(name, actual)...)
+		}
+	}
+
+	for name := range entries {
+		_, ok := manifest.Files[name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%q isn't listed in the manifest", name))
+		}
+	}
+
+	if len(problems) > 0 {
+		slices.Sort(problems)
+		return nil, fmt.Errorf("Backup failed verification: %s", strings.Join(problems, ", "))
+	}
+
+	return &Result{
+		Files:       len(entries),
+		Certificate: cert,
+		Fingerprint: localtls.CertFingerprint(cert),
+	}, nil
+}
+
+// checkSignature validates the signature of data against the certificate's public key.
+func checkSignature(cert *x509.Certificate, data []byte, signature []byte) error {
+	switch cert.PublicKey.(type) {
+	case *ecdsa.PublicKey:
+		return cert.CheckSignature(x509.ECDSAWithSHA256, data, signature)
+	case *rsa.PublicKey:
+		return cert.CheckSignature(x509.SHA256WithRSA, data, signature)
+	case ed25519.PublicKey:
+		return cert.CheckSignature(x509.PureEd25519, data, signature)
+	}
+
+	return fmt.Errorf("Unsupported public key type %T", cert.PublicKey)
+}
@@ -0,0 +1,218 @@
+package backupmanifest
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/shared/tls/tlstest"
+)
+
+// testEntry is an entry of a test tarball.
+type testEntry struct {
+	hdr     tar.Header
+	content string
+}
+
+// testFiles returns the test entries for regular files with the provided content.
+func testFiles(files map[string]string) map[string]testEntry {
+	entries := map[string]testEntry{}
+	for name, content := range files {
+		entries[name] = testEntry{hdr: tar.Header{Typeflag: tar.TypeReg, Mode: 0o644}, content: content}
+	}
+
+	return entries
+}
+
+// buildTarball returns a tarball with the provided entries, followed by a manifest signed for signedEntries.
+func buildTarball(t *testing.T, entries map[string]testEntry, signedEntries map[string]testEntry) *tar.Reader {
+	cert := tlstest.TestingKeyPair(t)
+
+	manifestEntries := map[string]Entry{}
+	for name, entry := range signedEntries {
+		manifestEntry := NewEntry(&entry.hdr)
+		if entry.hdr.Typeflag == tar.TypeReg {
+			sum := sha256.Sum256([]byte(entry.content))
+			manifestEntry.Checksum = hex.EncodeToString(sum[:])
+		}
+
+		manifestEntries[name] = manifestEntry
+	}
+
+	manifest, signature, err := Sign(manifestEntries, cert)
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+
+	write := func(hdr tar.Header, content []byte) {
+		hdr.Size = int64(len(content))
+		err := tw.WriteHeader(&hdr)
+		require.NoError(t, err)
+
+		_, err = tw.Write(content)
+		require.NoError(t, err)
+	}
+
+	for name, entry := range entries {
+		hdr := entry.hdr
+		hdr.Name = name
+		write(hdr, []byte(entry.content))
+	}
+
+	write(tar.Header{Name: ManifestPath, Mode: 0o644, Typeflag: tar.TypeReg}, manifest)
+	write(tar.Header{Name: SignaturePath, Mode: 0o644, Typeflag: tar.TypeReg}, signature)
+
+	require.NoError(t, tw.Close())
+
+	return tar.NewReader(buf)
+}
+
+func TestVerify(t *testing.T) {
+	entries := testFiles(map[string]string{
+		"backup/index.yaml":       "name: c1\n",
+		"backup/container/a.txt":  "hello",
+		"backup/container/b.conf": "world",
+	})
+
+	entries["backup/container"] = testEntry{hdr: tar.Header{Typeflag: tar.TypeDir, Mode: 0o755}}
+	entries["backup/container/c"] = testEntry{hdr: tar.Header{Typeflag: tar.TypeSymlink, Mode: 0o777, Linkname: "a.txt"}}
+	entries["backup/container/d"] = testEntry{hdr: tar.Header{Typeflag: tar.TypeLink, Linkname: "backup/container/a.txt"}}
+	entries["backup/container/ping"] = testEntry{
+		hdr:     tar.Header{Typeflag: tar.TypeReg, Mode: 0o755, PAXRecords: map[string]string{"SCHILY.xattr.security.capability": "\x01\x00\x00\x02"}},
+		content: "binary",
+	}
+
+	result, err := Verify(buildTarball(t, entries, entries))
+	require.NoError(t, err)
+	assert.Equal(t, 7, result.Files)
+	assert.Equal(t, tlstest.TestingKeyPair(t).Fingerprint(), result.Fingerprint)
+}
+
+func TestVerify_Mismatch(t *testing.T) {
+	files := testFiles(map[string]string{
+		"backup/index.yaml":      "name: c1\n",
+		"backup/container/a.txt": "hello",
+	})
+
+	tampered := testFiles(map[string]string{
+		"backup/index.yaml":      "name: c1\n",
+		"backup/container/a.txt": "hellO",
+	})
+
+	_, err := Verify(buildTarball(t, tampered, files))
+	assert.ErrorContains(t, err, `"backup/container/a.txt" doesn't match its checksum`)
+
+	missing := testFiles(map[string]string{
+		"backup/index.yaml": "name: c1\n",
+	})
+
+	_, err = Verify(buildTarball(t, missing, files))
+	assert.ErrorContains(t, err, `"backup/container/a.txt" is missing`)
+}
+
+func TestVerify_MetadataMismatch(t *testing.T) {
+	signed := map[string]testEntry{
+		"backup/container/a":    {hdr: tar.Header{Typeflag: tar.TypeSymlink, Mode: 0o777, Linkname: "b"}},
+		"backup/container/bin":  {hdr: tar.Header{Typeflag: tar.TypeReg, Mode: 0o755}, content: "binary"},
+		"backup/container/etc":  {hdr: tar.Header{Typeflag: tar.TypeDir, Mode: 0o755}},
+		"backup/container/link": {hdr: tar.Header{Typeflag: tar.TypeLink, Linkname: "backup/container/bin"}},
+	}
+
+	tests := []struct {
+		name     string
+		entry    string
+		tampered testEntry
+		err      string
+	}{
+		{
+			name:     "Symlink target",
+			entry:    "backup/container/a",
+			tampered: testEntry{hdr: tar.Header{Typeflag: tar.TypeSymlink, Mode: 0o777, Linkname: "/etc/shadow"}},
+			err:      `"backup/container/a" doesn't match its link target`,
+		},
+		{
+			name:     "Hardlink target",
+			entry:    "backup/container/link",
+			tampered: testEntry{hdr: tar.Header{Typeflag: tar.TypeLink, Linkname: "backup/container/etc"}},
+			err:      `"backup/container/link" doesn't match its link target`,
+		},
+		{
+			name:     "Setuid bit",
+			entry:    "backup/container/bin",
+			tampered: testEntry{hdr: tar.Header{Typeflag: tar.TypeReg, Mode: 0o4755}, content: "binary"},
+			err:      `"backup/container/bin" doesn't match its mode`,
+		},
+		{
+			name:     "Ownership",
+			entry:    "backup/container/etc",
+			tampered: testEntry{hdr: tar.Header{Typeflag: tar.TypeDir, Mode: 0o755, Uid: 1000, Gid: 1000}},
+			err:      `"backup/container/etc" doesn't match its ownership`,
+		},
+		{
+			name:     "File capabilities",
+			entry:    "backup/container/bin",
+			tampered: testEntry{hdr: tar.Header{Typeflag: tar.TypeReg, Mode: 0o755, PAXRecords: map[string]string{"SCHILY.xattr.security.capability": "\x01"}}, content: "binary"},
+			err:      `"backup/container/bin" doesn't match its extended attributes`,
+		},
+		{
+			name:     "Type",
+			entry:    "backup/container/etc",
+			tampered: testEntry{hdr: tar.Header{Typeflag: tar.TypeSymlink, Mode: 0o755, Linkname: "/etc"}},
+			err:      `"backup/container/etc" isn't a directory`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			entries := maps.Clone(signed)
+			entries[test.entry] = test.tampered
+
+			_, err := Verify(buildTarball(t, entries, signed))
+			assert.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestVerify_NoManifest(t *testing.T) {
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	require.NoError(t, tw.Close())
+
+	_, err := Verify(tar.NewReader(buf))
+	assert.ErrorIs(t, err, ErrNoManifest)
+}
+
+func TestVerifyFile(t *testing.T) {
+	cert := tlstest.TestingKeyPair(t)
+	content := "age-encryption.org/v1\nencrypted content"
+
+	detached, err := SignFile(strings.NewReader(content), cert)
+	require.NoError(t, err)
+
+	result, err := VerifyFile(strings.NewReader(content), detached)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Files)
+	assert.Equal(t, cert.Fingerprint(), result.Fingerprint)
+
+	_, err = VerifyFile(strings.NewReader(content+"tampered"), detached)
+	assert.ErrorContains(t, err, `"backup" doesn't match its checksum`)
+
+	_, err = VerifyFile(strings.NewReader(content), []byte("{}"))
+	assert.ErrorIs(t, err, ErrNoManifest)
+}
+
+func TestCheckSigner(t *testing.T) {
+	result := &Result{Fingerprint: "abcdef"}
+
+	assert.NoError(t, CheckSigner(result, []string{"123456", "ABCDEF"}))
+	assert.ErrorContains(t, CheckSigner(result, []string{"123456"}), "untrusted certificate")
+	assert.Error(t, CheckSigner(result, nil))
+}
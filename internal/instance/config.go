@@ -3,10 +3,12 @@ package instance
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/lxc/incus/v6/internal/server/attestation"
 	scriptletLoad "github.com/lxc/incus/v6/internal/server/scriptlet/load"
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/units"
@@ -78,6 +80,7 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	// Valid values are: `stop`, `force-stop` or `stateful-stop`
 	// ---
 	//  type: string
+	//  allowedvalues: stop, force-stop, stateful-stop
 	//  defaultdesc: stop
 	//  liveupdate: yes
 	//  shortdesc: What action to take on the instance when the host is shut down
@@ -172,6 +175,7 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	// See {ref}`cluster-evacuate` for more information.
 	// ---
 	//  type: string
+	//  allowedvalues: auto, migrate, live-migrate, stop, stateful-stop, force-stop
 	//  defaultdesc: `auto`
 	//  liveupdate: no
 	//  shortdesc: What to do when evacuating the instance
@@ -329,6 +333,14 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: Template for the snapshot name
 	"snapshots.pattern": validate.IsAny,
 
+	// gendoc:generate(entity=instance, group=snapshots, key=snapshots.max)
+	// Once the instance has that many snapshots, creating a snapshot through the API fails while scheduled snapshots delete the oldest snapshots to make room for the new one.
+	// ---
+	//  type: integer
+	//  liveupdate: no
+	//  shortdesc: Maximum number of snapshots of the instance
+	"snapshots.max": validate.Optional(validate.IsInRange(1, math.MaxUint32)),
+
 	// gendoc:generate(entity=instance, group=snapshots, key=snapshots.expiry)
 	// Specify an expression like `1M 2H 3d 4w 5m 6y`.
 	// ---
@@ -357,7 +369,7 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: Hash of the base image
 	"volatile.base_image": validate.IsAny,
 
-	// gendoc:generate(entity=instance, group=volatile, key=volatile.cloud_init.instance-id)
+	// gendoc:generate(entity=instance, group=volatile, key=volatile.cloud-init.instance-id)
 	//
 	// ---
 	//  type: string
@@ -536,6 +548,7 @@ var InstanceConfigKeysContainer = map[string]func(value string) error{
 	// If it is `soft`, the instance can exceed its memory limit when extra host memory is available.
 	// ---
 	//  type: string
+	//  allowedvalues: soft, hard
 	//  defaultdesc: `hard`
 	//  liveupdate: yes
 	//  condition: container
@@ -699,6 +712,15 @@ var InstanceConfigKeysContainer = map[string]func(value string) error{
 	//  shortdesc: Raw LXC configuration to be appended to the generated one
 	"raw.lxc": validate.IsAny,
 
+	// gendoc:generate(entity=instance, group=raw, key=raw.lxc.scriptlet)
+	//
+	// ---
+	//  type: string
+	//  liveupdate: no
+	//  condition: container
+	//  shortdesc: LXC scriptlet to run against the generated LXC configuration
+	"raw.lxc.scriptlet": validate.Optional(scriptletLoad.LXCValidate),
+
 	// gendoc:generate(entity=instance, group=raw, key=raw.seccomp)
 	//
 	// ---
@@ -1024,7 +1046,7 @@ var InstanceConfigKeysVM = map[string]func(value string) error{
 	//  type: string
 	//  liveupdate: no
 	//  condition: virtual machine
-	//  shortdesc: QEMU scriptlet to run at early, pre-start and post-start stages
+	//  shortdesc: QEMU scriptlet to run at startup, hotplug, stop and migration stages
 	"raw.qemu.scriptlet": validate.Optional(scriptletLoad.QEMUValidate),
 
 	// gendoc:generate(entity=instance, group=security, key=security.agent.metrics)
@@ -1037,6 +1059,18 @@ var InstanceConfigKeysVM = map[string]func(value string) error{
 	//  shortdesc: Whether the `incus-agent` is queried for state information and metrics
 	"security.agent.metrics": validate.Optional(validate.IsBool),
 
+	// gendoc:generate(entity=instance, group=security, key=security.attestation.pcrs)
+	// Comma-separated list of `<pcr>=<sha256>` entries, for example `0=<digest>,7=<digest>`.
+	// When set, the boot measurements are checked whenever the `incus-agent` starts, and a warning and an `instance-attestation-failed` event are raised if they deviate.
+	// Requires a `tpm` device and `tpm2-tools` inside the instance.
+	// See {ref}`devices-tpm-attestation`.
+	// ---
+	//  type: string
+	//  liveupdate: yes
+	//  condition: virtual machine
+	//  shortdesc: Reference SHA256 PCR values for measured boot attestation
+	"security.attestation.pcrs": validate.Optional(attestation.ValidatePolicy),
+
 	// gendoc:generate(entity=instance, group=security, key=security.csm)
 	// When enabling this option, set {config:option}`instance-security:security.secureboot` to `false`.
 	// ---
@@ -1,6 +1,8 @@
 package instance
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -9,3 +11,19 @@ const SnapshotDelimiter = "/"
 func IsSnapshot(name string) bool {
 	return strings.Contains(name, SnapshotDelimiter)
 }
+
+// GetSnapshotsMax returns the maximum number of snapshots set through the snapshots.max key of the given
+// configuration, or -1 if there is no limit.
+func GetSnapshotsMax(config map[string]string) (int, error) {
+	value := config["snapshots.max"]
+	if value == "" {
+		return -1, nil
+	}
+
+	snapshotsMax, err := strconv.Atoi(value)
+	if err != nil || snapshotsMax < 1 {
+		return -1, fmt.Errorf("Invalid snapshots.max value %q", value)
+	}
+
+	return snapshotsMax, nil
+}
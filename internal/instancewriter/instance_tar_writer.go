@@ -4,6 +4,8 @@ package instancewriter
 
 import (
 	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
@@ -11,6 +13,7 @@ import (
 
 	"golang.org/x/sys/unix"
 
+	"github.com/lxc/incus/v6/internal/backupmanifest"
 	"github.com/lxc/incus/v6/internal/linux"
 	"github.com/lxc/incus/v6/shared/idmap"
 	"github.com/lxc/incus/v6/shared/logger"
@@ -21,6 +24,7 @@ type InstanceTarWriter struct {
 	tarWriter *tar.Writer
 	idmapSet  *idmap.Set
 	linkMap   map[uint64]string
+	entries   map[string]backupmanifest.Entry
 }
 
 // NewInstanceTarWriter returns a ContainerTarWriter for the provided target Writer and id map.
@@ -29,6 +33,7 @@ func NewInstanceTarWriter(writer io.Writer, idmapSet *idmap.Set) *InstanceTarWri
 	ctw.tarWriter = tar.NewWriter(writer)
 	ctw.idmapSet = idmapSet
 	ctw.linkMap = map[uint64]string{}
+	ctw.entries = map[string]backupmanifest.Entry{}
 	return ctw
 }
 
@@ -154,9 +159,9 @@ func (ctw *InstanceTarWriter) WriteFile(name string, srcPath string, fi os.FileI
 		}
 	}
 
-	err = ctw.tarWriter.WriteHeader(hdr)
+	err = ctw.writeHeader(hdr)
 	if err != nil {
-		return fmt.Errorf("Failed to write tar header: %w", err)
+		return err
 	}
 
 	if hdr.Typeflag == tar.TypeReg {
@@ -172,7 +177,7 @@ func (ctw *InstanceTarWriter) WriteFile(name string, srcPath string, fi os.FileI
 			r = io.LimitReader(r, fi.Size())
 		}
 
-		_, err = io.Copy(ctw.tarWriter, r)
+		err = ctw.copyContent(hdr.Name, r)
 		if err != nil {
 			return fmt.Errorf("Failed to copy file content %q: %w", srcPath, err)
 		}
@@ -194,13 +199,44 @@ func (ctw *InstanceTarWriter) WriteFileFromReader(src io.Reader, fi os.FileInfo)
 		return fmt.Errorf("Failed to create tar info header: %w", err)
 	}
 
-	err = ctw.tarWriter.WriteHeader(hdr)
+	err = ctw.writeHeader(hdr)
+	if err != nil {
+		return err
+	}
+
+	return ctw.copyContent(hdr.Name, src)
+}
+
+// writeHeader writes the tar header while recording the entry for the backup manifest.
+func (ctw *InstanceTarWriter) writeHeader(hdr *tar.Header) error {
+	err := ctw.tarWriter.WriteHeader(hdr)
 	if err != nil {
 		return fmt.Errorf("Failed to write tar header: %w", err)
 	}
 
-	_, err = io.Copy(ctw.tarWriter, src)
-	return err
+	ctw.entries[hdr.Name] = backupmanifest.NewEntry(hdr)
+
+	return nil
+}
+
+// copyContent writes the file content to the tarball while recording its checksum.
+func (ctw *InstanceTarWriter) copyContent(name string, src io.Reader) error {
+	h := sha256.New()
+	_, err := io.Copy(io.MultiWriter(ctw.tarWriter, h), src)
+	if err != nil {
+		return err
+	}
+
+	entry := ctw.entries[name]
+	entry.Checksum = hex.EncodeToString(h.Sum(nil))
+	ctw.entries[name] = entry
+
+	return nil
+}
+
+// Entries returns the manifest entries of everything written to the tarball so far.
+func (ctw *InstanceTarWriter) Entries() map[string]backupmanifest.Entry {
+	return ctw.entries
 }
 
 // Close finishes writing the tarball.
@@ -0,0 +1,99 @@
+//go:build linux
+
+package linux
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/unix"
+)
+
+// Possible cloud-init statuses.
+const (
+	CloudInitStatusPending  = "pending"
+	CloudInitStatusRunning  = "running"
+	CloudInitStatusDone     = "done"
+	CloudInitStatusError    = "error"
+	CloudInitStatusDisabled = "disabled"
+)
+
+// CloudInitStatus returns the cloud-init status of the system whose root filesystem is at rootPath.
+// When rootPath isn't the current root, paths are resolved within it so symlinks can't lead outside of it.
+// An empty string is returned when cloud-init isn't installed.
+func CloudInitStatus(rootPath string) string {
+	var root *os.File
+	if rootPath != "/" {
+		var err error
+
+		root, err = os.OpenFile(rootPath, unix.O_PATH|unix.O_CLOEXEC, 0)
+		if err != nil {
+			return ""
+		}
+
+		defer func() { _ = root.Close() }()
+	}
+
+	// openFile opens a path relative to the root filesystem.
+	openFile := func(path string, flags int) (*os.File, error) {
+		if root == nil {
+			return os.OpenFile(filepath.Join("/", path), flags|unix.O_CLOEXEC, 0)
+		}
+
+		fd, err := unix.Openat2(int(root.Fd()), path, &unix.OpenHow{
+			Flags:   uint64(flags | unix.O_CLOEXEC),
+			Resolve: unix.RESOLVE_IN_ROOT | unix.RESOLVE_NO_MAGICLINKS,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return os.NewFile(uintptr(fd), path), nil
+	}
+
+	exists := func(path string) bool {
+		f, err := openFile(path, unix.O_PATH)
+		if err != nil {
+			return false
+		}
+
+		_ = f.Close()
+
+		return true
+	}
+
+	// The result file is written once cloud-init is done with all its stages.
+	f, err := openFile("run/cloud-init/result.json", unix.O_RDONLY)
+	if err == nil {
+		defer func() { _ = f.Close() }()
+
+		result := struct {
+			V1 struct {
+				Errors []any `json:"errors"`
+			} `json:"v1"`
+		}{}
+
+		err = json.NewDecoder(io.LimitReader(f, 1024*1024)).Decode(&result)
+		if err != nil || len(result.V1.Errors) > 0 {
+			return CloudInitStatusError
+		}
+
+		return CloudInitStatusDone
+	}
+
+	if exists("run/cloud-init/disabled") {
+		return CloudInitStatusDisabled
+	}
+
+	if exists("run/cloud-init/status.json") {
+		return CloudInitStatusRunning
+	}
+
+	if exists("usr/bin/cloud-init") {
+		return CloudInitStatusPending
+	}
+
+	return ""
+}
@@ -0,0 +1,66 @@
+//go:build linux
+
+package linux
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeRootFile creates a file within the root filesystem.
+func writeRootFile(t *testing.T, root string, path string, content string) {
+	t.Helper()
+
+	path = filepath.Join(root, path)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestCloudInitStatus(t *testing.T) {
+	root := t.TempDir()
+
+	// Missing root and cloud-init not installed.
+	assert.Equal(t, "", CloudInitStatus(filepath.Join(root, "missing")))
+	assert.Equal(t, "", CloudInitStatus(root))
+
+	writeRootFile(t, root, "usr/bin/cloud-init", "")
+	assert.Equal(t, CloudInitStatusPending, CloudInitStatus(root))
+
+	writeRootFile(t, root, "run/cloud-init/status.json", "{}")
+	assert.Equal(t, CloudInitStatusRunning, CloudInitStatus(root))
+
+	writeRootFile(t, root, "run/cloud-init/result.json", `{"v1": {"datasource": "DataSourceNoCloud", "errors": []}}`)
+	assert.Equal(t, CloudInitStatusDone, CloudInitStatus(root))
+
+	writeRootFile(t, root, "run/cloud-init/result.json", `{"v1": {"errors": ["failed running module"]}}`)
+	assert.Equal(t, CloudInitStatusError, CloudInitStatus(root))
+
+	writeRootFile(t, root, "run/cloud-init/result.json", "invalid")
+	assert.Equal(t, CloudInitStatusError, CloudInitStatus(root))
+
+	require.NoError(t, os.RemoveAll(filepath.Join(root, "run")))
+	writeRootFile(t, root, "run/cloud-init/disabled", "")
+	assert.Equal(t, CloudInitStatusDisabled, CloudInitStatus(root))
+}
+
+func TestCloudInitStatusSymlinks(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+
+	writeRootFile(t, outside, "run/cloud-init/result.json", `{"v1": {"errors": []}}`)
+
+	// Absolute symlinks are resolved within the root filesystem rather than on the host.
+	require.NoError(t, os.Symlink(filepath.Join(outside, "run"), filepath.Join(root, "run")))
+	assert.Equal(t, "", CloudInitStatus(root))
+
+	// As are relative ones escaping it.
+	require.NoError(t, os.Remove(filepath.Join(root, "run")))
+	relative, err := filepath.Rel(root, filepath.Join(outside, "run"))
+	require.NoError(t, err)
+	require.NoError(t, os.Symlink(relative, filepath.Join(root, "run")))
+	assert.Equal(t, "", CloudInitStatus(root))
+}
@@ -0,0 +1,298 @@
+package migration
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lxc/incus/v6/internal/instance/qemudefault"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/units"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// Types of problems reported by the migration pre-flight check.
+const (
+	CheckTypeCPU      = "cpu"
+	CheckTypeMemory   = "memory"
+	CheckTypeStorage  = "storage"
+	CheckTypeNetwork  = "network"
+	CheckTypeDevice   = "device"
+	CheckTypeDriver   = "driver"
+	CheckTypeInstance = "instance"
+)
+
+// CheckArgs represents what's needed to check whether an instance can be migrated.
+// All the target fields are optional, the checks relying on them are skipped when not provided.
+type CheckArgs struct {
+	// Instance with its expanded configuration and devices.
+	Instance *api.Instance
+
+	// Whether the instance is to be live migrated.
+	Live bool
+
+	// Storage pool the instance is to be moved to (defaults to the current one).
+	Pool string
+
+	// CPU flags the instance relies on, the source CPU flags are used if not set.
+	CPUFlags []string
+
+	// Devices of the instance which can't be migrated.
+	NonMigratableDevices []string
+
+	// Instance driver versions on the source (indexed by driver name).
+	SourceDriverVersions map[string]string
+
+	// Resources of the source.
+	SourceResources *api.Resources
+
+	// Target server.
+	Target *api.Server
+
+	// Resources of the target.
+	TargetResources *api.Resources
+
+	// Storage pools on the target.
+	TargetPools []api.StoragePool
+
+	// Networks on the target (in the instance's project).
+	TargetNetworks []api.Network
+}
+
+// DriverVersions returns the versions of the instance drivers in the server environment, indexed by driver name.
+func DriverVersions(env api.ServerEnvironment) map[string]string {
+	versions := map[string]string{}
+
+	names := strings.Split(env.Driver, " | ")
+	values := strings.Split(env.DriverVersion, " | ")
+	for i, name := range names {
+		if name == "" || i >= len(values) {
+			continue
+		}
+
+		versions[name] = values[i]
+	}
+
+	return versions
+}
+
+// Check runs the pre-flight checks for the migration of an instance.
+func Check(args CheckArgs) *api.InstanceMigrationCheck {
+	result := &api.InstanceMigrationCheck{
+		Blockers: []api.InstanceMigrationCheckEntry{},
+		Warnings: []api.InstanceMigrationCheckEntry{},
+	}
+
+	addBlocker := func(checkType string, format string, a ...any) {
+		result.Blockers = append(result.Blockers, api.InstanceMigrationCheckEntry{Type: checkType, Description: fmt.Sprintf(format, a...)})
+	}
+
+	addWarning := func(checkType string, format string, a ...any) {
+		result.Warnings = append(result.Warnings, api.InstanceMigrationCheckEntry{Type: checkType, Description: fmt.Sprintf(format, a...)})
+	}
+
+	inst := args.Instance
+	isVM := inst.Type == string(api.InstanceTypeVM)
+	isRunning := inst.StatusCode == api.Running || inst.StatusCode == api.Frozen
+
+	// Live migration requirements (stopped instances are always migrated statelessly).
+	if args.Live && !isRunning {
+		args.Live = false
+	}
+
+	if args.Live && isVM && !util.IsTrue(inst.ExpandedConfig["migration.stateful"]) {
+		addBlocker(CheckTypeInstance, "Live migration of virtual machines requires migration.stateful to be enabled")
+	}
+
+	// Devices which can't follow the instance.
+	for _, devName := range args.NonMigratableDevices {
+		if args.Live {
+			addBlocker(CheckTypeDevice, "Device %q can't be live migrated", devName)
+		} else {
+			addWarning(CheckTypeDevice, "Device %q may not be usable on the target", devName)
+		}
+	}
+
+	// Stop here if nothing is known about the target.
+	if args.Target == nil {
+		return result
+	}
+
+	targetName := args.Target.Environment.ServerName
+
+	// Architecture.
+	if !slices.Contains(args.Target.Environment.Architectures, inst.Architecture) {
+		addBlocker(CheckTypeCPU, "Target doesn't support the %q architecture", inst.Architecture)
+	}
+
+	// Instance driver.
+	driverName := "lxc"
+	if isVM {
+		driverName = "qemu"
+	}
+
+	targetDriverVersions := DriverVersions(args.Target.Environment)
+	targetDriverVersion, ok := targetDriverVersions[driverName]
+	if !ok {
+		addBlocker(CheckTypeDriver, "Target doesn't support %ss", inst.Type)
+	} else if args.Live && isVM {
+		// QEMU can't load the state of a newer QEMU.
+		sourceDriverVersion := args.SourceDriverVersions[driverName]
+		if sourceDriverVersion != "" && sourceDriverVersion != targetDriverVersion {
+			sourceVersion, errSource := version.Parse(sourceDriverVersion)
+			targetVersion, errTarget := version.Parse(targetDriverVersion)
+			if errSource == nil && errTarget == nil && targetVersion.Compare(sourceVersion) < 0 {
+				addBlocker(CheckTypeDriver, "Target QEMU version %s is older than source version %s", targetDriverVersion, sourceDriverVersion)
+			} else {
+				addWarning(CheckTypeDriver, "Target QEMU version %s differs from source version %s", targetDriverVersion, sourceDriverVersion)
+			}
+		}
+	}
+
+	// Storage pools.
+	hasPool := func(poolName string) bool {
+		for _, pool := range args.TargetPools {
+			if pool.Name == poolName {
+				return len(pool.Locations) == 0 || targetName == "" || slices.Contains(pool.Locations, targetName)
+			}
+		}
+
+		return false
+	}
+
+	// Networks.
+	hasNetwork := func(networkName string) bool {
+		for _, network := range args.TargetNetworks {
+			if network.Name == networkName && network.Managed {
+				return len(network.Locations) == 0 || targetName == "" || slices.Contains(network.Locations, targetName)
+			}
+		}
+
+		return false
+	}
+
+	devNames := make([]string, 0, len(inst.ExpandedDevices))
+	for devName := range inst.ExpandedDevices {
+		devNames = append(devNames, devName)
+	}
+
+	sort.Strings(devNames)
+
+	for _, devName := range devNames {
+		dev := inst.ExpandedDevices[devName]
+
+		switch dev["type"] {
+		case "disk":
+			if dev["path"] == "/" && dev["pool"] != "" {
+				poolName := dev["pool"]
+				if args.Pool != "" {
+					poolName = args.Pool
+				}
+
+				if args.TargetPools != nil && !hasPool(poolName) {
+					addBlocker(CheckTypeStorage, "Storage pool %q doesn't exist on the target", poolName)
+				}
+			} else if dev["pool"] != "" {
+				if args.TargetPools != nil && !hasPool(dev["pool"]) {
+					addBlocker(CheckTypeStorage, "Storage pool %q used by device %q doesn't exist on the target", dev["pool"], devName)
+				} else {
+					addWarning(CheckTypeStorage, "Custom volume %q used by device %q must be available on the target", dev["source"], devName)
+				}
+			}
+
+		case "nic":
+			if dev["network"] != "" {
+				if args.TargetNetworks != nil && !hasNetwork(dev["network"]) {
+					addBlocker(CheckTypeNetwork, "Network %q used by device %q doesn't exist on the target", dev["network"], devName)
+				}
+			} else if dev["parent"] != "" {
+				addWarning(CheckTypeNetwork, "Parent interface %q used by device %q can't be checked on the target", dev["parent"], devName)
+			}
+		}
+	}
+
+	if args.TargetResources == nil {
+		return result
+	}
+
+	// Memory.
+	memoryLimit := inst.ExpandedConfig["limits.memory"]
+	if memoryLimit == "" && isVM {
+		memoryLimit = qemudefault.MemSize
+	}
+
+	if memoryLimit != "" && !strings.HasSuffix(memoryLimit, "%") {
+		memory, err := units.ParseByteSizeString(memoryLimit)
+		targetMemory := args.TargetResources.Memory
+		if err == nil && targetMemory.Total > 0 && uint64(memory) > targetMemory.Total-targetMemory.Used {
+			format := "Target only has %s of free memory, instance requires %s"
+			free := units.GetByteSizeStringIEC(int64(targetMemory.Total-targetMemory.Used), 2)
+
+			if isRunning {
+				addBlocker(CheckTypeMemory, format, free, memoryLimit)
+			} else {
+				addWarning(CheckTypeMemory, format, free, memoryLimit)
+			}
+		}
+	}
+
+	// CPU count.
+	cpus, err := strconv.ParseUint(inst.ExpandedConfig["limits.cpu"], 10, 64)
+	if err == nil && args.TargetResources.CPU.Total > 0 && cpus > args.TargetResources.CPU.Total {
+		if isVM {
+			addBlocker(CheckTypeCPU, "Target only has %d CPU threads, instance requires %d", args.TargetResources.CPU.Total, cpus)
+		} else {
+			addWarning(CheckTypeCPU, "Target only has %d CPU threads, instance is limited to %d", args.TargetResources.CPU.Total, cpus)
+		}
+	}
+
+	// CPU flags (only relevant to the live migration of VMs).
+	if args.Live && isVM {
+		cpuFlags := args.CPUFlags
+		if cpuFlags == nil && args.SourceResources != nil {
+			cpuFlags = commonCPUFlags(args.SourceResources)
+		}
+
+		targetFlags := commonCPUFlags(args.TargetResources)
+
+		missing := []string{}
+		for _, flag := range cpuFlags {
+			if !slices.Contains(targetFlags, flag) {
+				missing = append(missing, flag)
+			}
+		}
+
+		if len(missing) > 0 {
+			addBlocker(CheckTypeCPU, "Target CPU is missing flags used by the instance: %s", strings.Join(missing, ", "))
+		}
+	}
+
+	return result
+}
+
+// commonCPUFlags returns the CPU flags supported by all the CPU cores.
+func commonCPUFlags(res *api.Resources) []string {
+	var flags []string
+	first := true
+
+	for _, socket := range res.CPU.Sockets {
+		for _, core := range socket.Cores {
+			if first {
+				flags = slices.Clone(core.Flags)
+				first = false
+				continue
+			}
+
+			flags = slices.DeleteFunc(flags, func(flag string) bool {
+				return !slices.Contains(core.Flags, flag)
+			})
+		}
+	}
+
+	sort.Strings(flags)
+
+	return flags
+}
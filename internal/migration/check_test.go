@@ -0,0 +1,208 @@
+package migration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// checkTarget returns a target server with the given driver versions.
+func checkTarget(driver string, driverVersion string) *api.Server {
+	return &api.Server{
+		Environment: api.ServerEnvironment{
+			ServerName:    "member2",
+			Architectures: []string{"x86_64"},
+			Driver:        driver,
+			DriverVersion: driverVersion,
+		},
+	}
+}
+
+// checkResources returns the resources of a server with the given free memory, threads and CPU flags.
+func checkResources(freeMemory uint64, threads uint64, flags ...string) *api.Resources {
+	res := &api.Resources{}
+	res.Memory.Total = 8 * 1024 * 1024 * 1024
+	res.Memory.Used = res.Memory.Total - freeMemory
+	res.CPU.Total = threads
+	res.CPU.Sockets = []api.ResourcesCPUSocket{{Cores: []api.ResourcesCPUCore{{Flags: flags}, {Flags: append(flags, "extra")}}}}
+
+	return res
+}
+
+// checkDescriptions returns the descriptions of the check entries.
+func checkDescriptions(entries []api.InstanceMigrationCheckEntry) []string {
+	descriptions := []string{}
+	for _, entry := range entries {
+		descriptions = append(descriptions, entry.Description)
+	}
+
+	return descriptions
+}
+
+func TestDriverVersions(t *testing.T) {
+	env := api.ServerEnvironment{Driver: "lxc | qemu", DriverVersion: "6.0.0 | 9.0.2"}
+	assert.Equal(t, map[string]string{"lxc": "6.0.0", "qemu": "9.0.2"}, DriverVersions(env))
+
+	env = api.ServerEnvironment{Driver: "lxc | qemu", DriverVersion: "6.0.0"}
+	assert.Equal(t, map[string]string{"lxc": "6.0.0"}, DriverVersions(env))
+
+	assert.Empty(t, DriverVersions(api.ServerEnvironment{}))
+}
+
+func TestCheck(t *testing.T) {
+	vm := func(status api.StatusCode, config map[string]string, devices map[string]map[string]string) *api.Instance {
+		return &api.Instance{
+			InstancePut:     api.InstancePut{Architecture: "x86_64"},
+			Type:            string(api.InstanceTypeVM),
+			StatusCode:      status,
+			ExpandedConfig:  config,
+			ExpandedDevices: devices,
+		}
+	}
+
+	rootDisk := map[string]map[string]string{"root": {"type": "disk", "path": "/", "pool": "local"}}
+	pools := []api.StoragePool{{Name: "local"}, {Name: "remote", Locations: []string{"member1"}}}
+	networks := []api.Network{{Name: "incusbr0", Managed: true}, {Name: "eth0"}}
+
+	tests := []struct {
+		name     string
+		args     CheckArgs
+		blockers []string
+		warnings []string
+	}{
+		{
+			name:     "Live migration without migration.stateful",
+			args:     CheckArgs{Instance: vm(api.Running, nil, nil), Live: true},
+			blockers: []string{"Live migration of virtual machines requires migration.stateful to be enabled"},
+		},
+		{
+			name: "Live migration of a stopped instance",
+			args: CheckArgs{Instance: vm(api.Stopped, nil, nil), Live: true},
+		},
+		{
+			name:     "Non-migratable device on live migration",
+			args:     CheckArgs{Instance: vm(api.Running, map[string]string{"migration.stateful": "true"}, nil), Live: true, NonMigratableDevices: []string{"gpu0"}},
+			blockers: []string{`Device "gpu0" can't be live migrated`},
+		},
+		{
+			name:     "Non-migratable device on stateless migration",
+			args:     CheckArgs{Instance: vm(api.Stopped, nil, nil), NonMigratableDevices: []string{"gpu0"}},
+			warnings: []string{`Device "gpu0" may not be usable on the target`},
+		},
+		{
+			name:     "Unsupported architecture and driver",
+			args:     CheckArgs{Instance: &api.Instance{InstancePut: api.InstancePut{Architecture: "aarch64"}, Type: string(api.InstanceTypeVM)}, Target: checkTarget("lxc", "6.0.0")},
+			blockers: []string{`Target doesn't support the "aarch64" architecture`, "Target doesn't support virtual-machines"},
+		},
+		{
+			name: "Older target QEMU on live migration",
+			args: CheckArgs{
+				Instance:             vm(api.Running, map[string]string{"migration.stateful": "true"}, nil),
+				Live:                 true,
+				SourceDriverVersions: map[string]string{"qemu": "9.1.0"},
+				Target:               checkTarget("qemu", "9.0.2"),
+			},
+			blockers: []string{"Target QEMU version 9.0.2 is older than source version 9.1.0"},
+		},
+		{
+			name: "Newer target QEMU on live migration",
+			args: CheckArgs{
+				Instance:             vm(api.Running, map[string]string{"migration.stateful": "true"}, nil),
+				Live:                 true,
+				SourceDriverVersions: map[string]string{"qemu": "9.0.2"},
+				Target:               checkTarget("qemu", "9.1.0"),
+			},
+			warnings: []string{"Target QEMU version 9.1.0 differs from source version 9.0.2"},
+		},
+		{
+			name: "Missing storage pools and networks",
+			args: CheckArgs{
+				Instance: vm(api.Stopped, nil, map[string]map[string]string{
+					"root":  {"type": "disk", "path": "/", "pool": "local"},
+					"data":  {"type": "disk", "path": "/data", "pool": "remote", "source": "vol1"},
+					"eth0":  {"type": "nic", "network": "incusbr0"},
+					"eth1":  {"type": "nic", "network": "eth0"},
+					"eth2":  {"type": "nic", "nictype": "macvlan", "parent": "enp5s0"},
+					"local": {"type": "disk", "path": "/mnt", "source": "/srv"},
+				}),
+				Pool:           "ssd",
+				Target:         checkTarget("qemu", "9.0.2"),
+				TargetPools:    pools,
+				TargetNetworks: networks,
+			},
+			blockers: []string{
+				`Storage pool "remote" used by device "data" doesn't exist on the target`,
+				`Network "eth0" used by device "eth1" doesn't exist on the target`,
+				`Storage pool "ssd" doesn't exist on the target`,
+			},
+			warnings: []string{`Parent interface "enp5s0" used by device "eth2" can't be checked on the target`},
+		},
+		{
+			name: "Unknown target pools and networks",
+			args: CheckArgs{
+				Instance: vm(api.Stopped, nil, rootDisk),
+				Target:   checkTarget("qemu", "9.0.2"),
+			},
+		},
+		{
+			name: "Default VM memory above the free memory of the target",
+			args: CheckArgs{
+				Instance:        vm(api.Running, nil, rootDisk),
+				Target:          checkTarget("qemu", "9.0.2"),
+				TargetResources: checkResources(512*1024*1024, 4),
+			},
+			blockers: []string{"Target only has 512.00MiB of free memory, instance requires 1GiB"},
+		},
+		{
+			name: "Memory of a stopped instance above the free memory of the target",
+			args: CheckArgs{
+				Instance:        vm(api.Stopped, map[string]string{"limits.memory": "2GiB", "limits.cpu": "8"}, rootDisk),
+				Target:          checkTarget("qemu", "9.0.2"),
+				TargetResources: checkResources(1024*1024*1024, 4),
+			},
+			blockers: []string{"Target only has 4 CPU threads, instance requires 8"},
+			warnings: []string{"Target only has 1.00GiB of free memory, instance requires 2GiB"},
+		},
+		{
+			name: "Missing CPU flags on live migration",
+			args: CheckArgs{
+				Instance:        vm(api.Running, map[string]string{"migration.stateful": "true", "limits.memory": "50%"}, rootDisk),
+				Live:            true,
+				Target:          checkTarget("qemu", "9.0.2"),
+				SourceResources: checkResources(0, 4, "avx", "avx2", "sse4_2"),
+				TargetResources: checkResources(4*1024*1024*1024, 4, "sse4_2"),
+			},
+			blockers: []string{"Target CPU is missing flags used by the instance: avx, avx2"},
+		},
+		{
+			name: "CPU flags from the cluster group on live migration",
+			args: CheckArgs{
+				Instance:        vm(api.Running, map[string]string{"migration.stateful": "true", "limits.memory": "1GiB"}, rootDisk),
+				Live:            true,
+				CPUFlags:        []string{"sse4_2"},
+				Target:          checkTarget("qemu", "9.0.2"),
+				SourceResources: checkResources(0, 4, "avx", "sse4_2"),
+				TargetResources: checkResources(4*1024*1024*1024, 4, "sse4_2"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := Check(test.args)
+
+			if test.blockers == nil {
+				test.blockers = []string{}
+			}
+
+			if test.warnings == nil {
+				test.warnings = []string{}
+			}
+
+			assert.ElementsMatch(t, test.blockers, checkDescriptions(result.Blockers))
+			assert.ElementsMatch(t, test.warnings, checkDescriptions(result.Warnings))
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package attestation
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// marshalPublic returns the TPM2B_PUBLIC structure of a restricted RSA signing key.
+func marshalPublic(key *rsa.PublicKey, attributes uint32) []byte {
+	public := binary.BigEndian.AppendUint16(nil, algRSA)
+	public = binary.BigEndian.AppendUint16(public, algSHA256)
+	public = binary.BigEndian.AppendUint32(public, attributes)
+	public = appendSized(public, nil)
+	public = binary.BigEndian.AppendUint16(public, algNull)
+	public = binary.BigEndian.AppendUint16(public, algRSASSA)
+	public = binary.BigEndian.AppendUint16(public, algSHA256)
+	public = binary.BigEndian.AppendUint16(public, uint16(key.N.BitLen()))
+	public = binary.BigEndian.AppendUint32(public, 0)
+	public = appendSized(public, key.N.Bytes())
+
+	return appendSized(nil, public)
+}
+
+// marshalQuote returns a TPMS_ATTEST quote structure over SHA256 PCRs.
+func marshalQuote(nonce []byte, pcrs []int, digest []byte) []byte {
+	quote := binary.BigEndian.AppendUint32(nil, attestMagic)
+	quote = binary.BigEndian.AppendUint16(quote, attestTypeQuote)
+	quote = appendSized(quote, []byte("signer"))
+	quote = appendSized(quote, nonce)
+	quote = append(quote, make([]byte, 8+4+4+1+8)...)
+	quote = binary.BigEndian.AppendUint32(quote, 1)
+	quote = binary.BigEndian.AppendUint16(quote, algSHA256)
+
+	bitmap := make([]byte, 3)
+	for _, pcr := range pcrs {
+		bitmap[pcr/8] |= 1 << (pcr % 8)
+	}
+
+	quote = append(quote, byte(len(bitmap)))
+	quote = append(quote, bitmap...)
+
+	return appendSized(quote, digest)
+}
+
+func TestVerifyQuote(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ak, err := ParseAttestationKey(marshalPublic(&key.PublicKey, attrFixedTPM|attrRestricted|attrSign))
+	require.NoError(t, err)
+	assert.Equal(t, 0, key.PublicKey.N.Cmp(ak.PublicKey.N))
+	assert.Equal(t, 65537, ak.PublicKey.E)
+	assert.Len(t, ak.Name, 2+sha256.Size)
+
+	pcrs := map[int][]byte{0: make([]byte, sha256.Size), 7: make([]byte, sha256.Size)}
+	pcrs[7][0] = 1
+
+	h := sha256.New()
+	_, _ = h.Write(pcrs[0])
+	_, _ = h.Write(pcrs[7])
+
+	nonce := []byte("nonce")
+	quote := marshalQuote(nonce, []int{0, 7}, h.Sum(nil))
+
+	digest := sha256.Sum256(quote)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	assert.NoError(t, VerifyQuote(ak, quote, signature, nonce, pcrs))
+	assert.Error(t, VerifyQuote(ak, quote, signature, []byte("other"), pcrs))
+	assert.Error(t, VerifyQuote(ak, quote, signature, nonce, map[int][]byte{0: pcrs[0], 7: pcrs[0]}))
+	assert.Error(t, VerifyQuote(ak, quote, signature, nonce, map[int][]byte{0: pcrs[0]}))
+
+	signature[0] ^= 0xff
+	assert.Error(t, VerifyQuote(ak, quote, signature, nonce, pcrs))
+
+	// Keys which aren't restricted signing keys are rejected.
+	_, err = ParseAttestationKey(marshalPublic(&key.PublicKey, attrFixedTPM|attrSign))
+	assert.Error(t, err)
+}
+
+func TestMakeCredential(t *testing.T) {
+	ek, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	name := []byte("attestation key name")
+	secret := []byte("0123456789abcdef")
+
+	out, err := MakeCredential(&ek.PublicKey, name, secret)
+	require.NoError(t, err)
+
+	// Undo what TPM2_ActivateCredential would do.
+	assert.Equal(t, uint32(credentialMagic), binary.BigEndian.Uint32(out[0:4]))
+	assert.Equal(t, uint32(credentialVersion), binary.BigEndian.Uint32(out[4:8]))
+
+	idSize := int(binary.BigEndian.Uint16(out[8:10]))
+	idObject := out[10 : 10+idSize]
+	encSeed := out[10+idSize+2:]
+
+	seed, err := rsa.DecryptOAEP(sha256.New(), nil, ek, encSeed, []byte("IDENTITY\x00"))
+	require.NoError(t, err)
+
+	macSize := int(binary.BigEndian.Uint16(idObject[0:2]))
+	outerMAC := idObject[2 : 2+macSize]
+	encIdentity := idObject[2+macSize:]
+
+	mac := hmac.New(sha256.New, kdfa(crypto.SHA256, seed, "INTEGRITY", nil, nil, 256))
+	_, _ = mac.Write(encIdentity)
+	_, _ = mac.Write(name)
+	assert.Equal(t, mac.Sum(nil), outerMAC)
+
+	block, err := aes.NewCipher(kdfa(crypto.SHA256, seed, "STORAGE", name, nil, 128))
+	require.NoError(t, err)
+
+	identity := make([]byte, len(encIdentity))
+	cipher.NewCFBDecrypter(block, make([]byte, aes.BlockSize)).XORKeyStream(identity, encIdentity)
+	assert.Equal(t, appendSized(nil, secret), identity)
+}
+
+func TestEventLog(t *testing.T) {
+	// Spec ID event (SHA1 and SHA256 digests).
+	specID := append([]byte{}, eventSpecID...)
+	specID = append(specID, make([]byte, 8)...)
+	specID = binary.LittleEndian.AppendUint32(specID, 2)
+	specID = binary.LittleEndian.AppendUint16(specID, algSHA1)
+	specID = binary.LittleEndian.AppendUint16(specID, 20)
+	specID = binary.LittleEndian.AppendUint16(specID, algSHA256)
+	specID = binary.LittleEndian.AppendUint16(specID, 32)
+	specID = append(specID, 0)
+
+	log := binary.LittleEndian.AppendUint32(nil, 0)
+	log = binary.LittleEndian.AppendUint32(log, eventNoAction)
+	log = append(log, make([]byte, 20)...)
+	log = binary.LittleEndian.AppendUint32(log, uint32(len(specID)))
+	log = append(log, specID...)
+
+	appendEvent := func(pcr uint32, eventType uint32, digest []byte, data []byte) {
+		log = binary.LittleEndian.AppendUint32(log, pcr)
+		log = binary.LittleEndian.AppendUint32(log, eventType)
+		log = binary.LittleEndian.AppendUint32(log, 2)
+		log = binary.LittleEndian.AppendUint16(log, algSHA1)
+		log = append(log, make([]byte, 20)...)
+		log = binary.LittleEndian.AppendUint16(log, algSHA256)
+		log = append(log, digest...)
+		log = binary.LittleEndian.AppendUint32(log, uint32(len(data)))
+		log = append(log, data...)
+	}
+
+	digest := sha256.Sum256([]byte("firmware"))
+	appendEvent(0, eventNoAction, make([]byte, 32), append(append([]byte{}, eventStartupLocality...), 3))
+	appendEvent(0, 0x80000008, digest[:], []byte("firmware"))
+	appendEvent(7, 0x80000001, digest[:], nil)
+
+	events, err := ParseEventLog(log)
+	require.NoError(t, err)
+	assert.Len(t, events, 3)
+
+	pcrs := ReplayEventLog(events)
+	assert.Len(t, pcrs, 2)
+
+	initial := make([]byte, 32)
+	initial[31] = 3
+	expected := sha256.Sum256(append(initial, digest[:]...))
+	assert.Equal(t, expected[:], pcrs[0])
+
+	expected = sha256.Sum256(append(make([]byte, 32), digest[:]...))
+	assert.Equal(t, expected[:], pcrs[7])
+
+	_, err = ParseEventLog(log[:len(log)-1])
+	assert.Error(t, err)
+}
+
+func TestParsePolicy(t *testing.T) {
+	digest := hex.EncodeToString(make([]byte, sha256.Size))
+
+	policy, err := ParsePolicy("7=" + digest + ", 0=" + digest)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 7}, policy.PCRs())
+	assert.True(t, policy.Match(7, make([]byte, sha256.Size)))
+	assert.False(t, policy.Match(7, []byte("other")))
+	assert.True(t, policy.Match(4, []byte("other")))
+
+	for _, value := range []string{"7", "24=" + digest, "7=abcd", "7=" + digest + ",7=" + digest} {
+		_, err := ParsePolicy(value)
+		assert.Error(t, err, value)
+	}
+}
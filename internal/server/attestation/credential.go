@@ -0,0 +1,87 @@
+package attestation
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/binary"
+	"fmt"
+)
+
+// credentialMagic and credentialVersion identify the credential file format used by tpm2-tools.
+const (
+	credentialMagic   = 0xBADCC0DE
+	credentialVersion = 1
+)
+
+// MakeCredential wraps a secret so that it can only be recovered by the TPM holding both the endorsement key
+// and the attestation key with the provided name (TPM2_ActivateCredential).
+// The endorsement key is expected to use the default template (SHA256 name algorithm and AES-128-CFB).
+// The result uses the credential file format expected by tpm2_activatecredential.
+func MakeCredential(ek *rsa.PublicKey, akName []byte, secret []byte) ([]byte, error) {
+	nameHash := crypto.SHA256
+
+	if len(secret) > nameHash.Size() {
+		return nil, fmt.Errorf("Secret can't be larger than %d bytes", nameHash.Size())
+	}
+
+	// Generate the seed and encrypt it for the endorsement key.
+	seed := make([]byte, nameHash.Size())
+	_, err := rand.Read(seed)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to generate seed: %w", err)
+	}
+
+	encSeed, err := rsa.EncryptOAEP(nameHash.New(), rand.Reader, ek, seed, []byte("IDENTITY\x00"))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to encrypt seed: %w", err)
+	}
+
+	// Encrypt the secret with the storage key derived from the seed.
+	symKey := kdfa(nameHash, seed, "STORAGE", akName, nil, 128)
+
+	block, err := aes.NewCipher(symKey)
+	if err != nil {
+		return nil, err
+	}
+
+	encIdentity := appendSized(nil, secret)
+	cipher.NewCFBEncrypter(block, make([]byte, aes.BlockSize)).XORKeyStream(encIdentity, encIdentity)
+
+	// Protect the integrity of the encrypted secret and bind it to the attestation key.
+	hmacKey := kdfa(nameHash, seed, "INTEGRITY", nil, nil, nameHash.Size()*8)
+	mac := hmac.New(nameHash.New, hmacKey)
+	_, _ = mac.Write(encIdentity)
+	_, _ = mac.Write(akName)
+
+	idObject := appendSized(nil, mac.Sum(nil))
+	idObject = append(idObject, encIdentity...)
+
+	out := binary.BigEndian.AppendUint32(nil, credentialMagic)
+	out = binary.BigEndian.AppendUint32(out, credentialVersion)
+	out = appendSized(out, idObject)
+	out = appendSized(out, encSeed)
+
+	return out, nil
+}
+
+// kdfa implements the TPM key derivation function (SP800-108 counter mode with HMAC).
+func kdfa(h crypto.Hash, key []byte, label string, contextU []byte, contextV []byte, bits int) []byte {
+	out := []byte{}
+
+	for counter := uint32(1); len(out)*8 < bits; counter++ {
+		mac := hmac.New(h.New, key)
+		_ = binary.Write(mac, binary.BigEndian, counter)
+		_, _ = mac.Write([]byte(label))
+		_, _ = mac.Write([]byte{0})
+		_, _ = mac.Write(contextU)
+		_, _ = mac.Write(contextV)
+		_ = binary.Write(mac, binary.BigEndian, uint32(bits))
+		out = mac.Sum(out)
+	}
+
+	return out[:bits/8]
+}
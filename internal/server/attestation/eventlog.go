@@ -0,0 +1,214 @@
+package attestation
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Event types with special handling during replay.
+const (
+	eventNoAction = 0x00000003
+)
+
+// maxEventCount is the maximum number of events accepted in an event log.
+const maxEventCount = 100000
+
+// Signatures of the special EV_NO_ACTION events.
+var (
+	eventSpecID          = []byte("Spec ID Event03\x00")
+	eventStartupLocality = []byte("StartupLocality\x00")
+)
+
+// Event represents an entry of the TPM event log.
+type Event struct {
+	// PCR which was extended.
+	PCR int
+
+	// Type of the event.
+	Type uint32
+
+	// SHA256 digest the PCR was extended with.
+	Digest []byte
+
+	// Event data.
+	Data []byte
+}
+
+// ParseEventLog parses a crypto agile (TCG PC Client) TPM event log and returns the SHA256 measurements.
+func ParseEventLog(data []byte) ([]Event, error) {
+	r := bytes.NewReader(data)
+
+	// The first event uses the legacy (SHA1) format and describes the digests used by the following events.
+	var header struct {
+		PCR  uint32
+		Type uint32
+		SHA1 [20]byte
+		Size uint32
+	}
+
+	err := binary.Read(r, binary.LittleEndian, &header)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read event log header: %w", err)
+	}
+
+	if header.Type != eventNoAction || int(header.Size) > r.Len() {
+		return nil, errors.New("Event log isn't in the crypto agile format")
+	}
+
+	specID := make([]byte, header.Size)
+	_, err = io.ReadFull(r, specID)
+	if err != nil {
+		return nil, err
+	}
+
+	digestSizes, err := parseSpecID(specID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, ok := digestSizes[algSHA256]
+	if !ok {
+		return nil, errors.New("Event log doesn't include SHA256 digests")
+	}
+
+	events := []Event{}
+	for r.Len() > 0 {
+		if len(events) >= maxEventCount {
+			return nil, errors.New("Too many events in event log")
+		}
+
+		var eventHeader struct {
+			PCR   uint32
+			Type  uint32
+			Count uint32
+		}
+
+		err := binary.Read(r, binary.LittleEndian, &eventHeader)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read event %d: %w", len(events), err)
+		}
+
+		event := Event{PCR: int(eventHeader.PCR), Type: eventHeader.Type}
+
+		for i := uint32(0); i < eventHeader.Count; i++ {
+			var alg uint16
+			err := binary.Read(r, binary.LittleEndian, &alg)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to read event %d: %w", len(events), err)
+			}
+
+			size, ok := digestSizes[alg]
+			if !ok {
+				return nil, fmt.Errorf("Unknown digest algorithm 0x%04x in event %d", alg, len(events))
+			}
+
+			digest := make([]byte, size)
+			_, err = io.ReadFull(r, digest)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to read event %d: %w", len(events), err)
+			}
+
+			if alg == algSHA256 {
+				event.Digest = digest
+			}
+		}
+
+		var size uint32
+		err = binary.Read(r, binary.LittleEndian, &size)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read event %d: %w", len(events), err)
+		}
+
+		if int64(size) > int64(r.Len()) {
+			return nil, fmt.Errorf("Event %d is truncated", len(events))
+		}
+
+		event.Data = make([]byte, size)
+		_, err = io.ReadFull(r, event.Data)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read event %d: %w", len(events), err)
+		}
+
+		if event.Digest == nil {
+			return nil, fmt.Errorf("Event %d is missing its SHA256 digest", len(events))
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// parseSpecID parses the Spec ID event and returns the digest size for each algorithm.
+func parseSpecID(data []byte) (map[uint16]int, error) {
+	if !bytes.HasPrefix(data, eventSpecID) {
+		return nil, errors.New("Event log is missing the Spec ID event")
+	}
+
+	r := bytes.NewReader(data[len(eventSpecID):])
+
+	var header struct {
+		PlatformClass uint32
+		VersionMinor  uint8
+		VersionMajor  uint8
+		Errata        uint8
+		UintnSize     uint8
+		Count         uint32
+	}
+
+	err := binary.Read(r, binary.LittleEndian, &header)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read Spec ID event: %w", err)
+	}
+
+	sizes := map[uint16]int{}
+	for i := uint32(0); i < header.Count; i++ {
+		var alg struct {
+			ID   uint16
+			Size uint16
+		}
+
+		err := binary.Read(r, binary.LittleEndian, &alg)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read Spec ID event: %w", err)
+		}
+
+		sizes[alg.ID] = int(alg.Size)
+	}
+
+	return sizes, nil
+}
+
+// ReplayEventLog computes the SHA256 PCR values resulting from the events.
+func ReplayEventLog(events []Event) map[int][]byte {
+	pcrs := map[int][]byte{}
+
+	for _, event := range events {
+		if event.Type == eventNoAction {
+			// The startup locality is reflected in the initial value of PCR 0.
+			if event.PCR == 0 && bytes.HasPrefix(event.Data, eventStartupLocality) && len(event.Data) > len(eventStartupLocality) {
+				initial := make([]byte, sha256.Size)
+				initial[len(initial)-1] = event.Data[len(eventStartupLocality)]
+				pcrs[0] = initial
+			}
+
+			continue
+		}
+
+		value, ok := pcrs[event.PCR]
+		if !ok {
+			value = make([]byte, sha256.Size)
+		}
+
+		h := sha256.New()
+		_, _ = h.Write(value)
+		_, _ = h.Write(event.Digest)
+		pcrs[event.PCR] = h.Sum(nil)
+	}
+
+	return pcrs
+}
@@ -0,0 +1,88 @@
+package attestation
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxPCR is the highest PCR index on a PC client TPM.
+const maxPCR = 23
+
+// DefaultPCRs is the list of PCRs quoted when no reference policy is set (firmware and boot loader measurements).
+var DefaultPCRs = []int{0, 1, 2, 3, 4, 5, 6, 7}
+
+// Policy maps PCR indexes to their expected SHA256 values.
+type Policy map[int][]byte
+
+// ParsePolicy parses a reference policy in the "<pcr>=<sha256>[,<pcr>=<sha256>...]" format.
+func ParsePolicy(value string) (Policy, error) {
+	policy := Policy{}
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		indexStr, digestStr, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("Invalid PCR entry %q, expected <pcr>=<sha256>", entry)
+		}
+
+		index, err := ParsePCR(indexStr)
+		if err != nil {
+			return nil, err
+		}
+
+		_, ok = policy[index]
+		if ok {
+			return nil, fmt.Errorf("Duplicate entry for PCR %d", index)
+		}
+
+		digest, err := hex.DecodeString(strings.TrimSpace(digestStr))
+		if err != nil || len(digest) != sha256.Size {
+			return nil, fmt.Errorf("Invalid SHA256 value for PCR %d", index)
+		}
+
+		policy[index] = digest
+	}
+
+	return policy, nil
+}
+
+// PCRs returns the sorted list of PCRs covered by the policy.
+func (p Policy) PCRs() []int {
+	return slices.Sorted(maps.Keys(p))
+}
+
+// Match returns whether the PCR value is the expected one (PCRs not covered by the policy always match).
+func (p Policy) Match(index int, value []byte) bool {
+	expected, ok := p[index]
+	if !ok {
+		return true
+	}
+
+	return bytes.Equal(expected, value)
+}
+
+// ParsePCR parses and validates a PCR index.
+func ParsePCR(value string) (int, error) {
+	index, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || index < 0 || index > maxPCR {
+		return -1, fmt.Errorf("Invalid PCR index %q", value)
+	}
+
+	return index, nil
+}
+
+// ValidatePolicy validates a reference policy config value.
+func ValidatePolicy(value string) error {
+	_, err := ParsePolicy(value)
+	return err
+}
@@ -0,0 +1,195 @@
+package attestation
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	_ "crypto/sha1"   // Register SHA1 for use through crypto.Hash.
+	_ "crypto/sha256" // Register SHA256 for use through crypto.Hash.
+	_ "crypto/sha512" // Register SHA384 and SHA512 for use through crypto.Hash.
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// TPM algorithm identifiers.
+const (
+	algRSA    = 0x0001
+	algSHA1   = 0x0004
+	algSHA256 = 0x000B
+	algSHA384 = 0x000C
+	algSHA512 = 0x000D
+	algNull   = 0x0010
+	algRSASSA = 0x0014
+)
+
+// TPM object attributes required for an attestation key.
+const (
+	attrFixedTPM   = 0x00000002
+	attrRestricted = 0x00010000
+	attrSign       = 0x00040000
+)
+
+// hashAlgorithm returns the Go hash matching a TPM algorithm identifier.
+func hashAlgorithm(alg uint16) (crypto.Hash, error) {
+	switch alg {
+	case algSHA1:
+		return crypto.SHA1, nil
+	case algSHA256:
+		return crypto.SHA256, nil
+	case algSHA384:
+		return crypto.SHA384, nil
+	case algSHA512:
+		return crypto.SHA512, nil
+	}
+
+	return 0, fmt.Errorf("Unsupported TPM hash algorithm 0x%04x", alg)
+}
+
+// AttestationKey represents the public part of a TPM attestation key.
+type AttestationKey struct {
+	// Public key used to check the quote signatures.
+	PublicKey *rsa.PublicKey
+
+	// Name of the key (name algorithm followed by the digest of its public area).
+	Name []byte
+
+	// Hash algorithm used by the key's signing scheme.
+	SigningHash crypto.Hash
+}
+
+// ParseAttestationKey parses a marshaled TPM2B_PUBLIC structure describing an attestation key.
+// Only restricted RSA signing keys resident in the TPM are accepted.
+func ParseAttestationKey(data []byte) (*AttestationKey, error) {
+	r := bytes.NewReader(data)
+
+	public, err := readSized(r)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read public area: %w", err)
+	}
+
+	if r.Len() != 0 {
+		return nil, errors.New("Trailing data after public area")
+	}
+
+	p := bytes.NewReader(public)
+
+	var header struct {
+		Type       uint16
+		NameAlg    uint16
+		Attributes uint32
+	}
+
+	err = binary.Read(p, binary.BigEndian, &header)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read public area header: %w", err)
+	}
+
+	if header.Type != algRSA {
+		return nil, fmt.Errorf("Unsupported attestation key type 0x%04x", header.Type)
+	}
+
+	required := uint32(attrFixedTPM | attrRestricted | attrSign)
+	if header.Attributes&required != required {
+		return nil, errors.New("Attestation key must be a restricted signing key fixed to the TPM")
+	}
+
+	nameHash, err := hashAlgorithm(header.NameAlg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Skip the authorization policy.
+	_, err = readSized(p)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read authorization policy: %w", err)
+	}
+
+	// Symmetric definition (must be null for signing keys).
+	var symmetric uint16
+	err = binary.Read(p, binary.BigEndian, &symmetric)
+	if err != nil {
+		return nil, err
+	}
+
+	if symmetric != algNull {
+		return nil, errors.New("Attestation key must not have a symmetric algorithm")
+	}
+
+	var scheme uint16
+	err = binary.Read(p, binary.BigEndian, &scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	if scheme != algRSASSA {
+		return nil, fmt.Errorf("Unsupported attestation key signing scheme 0x%04x", scheme)
+	}
+
+	var params struct {
+		SchemeHash uint16
+		KeyBits    uint16
+		Exponent   uint32
+	}
+
+	err = binary.Read(p, binary.BigEndian, &params)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read key parameters: %w", err)
+	}
+
+	signingHash, err := hashAlgorithm(params.SchemeHash)
+	if err != nil {
+		return nil, err
+	}
+
+	modulus, err := readSized(p)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read key modulus: %w", err)
+	}
+
+	if len(modulus)*8 != int(params.KeyBits) {
+		return nil, fmt.Errorf("Key modulus size doesn't match the expected %d bits", params.KeyBits)
+	}
+
+	exponent := int(params.Exponent)
+	if exponent == 0 {
+		exponent = 65537
+	}
+
+	// The name is the name algorithm followed by the digest of the public area.
+	h := nameHash.New()
+	_, _ = h.Write(public)
+	name := binary.BigEndian.AppendUint16(nil, header.NameAlg)
+	name = h.Sum(name)
+
+	return &AttestationKey{
+		PublicKey:   &rsa.PublicKey{N: new(big.Int).SetBytes(modulus), E: exponent},
+		Name:        name,
+		SigningHash: signingHash,
+	}, nil
+}
+
+// readSized reads a TPM2B structure (a big endian 16bit size followed by the data).
+func readSized(r io.Reader) ([]byte, error) {
+	var size uint16
+	err := binary.Read(r, binary.BigEndian, &size)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, size)
+	_, err = io.ReadFull(r, buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+// appendSized appends a TPM2B structure to the buffer.
+func appendSized(buf []byte, data []byte) []byte {
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(data)))
+	return append(buf, data...)
+}
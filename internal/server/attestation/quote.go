@@ -0,0 +1,136 @@
+package attestation
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// TPM attestation structure identifiers.
+const (
+	attestMagic     = 0xff544347
+	attestTypeQuote = 0x8018
+)
+
+// VerifyQuote checks that a quote was signed by the attestation key, includes the nonce and covers the
+// provided SHA256 PCR values.
+func VerifyQuote(ak *AttestationKey, quote []byte, signature []byte, nonce []byte, pcrs map[int][]byte) error {
+	// Check the signature.
+	h := ak.SigningHash.New()
+	_, _ = h.Write(quote)
+
+	err := rsa.VerifyPKCS1v15(ak.PublicKey, ak.SigningHash, h.Sum(nil), signature)
+	if err != nil {
+		return fmt.Errorf("Invalid quote signature: %w", err)
+	}
+
+	// Parse the attestation structure.
+	r := bytes.NewReader(quote)
+
+	var header struct {
+		Magic uint32
+		Type  uint16
+	}
+
+	err = binary.Read(r, binary.BigEndian, &header)
+	if err != nil {
+		return fmt.Errorf("Failed to read quote header: %w", err)
+	}
+
+	if header.Magic != attestMagic || header.Type != attestTypeQuote {
+		return errors.New("Attestation structure isn't a TPM quote")
+	}
+
+	// Skip the qualified signer.
+	_, err = readSized(r)
+	if err != nil {
+		return fmt.Errorf("Failed to read quote signer: %w", err)
+	}
+
+	extraData, err := readSized(r)
+	if err != nil {
+		return fmt.Errorf("Failed to read quote nonce: %w", err)
+	}
+
+	if subtle.ConstantTimeCompare(extraData, nonce) != 1 {
+		return errors.New("Quote nonce doesn't match")
+	}
+
+	// Skip the clock information (clock, reset count, restart count, safe) and firmware version.
+	_, err = r.Seek(8+4+4+1+8, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	// Parse the PCR selection.
+	var count uint32
+	err = binary.Read(r, binary.BigEndian, &count)
+	if err != nil {
+		return fmt.Errorf("Failed to read PCR selection: %w", err)
+	}
+
+	selected := []int{}
+	for i := uint32(0); i < count; i++ {
+		var bank struct {
+			Hash uint16
+			Size uint8
+		}
+
+		err = binary.Read(r, binary.BigEndian, &bank)
+		if err != nil {
+			return fmt.Errorf("Failed to read PCR selection: %w", err)
+		}
+
+		bitmap := make([]byte, bank.Size)
+		_, err = io.ReadFull(r, bitmap)
+		if err != nil {
+			return fmt.Errorf("Failed to read PCR selection: %w", err)
+		}
+
+		if bank.Hash != algSHA256 {
+			return fmt.Errorf("Unexpected PCR bank 0x%04x in quote", bank.Hash)
+		}
+
+		for byteIndex, b := range bitmap {
+			for bit := 0; bit < 8; bit++ {
+				if b&(1<<bit) != 0 {
+					selected = append(selected, byteIndex*8+bit)
+				}
+			}
+		}
+	}
+
+	pcrDigest, err := readSized(r)
+	if err != nil {
+		return fmt.Errorf("Failed to read PCR digest: %w", err)
+	}
+
+	// Check that the quote covers exactly the provided PCRs.
+	indexes := make([]int, 0, len(pcrs))
+	for index := range pcrs {
+		indexes = append(indexes, index)
+	}
+
+	slices.Sort(indexes)
+
+	if !slices.Equal(indexes, selected) {
+		return fmt.Errorf("Quote covers PCRs %v instead of %v", selected, indexes)
+	}
+
+	// Check that the PCR values match the quoted digest.
+	digest := ak.SigningHash.New()
+	for _, index := range indexes {
+		_, _ = digest.Write(pcrs[index])
+	}
+
+	if !bytes.Equal(digest.Sum(nil), pcrDigest) {
+		return errors.New("PCR values don't match the quoted digest")
+	}
+
+	return nil
+}
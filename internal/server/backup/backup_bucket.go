@@ -50,6 +50,11 @@ func (b *BucketBackup) Delete() error {
 		}
 	}
 
+	err := removeDetachedManifest(backupPath)
+	if err != nil {
+		return err
+	}
+
 	// Check if we can remove the bucket directory.
 	backupsPath := internalUtil.VarPath("backups", "buckets", b.poolName, project.StorageBucket(b.projectName, b.bucketName))
 	empty, _ := internalUtil.PathIsEmpty(backupsPath)
@@ -61,7 +66,7 @@ func (b *BucketBackup) Delete() error {
 	}
 
 	// Remove the database record.
-	err := b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
 		return tx.DeleteStoragePoolBucketBackup(ctx, b.name)
 	})
 	if err != nil {
@@ -101,6 +106,11 @@ func (b *BucketBackup) Rename(newName string) error {
 		return err
 	}
 
+	err = renameDetachedManifest(oldBackupPath, newBackupPath)
+	if err != nil {
+		return err
+	}
+
 	reverter.Add(func() { _ = os.Rename(newBackupPath, oldBackupPath) })
 
 	// Check if we can remove the old parent directory.
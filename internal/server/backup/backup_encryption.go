@@ -0,0 +1,136 @@
+package backup
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/lxc/incus/v6/internal/backupmanifest"
+	"github.com/lxc/incus/v6/shared/archive"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+var (
+	ageRecipientRegex     = regexp.MustCompile(`^age1[02-9ac-hj-np-z]{58}$`)
+	openPGPRecipientRegex = regexp.MustCompile(`^([0-9A-Fa-f]{40}|[0-9A-Fa-f]{64})$`)
+)
+
+// EncryptionType returns the type of encryption (age or OpenPGP) to use for the provided recipients.
+// Age recipients are either native age public keys or SSH public keys, OpenPGP recipients are key
+// fingerprints. All recipients must be of the same type.
+func EncryptionType(recipients []string) (string, error) {
+	encryption := ""
+
+	for _, recipient := range recipients {
+		var recipientType string
+
+		if ageRecipientRegex.MatchString(recipient) || strings.HasPrefix(recipient, "ssh-") {
+			recipientType = archive.EncryptionAge
+		} else if openPGPRecipientRegex.MatchString(recipient) {
+			recipientType = archive.EncryptionOpenPGP
+		} else {
+			return "", fmt.Errorf("Invalid encryption recipient %q", recipient)
+		}
+
+		if encryption != "" && encryption != recipientType {
+			return "", fmt.Errorf("Encryption recipients can't mix age and OpenPGP keys")
+		}
+
+		encryption = recipientType
+	}
+
+	return encryption, nil
+}
+
+// ValidateEncryptionRecipients validates a comma separated list of encryption recipients.
+// The availability of the encryption tool is only checked when creating backups, as it may differ between servers.
+func ValidateEncryptionRecipients(value string) error {
+	if value == "" {
+		return nil
+	}
+
+	_, err := EncryptionType(util.SplitNTrimSpace(value, ",", -1, true))
+	return err
+}
+
+// CheckEncryption validates the recipients and checks that the tool needed to encrypt to them is available.
+func CheckEncryption(recipients []string) error {
+	encryption, err := EncryptionType(recipients)
+	if err != nil {
+		return err
+	}
+
+	_, err = exec.LookPath(encryptionCommand(encryption))
+	if err != nil {
+		return fmt.Errorf("Required tool %q is missing", encryptionCommand(encryption))
+	}
+
+	return nil
+}
+
+// encryptionCommand returns the command used for the encryption type.
+func encryptionCommand(encryption string) string {
+	if encryption == archive.EncryptionOpenPGP {
+		return "gpg"
+	}
+
+	return "age"
+}
+
+// Encrypt encrypts the content of infile to the provided recipients and writes the result to outfile.
+// OpenPGP recipients must be present in the keyring of the user running the daemon.
+func Encrypt(recipients []string, infile io.Reader, outfile io.Writer) error {
+	encryption, err := EncryptionType(recipients)
+	if err != nil {
+		return err
+	}
+
+	args := []string{"--encrypt"}
+	if encryption == archive.EncryptionOpenPGP {
+		args = []string{"--batch", "--no-tty", "--trust-model", "always", "--encrypt"}
+	}
+
+	for _, recipient := range recipients {
+		args = append(args, "--recipient", recipient)
+	}
+
+	var stderr bytes.Buffer
+
+	cmd := exec.Command(encryptionCommand(encryption), args...)
+	cmd.Stdin = infile
+	cmd.Stdout = outfile
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+	if err != nil {
+		return fmt.Errorf("Failed encrypting backup: %w (%s)", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// renameDetachedManifest moves the detached manifest of an encrypted backup along with the backup.
+func renameDetachedManifest(oldBackupPath string, newBackupPath string) error {
+	err := os.Rename(oldBackupPath+backupmanifest.DetachedSuffix, newBackupPath+backupmanifest.DetachedSuffix)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// removeDetachedManifest removes the detached manifest of an encrypted backup.
+func removeDetachedManifest(backupPath string) error {
+	err := os.Remove(backupPath + backupmanifest.DetachedSuffix)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
@@ -85,6 +85,11 @@ func (b *InstanceBackup) Rename(newName string) error {
 		return err
 	}
 
+	err = renameDetachedManifest(oldBackupPath, newBackupPath)
+	if err != nil {
+		return err
+	}
+
 	// Check if we can remove the old parent directory.
 	empty, _ := internalUtil.PathIsEmpty(oldParentBackupsPath)
 	if empty {
@@ -120,6 +125,11 @@ func (b *InstanceBackup) Delete() error {
 		}
 	}
 
+	err := removeDetachedManifest(backupPath)
+	if err != nil {
+		return err
+	}
+
 	// Check if we can remove the instance directory.
 	backupsPath := internalUtil.VarPath("backups", "instances", project.Instance(b.instance.Project().Name, b.instance.Name()))
 	empty, _ := internalUtil.PathIsEmpty(backupsPath)
@@ -131,7 +141,7 @@ func (b *InstanceBackup) Delete() error {
 	}
 
 	// Remove the database record.
-	err := b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
 		return tx.DeleteInstanceBackup(ctx, b.name)
 	})
 	if err != nil {
@@ -17,6 +17,20 @@ func TarReader(r io.ReadSeeker, sysOS *sys.OS, outputPath string) (*tar.Reader,
 		return nil, nil, err
 	}
 
+	encryption, err := archive.DetectEncryptionFile(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if encryption != "" {
+		return nil, nil, fmt.Errorf("Backup is encrypted using %s and must be decrypted first", encryption)
+	}
+
+	_, err = r.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	_, _, unpacker, err := archive.DetectCompressionFile(r)
 	if err != nil {
 		return nil, nil, err
@@ -83,6 +83,11 @@ func (b *VolumeBackup) Rename(newName string) error {
 		return err
 	}
 
+	err = renameDetachedManifest(oldBackupPath, newBackupPath)
+	if err != nil {
+		return err
+	}
+
 	reverter.Add(func() { _ = os.Rename(newBackupPath, oldBackupPath) })
 
 	// Check if we can remove the old parent directory.
@@ -117,6 +122,11 @@ func (b *VolumeBackup) Delete() error {
 		}
 	}
 
+	err := removeDetachedManifest(backupPath)
+	if err != nil {
+		return err
+	}
+
 	// Check if we can remove the volume directory.
 	backupsPath := internalUtil.VarPath("backups", "custom", b.poolName, project.StorageVolume(b.projectName, b.volumeName))
 	empty, _ := internalUtil.PathIsEmpty(backupsPath)
@@ -128,7 +138,7 @@ func (b *VolumeBackup) Delete() error {
 	}
 
 	// Remove the database record.
-	err := b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+	err = b.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
 		return tx.DeleteStoragePoolVolumeBackup(ctx, b.name)
 	})
 	if err != nil {
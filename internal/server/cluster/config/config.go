@@ -479,6 +479,7 @@ var ConfigSchema = config.Schema{
 	// Possible values are `DNS-01` and `HTTP-01`.
 	// ---
 	//  type: string
+	//  allowedvalues: DNS-01, HTTP-01
 	//  scope: global
 	//  defaultdesc: `HTTP-01`
 	//  shortdesc: ACME challenge type to use
@@ -810,6 +811,7 @@ var ConfigSchema = config.Schema{
 	// If set to `mac`, generate a host name in the form `inc<mac_address>` (MAC without leading two digits).
 	// ---
 	//  type: string
+	//  allowedvalues: random, mac
 	//  scope: global
 	//  defaultdesc: `random`
 	//  shortdesc: How to set the host name for a NIC
@@ -829,6 +831,7 @@ var ConfigSchema = config.Schema{
 	// ---
 	//  type: string
 	//  scope: global
+	//  deprecated: Use 'logging.*.target.username' instead
 	//  shortdesc: User name used for Loki authentication
 	"loki.auth.username": {Deprecated: "Use 'logging.*.target.username' instead"},
 
@@ -837,6 +840,7 @@ var ConfigSchema = config.Schema{
 	// ---
 	//  type: string
 	//  scope: global
+	//  deprecated: Use 'logging.*.target.password' instead
 	//  shortdesc: Password used for Loki authentication
 	"loki.auth.password": {Deprecated: "Use 'logging.*.target.password' instead"},
 
@@ -845,6 +849,7 @@ var ConfigSchema = config.Schema{
 	// ---
 	//  type: string
 	//  scope: global
+	//  deprecated: Use 'logging.*.target.ca_cert' instead
 	//  shortdesc: CA certificate for the Loki server
 	"loki.api.ca_cert": {Deprecated: "Use 'logging.*.target.ca_cert' instead"},
 
@@ -853,6 +858,7 @@ var ConfigSchema = config.Schema{
 	// ---
 	//  type: string
 	//  scope: global
+	//  deprecated: Use 'logging.*.target.address' instead
 	//  shortdesc: URL to the Loki server
 	"loki.api.url": {Deprecated: "Use 'logging.*.target.address' instead"},
 
@@ -861,6 +867,7 @@ var ConfigSchema = config.Schema{
 	// ---
 	//  type: string
 	//  scope: global
+	//  deprecated: Use 'logging.*.target.instance' instead
 	//  defaultdesc: Local server host name or cluster member name
 	//  shortdesc: Name to use as the instance field in Loki events.
 	"loki.instance": {Deprecated: "Use 'logging.*.target.instance' instead"},
@@ -870,6 +877,7 @@ var ConfigSchema = config.Schema{
 	// ---
 	//  type: string
 	//  scope: global
+	//  deprecated: Use 'logging.*.target.labels' instead
 	//  shortdesc: Labels for a Loki log entry
 	"loki.labels": {Deprecated: "Use 'logging.*.target.labels' instead"},
 
@@ -878,6 +886,7 @@ var ConfigSchema = config.Schema{
 	// ---
 	//  type: string
 	//  scope: global
+	//  deprecated: Use 'logging.*.logging.level' instead
 	//  defaultdesc: `info`
 	//  shortdesc: Minimum log level to send to the Loki server
 	"loki.loglevel": {Validator: config.LogLevelValidator, Default: logrus.InfoLevel.String(), Deprecated: "Use 'logging.*.logging.level' instead"},
@@ -888,6 +897,7 @@ var ConfigSchema = config.Schema{
 	// ---
 	//  type: string
 	//  scope: global
+	//  deprecated: Use 'logging.*.types' instead
 	//  defaultdesc: `lifecycle,logging`
 	//  shortdesc: Events to send to the Loki server
 	"loki.types": {Validator: validate.Optional(validate.IsListOf(validate.IsOneOf("lifecycle", "logging", "network-acl"))), Default: "lifecycle,logging", Deprecated: "Use 'logging.*.types' instead"},
@@ -0,0 +1,313 @@
+package enrollment
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+	"gopkg.in/yaml.v2"
+
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// ApprovalAuto lets servers matching the policy join without any intervention.
+const ApprovalAuto = "auto"
+
+// ApprovalManual requires enrollments to be approved before the server can join.
+const ApprovalManual = "manual"
+
+// secretHashPrefix identifies hashed enrollment secrets.
+const secretHashPrefix = "scrypt:"
+
+// maxSecretChecks is the maximum number of hashed secrets being checked at once, as hashing a secret
+// uses about 16MiB of memory and a noticeable amount of CPU time.
+const maxSecretChecks = 4
+
+// secretChecks holds a slot for each hashed secret being checked.
+var secretChecks = make(chan struct{}, maxSecretChecks)
+
+// ErrTooManyRequests is returned when too many enrollment requests are being checked at once.
+var ErrTooManyRequests = errors.New("Too many concurrent enrollment requests")
+
+// Policy represents the enrollment policy of a cluster group.
+type Policy struct {
+	// Shared secret the enrolling servers must provide (usually hashed through HashSecret).
+	Secret string
+
+	// CA which must have issued the certificate of the enrolling servers.
+	TrustedCA *x509.CertPool
+
+	// Patterns the name of the enrolling servers must match.
+	Hostnames []string
+
+	// Subnets the enrollment requests must originate from.
+	Subnets []*net.IPNet
+
+	// Whether enrollments need to be approved.
+	Approval string
+
+	// Member configuration applied to the enrolling servers.
+	MemberConfig []api.ClusterMemberConfigKey
+}
+
+// ParsePolicy returns the enrollment policy described by the cluster group configuration.
+// Nil is returned when enrollment isn't enabled on the group.
+func ParsePolicy(config map[string]string) (*Policy, error) {
+	if config["enrollment.secret"] == "" && config["enrollment.trusted_ca"] == "" {
+		return nil, nil
+	}
+
+	policy := &Policy{
+		Secret:    config["enrollment.secret"],
+		Hostnames: util.SplitNTrimSpace(config["enrollment.hostnames"], ",", -1, true),
+		Approval:  config["enrollment.approval"],
+	}
+
+	if policy.Approval == "" {
+		policy.Approval = ApprovalAuto
+	}
+
+	if config["enrollment.trusted_ca"] != "" {
+		certs, err := parseCertificates(config["enrollment.trusted_ca"])
+		if err != nil {
+			return nil, err
+		}
+
+		policy.TrustedCA = x509.NewCertPool()
+		for _, cert := range certs {
+			policy.TrustedCA.AddCert(cert)
+		}
+	}
+
+	for _, subnet := range util.SplitNTrimSpace(config["enrollment.subnets"], ",", -1, true) {
+		_, ipNet, err := net.ParseCIDR(subnet)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid enrollment subnet %q: %w", subnet, err)
+		}
+
+		policy.Subnets = append(policy.Subnets, ipNet)
+	}
+
+	memberConfig, err := parseMemberConfig(config["enrollment.member_config"])
+	if err != nil {
+		return nil, err
+	}
+
+	policy.MemberConfig = memberConfig
+
+	return policy, nil
+}
+
+// Check verifies that an enrollment request is allowed by the policy.
+// The request must be authenticated through either the secret or a certificate issued by the trusted CA.
+// The server name and source address are checked first, so that the secret is only hashed for allowed requests.
+func (p *Policy) Check(serverName string, secret string, cert *x509.Certificate, address net.IP) error {
+	if len(p.Hostnames) > 0 {
+		matched := false
+		for _, pattern := range p.Hostnames {
+			ok, _ := filepath.Match(pattern, serverName)
+			if ok {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return fmt.Errorf("Server name %q isn't allowed to enroll", serverName)
+		}
+	}
+
+	if len(p.Subnets) > 0 {
+		matched := false
+		for _, subnet := range p.Subnets {
+			if address != nil && subnet.Contains(address) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return fmt.Errorf("Address %q isn't allowed to enroll", address)
+		}
+	}
+
+	if p.TrustedCA != nil && cert != nil {
+		// The certificate must be meant for TLS authentication and issued to the enrolling server.
+		_, err := cert.Verify(x509.VerifyOptions{
+			Roots:     p.TrustedCA,
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		})
+		if err == nil && certificateMatchesName(cert, serverName) {
+			return nil
+		}
+	}
+
+	if p.Secret != "" && secret != "" {
+		ok, err := checkSecret(p.Secret, secret)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			return nil
+		}
+	}
+
+	return errors.New("Enrollment request couldn't be authenticated")
+}
+
+// HashSecret returns the hashed form of an enrollment secret, as stored in the cluster group configuration.
+// Secrets which are already hashed are returned unchanged.
+func HashSecret(secret string) (string, error) {
+	if secret == "" || strings.HasPrefix(secret, secretHashPrefix) {
+		return secret, nil
+	}
+
+	salt := make([]byte, 32)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", fmt.Errorf("Failed generating salt: %w", err)
+	}
+
+	hash, err := scrypt.Key([]byte(secret), salt, 1<<14, 8, 1, 64)
+	if err != nil {
+		return "", fmt.Errorf("Failed hashing secret: %w", err)
+	}
+
+	return secretHashPrefix + hex.EncodeToString(salt) + ":" + hex.EncodeToString(hash), nil
+}
+
+// checkSecret compares the provided secret with the expected one (hashed or not).
+// ErrTooManyRequests is returned when too many hashed secrets are already being checked.
+func checkSecret(expected string, secret string) (bool, error) {
+	if !strings.HasPrefix(expected, secretHashPrefix) {
+		return subtle.ConstantTimeCompare([]byte(expected), []byte(secret)) == 1, nil
+	}
+
+	saltHex, hashHex, ok := strings.Cut(strings.TrimPrefix(expected, secretHashPrefix), ":")
+	if !ok {
+		return false, nil
+	}
+
+	salt, err := hex.DecodeString(saltHex)
+	if err != nil {
+		return false, nil
+	}
+
+	expectedHash, err := hex.DecodeString(hashHex)
+	if err != nil {
+		return false, nil
+	}
+
+	select {
+	case secretChecks <- struct{}{}:
+		defer func() { <-secretChecks }()
+	default:
+		return false, ErrTooManyRequests
+	}
+
+	hash, err := scrypt.Key([]byte(secret), salt, 1<<14, 8, 1, len(expectedHash))
+	if err != nil {
+		return false, nil
+	}
+
+	return subtle.ConstantTimeCompare(expectedHash, hash) == 1, nil
+}
+
+// certificateMatchesName checks whether the certificate was issued to the given server name,
+// either through its subject alternative names or its common name.
+func certificateMatchesName(cert *x509.Certificate, serverName string) bool {
+	if strings.EqualFold(cert.Subject.CommonName, serverName) {
+		return true
+	}
+
+	return slices.ContainsFunc(cert.DNSNames, func(name string) bool { return strings.EqualFold(name, serverName) })
+}
+
+// ValidateTrustedCA validates a list of PEM encoded CA certificates.
+func ValidateTrustedCA(value string) error {
+	_, err := parseCertificates(value)
+	return err
+}
+
+// ValidateHostname validates a server name pattern.
+func ValidateHostname(value string) error {
+	_, err := filepath.Match(value, "")
+	if err != nil {
+		return fmt.Errorf("Invalid server name pattern %q: %w", value, err)
+	}
+
+	return nil
+}
+
+// ValidateMemberConfig validates a member configuration template.
+func ValidateMemberConfig(value string) error {
+	_, err := parseMemberConfig(value)
+	return err
+}
+
+// parseCertificates parses a list of PEM encoded certificates.
+func parseCertificates(value string) ([]*x509.Certificate, error) {
+	certs := []*x509.Certificate{}
+
+	rest := []byte(value)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid CA certificate: %w", err)
+		}
+
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, errors.New("No CA certificate found")
+	}
+
+	return certs, nil
+}
+
+// parseMemberConfig parses a YAML list of member configuration keys (same format as in preseed files).
+func parseMemberConfig(value string) ([]api.ClusterMemberConfigKey, error) {
+	memberConfig := []api.ClusterMemberConfigKey{}
+	if strings.TrimSpace(value) == "" {
+		return memberConfig, nil
+	}
+
+	err := yaml.Unmarshal([]byte(value), &memberConfig)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid member configuration: %w", err)
+	}
+
+	for _, key := range memberConfig {
+		if key.Entity != "storage-pool" && key.Entity != "network" {
+			return nil, fmt.Errorf("Invalid member configuration entity %q", key.Entity)
+		}
+
+		if key.Name == "" || key.Key == "" {
+			return nil, errors.New("Member configuration entries require a name and a key")
+		}
+	}
+
+	return memberConfig, nil
+}
@@ -0,0 +1,53 @@
+package enrollment
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Only a limited number of hashed secrets get checked at once, and only for requests from allowed servers.
+func TestPolicyCheck_SecretLimit(t *testing.T) {
+	hash, err := HashSecret("foo")
+	require.NoError(t, err)
+
+	policy, err := ParsePolicy(map[string]string{
+		"enrollment.secret":    hash,
+		"enrollment.hostnames": "node*",
+		"enrollment.subnets":   "10.0.0.0/24",
+	})
+	require.NoError(t, err)
+
+	address := net.ParseIP("10.0.0.10")
+
+	// Take all the slots.
+	for range maxSecretChecks {
+		secretChecks <- struct{}{}
+	}
+
+	// Requests which aren't allowed get rejected without checking the secret.
+	err = policy.Check("other", "foo", nil, address)
+	assert.ErrorContains(t, err, "isn't allowed to enroll")
+
+	err = policy.Check("node1", "foo", nil, net.ParseIP("10.0.1.10"))
+	assert.ErrorContains(t, err, "isn't allowed to enroll")
+
+	// Allowed requests need a slot to get their secret checked.
+	err = policy.Check("node1", "foo", nil, address)
+	assert.ErrorIs(t, err, ErrTooManyRequests)
+
+	// Unhashed secrets are cheap to compare and don't need a slot.
+	plainPolicy, err := ParsePolicy(map[string]string{"enrollment.secret": "foo"})
+	require.NoError(t, err)
+	assert.NoError(t, plainPolicy.Check("node1", "foo", nil, address))
+
+	// Release the slots.
+	for range maxSecretChecks {
+		<-secretChecks
+	}
+
+	assert.NoError(t, policy.Check("node1", "foo", nil, address))
+	assert.Empty(t, secretChecks)
+}
@@ -0,0 +1,160 @@
+package enrollment_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/internal/server/cluster/enrollment"
+)
+
+// Generate a CA certificate and a server certificate (for "node1") signed by it.
+func generateCertificates(t *testing.T, usages ...x509.ExtKeyUsage) (string, *x509.Certificate) {
+	if len(usages) == 0 {
+		usages = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	}
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	serverKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serverTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "node1"},
+		DNSNames:     []string{"node1.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  usages,
+	}
+
+	serverDER, err := x509.CreateCertificate(rand.Reader, serverTemplate, caCert, &serverKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	serverCert, err := x509.ParseCertificate(serverDER)
+	require.NoError(t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})), serverCert
+}
+
+// Enrollment is disabled unless a secret or a trusted CA is set.
+func TestParsePolicy_Disabled(t *testing.T) {
+	policy, err := enrollment.ParsePolicy(map[string]string{"enrollment.hostnames": "node*"})
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+}
+
+// The approval mode defaults to automatic.
+func TestParsePolicy_Defaults(t *testing.T) {
+	policy, err := enrollment.ParsePolicy(map[string]string{"enrollment.secret": "foo"})
+	require.NoError(t, err)
+	require.NotNil(t, policy)
+	assert.Equal(t, enrollment.ApprovalAuto, policy.Approval)
+	assert.Empty(t, policy.MemberConfig)
+}
+
+// Invalid keys are reported.
+func TestParsePolicy_Invalid(t *testing.T) {
+	cases := []map[string]string{
+		{"enrollment.secret": "foo", "enrollment.subnets": "10.0.0.0"},
+		{"enrollment.trusted_ca": "garbage"},
+		{"enrollment.secret": "foo", "enrollment.member_config": "- entity: instance\n  name: foo\n  key: bar"},
+	}
+
+	for _, config := range cases {
+		_, err := enrollment.ParsePolicy(config)
+		assert.Error(t, err)
+	}
+}
+
+// Requests are checked against the secret, server name and source address.
+func TestPolicyCheck_Secret(t *testing.T) {
+	policy, err := enrollment.ParsePolicy(map[string]string{
+		"enrollment.secret":    "foo",
+		"enrollment.hostnames": "node*, edge-??",
+		"enrollment.subnets":   "10.0.0.0/24",
+	})
+	require.NoError(t, err)
+
+	address := net.ParseIP("10.0.0.10")
+
+	assert.NoError(t, policy.Check("node1", "foo", nil, address))
+	assert.NoError(t, policy.Check("edge-01", "foo", nil, address))
+	assert.Error(t, policy.Check("node1", "bar", nil, address))
+	assert.Error(t, policy.Check("node1", "", nil, address))
+	assert.Error(t, policy.Check("other", "foo", nil, address))
+	assert.Error(t, policy.Check("node1", "foo", nil, net.ParseIP("10.0.1.10")))
+}
+
+// Requests can be authenticated through a certificate issued by the trusted CA.
+func TestPolicyCheck_TrustedCA(t *testing.T) {
+	caPEM, serverCert := generateCertificates(t)
+	_, otherCert := generateCertificates(t)
+
+	policy, err := enrollment.ParsePolicy(map[string]string{"enrollment.trusted_ca": caPEM})
+	require.NoError(t, err)
+
+	assert.NoError(t, policy.Check("node1", "", serverCert, nil))
+	assert.NoError(t, policy.Check("node1.example.com", "", serverCert, nil))
+	assert.Error(t, policy.Check("node1", "", otherCert, nil))
+	assert.Error(t, policy.Check("node1", "", nil, nil))
+
+	// The certificate must have been issued to the enrolling server.
+	assert.Error(t, policy.Check("node2", "", serverCert, nil))
+}
+
+// Certificates not meant for TLS authentication are rejected.
+func TestPolicyCheck_TrustedCAKeyUsage(t *testing.T) {
+	caPEM, serverCert := generateCertificates(t, x509.ExtKeyUsageCodeSigning)
+
+	policy, err := enrollment.ParsePolicy(map[string]string{"enrollment.trusted_ca": caPEM})
+	require.NoError(t, err)
+
+	assert.Error(t, policy.Check("node1", "", serverCert, nil))
+}
+
+// Secrets are stored hashed.
+func TestHashSecret(t *testing.T) {
+	hash, err := enrollment.HashSecret("foo")
+	require.NoError(t, err)
+	assert.NotContains(t, hash, "foo")
+
+	// Hashing is idempotent, allowing the configuration to be written back.
+	rehashed, err := enrollment.HashSecret(hash)
+	require.NoError(t, err)
+	assert.Equal(t, hash, rehashed)
+
+	policy, err := enrollment.ParsePolicy(map[string]string{"enrollment.secret": hash})
+	require.NoError(t, err)
+
+	assert.NoError(t, policy.Check("node1", "foo", nil, nil))
+	assert.Error(t, policy.Check("node1", "bar", nil, nil))
+	assert.Error(t, policy.Check("node1", hash, nil, nil))
+}
@@ -25,6 +25,7 @@ type InstanceBackup struct {
 	InstanceOnly         bool
 	OptimizedStorage     bool
 	CompressionAlgorithm string
+	EncryptionRecipients []string
 }
 
 // StoragePoolVolumeBackup is a value object holding all db-related details about a storage volume backup.
@@ -37,6 +38,7 @@ type StoragePoolVolumeBackup struct {
 	VolumeOnly           bool
 	OptimizedStorage     bool
 	CompressionAlgorithm string
+	EncryptionRecipients []string
 }
 
 // StoragePoolBucketBackup is a value object holding all db-related details about a storage bucket backup.
@@ -47,6 +49,7 @@ type StoragePoolBucketBackup struct {
 	CreationDate         time.Time
 	ExpiryDate           time.Time
 	CompressionAlgorithm string
+	EncryptionRecipients []string
 }
 
 // Returns the ID of the instance backup with the given name.
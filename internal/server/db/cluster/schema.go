@@ -103,6 +103,7 @@ CREATE TABLE "images_source" (
     protocol INTEGER NOT NULL,
     certificate TEXT NOT NULL,
     alias TEXT NOT NULL,
+    registry_auth TEXT NOT NULL DEFAULT "",
     FOREIGN KEY (image_id) REFERENCES "images" (id) ON DELETE CASCADE
 );
 CREATE TABLE "instances" (
@@ -285,6 +286,16 @@ CREATE TABLE "networks_forwards_config" (
     UNIQUE (network_forward_id, key),
     FOREIGN KEY (network_forward_id) REFERENCES "networks_forwards" (id) ON DELETE CASCADE
 );
+CREATE TABLE "networks_gateway_leases" (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    network_id INTEGER NOT NULL,
+    node_id INTEGER NOT NULL,
+    term INTEGER NOT NULL,
+    expiry DATETIME NOT NULL,
+    UNIQUE (network_id),
+    FOREIGN KEY (network_id) REFERENCES "networks" (id) ON DELETE CASCADE,
+    FOREIGN KEY (node_id) REFERENCES "nodes" (id) ON DELETE CASCADE
+);
 CREATE TABLE networks_integrations (
     id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
     name TEXT NOT NULL,
@@ -674,5 +685,5 @@ CREATE TABLE "warnings" (
 );
 CREATE UNIQUE INDEX warnings_unique_node_id_project_id_entity_type_code_entity_id_type_code ON warnings(IFNULL(node_id, -1), IFNULL(project_id, -1), entity_type_code, entity_id, type_code);
 
-INSERT INTO schema (version, updated_at) VALUES (76, strftime("%s"))
+INSERT INTO schema (version, updated_at) VALUES (78, strftime("%s"))
 `
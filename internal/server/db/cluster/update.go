@@ -113,6 +113,40 @@ var updates = map[int]schema.Update{
 	74: updateFromV73,
 	75: updateFromV74,
 	76: updateFromV75,
+	77: updateFromV76,
+	78: updateFromV77,
+}
+
+// updateFromV77 adds the credentials used to refresh images from authenticated OCI registries.
+func updateFromV77(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE images_source ADD COLUMN registry_auth TEXT NOT NULL DEFAULT "";`)
+	if err != nil {
+		return fmt.Errorf("Failed adding registry_auth column to images_source table: %w", err)
+	}
+
+	return nil
+}
+
+// updateFromV76 adds the table recording which member holds the gateway of highly available bridge networks.
+func updateFromV76(ctx context.Context, tx *sql.Tx) error {
+	q := `
+CREATE TABLE "networks_gateway_leases" (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    network_id INTEGER NOT NULL,
+    node_id INTEGER NOT NULL,
+    term INTEGER NOT NULL,
+    expiry DATETIME NOT NULL,
+    UNIQUE (network_id),
+    FOREIGN KEY (network_id) REFERENCES "networks" (id) ON DELETE CASCADE,
+    FOREIGN KEY (node_id) REFERENCES "nodes" (id) ON DELETE CASCADE
+);
+`
+	_, err := tx.Exec(q)
+	if err != nil {
+		return fmt.Errorf("Failed creating networks_gateway_leases table: %w", err)
+	}
+
+	return nil
 }
 
 func updateFromV75(ctx context.Context, tx *sql.Tx) error {
@@ -279,7 +279,7 @@ func (s *dbTestSuite) Test_GetCachedImageSourceFingerprint() {
 		imageID, _, err := tx.GetImage(ctx, "fingerprint", cluster.ImageFilter{Project: &project})
 		s.Nil(err)
 
-		err = tx.CreateImageSource(ctx, imageID, "server.remote", "simplestreams", "", "test")
+		err = tx.CreateImageSource(ctx, imageID, "server.remote", "simplestreams", "", "test", nil)
 		s.Nil(err)
 
 		fingerprint, err := tx.GetCachedImageSourceFingerprint(ctx, "server.remote", "simplestreams", "test", "container", 0)
@@ -296,7 +296,7 @@ func (s *dbTestSuite) Test_GetCachedImageSourceFingerprint_no_match() {
 		imageID, _, err := tx.GetImage(ctx, "fingerprint", cluster.ImageFilter{Project: &project})
 		s.Nil(err)
 
-		err = tx.CreateImageSource(ctx, imageID, "server.remote", "simplestreams", "", "test")
+		err = tx.CreateImageSource(ctx, imageID, "server.remote", "simplestreams", "", "test", nil)
 		s.Nil(err)
 
 		_, err = tx.GetCachedImageSourceFingerprint(ctx, "server.remote", "incus", "test", "container", 0)
@@ -304,3 +304,29 @@ func (s *dbTestSuite) Test_GetCachedImageSourceFingerprint_no_match() {
 		return nil
 	})
 }
+
+func (s *dbTestSuite) Test_GetImageSourceRegistryAuth() {
+	project := "default"
+
+	_ = s.db.Transaction(context.TODO(), func(ctx context.Context, tx *ClusterTx) error {
+		imageID, _, err := tx.GetImage(ctx, "fingerprint", cluster.ImageFilter{Project: &project})
+		s.Nil(err)
+
+		_, err = tx.GetImageSourceRegistryAuth(ctx, imageID)
+		s.True(api.StatusErrorCheck(err, http.StatusNotFound))
+
+		registryAuth := &api.ImageRegistryAuth{Username: "user", Password: "secret", Mirrors: []string{"https://mirror.example.com"}}
+		err = tx.CreateImageSource(ctx, imageID, "https://registry.example.com", "oci", "", "app", registryAuth)
+		s.Nil(err)
+
+		auth, err := tx.GetImageSourceRegistryAuth(ctx, imageID)
+		s.Nil(err)
+		s.Equal(registryAuth, auth)
+
+		// The credentials aren't exposed through the image source.
+		_, source, err := tx.GetImageSource(ctx, imageID)
+		s.Nil(err)
+		s.Equal("app", source.Alias)
+		return nil
+	})
+}
@@ -5,6 +5,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -210,8 +211,35 @@ SELECT fingerprint
 	return fingerprints, nil
 }
 
+// GetImageSourceRegistryAuth returns the OCI registry credentials of the source of the image with the given ID, if any.
+func (c *ClusterTx) GetImageSourceRegistryAuth(ctx context.Context, imageID int) (*api.ImageRegistryAuth, error) {
+	q := `SELECT registry_auth FROM images_source WHERE image_id=?`
+
+	values, err := query.SelectStrings(ctx, c.tx, q, imageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(values) == 0 {
+		return nil, api.StatusErrorf(http.StatusNotFound, "Image source not found")
+	}
+
+	if values[0] == "" {
+		return nil, nil
+	}
+
+	auth := api.ImageRegistryAuth{}
+	err = json.Unmarshal([]byte(values[0]), &auth)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing image source registry credentials: %w", err)
+	}
+
+	return &auth, nil
+}
+
 // CreateImageSource inserts a new image source.
-func (c *ClusterTx) CreateImageSource(ctx context.Context, id int, server string, protocol string, certificate string, alias string) error {
+// The OCI registry credentials, if any, are kept so the image can be refreshed from the registry.
+func (c *ClusterTx) CreateImageSource(ctx context.Context, id int, server string, protocol string, certificate string, alias string, registryAuth *api.ImageRegistryAuth) error {
 	protocolInt := -1
 	for protoInt, protoString := range ImageSourceProtocol {
 		if protoString == protocol {
@@ -223,18 +251,30 @@ func (c *ClusterTx) CreateImageSource(ctx context.Context, id int, server string
 		return fmt.Errorf("Invalid protocol: %s", protocol)
 	}
 
+	auth := ""
+	if registryAuth != nil {
+		data, err := json.Marshal(registryAuth)
+		if err != nil {
+			return err
+		}
+
+		auth = string(data)
+	}
+
 	_, err := query.UpsertObject(c.tx, "images_source", []string{
 		"image_id",
 		"server",
 		"protocol",
 		"certificate",
 		"alias",
+		"registry_auth",
 	}, []any{
 		id,
 		server,
 		protocolInt,
 		certificate,
 		alias,
+		auth,
 	})
 
 	return err
@@ -0,0 +1,56 @@
+//go:build linux && cgo && !agent
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// NetworkGatewayLease represents the lease a cluster member holds on the gateway of a highly available network.
+type NetworkGatewayLease struct {
+	NodeID int64
+	Node   string
+	Term   int64
+	Expiry time.Time
+}
+
+// GetNetworkGatewayLease returns the gateway lease of the network, or nil if no member holds it.
+func (c *ClusterTx) GetNetworkGatewayLease(ctx context.Context, networkID int64) (*NetworkGatewayLease, error) {
+	q := `
+SELECT networks_gateway_leases.node_id, nodes.name, networks_gateway_leases.term, networks_gateway_leases.expiry
+  FROM networks_gateway_leases
+  JOIN nodes ON nodes.id = networks_gateway_leases.node_id
+ WHERE networks_gateway_leases.network_id = ?
+`
+
+	lease := NetworkGatewayLease{}
+	err := c.tx.QueryRowContext(ctx, q, networkID).Scan(&lease.NodeID, &lease.Node, &lease.Term, &lease.Expiry)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &lease, nil
+}
+
+// SetNetworkGatewayLease records the gateway lease of the network.
+func (c *ClusterTx) SetNetworkGatewayLease(ctx context.Context, networkID int64, lease NetworkGatewayLease) error {
+	q := "INSERT OR REPLACE INTO networks_gateway_leases (network_id, node_id, term, expiry) VALUES (?, ?, ?, ?)"
+	_, err := c.tx.ExecContext(ctx, q, networkID, lease.NodeID, lease.Term, lease.Expiry.UTC())
+
+	return err
+}
+
+// DeleteNetworkGatewayLease releases the gateway lease of the network if it's held by the given member.
+func (c *ClusterTx) DeleteNetworkGatewayLease(ctx context.Context, networkID int64, nodeID int64) error {
+	q := "DELETE FROM networks_gateway_leases WHERE network_id = ? AND node_id = ?"
+	_, err := c.tx.ExecContext(ctx, q, networkID, nodeID)
+
+	return err
+}
@@ -5,6 +5,7 @@ package db_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -105,3 +106,50 @@ func TestNetworksCreatePending_NonExistingNode(t *testing.T) {
 	err := tx.CreatePendingNetwork(context.Background(), "buzz", api.ProjectDefaultName, "network1", "", db.NetworkTypeBridge, map[string]string{})
 	require.True(t, response.IsNotFoundError(err))
 }
+
+func TestNetworkGatewayLease(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	nodeID, err := tx.CreateNode("buzz", "1.2.3.4:666")
+	require.NoError(t, err)
+
+	networkID, err := tx.CreateNetwork(context.Background(), api.ProjectDefaultName, "network1", "", db.NetworkTypeBridge, nil)
+	require.NoError(t, err)
+
+	// No member holds the gateway initially.
+	lease, err := tx.GetNetworkGatewayLease(context.Background(), networkID)
+	require.NoError(t, err)
+	assert.Nil(t, lease)
+
+	expiry := time.Now().Add(time.Minute).UTC().Truncate(time.Second)
+	err = tx.SetNetworkGatewayLease(context.Background(), networkID, db.NetworkGatewayLease{NodeID: nodeID, Term: 1, Expiry: expiry})
+	require.NoError(t, err)
+
+	// Updating the lease replaces the existing one.
+	err = tx.SetNetworkGatewayLease(context.Background(), networkID, db.NetworkGatewayLease{NodeID: nodeID, Term: 2, Expiry: expiry})
+	require.NoError(t, err)
+
+	lease, err = tx.GetNetworkGatewayLease(context.Background(), networkID)
+	require.NoError(t, err)
+	require.NotNil(t, lease)
+	assert.Equal(t, nodeID, lease.NodeID)
+	assert.Equal(t, "buzz", lease.Node)
+	assert.Equal(t, int64(2), lease.Term)
+	assert.True(t, expiry.Equal(lease.Expiry))
+
+	// Only the holder can release the lease.
+	err = tx.DeleteNetworkGatewayLease(context.Background(), networkID, nodeID+1)
+	require.NoError(t, err)
+
+	lease, err = tx.GetNetworkGatewayLease(context.Background(), networkID)
+	require.NoError(t, err)
+	assert.NotNil(t, lease)
+
+	err = tx.DeleteNetworkGatewayLease(context.Background(), networkID, nodeID)
+	require.NoError(t, err)
+
+	lease, err = tx.GetNetworkGatewayLease(context.Background(), networkID)
+	require.NoError(t, err)
+	assert.Nil(t, lease)
+}
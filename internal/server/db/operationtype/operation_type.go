@@ -79,6 +79,12 @@ const (
 	BucketBackupRemove
 	BucketBackupRename
 	BucketBackupRestore
+	InstanceConvert
+	BackupVerify
+	CustomVolumeBackupVerify
+	BucketBackupVerify
+	InstanceWait
+	ClusterEnrollment
 )
 
 // Description return a human-readable description of the operation type.
@@ -96,6 +102,8 @@ func (t Type) Description() string {
 		return "Restoring backup"
 	case BackupRemove:
 		return "Removing instance backup"
+	case BackupVerify:
+		return "Verifying instance backup"
 	case ConsoleShow:
 		return "Showing console"
 	case InstanceCreate:
@@ -122,6 +130,8 @@ func (t Type) Description() string {
 		return "Restarting instance"
 	case InstanceRebuild:
 		return "Rebuilding instance"
+	case InstanceConvert:
+		return "Converting instance"
 	case CommandExec:
 		return "Executing command"
 	case SnapshotCreate:
@@ -188,6 +198,8 @@ func (t Type) Description() string {
 		return "Renaming custom volume backup"
 	case CustomVolumeBackupRestore:
 		return "Restoring custom volume backup"
+	case CustomVolumeBackupVerify:
+		return "Verifying custom volume backup"
 	case WarningsPruneResolved:
 		return "Pruning resolved warnings"
 	case ClusterMemberEvacuate:
@@ -210,6 +222,12 @@ func (t Type) Description() string {
 		return "Renaming bucket backup"
 	case BucketBackupRestore:
 		return "Restoring bucket backup"
+	case BucketBackupVerify:
+		return "Verifying bucket backup"
+	case InstanceWait:
+		return "Waiting for instance"
+	case ClusterEnrollment:
+		return "Pending cluster enrollment"
 	default:
 		return "Executing operation"
 	}
@@ -226,6 +244,8 @@ func (t Type) Permission() (auth.ObjectType, auth.Entitlement) {
 		return auth.ObjectTypeInstance, auth.EntitlementCanManageBackups
 	case BackupRemove:
 		return auth.ObjectTypeInstance, auth.EntitlementCanManageBackups
+	case BackupVerify:
+		return auth.ObjectTypeInstance, auth.EntitlementCanManageBackups
 	case ConsoleShow:
 		return auth.ObjectTypeInstance, auth.EntitlementCanAccessConsole
 	case InstanceFreeze:
@@ -265,6 +285,10 @@ func (t Type) Permission() (auth.ObjectType, auth.Entitlement) {
 		return auth.ObjectTypeInstance, auth.EntitlementCanEdit
 	case InstanceRebuild:
 		return auth.ObjectTypeInstance, auth.EntitlementCanEdit
+	case InstanceConvert:
+		return auth.ObjectTypeInstance, auth.EntitlementCanEdit
+	case InstanceWait:
+		return auth.ObjectTypeInstance, auth.EntitlementCanView
 	case SnapshotRestore:
 		return auth.ObjectTypeInstance, auth.EntitlementCanEdit
 
@@ -291,6 +315,8 @@ func (t Type) Permission() (auth.ObjectType, auth.Entitlement) {
 		return auth.ObjectTypeStorageVolume, auth.EntitlementCanManageBackups
 	case CustomVolumeBackupRestore:
 		return auth.ObjectTypeStorageVolume, auth.EntitlementCanEdit
+	case CustomVolumeBackupVerify:
+		return auth.ObjectTypeStorageVolume, auth.EntitlementCanManageBackups
 
 	case BucketBackupCreate:
 		return auth.ObjectTypeStorageVolume, auth.EntitlementCanManageBackups
@@ -300,6 +326,8 @@ func (t Type) Permission() (auth.ObjectType, auth.Entitlement) {
 		return auth.ObjectTypeStorageVolume, auth.EntitlementCanManageBackups
 	case BucketBackupRestore:
 		return auth.ObjectTypeStorageVolume, auth.EntitlementCanEdit
+	case BucketBackupVerify:
+		return auth.ObjectTypeStorageVolume, auth.EntitlementCanManageBackups
 	}
 
 	return "", ""
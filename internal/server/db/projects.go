@@ -4,8 +4,10 @@ package db
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/db/query"
 )
 
 // GetProject returns the project with the given key.
@@ -26,3 +28,62 @@ func (db *DB) GetProject(ctx context.Context, projectName string) (*cluster.Proj
 
 	return p, nil
 }
+
+// GetProjectSnapshotsCount returns the number of instance and custom volume snapshots in the project.
+func (c *ClusterTx) GetProjectSnapshotsCount(ctx context.Context, projectName string) (int, error) {
+	instanceSnapshots, err := query.Count(ctx, c.tx, "instances_snapshots JOIN instances ON instances.id = instances_snapshots.instance_id JOIN projects ON projects.id = instances.project_id", "projects.name = ?", projectName)
+	if err != nil {
+		return -1, fmt.Errorf("Failed counting instance snapshots: %w", err)
+	}
+
+	volumeSnapshots, err := query.Count(ctx, c.tx, "storage_volumes_snapshots JOIN storage_volumes ON storage_volumes.id = storage_volumes_snapshots.storage_volume_id JOIN projects ON projects.id = storage_volumes.project_id", "projects.name = ? AND storage_volumes.type = ?", projectName, StoragePoolVolumeTypeCustom)
+	if err != nil {
+		return -1, fmt.Errorf("Failed counting custom volume snapshots: %w", err)
+	}
+
+	return instanceSnapshots + volumeSnapshots, nil
+}
+
+// GetProjectBackupsCount returns the number of instance, custom volume and storage bucket backups in the project.
+func (c *ClusterTx) GetProjectBackupsCount(ctx context.Context, projectName string) (int, error) {
+	instanceBackups, err := query.Count(ctx, c.tx, "instances_backups JOIN instances ON instances.id = instances_backups.instance_id JOIN projects ON projects.id = instances.project_id", "projects.name = ?", projectName)
+	if err != nil {
+		return -1, fmt.Errorf("Failed counting instance backups: %w", err)
+	}
+
+	volumeBackups, err := query.Count(ctx, c.tx, "storage_volumes_backups JOIN storage_volumes ON storage_volumes.id = storage_volumes_backups.storage_volume_id JOIN projects ON projects.id = storage_volumes.project_id", "projects.name = ?", projectName)
+	if err != nil {
+		return -1, fmt.Errorf("Failed counting custom volume backups: %w", err)
+	}
+
+	bucketBackups, err := query.Count(ctx, c.tx, "storage_buckets_backups JOIN storage_buckets ON storage_buckets.id = storage_buckets_backups.storage_bucket_id JOIN projects ON projects.id = storage_buckets.project_id", "projects.name = ?", projectName)
+	if err != nil {
+		return -1, fmt.Errorf("Failed counting storage bucket backups: %w", err)
+	}
+
+	return instanceBackups + volumeBackups + bucketBackups, nil
+}
+
+// GetProjectBucketsCount returns the number of storage buckets in the project.
+func (c *ClusterTx) GetProjectBucketsCount(ctx context.Context, projectName string) (int, error) {
+	count, err := query.Count(ctx, c.tx, "storage_buckets JOIN projects ON projects.id = storage_buckets.project_id", "projects.name = ?", projectName)
+	if err != nil {
+		return -1, fmt.Errorf("Failed counting storage buckets: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetProjectImagesUsage returns the number and the total size of the images in the project.
+func (c *ClusterTx) GetProjectImagesUsage(ctx context.Context, projectName string) (int, int64, error) {
+	var count int
+	var size int64
+
+	stmt := "SELECT COUNT(*), COALESCE(SUM(images.size), 0) FROM images JOIN projects ON projects.id = images.project_id WHERE projects.name = ?"
+	err := c.tx.QueryRowContext(ctx, stmt, projectName).Scan(&count, &size)
+	if err != nil {
+		return -1, -1, fmt.Errorf("Failed getting images usage: %w", err)
+	}
+
+	return count, size, nil
+}
@@ -676,6 +676,28 @@ func bgpAddPrefix(d *deviceCommon, n network.Network, config map[string]string)
 		}
 	}
 
+	// Add the NIC specific SNAT addresses.
+	for _, key := range []string{"ipv4.nat.address", "ipv6.nat.address"} {
+		if config[key] == "" {
+			continue
+		}
+
+		prefixNet, err := network.ParseIPToNet(config[key])
+		if err != nil {
+			return err
+		}
+
+		nexthop := nexthopV4
+		if prefixNet.IP.To4() == nil {
+			nexthop = nexthopV6
+		}
+
+		err = d.state.BGP.AddPrefix(*prefixNet, nexthop, bgpOwner)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
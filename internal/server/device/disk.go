@@ -339,6 +339,7 @@ func (d *disk) validateConfig(instConf instance.ConfigReader) error {
 		// - `unsafe`
 		// ---
 		//  type: string
+		//  allowedvalues: none, metadata, writeback, unsafe
 		//  default: `none`
 		//  required: no
 		//  shortdesc: Only for VMs: Override the caching mode for the device
@@ -359,6 +360,7 @@ func (d *disk) validateConfig(instConf instance.ConfigReader) error {
 		// - `virtiofs`
 		// ---
 		//  type: string
+		//  allowedvalues: nvme, virtio-blk, virtio-scsi, auto, 9p, virtiofs, usb
 		//  default: `virtio-scsi` for block, `auto` for file system
 		//  required: no
 		//  shortdesc: Only for VMs: Override the bus for the device
@@ -1043,15 +1045,16 @@ func (d *disk) startVM() (*deviceConfig.RunConfig, error) {
 		opts = append(opts, fmt.Sprintf("cache=%s", d.config["io.cache"]))
 	}
 
-	// Add I/O limits if set.
+	// Add I/O limits if set on the device or its storage volume.
 	var diskLimits *deviceConfig.DiskLimits
-	if d.config["limits.read"] != "" || d.config["limits.write"] != "" || d.config["limits.max"] != "" {
-		// Parse the limits into usable values.
-		readBps, readIops, writeBps, writeIops, err := d.parseLimit(d.config)
-		if err != nil {
-			return nil, err
-		}
 
+	// Parse the limits into usable values.
+	readBps, readIops, writeBps, writeIops, err := d.parseLimit(d.config)
+	if err != nil {
+		return nil, err
+	}
+
+	if readBps > 0 || readIops > 0 || writeBps > 0 || writeIops > 0 {
 		diskLimits = &deviceConfig.DiskLimits{
 			ReadBytes:  readBps,
 			ReadIOps:   readIops,
@@ -1610,8 +1613,14 @@ func (d *disk) generateLimits(runConf *deviceConfig.RunConfig) error {
 			continue
 		}
 
-		if dev["limits.read"] != "" || dev["limits.write"] != "" || dev["limits.max"] != "" {
+		readBps, readIops, writeBps, writeIops, err := d.parseLimit(dev)
+		if err != nil {
+			return err
+		}
+
+		if readBps > 0 || readIops > 0 || writeBps > 0 || writeIops > 0 {
 			hasDiskLimits = true
+			break
 		}
 	}
 
@@ -2398,14 +2407,99 @@ func (d *disk) getDiskLimits() (map[string]diskBlockLimit, error) {
 }
 
 // parseLimit parses the disk configuration for its I/O limits and returns the I/O bytes/iops limits.
+// When the disk is backed by a storage volume which has its own limits, those act as an upper bound
+// which the device limits can tighten but not exceed.
 func (d *disk) parseLimit(dev deviceConfig.Device) (int64, int64, int64, int64, error) {
-	readSpeed := dev["limits.read"]
-	writeSpeed := dev["limits.write"]
+	volConfig, err := d.volumeConfig(dev)
+	if err != nil {
+		return -1, -1, -1, -1, err
+	}
+
+	return diskEffectiveLimit(dev, volConfig)
+}
+
+// diskEffectiveLimit returns the I/O bytes/iops limits of a disk device, bounded by the limits of the
+// storage volume backing it (if any).
+func diskEffectiveLimit(dev map[string]string, volConfig map[string]string) (int64, int64, int64, int64, error) {
+	readBps, readIops, writeBps, writeIops, err := diskParseLimit(dev)
+	if err != nil {
+		return -1, -1, -1, -1, err
+	}
+
+	volReadBps, volReadIops, volWriteBps, volWriteIops, err := diskParseLimit(volConfig)
+	if err != nil {
+		return -1, -1, -1, -1, fmt.Errorf("Failed parsing storage volume limits: %w", err)
+	}
+
+	// lowestLimit returns the tightest of two limits, where 0 means unlimited.
+	lowestLimit := func(limit int64, volLimit int64) int64 {
+		if limit == 0 || (volLimit > 0 && volLimit < limit) {
+			return volLimit
+		}
+
+		return limit
+	}
+
+	return lowestLimit(readBps, volReadBps), lowestLimit(readIops, volReadIops), lowestLimit(writeBps, volWriteBps), lowestLimit(writeIops, volWriteIops), nil
+}
+
+// volumeConfig returns the configuration of the storage volume backing the disk device.
+// It returns nil if the disk isn't backed by a storage volume.
+func (d *disk) volumeConfig(dev deviceConfig.Device) (map[string]string, error) {
+	if d.inst == nil || d.inst.IsSnapshot() || dev["pool"] == "" {
+		return nil, nil
+	}
+
+	pool, err := storagePools.LoadByName(d.state, dev["pool"])
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get storage pool %q: %w", dev["pool"], err)
+	}
+
+	var dbVolume *db.StorageVolume
+	if internalInstance.IsRootDiskDevice(dev) {
+		volType, err := storagePools.InstanceTypeToVolumeType(d.inst.Type())
+		if err != nil {
+			return nil, err
+		}
+
+		dbVolume, err = storagePools.VolumeDBGet(pool, d.inst.Project().Name, d.inst.Name(), volType)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if dev["source"] == "" {
+			return nil, nil
+		}
+
+		// Derive the effective storage project name from the instance config's project.
+		storageProjectName, err := project.StorageVolumeProject(d.state.DB.Cluster, d.inst.Project().Name, db.StoragePoolVolumeTypeCustom)
+		if err != nil {
+			return nil, err
+		}
+
+		// Parse the volume name and path.
+		volFields := strings.SplitN(dev["source"], "/", 2)
+		volName := volFields[0]
+
+		dbVolume, err = storagePools.VolumeDBGet(pool, storageProjectName, volName, storageDrivers.VolumeTypeCustom)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return dbVolume.Config, nil
+}
+
+// diskParseLimit parses the I/O limits of a disk device or storage volume configuration and returns the
+// I/O bytes/iops limits.
+func diskParseLimit(config map[string]string) (int64, int64, int64, int64, error) {
+	readSpeed := config["limits.read"]
+	writeSpeed := config["limits.write"]
 
 	// Apply max limit.
-	if dev["limits.max"] != "" {
-		readSpeed = dev["limits.max"]
-		writeSpeed = dev["limits.max"]
+	if config["limits.max"] != "" {
+		readSpeed = config["limits.max"]
+		writeSpeed = config["limits.max"]
 	}
 
 	// parseValue parses a single value to either a B/s limit or iops limit.
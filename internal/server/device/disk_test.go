@@ -0,0 +1,85 @@
+package device
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiskParseLimit(t *testing.T) {
+	tests := []struct {
+		config map[string]string
+		limits []int64
+		err    bool
+	}{
+		{config: nil, limits: []int64{0, 0, 0, 0}},
+		{config: map[string]string{"limits.read": "10MB", "limits.write": "200iops"}, limits: []int64{10000000, 0, 0, 200}},
+		{config: map[string]string{"limits.read": "1MiB", "limits.write": "2MiB", "limits.max": "100iops"}, limits: []int64{0, 100, 0, 100}},
+		{config: map[string]string{"limits.read": "fast"}, err: true},
+		{config: map[string]string{"limits.max": "manyiops"}, err: true},
+	}
+
+	for _, test := range tests {
+		readBps, readIops, writeBps, writeIops, err := diskParseLimit(test.config)
+		if test.err {
+			assert.Error(t, err, test.config)
+			continue
+		}
+
+		assert.NoError(t, err, test.config)
+		assert.Equal(t, test.limits, []int64{readBps, readIops, writeBps, writeIops}, test.config)
+	}
+}
+
+func TestDiskEffectiveLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		dev       map[string]string
+		volConfig map[string]string
+		limits    []int64
+		err       string
+	}{
+		{
+			name:   "Device limits only",
+			dev:    map[string]string{"limits.read": "10MB", "limits.write": "100iops"},
+			limits: []int64{10000000, 0, 0, 100},
+		},
+		{
+			name:      "Volume limits only",
+			dev:       map[string]string{},
+			volConfig: map[string]string{"limits.max": "20MB"},
+			limits:    []int64{20000000, 0, 20000000, 0},
+		},
+		{
+			name:      "Device limits tighter than the volume ones",
+			dev:       map[string]string{"limits.read": "10MB", "limits.write": "50iops"},
+			volConfig: map[string]string{"limits.read": "20MB", "limits.write": "100iops"},
+			limits:    []int64{10000000, 0, 0, 50},
+		},
+		{
+			name:      "Device limits can't exceed the volume ones",
+			dev:       map[string]string{"limits.max": "30MB"},
+			volConfig: map[string]string{"limits.read": "20MB", "limits.write": "100iops"},
+			limits:    []int64{20000000, 0, 30000000, 100},
+		},
+		{
+			name:      "Invalid volume limits",
+			dev:       map[string]string{"limits.read": "10MB"},
+			volConfig: map[string]string{"limits.read": "fast"},
+			err:       "Failed parsing storage volume limits",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			readBps, readIops, writeBps, writeIops, err := diskEffectiveLimit(test.dev, test.volConfig)
+			if test.err != "" {
+				assert.ErrorContains(t, err, test.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.limits, []int64{readBps, readIops, writeBps, writeIops})
+		})
+	}
+}
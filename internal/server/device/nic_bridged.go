@@ -46,6 +46,7 @@ import (
 
 type bridgeNetwork interface {
 	UsesDNSMasq() bool
+	InstanceDeviceValidateNATAddresses(deviceInstance instance.Instance, deviceName string, natAddresses []*net.IPNet) error
 }
 
 type nicBridged struct {
@@ -212,6 +213,22 @@ func (d *nicBridged) validateConfig(instConf instance.ConfigReader) error {
 		//  shortdesc: Comma-delimited list of IPv6 static routes to route to the NIC and publish on uplink network (BGP)
 		"ipv6.routes.external",
 
+		// gendoc:generate(entity=devices, group=nic_bridged, key=ipv4.nat.address)
+		//
+		// ---
+		//  type: string
+		//  managed: no
+		//  shortdesc: The source address used for outbound IPv4 traffic from the NIC (overrides the network's `ipv4.nat.address`, requires `ipv4.address`)
+		"ipv4.nat.address",
+
+		// gendoc:generate(entity=devices, group=nic_bridged, key=ipv6.nat.address)
+		//
+		// ---
+		//  type: string
+		//  managed: no
+		//  shortdesc: The source address used for outbound IPv6 traffic from the NIC (overrides the network's `ipv6.nat.address`, requires `ipv6.address`)
+		"ipv6.nat.address",
+
 		// gendoc:generate(entity=devices, group=nic_bridged, key=security.mac_filtering)
 		//
 		// ---
@@ -400,6 +417,23 @@ func (d *nicBridged) validateConfig(instConf instance.ConfigReader) error {
 			}
 		}
 
+		// Check that NIC specific SNAT addresses are only used alongside a static address on a NAT network.
+		for _, keyPrefix := range []string{"ipv4", "ipv6"} {
+			natAddressKey := fmt.Sprintf("%s.nat.address", keyPrefix)
+			if d.config[natAddressKey] == "" {
+				continue
+			}
+
+			if util.IsFalseOrEmpty(netConfig[fmt.Sprintf("%s.nat", keyPrefix)]) {
+				return fmt.Errorf("Cannot specify %q when %q is disabled on network %q", natAddressKey, fmt.Sprintf("%s.nat", keyPrefix), n.Name())
+			}
+
+			addressKey := fmt.Sprintf("%s.address", keyPrefix)
+			if slices.Contains([]string{"", "none"}, d.config[addressKey]) {
+				return fmt.Errorf("Cannot specify %q without a static %q", natAddressKey, addressKey)
+			}
+		}
+
 		// When we know the parent network is managed, we can validate the NIC's VLAN settings based on
 		// on the bridge driver type.
 		if slices.Contains([]string{"", "native"}, netConfig["bridge.driver"]) {
@@ -492,6 +526,11 @@ func (d *nicBridged) validateConfig(instConf instance.ConfigReader) error {
 				// Static IP cannot be used with unmanaged parent.
 				return fmt.Errorf("Cannot use manually specified ipv6.address when using unmanaged parent bridge")
 			}
+
+			// NIC specific SNAT requires a managed parent bridge.
+			if d.config["ipv4.nat.address"] != "" || d.config["ipv6.nat.address"] != "" {
+				return fmt.Errorf("Cannot use NIC specific NAT addresses when using unmanaged parent bridge")
+			}
 		}
 	}
 
@@ -511,6 +550,31 @@ func (d *nicBridged) validateConfig(instConf instance.ConfigReader) error {
 		}
 	}
 
+	// Check the NIC specific SNAT addresses aren't used elsewhere.
+	if d.config["ipv4.nat.address"] != "" || d.config["ipv6.nat.address"] != "" {
+		var natAddresses []*net.IPNet
+		for _, key := range []string{"ipv4.nat.address", "ipv6.nat.address"} {
+			if d.config[key] == "" {
+				continue
+			}
+
+			natAddress, err := network.ParseIPToNet(d.config[key])
+			if err != nil {
+				return fmt.Errorf("Invalid %q value: %w", key, err)
+			}
+
+			natAddresses = append(natAddresses, natAddress)
+		}
+
+		bridgeNet, ok := d.network.(bridgeNetwork)
+		if ok {
+			err := bridgeNet.InstanceDeviceValidateNATAddresses(d.inst, d.name, natAddresses)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	// Check if security ACL(s) are configured.
 	if d.config["security.acls"] != "" {
 		if d.state.Firewall.String() != "nftables" {
@@ -585,6 +649,9 @@ func (d *nicBridged) validateConfig(instConf instance.ConfigReader) error {
 		return validate.IsNetworkAddressV6(value)
 	}
 
+	rules["ipv4.nat.address"] = validate.Optional(validate.IsNetworkAddressV4)
+	rules["ipv6.nat.address"] = validate.Optional(validate.IsNetworkAddressV6)
+
 	// Now run normal validation.
 	err := d.config.Validate(rules)
 	if err != nil {
@@ -695,7 +762,7 @@ func (d *nicBridged) UpdatableFields(oldDevice Type) []string {
 		return []string{}
 	}
 
-	return []string{"limits.ingress", "limits.egress", "limits.max", "limits.priority", "ipv4.routes", "ipv6.routes", "ipv4.routes.external", "ipv6.routes.external", "ipv4.address", "ipv6.address", "security.mac_filtering", "security.ipv4_filtering", "security.ipv6_filtering", "security.acls", "security.acls.default.egress.action", "security.acls.default.egress.logged", "security.acls.default.ingress.action", "security.acls.default.ingress.logged"}
+	return []string{"network", "parent", "vlan", "vlan.tagged", "limits.ingress", "limits.egress", "limits.max", "limits.priority", "ipv4.routes", "ipv6.routes", "ipv4.routes.external", "ipv6.routes.external", "ipv4.address", "ipv6.address", "ipv4.nat.address", "ipv6.nat.address", "security.mac_filtering", "security.ipv4_filtering", "security.ipv6_filtering", "security.acls", "security.acls.default.egress.action", "security.acls.default.egress.logged", "security.acls.default.ingress.action", "security.acls.default.ingress.logged"}
 }
 
 // Add is run when a device is added to a non-snapshot instance whether or not the instance is running.
@@ -816,79 +883,26 @@ func (d *nicBridged) Start() (*deviceConfig.RunConfig, error) {
 
 	reverter.Add(r)
 
-	// Attach host side veth interface to bridge.
-	err = network.AttachInterface(d.state, d.config["parent"], saveData["host_name"])
+	// Apply NIC specific egress SNAT rules.
+	err = d.setupSNAT()
 	if err != nil {
 		return nil, err
 	}
 
-	reverter.Add(func() { _ = network.DetachInterface(d.state, d.config["parent"], saveData["host_name"]) })
-
-	// Attempt to disable router advertisement acceptance.
-	err = localUtil.SysctlSet(fmt.Sprintf("net/ipv6/conf/%s/accept_ra", saveData["host_name"]), "0")
-	if err != nil && !errors.Is(err, fs.ErrNotExist) {
-		return nil, err
-	}
-
-	// Attempt to enable port isolation.
-	if util.IsTrue(d.config["security.port_isolation"]) {
-		link := &ip.Link{Name: saveData["host_name"]}
-		err = link.BridgeLinkSetIsolated(true)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	// Detect bridge type.
-	nativeBridge := network.IsNativeBridge(d.config["parent"])
-
-	// Setup VLAN settings on bridge port.
-	if nativeBridge {
-		err = d.setupNativeBridgePortVLANs(saveData["host_name"])
-	} else {
-		err = d.setupOVSBridgePortVLANs(saveData["host_name"])
-	}
+	reverter.Add(func() { _ = d.state.Firewall.InstanceClearSNAT(d.inst.Project().Name, d.inst.Name(), d.name) })
 
+	// Attach host side veth interface to bridge and configure the bridge port.
+	r, err = d.attachHostInterface(d.config, d.network, saveData["host_name"])
 	if err != nil {
 		return nil, err
 	}
 
-	// Check if hairpin mode needs to be enabled.
-	if nativeBridge && d.network != nil {
-		brNetfilterEnabled := false
-		for _, ipVersion := range []uint{4, 6} {
-			if network.BridgeNetfilterEnabled(ipVersion) == nil {
-				brNetfilterEnabled = true
-				break
-			}
-		}
-
-		if brNetfilterEnabled {
-			var listenAddresses map[int64]string
-
-			err = d.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
-				listenAddresses, err = tx.GetNetworkForwardListenAddresses(ctx, d.network.ID(), true)
-
-				return err
-			})
-			if err != nil {
-				return nil, fmt.Errorf("Failed loading network forwards: %w", err)
-			}
-
-			// If br_netfilter is enabled and bridge has forwards, we enable hairpin mode on NIC's
-			// bridge port in case any of the forwards target this NIC and the instance attempts to
-			// connect to the forward's listener. Without hairpin mode on the target of the forward
-			// will not be able to connect to the listener.
-			if len(listenAddresses) > 0 {
-				link := &ip.Link{Name: saveData["host_name"]}
-				err = link.BridgeLinkSetHairpin(true)
-				if err != nil {
-					return nil, fmt.Errorf("Error enabling hairpin mode on bridge port %q: %w", link.Name, err)
-				}
+	reverter.Add(r)
 
-				d.logger.Debug("Enabled hairpin mode on NIC bridge port", logger.Ctx{"dev": link.Name})
-			}
-		}
+	// Attempt to disable router advertisement acceptance.
+	err = localUtil.SysctlSet(fmt.Sprintf("net/ipv6/conf/%s/accept_ra", saveData["host_name"]), "0")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
 	}
 
 	err = d.volatileSet(saveData)
@@ -953,6 +967,9 @@ func (d *nicBridged) Update(oldDevices deviceConfig.Devices, isRunning bool) err
 		}
 	}
 
+	// Check whether the NIC is being moved to a different bridge or VLAN.
+	oldBridgeName, moved := nicBridgedMoved(oldConfig, d.config)
+
 	reverter := revert.New()
 	defer reverter.Fail()
 
@@ -970,6 +987,16 @@ func (d *nicBridged) Update(oldDevices deviceConfig.Devices, isRunning bool) err
 			return err
 		}
 
+		// Move the host side interface to the new parent bridge or VLANs, keeping the instance interface.
+		if moved {
+			r, err := d.moveHostInterface(oldConfig)
+			if err != nil {
+				return err
+			}
+
+			reverter.Add(r)
+		}
+
 		// Remove old host-side routes from bridge interface.
 
 		oldRoutes := []string{}
@@ -1003,6 +1030,25 @@ func (d *nicBridged) Update(oldDevices deviceConfig.Devices, isRunning bool) err
 		}
 
 		reverter.Add(r)
+
+		// Re-apply NIC specific egress SNAT rules.
+		err = d.state.Firewall.InstanceClearSNAT(d.inst.Project().Name, d.inst.Name(), d.name)
+		if err != nil {
+			return err
+		}
+
+		err = d.setupSNAT()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Remove the dnsmasq entry from the previous bridge.
+	if oldBridgeName != "" && oldBridgeName != d.config["parent"] {
+		err := d.removeDnsmasqEntry(oldBridgeName, oldConfig["hwaddr"])
+		if err != nil {
+			return err
+		}
 	}
 
 	// Rebuild dnsmasq entry if needed and reload.
@@ -1011,10 +1057,10 @@ func (d *nicBridged) Update(oldDevices deviceConfig.Devices, isRunning bool) err
 		return err
 	}
 
-	// If an IPv6 address has changed, if the instance is running we should bounce the host-side
-	// veth interface to give the instance a chance to detect the change and re-apply for an
-	// updated lease with new IP address.
-	if d.config["ipv6.address"] != oldConfig["ipv6.address"] && d.config["host_name"] != "" && util.PathExists(fmt.Sprintf("/sys/class/net/%s", d.config["host_name"])) {
+	// If an IPv6 address has changed or the NIC was moved, if the instance is running we should bounce
+	// the host-side veth interface to give the instance a chance to detect the change and re-apply for
+	// an updated lease with new IP address.
+	if (moved || d.config["ipv6.address"] != oldConfig["ipv6.address"]) && d.config["host_name"] != "" && util.PathExists(fmt.Sprintf("/sys/class/net/%s", d.config["host_name"])) {
 		link := &ip.Link{Name: d.config["host_name"]}
 		err := link.SetDown()
 		if err != nil {
@@ -1043,6 +1089,88 @@ func (d *nicBridged) Update(oldDevices deviceConfig.Devices, isRunning bool) err
 	return nil
 }
 
+// nicBridgedMoved returns the parent bridge of the old config and whether the NIC is attached to a different
+// parent bridge or VLANs in the new config.
+func nicBridgedMoved(oldConfig deviceConfig.Device, newConfig deviceConfig.Device) (string, bool) {
+	oldBridgeName := oldConfig["parent"]
+	if oldBridgeName == "" {
+		oldBridgeName = oldConfig["network"]
+	}
+
+	moved := oldBridgeName != newConfig["parent"] || oldConfig["vlan"] != newConfig["vlan"] || oldConfig["vlan.tagged"] != newConfig["vlan.tagged"]
+
+	return oldBridgeName, moved
+}
+
+// moveHostInterface re-plugs the host side interface of a running NIC from the parent bridge and VLANs
+// of the old config into the ones of the current config. The interface, and so the MAC address and name
+// seen by the instance, is kept as is.
+func (d *nicBridged) moveHostInterface(oldConfig deviceConfig.Device) (revert.Hook, error) {
+	hostName := d.config["host_name"]
+	if hostName == "" || !network.InterfaceExists(hostName) {
+		return nil, fmt.Errorf("Host interface of NIC %q not found", d.name)
+	}
+
+	// The MTU of the instance interface can't be changed live.
+	mtu, err := d.getHostMTU()
+	if err != nil {
+		return nil, err
+	}
+
+	newMTU := d.config["mtu"]
+	if newMTU == "" {
+		parentMTU, err := network.GetDevMTU(d.config["parent"])
+		if err != nil {
+			return nil, err
+		}
+
+		newMTU = fmt.Sprintf("%d", parentMTU)
+	}
+
+	if newMTU != fmt.Sprintf("%d", mtu) {
+		return nil, fmt.Errorf("Cannot move NIC %q to %q while running as its MTU would change from %d to %s", d.name, d.config["parent"], mtu, newMTU)
+	}
+
+	// Load the old managed network (if any) so the bridge port can be restored on failure.
+	var oldNetwork network.Network
+	if oldConfig["network"] != "" {
+		networkProjectName, _, err := project.NetworkProject(d.state.DB.Cluster, d.inst.Project().Name)
+		if err != nil {
+			return nil, fmt.Errorf("Failed loading network project name: %w", err)
+		}
+
+		oldNetwork, err = network.LoadByName(d.state, networkProjectName, oldConfig["network"])
+		if err != nil {
+			return nil, fmt.Errorf("Error loading network config for %q: %w", oldConfig["network"], err)
+		}
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	// Detaching the port also drops any VLAN membership it had on the old bridge.
+	err = network.DetachInterface(d.state, oldConfig["parent"], hostName)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to detach interface %q from %q: %w", hostName, oldConfig["parent"], err)
+	}
+
+	reverter.Add(func() { _, _ = d.attachHostInterface(oldConfig, oldNetwork, hostName) })
+
+	r, err := d.attachHostInterface(d.config, d.network, hostName)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to attach interface %q to %q: %w", hostName, d.config["parent"], err)
+	}
+
+	reverter.Add(r)
+
+	d.logger.Debug("Moved NIC host interface", logger.Ctx{"dev": hostName, "oldParent": oldConfig["parent"], "parent": d.config["parent"]})
+
+	cleanup := reverter.Clone().Fail
+	reverter.Success()
+
+	return cleanup, nil
+}
+
 // Stop is run when the device is removed from the instance.
 func (d *nicBridged) Stop() (*deviceConfig.RunConfig, error) {
 	// Remove BGP announcements.
@@ -1111,6 +1239,20 @@ func (d *nicBridged) postStop() error {
 		d.removeFilters(d.config)
 	}
 
+	if d.config["security.acls"] != "" {
+		err := acl.FirewallDeleteUnusedCounters(d.state)
+		if err != nil {
+			d.logger.Warn("Failed removing unused ACL counters", logger.Ctx{"err": err})
+		}
+	}
+
+	if d.config["ipv4.nat.address"] != "" || d.config["ipv6.nat.address"] != "" {
+		err := d.state.Firewall.InstanceClearSNAT(d.inst.Project().Name, d.inst.Name(), d.name)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -1123,29 +1265,37 @@ func (d *nicBridged) Remove() error {
 	}
 
 	if bridgeName != "" {
-		dnsmasq.ConfigMutex.Lock()
-		defer dnsmasq.ConfigMutex.Unlock()
+		return d.removeDnsmasqEntry(bridgeName, d.config["hwaddr"])
+	}
 
-		if network.InterfaceExists(bridgeName) {
-			err := d.networkClearLease(d.inst.Name(), bridgeName, d.config["hwaddr"], clearLeaseAll)
-			if err != nil {
-				return fmt.Errorf("Failed clearing leases: %w", err)
-			}
-		}
+	return nil
+}
 
-		// Remove dnsmasq config if it exists (doesn't return error if file is missing).
-		err := dnsmasq.RemoveStaticEntry(bridgeName, d.inst.Project().Name, d.inst.Name(), d.Name())
-		if err != nil {
-			return err
-		}
+// removeDnsmasqEntry clears the leases and removes the dnsmasq host entry of the NIC on the bridge and
+// reloads dnsmasq.
+func (d *nicBridged) removeDnsmasqEntry(bridgeName string, hwaddr string) error {
+	dnsmasq.ConfigMutex.Lock()
+	defer dnsmasq.ConfigMutex.Unlock()
 
-		// Reload dnsmasq to apply new settings if dnsmasq is running.
-		err = dnsmasq.Kill(bridgeName, true)
+	if network.InterfaceExists(bridgeName) {
+		err := d.networkClearLease(d.inst.Name(), bridgeName, hwaddr, clearLeaseAll)
 		if err != nil {
-			return err
+			return fmt.Errorf("Failed clearing leases: %w", err)
 		}
 	}
 
+	// Remove dnsmasq config if it exists (doesn't return error if file is missing).
+	err := dnsmasq.RemoveStaticEntry(bridgeName, d.inst.Project().Name, d.inst.Name(), d.Name())
+	if err != nil {
+		return err
+	}
+
+	// Reload dnsmasq to apply new settings if dnsmasq is running.
+	err = dnsmasq.Kill(bridgeName, true)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -1234,12 +1384,71 @@ func (d *nicBridged) setupHostFilters(oldConfig deviceConfig.Device) (revert.Hoo
 		reverter.Add(func() { d.removeFilters(d.config) })
 	}
 
+	// Remove the counters of ACL rules which are no longer applied.
+	if oldConfig != nil && oldConfig["security.acls"] != "" {
+		err := acl.FirewallDeleteUnusedCounters(d.state)
+		if err != nil {
+			d.logger.Warn("Failed removing unused ACL counters", logger.Ctx{"err": err})
+		}
+	}
+
 	cleanup := reverter.Clone().Fail
 	reverter.Success()
 
 	return cleanup, nil
 }
 
+// setupSNAT applies the NIC specific egress SNAT rules (if any).
+func (d *nicBridged) setupSNAT() error {
+	SNATV4, SNATV6, err := nicSNATOpts(d.config)
+	if err != nil {
+		return err
+	}
+
+	if SNATV4 == nil && SNATV6 == nil {
+		return nil
+	}
+
+	err = d.state.Firewall.InstanceSetupSNAT(d.inst.Project().Name, d.inst.Name(), d.name, d.config["parent"], SNATV4, SNATV6)
+	if err != nil {
+		return fmt.Errorf("Failed setting up NIC SNAT rules: %w", err)
+	}
+
+	return nil
+}
+
+// nicSNATOpts returns the IPv4 and IPv6 SNAT rules translating the NIC's address to its NAT address.
+// A rule is nil when the NIC doesn't have a NAT address for that family.
+func nicSNATOpts(config deviceConfig.Device) (*firewallDrivers.SNATOpts, *firewallDrivers.SNATOpts, error) {
+	var SNATV4, SNATV6 *firewallDrivers.SNATOpts
+
+	if config["ipv4.nat.address"] != "" {
+		subnet, err := network.ParseIPToNet(config["ipv4.address"])
+		if err != nil {
+			return nil, nil, fmt.Errorf("Failed parsing %q: %w", "ipv4.address", err)
+		}
+
+		SNATV4 = &firewallDrivers.SNATOpts{
+			Subnet:      subnet,
+			SNATAddress: net.ParseIP(config["ipv4.nat.address"]),
+		}
+	}
+
+	if config["ipv6.nat.address"] != "" {
+		subnet, err := network.ParseIPToNet(config["ipv6.address"])
+		if err != nil {
+			return nil, nil, fmt.Errorf("Failed parsing %q: %w", "ipv6.address", err)
+		}
+
+		SNATV6 = &firewallDrivers.SNATOpts{
+			Subnet:      subnet,
+			SNATAddress: net.ParseIP(config["ipv6.nat.address"]),
+		}
+	}
+
+	return SNATV4, SNATV6, nil
+}
+
 // removeFilters removes any network level filters defined for the instance.
 func (d *nicBridged) removeFilters(m deviceConfig.Device) {
 	if m["hwaddr"] == "" {
@@ -1799,38 +2008,118 @@ func (d *nicBridged) networkDHCPv6CreateIAAddress(IP net.IP) []byte {
 	return data
 }
 
+// attachHostInterface attaches the host side interface to the parent bridge of the supplied config and
+// configures the bridge port (port isolation, VLANs and hairpin mode).
+func (d *nicBridged) attachHostInterface(config deviceConfig.Device, n network.Network, hostName string) (revert.Hook, error) {
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	err := network.AttachInterface(d.state, config["parent"], hostName)
+	if err != nil {
+		return nil, err
+	}
+
+	reverter.Add(func() { _ = network.DetachInterface(d.state, config["parent"], hostName) })
+
+	// Attempt to enable port isolation.
+	if util.IsTrue(config["security.port_isolation"]) {
+		link := &ip.Link{Name: hostName}
+		err = link.BridgeLinkSetIsolated(true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Detect bridge type.
+	nativeBridge := network.IsNativeBridge(config["parent"])
+
+	// Setup VLAN settings on bridge port.
+	if nativeBridge {
+		err = d.setupNativeBridgePortVLANs(config, hostName)
+	} else {
+		err = d.setupOVSBridgePortVLANs(config, hostName)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if hairpin mode needs to be enabled.
+	if nativeBridge && n != nil {
+		brNetfilterEnabled := false
+		for _, ipVersion := range []uint{4, 6} {
+			if network.BridgeNetfilterEnabled(ipVersion) == nil {
+				brNetfilterEnabled = true
+				break
+			}
+		}
+
+		if brNetfilterEnabled {
+			var listenAddresses map[int64]string
+
+			err = d.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+				listenAddresses, err = tx.GetNetworkForwardListenAddresses(ctx, n.ID(), true)
+
+				return err
+			})
+			if err != nil {
+				return nil, fmt.Errorf("Failed loading network forwards: %w", err)
+			}
+
+			// If br_netfilter is enabled and bridge has forwards, we enable hairpin mode on NIC's
+			// bridge port in case any of the forwards target this NIC and the instance attempts to
+			// connect to the forward's listener. Without hairpin mode on the target of the forward
+			// will not be able to connect to the listener.
+			if len(listenAddresses) > 0 {
+				link := &ip.Link{Name: hostName}
+				err = link.BridgeLinkSetHairpin(true)
+				if err != nil {
+					return nil, fmt.Errorf("Error enabling hairpin mode on bridge port %q: %w", link.Name, err)
+				}
+
+				d.logger.Debug("Enabled hairpin mode on NIC bridge port", logger.Ctx{"dev": link.Name})
+			}
+		}
+	}
+
+	cleanup := reverter.Clone().Fail
+	reverter.Success()
+
+	return cleanup, nil
+}
+
 // setupNativeBridgePortVLANs configures the bridge port with the specified VLAN settings on the native bridge.
-func (d *nicBridged) setupNativeBridgePortVLANs(hostName string) error {
+func (d *nicBridged) setupNativeBridgePortVLANs(config deviceConfig.Device, hostName string) error {
 	link := &ip.Link{Name: hostName}
 
 	// Check vlan_filtering is enabled on bridge if needed.
-	if d.config["vlan"] != "" || d.config["vlan.tagged"] != "" {
-		vlanFilteringStatus, err := network.BridgeVLANFilteringStatus(d.config["parent"])
+	if config["vlan"] != "" || config["vlan.tagged"] != "" {
+		vlanFilteringStatus, err := network.BridgeVLANFilteringStatus(config["parent"])
 		if err != nil {
 			return err
 		}
 
 		if vlanFilteringStatus != "1" {
-			return fmt.Errorf("VLAN filtering is not enabled in parent bridge %q", d.config["parent"])
+			return fmt.Errorf("VLAN filtering is not enabled in parent bridge %q", config["parent"])
 		}
 	}
 
 	// Set port on bridge to specified untagged PVID.
-	if d.config["vlan"] != "" {
+	if config["vlan"] != "" {
 		// Reject VLAN ID 0 if specified (as validation allows VLAN ID 0 on unmanaged bridges for OVS).
-		if d.config["vlan"] == "0" {
+		if config["vlan"] == "0" {
 			return fmt.Errorf("VLAN ID 0 is not allowed for native Linux bridges")
 		}
 
 		// Get default PVID membership on port.
-		defaultPVID, err := network.BridgeVLANDefaultPVID(d.config["parent"])
+		defaultPVID, err := network.BridgeVLANDefaultPVID(config["parent"])
 		if err != nil {
 			return err
 		}
 
 		// If the bridge has a default PVID and it is different to the specified untagged VLAN or if tagged
 		// VLAN is set to "none" then remove the default untagged membership.
-		if defaultPVID != "0" && (defaultPVID != d.config["vlan"] || d.config["vlan"] == "none") {
+		if defaultPVID != "0" && (defaultPVID != config["vlan"] || config["vlan"] == "none") {
 			err = link.BridgeVLANDelete(defaultPVID, false)
 			if err != nil {
 				return fmt.Errorf("Failed removing default PVID membership: %w", err)
@@ -1838,8 +2127,8 @@ func (d *nicBridged) setupNativeBridgePortVLANs(hostName string) error {
 		}
 
 		// Configure the untagged membership settings of the port if VLAN ID specified.
-		if d.config["vlan"] != "none" {
-			err = link.BridgeVLANAdd(d.config["vlan"], true, true, false)
+		if config["vlan"] != "none" {
+			err = link.BridgeVLANAdd(config["vlan"], true, true, false)
 			if err != nil {
 				return err
 			}
@@ -1847,8 +2136,8 @@ func (d *nicBridged) setupNativeBridgePortVLANs(hostName string) error {
 	}
 
 	// Add any tagged VLAN memberships.
-	if d.config["vlan.tagged"] != "" {
-		networkVLANList, err := networkVLANListExpand(util.SplitNTrimSpace(d.config["vlan.tagged"], ",", -1, true))
+	if config["vlan.tagged"] != "" {
+		networkVLANList, err := networkVLANListExpand(util.SplitNTrimSpace(config["vlan.tagged"], ",", -1, true))
 		if err != nil {
 			return err
 		}
@@ -1870,15 +2159,15 @@ func (d *nicBridged) setupNativeBridgePortVLANs(hostName string) error {
 }
 
 // setupOVSBridgePortVLANs configures the bridge port with the specified VLAN settings on the openvswitch bridge.
-func (d *nicBridged) setupOVSBridgePortVLANs(hostName string) error {
+func (d *nicBridged) setupOVSBridgePortVLANs(config deviceConfig.Device, hostName string) error {
 	vswitch, err := d.state.OVS()
 	if err != nil {
 		return fmt.Errorf("Failed to connect to OVS: %w", err)
 	}
 
 	// Set port on bridge to specified untagged PVID.
-	if d.config["vlan"] != "" {
-		if d.config["vlan"] == "none" && d.config["vlan.tagged"] == "" {
+	if config["vlan"] != "" {
+		if config["vlan"] == "none" && config["vlan.tagged"] == "" {
 			return fmt.Errorf("vlan=none is not supported with openvswitch bridges when not using vlan.tagged")
 		}
 
@@ -1886,8 +2175,8 @@ func (d *nicBridged) setupOVSBridgePortVLANs(hostName string) error {
 		// Also set the vlan_mode=access, which will drop any tagged frames.
 		// Order is important here, as vlan_mode is set to "access", assuming that vlan.tagged is not used.
 		// If vlan.tagged is specified, then we expect it to also change the vlan_mode as needed.
-		if d.config["vlan"] != "none" {
-			vlanID, err := strconv.Atoi(d.config["vlan"])
+		if config["vlan"] != "none" {
+			vlanID, err := strconv.Atoi(config["vlan"])
 			if err != nil {
 				return err
 			}
@@ -1900,14 +2189,14 @@ func (d *nicBridged) setupOVSBridgePortVLANs(hostName string) error {
 	}
 
 	// Add any tagged VLAN memberships.
-	if d.config["vlan.tagged"] != "" {
-		intNetworkVLANs, err := networkVLANListExpand(util.SplitNTrimSpace(d.config["vlan.tagged"], ",", -1, true))
+	if config["vlan.tagged"] != "" {
+		intNetworkVLANs, err := networkVLANListExpand(util.SplitNTrimSpace(config["vlan.tagged"], ",", -1, true))
 		if err != nil {
 			return err
 		}
 
 		vlanMode := "trunk" // Default to only allowing tagged frames (drop untagged frames).
-		if d.config["vlan"] != "none" {
+		if config["vlan"] != "none" {
 			// If untagged vlan mode isn't "none" then allow untagged frames for port's 'native' VLAN.
 			vlanMode = "native-untagged"
 		}
@@ -0,0 +1,131 @@
+package device
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
+)
+
+func TestNICSNATOpts(t *testing.T) {
+	// Without NAT addresses there are no rules, even with static addresses.
+	SNATV4, SNATV6, err := nicSNATOpts(map[string]string{"ipv4.address": "10.0.0.5", "ipv6.address": "fd42::5"})
+	require.NoError(t, err)
+	assert.Nil(t, SNATV4)
+	assert.Nil(t, SNATV6)
+
+	// The NIC's own address is translated to its NAT address.
+	SNATV4, SNATV6, err = nicSNATOpts(map[string]string{
+		"ipv4.address":     "10.0.0.5",
+		"ipv4.nat.address": "192.0.2.5",
+		"ipv6.address":     "fd42::5",
+		"ipv6.nat.address": "2001:db8::5",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, SNATV4)
+	require.NotNil(t, SNATV6)
+	assert.Equal(t, "10.0.0.5/32", SNATV4.Subnet.String())
+	assert.Equal(t, "192.0.2.5", SNATV4.SNATAddress.String())
+	assert.Equal(t, "fd42::5/128", SNATV6.Subnet.String())
+	assert.Equal(t, "2001:db8::5", SNATV6.SNATAddress.String())
+
+	// Each family is handled separately.
+	SNATV4, SNATV6, err = nicSNATOpts(map[string]string{"ipv6.address": "fd42::5", "ipv6.nat.address": "2001:db8::5"})
+	require.NoError(t, err)
+	assert.Nil(t, SNATV4)
+	assert.NotNil(t, SNATV6)
+
+	// A NAT address requires a static address.
+	_, _, err = nicSNATOpts(map[string]string{"ipv4.nat.address": "192.0.2.5"})
+	assert.ErrorContains(t, err, `Failed parsing "ipv4.address"`)
+
+	_, _, err = nicSNATOpts(map[string]string{"ipv6.address": "invalid", "ipv6.nat.address": "2001:db8::5"})
+	assert.ErrorContains(t, err, `Failed parsing "ipv6.address"`)
+}
+
+func TestNICBridgedMoved(t *testing.T) {
+	tests := []struct {
+		name          string
+		oldConfig     map[string]string
+		newConfig     map[string]string
+		oldBridgeName string
+		moved         bool
+	}{
+		{
+			name:          "Unchanged",
+			oldConfig:     map[string]string{"parent": "br0", "vlan": "10", "vlan.tagged": "20,30"},
+			newConfig:     map[string]string{"parent": "br0", "vlan": "10", "vlan.tagged": "20,30", "limits.max": "10Mbit"},
+			oldBridgeName: "br0",
+		},
+		{
+			name:          "Managed network unchanged",
+			oldConfig:     map[string]string{"network": "net0"},
+			newConfig:     map[string]string{"network": "net0", "parent": "net0"},
+			oldBridgeName: "net0",
+		},
+		{
+			name:          "Parent changed",
+			oldConfig:     map[string]string{"parent": "br0"},
+			newConfig:     map[string]string{"parent": "br1"},
+			oldBridgeName: "br0",
+			moved:         true,
+		},
+		{
+			name:          "Network changed",
+			oldConfig:     map[string]string{"network": "net0"},
+			newConfig:     map[string]string{"network": "net1", "parent": "net1"},
+			oldBridgeName: "net0",
+			moved:         true,
+		},
+		{
+			name:          "VLAN changed",
+			oldConfig:     map[string]string{"parent": "br0", "vlan": "10"},
+			newConfig:     map[string]string{"parent": "br0", "vlan": "11"},
+			oldBridgeName: "br0",
+			moved:         true,
+		},
+		{
+			name:          "Tagged VLANs changed",
+			oldConfig:     map[string]string{"parent": "br0", "vlan.tagged": "20"},
+			newConfig:     map[string]string{"parent": "br0"},
+			oldBridgeName: "br0",
+			moved:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldBridgeName, moved := nicBridgedMoved(tt.oldConfig, tt.newConfig)
+			assert.Equal(t, tt.oldBridgeName, oldBridgeName)
+			assert.Equal(t, tt.moved, moved)
+		})
+	}
+}
+
+func TestNICBridgedUpdatableFields(t *testing.T) {
+	d := &nicBridged{}
+	updateFields := func(oldDevice deviceConfig.Device, newDevice deviceConfig.Device) []string {
+		return d.UpdatableFields(&nicBridged{})
+	}
+
+	oldDevices := deviceConfig.Devices{"eth0": {"type": "nic", "nictype": "bridged", "parent": "br0", "hwaddr": "00:16:3e:00:00:01"}}
+
+	// Moving the NIC to another bridge or VLANs updates it live.
+	newDevices := deviceConfig.Devices{"eth0": {"type": "nic", "nictype": "bridged", "parent": "br1", "vlan": "10", "vlan.tagged": "20", "hwaddr": "00:16:3e:00:00:01"}}
+	removed, added, updated, _ := oldDevices.Update(newDevices, updateFields)
+	assert.Empty(t, removed)
+	assert.Empty(t, added)
+	assert.Contains(t, updated, "eth0")
+
+	// Changing the MAC address requires the NIC to be re-added.
+	newDevices = deviceConfig.Devices{"eth0": {"type": "nic", "nictype": "bridged", "parent": "br1", "hwaddr": "00:16:3e:00:00:02"}}
+	removed, added, updated, _ = oldDevices.Update(newDevices, updateFields)
+	assert.Contains(t, removed, "eth0")
+	assert.Contains(t, added, "eth0")
+	assert.Empty(t, updated)
+
+	// Not compatible with other NIC types.
+	assert.Empty(t, d.UpdatableFields(&nicOVN{}))
+}
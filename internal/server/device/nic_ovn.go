@@ -43,6 +43,7 @@ type ovnNet interface {
 	network.Network
 
 	InstanceDevicePortValidateExternalRoutes(deviceInstance instance.Instance, deviceName string, externalRoutes []*net.IPNet) error
+	InstanceDevicePortValidateNATAddresses(deviceInstance instance.Instance, deviceName string, natAddresses []*net.IPNet) error
 	InstanceDevicePortAdd(instanceUUID string, deviceName string, deviceConfig deviceConfig.Device) error
 	InstanceDevicePortStart(opts *network.OVNInstanceNICSetupOpts, securityACLsRemove []string) (ovn.OVNSwitchPort, []net.IP, error)
 	InstanceDevicePortStop(ovsExternalOVNPort ovn.OVNSwitchPort, opts *network.OVNInstanceNICStopOpts) error
@@ -77,7 +78,7 @@ func (d *nicOVN) UpdatableFields(oldDevice Type) []string {
 		return []string{}
 	}
 
-	return []string{"security.acls"}
+	return []string{"network", "security.acls"}
 }
 
 // validateConfig checks the supplied config for correctness.
@@ -181,6 +182,22 @@ func (d *nicOVN) validateConfig(instConf instance.ConfigReader) error {
 		//  shortdesc: Comma-delimited list of IPv6 static routes to route to the NIC and publish on uplink network
 		"ipv6.routes.external",
 
+		// gendoc:generate(entity=devices, group=nic_ovn, key=ipv4.nat.address)
+		//
+		// ---
+		//  type: string
+		//  managed: no
+		//  shortdesc: The source address used for outbound IPv4 traffic from the NIC (overrides the network's `ipv4.nat.address`)
+		"ipv4.nat.address",
+
+		// gendoc:generate(entity=devices, group=nic_ovn, key=ipv6.nat.address)
+		//
+		// ---
+		//  type: string
+		//  managed: no
+		//  shortdesc: The source address used for outbound IPv6 traffic from the NIC (overrides the network's `ipv6.nat.address`)
+		"ipv6.nat.address",
+
 		// gendoc:generate(entity=devices, group=nic_ovn, key=boot.priority)
 		//
 		// ---
@@ -428,6 +445,9 @@ func (d *nicOVN) validateConfig(instConf instance.ConfigReader) error {
 		return validate.IsNetworkAddressV6(value)
 	})
 
+	rules["ipv4.nat.address"] = validate.Optional(validate.IsNetworkAddressV4)
+	rules["ipv6.nat.address"] = validate.Optional(validate.IsNetworkAddressV6)
+
 	// Now run normal validation.
 	err = d.config.Validate(rules)
 	if err != nil {
@@ -454,6 +474,37 @@ func (d *nicOVN) validateConfig(instConf instance.ConfigReader) error {
 		}
 	}
 
+	// Check NIC specific SNAT addresses are usable on the uplink network.
+	var natAddresses []*net.IPNet
+	for _, keyPrefix := range []string{"ipv4", "ipv6"} {
+		natAddressKey := fmt.Sprintf("%s.nat.address", keyPrefix)
+		if d.config[natAddressKey] == "" {
+			continue
+		}
+
+		if util.IsFalseOrEmpty(netConfig[fmt.Sprintf("%s.nat", keyPrefix)]) {
+			return fmt.Errorf("Cannot specify %q when %q is disabled on network %q", natAddressKey, fmt.Sprintf("%s.nat", keyPrefix), d.config["network"])
+		}
+
+		if d.config[fmt.Sprintf("%s.address", keyPrefix)] == "none" {
+			return fmt.Errorf("Cannot specify %q when %q is %q", natAddressKey, fmt.Sprintf("%s.address", keyPrefix), "none")
+		}
+
+		natAddress, err := network.ParseIPToNet(d.config[natAddressKey])
+		if err != nil {
+			return fmt.Errorf("Invalid %q value: %w", natAddressKey, err)
+		}
+
+		natAddresses = append(natAddresses, natAddress)
+	}
+
+	if len(natAddresses) > 0 {
+		err = d.network.InstanceDevicePortValidateNATAddresses(d.inst, d.name, natAddresses)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Check Security ACLs exist.
 	if d.config["security.acls"] != "" {
 		err = acl.Exists(d.state, networkProjectName, util.SplitNTrimSpace(d.config["security.acls"], ",", -1, true)...)
@@ -936,10 +987,25 @@ func (d *nicOVN) Update(oldDevices deviceConfig.Devices, isRunning bool) error {
 	// Populate device config with volatile fields if needed.
 	networkVethFillFromVolatile(d.config, d.volatileGet())
 
-	// If an IPv6 address has changed, if the instance is running we should bounce the host-side
-	// veth interface to give the instance a chance to detect the change and re-apply for an
-	// updated lease with new IP address.
-	if d.config["ipv6.address"] != oldConfig["ipv6.address"] && d.config["host_name"] != "" && network.InterfaceExists(d.config["host_name"]) {
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	// Move the logical switch port to the new network, keeping the instance interface.
+	var removeOldPort func() error
+	if d.config["network"] != oldConfig["network"] {
+		r, remove, err := d.moveNetwork(oldConfig, isRunning)
+		if err != nil {
+			return err
+		}
+
+		reverter.Add(r)
+		removeOldPort = remove
+	}
+
+	// If an IPv6 address has changed or the NIC was moved, if the instance is running we should bounce
+	// the host-side veth interface to give the instance a chance to detect the change and re-apply for
+	// an updated lease with new IP address.
+	if (d.config["network"] != oldConfig["network"] || d.config["ipv6.address"] != oldConfig["ipv6.address"]) && d.config["host_name"] != "" && network.InterfaceExists(d.config["host_name"]) {
 		link := &ip.Link{Name: d.config["host_name"]}
 		err := link.SetDown()
 		if err != nil {
@@ -972,28 +1038,13 @@ func (d *nicOVN) Update(oldDevices deviceConfig.Devices, isRunning bool) error {
 
 		// Setup the logical port with new ACLs if running.
 		if isRunning {
-			// Load uplink network config.
-			uplinkNetworkName := d.network.Config()["network"]
-			var uplink *api.Network
-			var uplinkConfig map[string]string
-
-			if uplinkNetworkName != "none" {
-				err := d.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
-					var err error
-
-					_, uplink, _, err = tx.GetNetworkInAnyState(ctx, api.ProjectDefaultName, uplinkNetworkName)
-
-					return err
-				})
-				if err != nil {
-					return fmt.Errorf("Failed to load uplink network %q: %w", uplinkNetworkName, err)
-				}
-
-				uplinkConfig = uplink.Config
+			uplinkConfig, err := d.uplinkConfig()
+			if err != nil {
+				return err
 			}
 
 			// Update OVN logical switch port for instance.
-			_, _, err := d.network.InstanceDevicePortStart(&network.OVNInstanceNICSetupOpts{
+			_, _, err = d.network.InstanceDevicePortStart(&network.OVNInstanceNICSetupOpts{
 				InstanceUUID: d.inst.LocalConfig()["volatile.uuid"],
 				DNSName:      d.inst.Name(),
 				DeviceName:   d.name,
@@ -1029,9 +1080,251 @@ func (d *nicOVN) Update(oldDevices deviceConfig.Devices, isRunning bool) error {
 		return err
 	}
 
+	// Remove the NIC from the old network only once everything else succeeded, so that it can be
+	// restored on failure.
+	if removeOldPort != nil {
+		err = removeOldPort()
+		if err != nil {
+			return err
+		}
+	}
+
+	reverter.Success()
+
 	return nil
 }
 
+// uplinkConfig returns the config of the uplink network of the NIC's network (if any).
+func (d *nicOVN) uplinkConfig() (map[string]string, error) {
+	uplinkNetworkName := d.network.Config()["network"]
+	if uplinkNetworkName == "none" {
+		return nil, nil
+	}
+
+	var uplink *api.Network
+
+	err := d.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		_, uplink, _, err = tx.GetNetworkInAnyState(ctx, api.ProjectDefaultName, uplinkNetworkName)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load uplink network %q: %w", uplinkNetworkName, err)
+	}
+
+	return uplink.Config, nil
+}
+
+// ovnNICPortMove holds the operations used to move the logical switch port of a running NIC to another
+// network.
+type ovnNICPortMove struct {
+	// startPort sets up the logical switch port on the new network.
+	startPort func() (ovn.OVNSwitchPort, revert.Hook, error)
+
+	// associatedPort returns the logical switch port the host side interface is associated with.
+	associatedPort func() (string, error)
+
+	// associatePort associates the host side interface with a logical switch port.
+	associatePort func(portName string) error
+
+	// setChassis binds the logical switch port to the local chassis.
+	setChassis func(portName ovn.OVNSwitchPort) error
+}
+
+// run sets up the new logical switch port and associates the host side interface with it. On failure the
+// host side interface is associated with the old logical switch port again before the new one is removed.
+// It returns the name of the old logical switch port and a hook reverting the move.
+func (m ovnNICPortMove) run() (string, revert.Hook, error) {
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	logicalPortName, r, err := m.startPort()
+	if err != nil {
+		return "", nil, err
+	}
+
+	reverter.Add(r)
+
+	oldLogicalPortName, err := m.associatedPort()
+	if err != nil {
+		return "", nil, err
+	}
+
+	err = m.associatePort(string(logicalPortName))
+	if err != nil {
+		return "", nil, err
+	}
+
+	reverter.Add(func() { _ = m.associatePort(oldLogicalPortName) })
+
+	err = m.setChassis(logicalPortName)
+	if err != nil {
+		return "", nil, err
+	}
+
+	cleanup := reverter.Clone().Fail
+	reverter.Success()
+
+	return oldLogicalPortName, cleanup, nil
+}
+
+// moveNetwork moves the NIC's logical switch port from the network of the old config to the current
+// network. If the instance is running, the host side interface is re-associated with the new logical
+// switch port so the interface, MAC address and name seen by the instance are kept as is.
+// It returns a hook reverting the move and a function removing the NIC from the old network, which must
+// only be called once the rest of the update succeeded as the move can't be reverted afterwards.
+func (d *nicOVN) moveNetwork(oldConfig deviceConfig.Device, isRunning bool) (revert.Hook, func() error, error) {
+	if isRunning && d.config["nested"] != "" {
+		return nil, nil, fmt.Errorf("Nested NICs cannot be moved to another network while running")
+	}
+
+	// The old network is in the same network project as the new one.
+	networkProjectName, _, err := project.NetworkProject(d.state.DB.Cluster, d.inst.Project().Name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed loading network project name: %w", err)
+	}
+
+	n, err := network.LoadByName(d.state, networkProjectName, oldConfig["network"])
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error loading network config for %q: %w", oldConfig["network"], err)
+	}
+
+	oldNetwork, ok := n.(ovnNet)
+	if !ok {
+		return nil, nil, fmt.Errorf("Network is not ovnNet interface type")
+	}
+
+	instanceUUID := d.inst.LocalConfig()["volatile.uuid"]
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	// Add the DNS record and DHCP reservation on the new network.
+	err = d.network.InstanceDevicePortAdd(instanceUUID, d.name, d.config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed adding OVN port: %w", err)
+	}
+
+	reverter.Add(func() { _ = d.network.InstanceDevicePortRemove(instanceUUID, d.name, d.config) })
+
+	var oldLogicalPortName string
+	if isRunning {
+		integrationBridgeNICName := d.config["host_name"]
+		if d.config["acceleration"] == "sriov" || d.config["acceleration"] == "vdpa" {
+			integrationBridgeNICName, err = d.findRepresentorPort(d.volatileGet())
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		vswitch, err := d.state.OVS()
+		if err != nil {
+			return nil, nil, fmt.Errorf("Failed to connect to OVS: %w", err)
+		}
+
+		move := ovnNICPortMove{
+			startPort: func() (ovn.OVNSwitchPort, revert.Hook, error) {
+				uplinkConfig, err := d.uplinkConfig()
+				if err != nil {
+					return "", nil, err
+				}
+
+				// Add new OVN logical switch port for instance on the new network.
+				logicalPortName, _, err := d.network.InstanceDevicePortStart(&network.OVNInstanceNICSetupOpts{
+					InstanceUUID: instanceUUID,
+					DNSName:      d.inst.Name(),
+					DeviceName:   d.name,
+					DeviceConfig: d.config,
+					UplinkConfig: uplinkConfig,
+				}, nil)
+				if err != nil {
+					return "", nil, fmt.Errorf("Failed setting up OVN port: %w", err)
+				}
+
+				return logicalPortName, func() {
+					_ = d.network.InstanceDevicePortStop(logicalPortName, &network.OVNInstanceNICStopOpts{
+						InstanceUUID: instanceUUID,
+						DeviceName:   d.name,
+						DeviceConfig: d.config,
+					})
+				}, nil
+			},
+			associatedPort: func() (string, error) {
+				portName, err := vswitch.GetInterfaceAssociatedOVNSwitchPort(context.TODO(), integrationBridgeNICName)
+				if err != nil {
+					return "", fmt.Errorf("Failed getting OVN switch port associated to %q: %w", integrationBridgeNICName, err)
+				}
+
+				return portName, nil
+			},
+			associatePort: func(portName string) error {
+				return vswitch.AssociateInterfaceOVNSwitchPort(context.TODO(), integrationBridgeNICName, portName)
+			},
+			setChassis: func(portName ovn.OVNSwitchPort) error {
+				chassisID, err := vswitch.GetChassisID(context.TODO())
+				if err != nil {
+					return fmt.Errorf("Failed getting OVS Chassis ID: %w", err)
+				}
+
+				err = d.ovnnb.UpdateLogicalSwitchPortOptions(context.TODO(), portName, map[string]string{"requested-chassis": chassisID})
+				if err != nil {
+					return fmt.Errorf("Failed setting logical switch port chassis ID: %w", err)
+				}
+
+				return nil
+			},
+		}
+
+		var r revert.Hook
+		oldLogicalPortName, r, err = move.run()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		reverter.Add(r)
+	}
+
+	removeOldPort := func() error {
+		// Remove the logical switch port from the old network.
+		if oldLogicalPortName != "" {
+			err := oldNetwork.InstanceDevicePortStop(ovn.OVNSwitchPort(oldLogicalPortName), &network.OVNInstanceNICStopOpts{
+				InstanceUUID: instanceUUID,
+				DeviceName:   d.name,
+				DeviceConfig: oldConfig,
+			})
+			if err != nil {
+				return fmt.Errorf("Failed removing OVN port from network %q: %w", oldConfig["network"], err)
+			}
+		}
+
+		// Remove the DNS record and DHCP reservation from the old network.
+		err := oldNetwork.InstanceDevicePortRemove(instanceUUID, d.name, oldConfig)
+		if err != nil {
+			return fmt.Errorf("Failed removing OVN port from network %q: %w", oldConfig["network"], err)
+		}
+
+		// Remove the ACL port groups which were only used by the NIC on the old network.
+		if oldConfig["security.acls"] != "" {
+			newACLs := util.SplitNTrimSpace(d.config["security.acls"], ",", -1, true)
+			err = acl.OVNPortGroupDeleteIfUnused(d.state, d.logger, d.ovnnb, oldNetwork.Project(), d.inst, d.name, newACLs...)
+			if err != nil {
+				return fmt.Errorf("Failed removing unused OVN port groups: %w", err)
+			}
+		}
+
+		d.logger.Debug("Moved NIC to network", logger.Ctx{"oldNetwork": oldConfig["network"], "network": d.config["network"]})
+
+		return nil
+	}
+
+	cleanup := reverter.Clone().Fail
+	reverter.Success()
+
+	return cleanup, removeOldPort, nil
+}
+
 func (d *nicOVN) findRepresentorPort(volatile map[string]string) (string, error) {
 	physSwitchID, pfID, err := network.SRIOVGetSwitchAndPFID(volatile["last_state.vf.parent"])
 	if err != nil {
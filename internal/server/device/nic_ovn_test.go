@@ -0,0 +1,82 @@
+package device
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/internal/server/network/ovn"
+	"github.com/lxc/incus/v6/shared/revert"
+)
+
+// ovnTestPortMove returns an ovnNICPortMove recording the operations it performs, with setChassis failing with
+// the supplied error.
+func ovnTestPortMove(calls *[]string, setChassisErr error) ovnNICPortMove {
+	return ovnNICPortMove{
+		startPort: func() (ovn.OVNSwitchPort, revert.Hook, error) {
+			*calls = append(*calls, "start new")
+
+			return "new", func() { *calls = append(*calls, "stop new") }, nil
+		},
+		associatedPort: func() (string, error) {
+			*calls = append(*calls, "get associated")
+
+			return "old", nil
+		},
+		associatePort: func(portName string) error {
+			*calls = append(*calls, "associate "+portName)
+
+			return nil
+		},
+		setChassis: func(portName ovn.OVNSwitchPort) error {
+			*calls = append(*calls, "set chassis "+string(portName))
+
+			return setChassisErr
+		},
+	}
+}
+
+func TestOVNNICPortMove(t *testing.T) {
+	var calls []string
+
+	oldPortName, cleanup, err := ovnTestPortMove(&calls, nil).run()
+	require.NoError(t, err)
+	assert.Equal(t, "old", oldPortName)
+	assert.Equal(t, []string{"start new", "get associated", "associate new", "set chassis new"}, calls)
+
+	// Reverting the move restores the association with the old port before removing the new one.
+	calls = nil
+	cleanup()
+	assert.Equal(t, []string{"associate old", "stop new"}, calls)
+}
+
+func TestOVNNICPortMove_Failure(t *testing.T) {
+	var calls []string
+
+	// The association with the old port is restored before the new one is removed and the error returned.
+	_, cleanup, err := ovnTestPortMove(&calls, errors.New("chassis failure")).run()
+	assert.EqualError(t, err, "chassis failure")
+	assert.Nil(t, cleanup)
+	assert.Equal(t, []string{"start new", "get associated", "associate new", "set chassis new", "associate old", "stop new"}, calls)
+
+	// Failing to associate the new port only removes it.
+	calls = nil
+	move := ovnTestPortMove(&calls, nil)
+	move.associatePort = func(portName string) error {
+		calls = append(calls, "associate "+portName)
+
+		return errors.New("association failure")
+	}
+
+	_, _, err = move.run()
+	assert.EqualError(t, err, "association failure")
+	assert.Equal(t, []string{"start new", "get associated", "associate new", "stop new"}, calls)
+}
+
+func TestNICOVNUpdatableFields(t *testing.T) {
+	d := &nicOVN{}
+	assert.Contains(t, d.UpdatableFields(&nicOVN{}), "network")
+	assert.Empty(t, d.UpdatableFields(&nicBridged{}))
+}
@@ -14,6 +14,8 @@ import (
 	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
 	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	internalUtil "github.com/lxc/incus/v6/internal/util"
+	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/revert"
 	"github.com/lxc/incus/v6/shared/subprocess"
 	"github.com/lxc/incus/v6/shared/util"
@@ -221,6 +223,14 @@ func (d *tpm) startVM() (*deviceConfig.RunConfig, error) {
 	// Delete any leftover socket.
 	_ = os.Remove(socketPath)
 
+	// Provision the endorsement key of new TPMs so the guest can be attested.
+	if !util.PathExists(filepath.Join(tpmDevPath, "tpm2-00.permall")) {
+		err := d.setupEndorsementKey(tpmDevPath)
+		if err != nil {
+			d.logger.Warn("Failed to provision TPM endorsement key, attestation won't be available", logger.Ctx{"err": err})
+		}
+	}
+
 	proc, err := subprocess.NewProcess("swtpm", []string{"socket", "--tpm2", "--tpmstate", fmt.Sprintf("dir=%s", tpmDevPath), "--ctrl", fmt.Sprintf("type=unixio,path=swtpm-%s.sock", d.name)}, "", "")
 	if err != nil {
 		return nil, err
@@ -266,6 +276,47 @@ func (d *tpm) startVM() (*deviceConfig.RunConfig, error) {
 	return &runConf, nil
 }
 
+// setupEndorsementKey initializes the TPM state with an endorsement key and matching certificate.
+// The certificate is issued by a local CA (created on first use) and a copy is kept alongside the TPM state,
+// allowing incusd to check that attestation requests are handled by the TPM it created.
+func (d *tpm) setupEndorsementKey(tpmDevPath string) error {
+	_, err := exec.LookPath("swtpm_setup")
+	if err != nil {
+		return fmt.Errorf("Required tool '%s' is missing", "swtpm_setup")
+	}
+
+	localCA, err := exec.LookPath("swtpm_localca")
+	if err != nil {
+		return fmt.Errorf("Required tool '%s' is missing", "swtpm_localca")
+	}
+
+	caPath := internalUtil.VarPath("tpm")
+	err = os.MkdirAll(caPath, 0o700)
+	if err != nil {
+		return fmt.Errorf("Failed to create TPM CA path %q: %w", caPath, err)
+	}
+
+	configs := map[string]string{
+		"swtpm_setup.conf":      fmt.Sprintf("create_certs_tool = %s\ncreate_certs_tool_config = %s\ncreate_certs_tool_options = %s\n", localCA, filepath.Join(caPath, "swtpm-localca.conf"), filepath.Join(caPath, "swtpm-localca.options")),
+		"swtpm-localca.conf":    fmt.Sprintf("statedir = %s\nsigningkey = %s\nissuercert = %s\ncertserial = %s\n", caPath, filepath.Join(caPath, "signkey.pem"), filepath.Join(caPath, "issuercert.pem"), filepath.Join(caPath, "certserial")),
+		"swtpm-localca.options": "--platform-manufacturer Incus\n--platform-version 2.1\n--platform-model QEMU\n",
+	}
+
+	for name, content := range configs {
+		err := os.WriteFile(filepath.Join(caPath, name), []byte(content), 0o600)
+		if err != nil {
+			return fmt.Errorf("Failed to write %q: %w", name, err)
+		}
+	}
+
+	_, err = subprocess.RunCommand("swtpm_setup", "--tpm2", "--tpmstate", tpmDevPath, "--createek", "--create-ek-cert", "--write-ek-cert-files", tpmDevPath, "--config", filepath.Join(caPath, "swtpm_setup.conf"))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // Stop terminates the TPM emulator.
 func (d *tpm) Stop() (*deviceConfig.RunConfig, error) {
 	pidPath := filepath.Join(d.inst.DevicesPath(), fmt.Sprintf("%s.pid", d.name))
@@ -0,0 +1,167 @@
+package events
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// changeLogSize is the number of changes kept in memory.
+const changeLogSize = 4096
+
+// ChangeLog keeps track of the entities modified on the server (and the rest of the cluster) based on lifecycle events.
+// Every change bumps the generation, allowing clients to cheaply find out what changed since they last looked.
+type ChangeLog struct {
+	lock sync.Mutex
+
+	epoch      string
+	generation uint64
+	entries    []api.ChangesEntity
+
+	// entities maps the URL of the entities in the log to the generation of their most recent change.
+	entities map[string]uint64
+
+	// trimmed is the generation of the most recent change dropped from the log.
+	trimmed uint64
+}
+
+// newChangeLog returns a new empty change log with a random epoch.
+func newChangeLog() *ChangeLog {
+	return &ChangeLog{
+		epoch:    uuid.New().String(),
+		entries:  make([]api.ChangesEntity, 0, changeLogSize),
+		entities: map[string]uint64{},
+	}
+}
+
+// record adds the entity referenced by a lifecycle event to the change log.
+func (c *ChangeLog) record(event api.Event) {
+	if event.Type != api.EventTypeLifecycle {
+		return
+	}
+
+	lifecycle := api.EventLifecycle{}
+	err := json.Unmarshal(event.Metadata, &lifecycle)
+	if err != nil || lifecycle.Source == "" {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.generation++
+
+	// Only keep the most recent changes around.
+	if len(c.entries) >= changeLogSize*2 {
+		cut := len(c.entries) - changeLogSize
+		c.trimmed = c.entries[cut-1].Generation
+		c.entries = append(make([]api.ChangesEntity, 0, changeLogSize*2), c.entries[cut:]...)
+
+		for url, generation := range c.entities {
+			if generation <= c.trimmed {
+				delete(c.entities, url)
+			}
+		}
+	}
+
+	c.entities[lifecycle.Source] = c.generation
+
+	c.entries = append(c.entries, api.ChangesEntity{
+		URL:        lifecycle.Source,
+		Project:    event.Project,
+		Action:     lifecycle.Action,
+		Generation: c.generation,
+		Timestamp:  event.Timestamp,
+		Location:   event.Location,
+	})
+}
+
+// Generation returns the epoch and current generation of the change log.
+func (c *ChangeLog) Generation() (string, uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.epoch, c.generation
+}
+
+// EntityGeneration returns the generation of the most recent change to the entities at or below the given URLs
+// (ignoring their query string), such as "/1.0/instances" for all instances.
+// As changes dropped from the log are no longer attributed to an entity, the generation of the most recent of them
+// is returned if higher. The result therefore changes whenever one of those entities does.
+func (c *ChangeLog) EntityGeneration(urls ...string) uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	generation := c.trimmed
+	for entityURL, entityGeneration := range c.entities {
+		if entityGeneration <= generation {
+			continue
+		}
+
+		path, _, _ := strings.Cut(entityURL, "?")
+		for _, url := range urls {
+			if path == url || strings.HasPrefix(path, url+"/") {
+				generation = entityGeneration
+				break
+			}
+		}
+	}
+
+	return generation
+}
+
+// Since returns the entities modified after the given generation, each listed once with its most recent change.
+// The filter function (if provided) allows restricting the entities returned.
+// If some of the changes since that generation are no longer known, the result is flagged as needing a reset.
+func (c *ChangeLog) Since(generation uint64, filter func(entity api.ChangesEntity) bool) *api.Changes {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	changes := &api.Changes{
+		Epoch:      c.epoch,
+		Generation: c.generation,
+		Entities:   []api.ChangesEntity{},
+	}
+
+	// A generation from the future comes from a previous epoch.
+	if generation > c.generation {
+		changes.Reset = true
+		return changes
+	}
+
+	// Check that no change was dropped since that generation.
+	if len(c.entries) > 0 && generation+1 < c.entries[0].Generation {
+		changes.Reset = true
+		return changes
+	}
+
+	seen := map[string]bool{}
+	for i := len(c.entries) - 1; i >= 0; i-- {
+		entity := c.entries[i]
+		if entity.Generation <= generation {
+			break
+		}
+
+		if seen[entity.URL] {
+			continue
+		}
+
+		seen[entity.URL] = true
+
+		if filter != nil && !filter(entity) {
+			continue
+		}
+
+		changes.Entities = append(changes.Entities, entity)
+	}
+
+	// Return the most recent changes last.
+	slices.Reverse(changes.Entities)
+
+	return changes
+}
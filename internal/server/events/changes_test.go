@@ -0,0 +1,120 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// recordChange adds a lifecycle event for the given entity to the change log.
+func recordChange(t *testing.T, c *ChangeLog, action string, source string) {
+	metadata, err := json.Marshal(api.EventLifecycle{Action: action, Source: source})
+	require.NoError(t, err)
+
+	c.record(api.Event{Type: api.EventTypeLifecycle, Project: "default", Metadata: metadata})
+}
+
+func changesURLs(changes *api.Changes) []string {
+	urls := []string{}
+	for _, entity := range changes.Entities {
+		urls = append(urls, entity.URL)
+	}
+
+	return urls
+}
+
+func TestChangeLogSince(t *testing.T) {
+	c := newChangeLog()
+
+	recordChange(t, c, "instance-created", "/1.0/instances/c1")
+	recordChange(t, c, "instance-created", "/1.0/instances/c2")
+	recordChange(t, c, "instance-updated", "/1.0/instances/c1")
+	recordChange(t, c, "network-created", "/1.0/networks/n1")
+
+	// Non-lifecycle events aren't recorded.
+	c.record(api.Event{Type: api.EventTypeLogging, Metadata: []byte("{}")})
+
+	changes := c.Since(0, nil)
+	assert.False(t, changes.Reset)
+	assert.Equal(t, uint64(4), changes.Generation)
+	assert.Equal(t, []string{"/1.0/instances/c2", "/1.0/instances/c1", "/1.0/networks/n1"}, changesURLs(changes))
+	assert.Equal(t, "instance-updated", changes.Entities[1].Action)
+	assert.Equal(t, uint64(3), changes.Entities[1].Generation)
+
+	changes = c.Since(2, nil)
+	assert.False(t, changes.Reset)
+	assert.Equal(t, []string{"/1.0/instances/c1", "/1.0/networks/n1"}, changesURLs(changes))
+
+	changes = c.Since(4, nil)
+	assert.False(t, changes.Reset)
+	assert.Empty(t, changes.Entities)
+
+	changes = c.Since(0, func(entity api.ChangesEntity) bool { return entity.URL != "/1.0/networks/n1" })
+	assert.Equal(t, []string{"/1.0/instances/c2", "/1.0/instances/c1"}, changesURLs(changes))
+}
+
+func TestChangeLogSinceReset(t *testing.T) {
+	c := newChangeLog()
+
+	recordChange(t, c, "instance-created", "/1.0/instances/c1")
+
+	// A generation from the future comes from a previous epoch.
+	changes := c.Since(10, nil)
+	assert.True(t, changes.Reset)
+	assert.Empty(t, changes.Entities)
+}
+
+func TestChangeLogSinceTrimmed(t *testing.T) {
+	c := newChangeLog()
+
+	for i := range changeLogSize * 2 {
+		recordChange(t, c, "instance-updated", fmt.Sprintf("/1.0/instances/c%d", i))
+	}
+
+	// Trigger the trimming of the oldest changes.
+	recordChange(t, c, "network-created", "/1.0/networks/n1")
+
+	trimmed := uint64(changeLogSize)
+	assert.Equal(t, trimmed, c.trimmed)
+	assert.Len(t, c.entries, changeLogSize+1)
+
+	// Changes dropped from the log can't be listed anymore.
+	changes := c.Since(trimmed-1, nil)
+	assert.True(t, changes.Reset)
+	assert.Empty(t, changes.Entities)
+
+	// All the changes since the most recent dropped one are still known.
+	changes = c.Since(trimmed, nil)
+	assert.False(t, changes.Reset)
+	assert.Len(t, changes.Entities, changeLogSize+1)
+	assert.Equal(t, "/1.0/networks/n1", changes.Entities[len(changes.Entities)-1].URL)
+
+	// Entities only modified before the trimmed changes are forgotten.
+	_, ok := c.entities["/1.0/instances/c0"]
+	assert.False(t, ok)
+}
+
+func TestChangeLogEntityGeneration(t *testing.T) {
+	c := newChangeLog()
+
+	assert.Equal(t, uint64(0), c.EntityGeneration("/1.0/instances"))
+
+	recordChange(t, c, "instance-created", "/1.0/instances/c1?project=foo")
+	recordChange(t, c, "instance-snapshot-created", "/1.0/instances/c1/snapshots/snap0?project=foo")
+	recordChange(t, c, "network-created", "/1.0/networks/n1")
+	recordChange(t, c, "instance-created", "/1.0/instances-other/c1")
+
+	assert.Equal(t, uint64(2), c.EntityGeneration("/1.0/instances"))
+	assert.Equal(t, uint64(2), c.EntityGeneration("/1.0/instances/c1"))
+	assert.Equal(t, uint64(3), c.EntityGeneration("/1.0/instances", "/1.0/networks"))
+	assert.Equal(t, uint64(0), c.EntityGeneration("/1.0/profiles"))
+
+	// Trimmed changes may have affected any entity.
+	c.trimmed = 10
+	assert.Equal(t, uint64(10), c.EntityGeneration("/1.0/profiles"))
+}
@@ -40,6 +40,7 @@ type Server struct {
 	listeners map[string]*Listener
 	notify    NotifyFunc
 	location  string
+	changes   *ChangeLog
 }
 
 // NewServer returns a new event server.
@@ -51,6 +52,7 @@ func NewServer(debug bool, verbose bool, notify NotifyFunc) *Server {
 		},
 		listeners: map[string]*Listener{},
 		notify:    notify,
+		changes:   newChangeLog(),
 	}
 
 	return server
@@ -65,6 +67,11 @@ func (s *Server) SetLocalLocation(location string) {
 	s.location = location
 }
 
+// Changes returns the log of the entities modified on this server and the rest of the cluster.
+func (s *Server) Changes() *ChangeLog {
+	return s.changes
+}
+
 // AddListener creates and returns a new event listener.
 func (s *Server) AddListener(projectName string, allProjects bool, projectPermissionFunc auth.PermissionChecker, connection EventListenerConnection, messageTypes []string, excludeSources []EventSource, recvFunc EventHandler, excludeLocations []string) (*Listener, error) {
 	if allProjects && projectName != "" {
@@ -180,6 +187,9 @@ func (s *Server) broadcast(event api.Event, eventSource EventSource) error {
 		s.notify(event)
 	}
 
+	// Keep track of the modified entities.
+	s.changes.record(event)
+
 	listeners := s.listeners
 	for _, listener := range listeners {
 		// If the event is project specific, check if the listener is requesting events from that project.
@@ -40,6 +40,13 @@ type ACLRule struct {
 	DestinationPort string
 	ICMPType        string
 	ICMPCode        string
+	Counter         string // Name of the counter counting matched packets (optional).
+}
+
+// ACLCounter represents the packets and bytes matched by the ACL rules using a counter.
+type ACLCounter struct {
+	Packets uint64
+	Bytes   uint64
 }
 
 // AddressForward represents a NAT address forward.
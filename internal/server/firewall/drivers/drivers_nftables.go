@@ -480,6 +480,8 @@ func (d Nftables) InstanceSetupBridgeFilter(projectName string, instanceName str
 	tplFields["aclOutAcceptRules"] = nftRules.outAcceptRules
 	tplFields["aclOutDefaultRule"] = nftRules.defaultOutRule
 
+	tplFields["counters"] = aclRuleCounters(aclRules)
+
 	// Required for basic connectivity
 	tplFields["dnsIPv4"] = IPv4DNS
 	tplFields["dnsIPv6"] = IPv6DNS
@@ -896,6 +898,55 @@ func (d Nftables) InstanceClearRPFilter(projectName string, instanceName string,
 	return nil
 }
 
+// InstanceSetupSNAT sets up egress SNAT rules for the specified instance device's addresses.
+// Traffic that leaves through the parent bridge (rather than being routed out of it) isn't translated.
+func (d Nftables) InstanceSetupSNAT(projectName string, instanceName string, deviceName string, parentName string, SNATV4 *SNATOpts, SNATV6 *SNATOpts) error {
+	rules := make(map[string]*SNATOpts)
+
+	if SNATV4 != nil {
+		rules["ip"] = SNATV4
+	}
+
+	if SNATV6 != nil {
+		rules["ip6"] = SNATV6
+	}
+
+	for ipFamily, rule := range rules {
+		if rule.Subnet == nil || rule.SNATAddress == nil {
+			return fmt.Errorf("Source subnet and SNAT address are required (%s)", ipFamily)
+		}
+	}
+
+	deviceLabel := d.instanceDeviceLabel(projectName, instanceName, deviceName)
+	tplFields := map[string]any{
+		"namespace":      nftablesNamespace,
+		"chainSeparator": nftablesChainSeparator,
+		"deviceLabel":    deviceLabel,
+		"parentName":     parentName,
+		"family":         "inet",
+		"rules":          rules,
+	}
+
+	err := d.applyNftConfig(nftablesInstanceSNAT, tplFields)
+	if err != nil {
+		return fmt.Errorf("Failed adding SNAT rules for instance device %q (%s): %w", deviceLabel, tplFields["family"], err)
+	}
+
+	return nil
+}
+
+// InstanceClearSNAT removes egress SNAT rules for the specified instance device.
+func (d Nftables) InstanceClearSNAT(projectName string, instanceName string, deviceName string) error {
+	deviceLabel := d.instanceDeviceLabel(projectName, instanceName, deviceName)
+
+	err := d.removeChains([]string{"inet"}, deviceLabel, "snat")
+	if err != nil {
+		return fmt.Errorf("Failed clearing SNAT rules for instance device %q: %w", deviceLabel, err)
+	}
+
+	return nil
+}
+
 // InstanceSetupNetPrio activates setting of skb->priority for the specified instance device on the host interface.
 func (d Nftables) InstanceSetupNetPrio(projectName string, instanceName string, deviceName string, netPrio uint32) error {
 	deviceLabel := d.instanceDeviceLabel(projectName, instanceName, deviceName)
@@ -971,6 +1022,7 @@ func (d Nftables) NetworkApplyACLRules(networkName string, rules []ACLRule) erro
 		"networkName":    networkName,
 		"family":         "inet",
 		"rules":          completeNftRules,
+		"counters":       aclRuleCounters(rules),
 	}
 
 	config := &strings.Builder{}
@@ -987,6 +1039,156 @@ func (d Nftables) NetworkApplyACLRules(networkName string, rules []ACLRule) erro
 	return nil
 }
 
+// aclRuleCounters returns the names of the counters used by the ACL rules.
+func aclRuleCounters(rules []ACLRule) []string {
+	counters := []string{}
+	for _, rule := range rules {
+		if rule.Counter != "" && !slices.Contains(counters, rule.Counter) {
+			counters = append(counters, rule.Counter)
+		}
+	}
+
+	return counters
+}
+
+// NetworkACLCounters returns the ACL rule counters with their current values, summed across the tables
+// using them.
+func (d Nftables) NetworkACLCounters() (map[string]ACLCounter, error) {
+	output, err := subprocess.RunCommand("nft", "--json", "list", "counters")
+	if err != nil {
+		return nil, fmt.Errorf("Failed listing nftables counters: %w", err)
+	}
+
+	v := &struct {
+		Nftables []map[string]struct {
+			Table   string `json:"table"`
+			Name    string `json:"name"`
+			Packets uint64 `json:"packets"`
+			Bytes   uint64 `json:"bytes"`
+		} `json:"nftables"`
+	}{}
+
+	err = json.Unmarshal([]byte(output), v)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing nftables counters: %w", err)
+	}
+
+	counters := map[string]ACLCounter{}
+	for _, item := range v.Nftables {
+		counter, ok := item["counter"]
+		if !ok || counter.Table != nftablesNamespace {
+			continue
+		}
+
+		total := counters[counter.Name]
+		total.Packets += counter.Packets
+		total.Bytes += counter.Bytes
+		counters[counter.Name] = total
+	}
+
+	return counters, nil
+}
+
+// NetworkDeleteACLCountersIfUnused deletes the ACL rule counters which aren't referenced by any rule anymore.
+func (d Nftables) NetworkDeleteACLCountersIfUnused() error {
+	output, err := subprocess.RunCommand("nft", "--json", "list", "counters")
+	if err != nil {
+		return fmt.Errorf("Failed listing nftables counters: %w", err)
+	}
+
+	counters := &struct {
+		Nftables []map[string]struct {
+			Family string `json:"family"`
+			Table  string `json:"table"`
+			Name   string `json:"name"`
+		} `json:"nftables"`
+	}{}
+
+	err = json.Unmarshal([]byte(output), counters)
+	if err != nil {
+		return fmt.Errorf("Failed parsing nftables counters: %w", err)
+	}
+
+	// Rules reference named counters with a "counter" statement holding the counter name.
+	output, err = subprocess.RunCommand("nft", "--json", "list", "ruleset")
+	if err != nil {
+		return fmt.Errorf("Failed listing nftables ruleset: %w", err)
+	}
+
+	var ruleset any
+	err = json.Unmarshal([]byte(output), &ruleset)
+	if err != nil {
+		return fmt.Errorf("Failed parsing nftables ruleset: %w", err)
+	}
+
+	used := map[string]bool{}
+	nftCounterReferences(ruleset, used)
+
+	for _, item := range counters.Nftables {
+		counter, ok := item["counter"]
+		if !ok || counter.Table != nftablesNamespace || !strings.HasPrefix(counter.Name, "incus_acl") {
+			continue
+		}
+
+		if used[counter.Family+"/"+counter.Name] {
+			continue
+		}
+
+		_, err := subprocess.RunCommand("nft", "delete", "counter", counter.Family, nftablesNamespace, counter.Name)
+		if err != nil {
+			return fmt.Errorf("Failed deleting unused counter %q: %w", counter.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// nftCounterReferences records the named counters referenced by the rules of our table in the JSON ruleset,
+// keyed by "<family>/<name>".
+func nftCounterReferences(ruleset any, used map[string]bool) {
+	root, ok := ruleset.(map[string]any)
+	if !ok {
+		return
+	}
+
+	items, _ := root["nftables"].([]any)
+	for _, item := range items {
+		entry, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		rule, ok := entry["rule"].(map[string]any)
+		if !ok || rule["table"] != nftablesNamespace {
+			continue
+		}
+
+		family, _ := rule["family"].(string)
+
+		var walk func(v any)
+		walk = func(v any) {
+			switch v := v.(type) {
+			case map[string]any:
+				name, ok := v["counter"].(string)
+				if ok {
+					used[family+"/"+name] = true
+				}
+
+				for _, child := range v {
+					walk(child)
+				}
+
+			case []any:
+				for _, child := range v {
+					walk(child)
+				}
+			}
+		}
+
+		walk(rule["expr"])
+	}
+}
+
 // buildRemainingRuleParts is a helper that returns the protocol, port, logging, and action parts of a rule.
 func (d Nftables) buildRemainingRuleParts(rule *ACLRule, ipVersion uint) (string, error) {
 	args := []string{}
@@ -1023,6 +1225,11 @@ func (d Nftables) buildRemainingRuleParts(rule *ACLRule, ipVersion uint) (string
 		}
 	}
 
+	// Handle counting.
+	if rule.Counter != "" {
+		args = append(args, "counter", "name", rule.Counter)
+	}
+
 	// Handle logging.
 	if rule.Log {
 		args = append(args, "log")
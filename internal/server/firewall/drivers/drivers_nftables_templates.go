@@ -168,6 +168,10 @@ var nftablesNetACLRules = template.Must(template.New("nftablesNetACLRules").Pars
 flush chain {{.family}} {{.namespace}} acl{{.chainSeparator}}{{.networkName}}
 
 table {{.family}} {{.namespace}} {
+	{{ range .counters }}
+	counter {{.}} {}
+	{{ end }}
+
 	chain acl{{.chainSeparator}}{{.networkName}} {
                 ct state established,related accept
 
@@ -187,6 +191,10 @@ table {{.family}} {{.namespace}} {
 // we need to use manual header offset extraction. This also drops IPv6 router advertisements from instance.
 // If IP filtering is enabled, this also drops unwanted ethernet frames.
 var nftablesInstanceBridgeFilter = template.Must(template.New("nftablesInstanceBridgeFilter").Parse(`
+{{ range .counters }}
+counter {{.}} {}
+{{ end }}
+
 chain in{{.chainSeparator}}{{.deviceLabel}} {
 	type filter hook input priority -200; policy accept;
 
@@ -370,6 +378,19 @@ chain prert{{.chainSeparator}}{{.deviceLabel}} {
 }
 `))
 
+// nftablesInstanceSNAT defines the rules to perform egress SNAT of an instance NIC's addresses.
+// The chain priority is one lower than the network's outbound NAT chain so that the first NAT decision made for a
+// connection (and so the one that applies) is the instance specific one.
+var nftablesInstanceSNAT = template.Must(template.New("nftablesInstanceSNAT").Parse(`
+chain snat{{.chainSeparator}}{{.deviceLabel}} {
+	type nat hook postrouting priority 99; policy accept;
+
+	{{ range $ipFamily, $config := .rules }}
+	{{$ipFamily}} saddr {{$config.Subnet}} oifname != "{{$.parentName}}" snat {{$config.SNATAddress}}
+	{{ end }}
+}
+`))
+
 // nftablesInstanceNetPrio defines the rules to perform setting of skb->priority.
 var nftablesInstanceNetPrio = template.Must(template.New("nftablesInstanceNetPrio").Parse(`
 chain egress{{.chainSeparator}}netprio{{.chainSeparator}}{{.deviceLabel}} {
@@ -0,0 +1,42 @@
+package drivers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_nftCounterReferences(t *testing.T) {
+	output := `{"nftables": [
+		{"metainfo": {"version": "1.0.9", "json_schema_version": 1}},
+		{"table": {"family": "inet", "name": "incus", "handle": 1}},
+		{"counter": {"family": "inet", "name": "incus_acl1_ingress_0a1b2c3d", "table": "incus", "packets": 0, "bytes": 0}},
+		{"counter": {"family": "inet", "name": "incus_acl1_ingress_deadbeef", "table": "incus", "packets": 0, "bytes": 0}},
+		{"rule": {"family": "inet", "table": "incus", "chain": "acl.incusbr0", "expr": [
+			{"match": {"op": "==", "left": {"payload": {"protocol": "ip", "field": "saddr"}}, "right": "192.0.2.1"}},
+			{"counter": "incus_acl1_ingress_0a1b2c3d"},
+			{"accept": null}
+		]}},
+		{"rule": {"family": "bridge", "table": "incus", "chain": "in.c1.eth0", "expr": [
+			{"counter": {"packets": 0, "bytes": 0}},
+			{"counter": "incus_acl2_egress_01234567"},
+			{"drop": null}
+		]}},
+		{"rule": {"family": "inet", "table": "other", "chain": "input", "expr": [
+			{"counter": "incus_acl1_ingress_deadbeef"}
+		]}}
+	]}`
+
+	var ruleset any
+	require.NoError(t, json.Unmarshal([]byte(output), &ruleset))
+
+	used := map[string]bool{}
+	nftCounterReferences(ruleset, used)
+
+	assert.Equal(t, map[string]bool{
+		"inet/incus_acl1_ingress_0a1b2c3d":  true,
+		"bridge/incus_acl2_egress_01234567": true,
+	}, used)
+}
@@ -1381,6 +1381,54 @@ func (d Xtables) InstanceClearRPFilter(projectName string, instanceName string,
 	return nil
 }
 
+// InstanceSetupSNAT sets up egress SNAT rules for the specified instance device's addresses.
+// Traffic that leaves through the parent bridge (rather than being routed out of it) isn't translated.
+func (d Xtables) InstanceSetupSNAT(projectName string, instanceName string, deviceName string, parentName string, SNATV4 *SNATOpts, SNATV6 *SNATOpts) error {
+	comment := fmt.Sprintf("%s snat", d.instanceDeviceIPTablesComment(projectName, instanceName, deviceName))
+
+	rules := map[uint]*SNATOpts{}
+	if SNATV4 != nil {
+		rules[4] = SNATV4
+	}
+
+	if SNATV6 != nil {
+		rules[6] = SNATV6
+	}
+
+	for ipVersion, rule := range rules {
+		if rule.Subnet == nil || rule.SNATAddress == nil {
+			return fmt.Errorf("Source subnet and SNAT address are required (IPv%d)", ipVersion)
+		}
+
+		// Prepend the rule so that it takes precedence over the network's outbound NAT rule.
+		err := d.iptablesPrepend(ipVersion, comment, "nat", "POSTROUTING", "-s", rule.Subnet.String(), "!", "-o", parentName, "-j", "SNAT", "--to", rule.SNATAddress.String())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// InstanceClearSNAT removes egress SNAT rules for the specified instance device.
+func (d Xtables) InstanceClearSNAT(projectName string, instanceName string, deviceName string) error {
+	comment := fmt.Sprintf("%s snat", d.instanceDeviceIPTablesComment(projectName, instanceName, deviceName))
+	errs := []error{}
+
+	for _, ipVersion := range []uint{4, 6} {
+		err := d.iptablesClear(ipVersion, []string{comment}, "nat")
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("Failed to remove SNAT rules for %q: %v", deviceName, errs)
+	}
+
+	return nil
+}
+
 // InstanceSetupNetPrio activates setting of skb->priority for the specified instance device on the host interface.
 func (d Xtables) InstanceSetupNetPrio(projectName string, instanceName string, deviceName string, netPrio uint32) error {
 	comment := fmt.Sprintf("%s netprio", d.instanceDeviceIPTablesComment(projectName, instanceName, deviceName))
@@ -1669,6 +1717,16 @@ func (d Xtables) NetworkApplyAddressSets(sets []AddressSet, nftTable string) err
 	return fmt.Errorf("Address sets aren't supported by xtables firewalling")
 }
 
+// NetworkACLCounters isn't supported under xtables.
+func (d Xtables) NetworkACLCounters() (map[string]ACLCounter, error) {
+	return nil, fmt.Errorf("ACL counters aren't supported by xtables firewalling")
+}
+
+// NetworkDeleteACLCountersIfUnused isn't supported under xtables.
+func (d Xtables) NetworkDeleteACLCountersIfUnused() error {
+	return fmt.Errorf("ACL counters aren't supported by xtables firewalling")
+}
+
 // NetworkDeleteAddressSetsIfUnused  isn't supported under xtables.
 func (d Xtables) NetworkDeleteAddressSetsIfUnused(nftTable string) error {
 	return fmt.Errorf("Address sets aren't supported by xtables firewalling")
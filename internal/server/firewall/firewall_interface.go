@@ -23,6 +23,8 @@ type Firewall interface {
 	NetworkSetup(networkName string, opts drivers.Opts) error
 	NetworkClear(networkName string, delete bool, ipVersions []uint) error
 	NetworkApplyACLRules(networkName string, rules []drivers.ACLRule) error
+	NetworkACLCounters() (map[string]drivers.ACLCounter, error)
+	NetworkDeleteACLCountersIfUnused() error
 	NetworkApplyForwards(networkName string, rules []drivers.AddressForward) error
 	NetworkApplyAddressSets(sets []drivers.AddressSet, nftTable string) error
 	NetworkDeleteAddressSetsIfUnused(nftTable string) error
@@ -36,6 +38,9 @@ type Firewall interface {
 	InstanceSetupRPFilter(projectName string, instanceName string, deviceName string, hostName string) error
 	InstanceClearRPFilter(projectName string, instanceName string, deviceName string) error
 
+	InstanceSetupSNAT(projectName string, instanceName string, deviceName string, parentName string, SNATV4 *drivers.SNATOpts, SNATV6 *drivers.SNATOpts) error
+	InstanceClearSNAT(projectName string, instanceName string, deviceName string) error
+
 	InstanceSetupNetPrio(projectName string, instanceName string, deviceName string, netPrio uint32) error
 	InstanceClearNetPrio(projectName string, instanceName string, deviceName string) error
 }
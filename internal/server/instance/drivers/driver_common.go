@@ -606,6 +606,45 @@ func (d *common) expandConfig() error {
 	d.expandedConfig = db.ExpandInstanceConfig(d.localConfig, d.profiles)
 	d.expandedDevices = db.ExpandInstanceDevices(d.localDevices, d.profiles)
 
+	// Apply the mandatory network ACLs of the project to the NICs connected to bridge and OVN networks.
+	if len(project.NetworkACLsMandatory(&d.project)) > 0 {
+		var networks map[int64]api.Network
+		err := d.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			var err error
+			networks, err = tx.GetCreatedNetworksByProject(ctx, project.NetworkProjectFromRecord(&d.project))
+
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("Failed loading networks: %w", err)
+		}
+
+		project.ApplyNetworkACLsMandatory(&d.project, d.expandedDevices, networks)
+	}
+
+	return nil
+}
+
+// refreshProject reloads the project of the instance, so that updates apply the current project config
+// (such as its mandatory network ACLs).
+func (d *common) refreshProject() error {
+	var p *api.Project
+	err := d.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		dbProject, err := dbCluster.GetProject(ctx, tx.Tx(), d.project.Name)
+		if err != nil {
+			return err
+		}
+
+		p, err = dbProject.ToAPI(ctx, tx.Tx())
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Failed loading project %q: %w", d.project.Name, err)
+	}
+
+	d.project = *p
+
 	return nil
 }
 
@@ -700,7 +739,8 @@ func (d *common) restartCommon(inst instance.Instance, timeout time.Duration) er
 }
 
 // rebuildCommon handles the common part of instance rebuilds.
-func (d *common) rebuildCommon(inst instance.Instance, img *api.Image, op *operations.Operation) error {
+// With keepSnapshots, the volume content is replaced in place so that existing snapshots are kept.
+func (d *common) rebuildCommon(inst instance.Instance, img *api.Image, keepSnapshots bool, op *operations.Operation) error {
 	instLocalConfig := d.localConfig
 
 	// Reset the "image.*" keys.
@@ -729,22 +769,29 @@ func (d *common) rebuildCommon(inst instance.Instance, img *api.Image, op *opera
 		return err
 	}
 
-	err = pool.DeleteInstance(inst, op)
-	if err != nil {
-		return err
-	}
-
-	// Rebuild as empty if there is no image provided.
-	if img == nil {
-		err = pool.CreateInstance(inst, nil)
+	if img != nil && keepSnapshots {
+		err = pool.ReplaceInstanceFromImage(inst, img.Fingerprint, op)
 		if err != nil {
 			return err
 		}
 	} else {
-		err = pool.CreateInstanceFromImage(inst, img.Fingerprint, op)
+		err = pool.DeleteInstance(inst, op)
 		if err != nil {
 			return err
 		}
+
+		// Rebuild as empty if there is no image provided.
+		if img == nil {
+			err = pool.CreateInstance(inst, nil)
+			if err != nil {
+				return err
+			}
+		} else {
+			err = pool.CreateInstanceFromImage(inst, img.Fingerprint, op)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	err = d.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
@@ -1050,6 +1097,20 @@ func (d *common) canMigrate(inst instance.Instance) string {
 	return "migrate"
 }
 
+// nonMigratableDevices returns the names of the instance devices which can't be migrated.
+func (d *common) nonMigratableDevices(inst instance.Instance) []string {
+	devNames := []string{}
+
+	for _, entry := range d.ExpandedDevices().Sorted() {
+		dev, err := d.deviceLoad(inst, entry.Name, entry.Config)
+		if err != nil || !dev.CanMigrate() {
+			devNames = append(devNames, entry.Name)
+		}
+	}
+
+	return devNames
+}
+
 // recordLastState records last power and used time into local config and database config.
 func (d *common) recordLastState() error {
 	var err error
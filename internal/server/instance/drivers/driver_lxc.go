@@ -66,6 +66,8 @@ import (
 	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/scriptlet"
+	scriptletLoad "github.com/lxc/incus/v6/internal/server/scriptlet/load"
 	"github.com/lxc/incus/v6/internal/server/seccomp"
 	"github.com/lxc/incus/v6/internal/server/state"
 	storagePools "github.com/lxc/incus/v6/internal/server/storage"
@@ -2592,7 +2594,7 @@ ff02::2 ip6-allrouters
 		}
 	}
 
-	// Load the LXC raw config.
+	// Load the LXC raw config and run the LXC scriptlet.
 	err = d.loadRawLXCConfig(cc)
 	if err != nil {
 		return "", nil, err
@@ -2640,7 +2642,12 @@ ff02::2 ip6-allrouters
 	}
 
 	if snapName != "" && expiry != nil {
-		err := d.snapshot(snapName, *expiry, false)
+		err := instance.PruneSnapshots(d)
+		if err != nil {
+			return "", nil, fmt.Errorf("Failed pruning snapshots: %w", err)
+		}
+
+		err = d.snapshot(snapName, *expiry, false)
 		if err != nil {
 			return "", nil, fmt.Errorf("Failed taking startup snapshot: %w", err)
 		}
@@ -3025,7 +3032,7 @@ func (d *lxc) Stop(stateful bool) error {
 
 	// Load the go-lxc struct
 	var cc *liblxc.Container
-	if d.expandedConfig["raw.lxc"] != "" {
+	if d.hasRawLXCConfig() {
 		cc, err = d.initLXC(true)
 		if err != nil {
 			op.Done(err)
@@ -3207,7 +3214,7 @@ func (d *lxc) Shutdown(timeout time.Duration) error {
 
 	// Load the go-lxc struct
 	var cc *liblxc.Container
-	if d.expandedConfig["raw.lxc"] != "" {
+	if d.hasRawLXCConfig() {
 		cc, err = d.initLXC(true)
 		if err != nil {
 			op.Done(err)
@@ -3271,8 +3278,8 @@ func (d *lxc) Restart(timeout time.Duration) error {
 }
 
 // Rebuild rebuilds the instance using the supplied image fingerprint as source.
-func (d *lxc) Rebuild(img *api.Image, op *operations.Operation) error {
-	return d.rebuildCommon(d, img, op)
+func (d *lxc) Rebuild(img *api.Image, keepSnapshots bool, op *operations.Operation) error {
+	return d.rebuildCommon(d, img, keepSnapshots, op)
 }
 
 // onStopNS is triggered by LXC's stop hook once a container is shutdown but before the container's
@@ -3820,6 +3827,7 @@ func (d *lxc) renderState(statusCode api.StatusCode, hostInterfaces []net.Interf
 		status.Network = d.networkState(hostInterfaces)
 		status.Pid = int64(pid)
 		status.Processes = processesState
+		status.CloudInit = linux.CloudInitStatus(fmt.Sprintf("/proc/%d/root", pid))
 
 		status.StartedAt, err = d.processStartedAt(d.InitPID())
 		if err != nil {
@@ -3876,7 +3884,7 @@ func (d *lxc) snapshot(name string, expiry time.Time, stateful bool) error {
 		}()
 
 		// Load the go-lxc struct
-		if d.expandedConfig["raw.lxc"] != "" {
+		if d.hasRawLXCConfig() {
 			cc, err := d.initLXC(true)
 			if err != nil {
 				return err
@@ -4632,6 +4640,7 @@ func (d *lxc) Update(args db.InstanceArgs, userRequested bool) error {
 	}
 
 	oldExpiryDate := d.expiryDate
+	oldProject := d.project
 
 	// Define a function which reverts everything.  Defer this function
 	// so that it doesn't need to be explicitly called in every failing
@@ -4649,6 +4658,7 @@ func (d *lxc) Update(args db.InstanceArgs, userRequested bool) error {
 			d.localDevices = oldLocalDevices
 			d.profiles = oldProfiles
 			d.expiryDate = oldExpiryDate
+			d.project = oldProject
 			d.release()
 			d.cConfig = false
 			_, _ = d.initLXC(true)
@@ -4665,6 +4675,12 @@ func (d *lxc) Update(args db.InstanceArgs, userRequested bool) error {
 	d.profiles = args.Profiles
 	d.expiryDate = args.ExpiryDate
 
+	// Refresh the project so that changes to its mandatory network ACLs get applied.
+	err = d.refreshProject()
+	if err != nil {
+		return err
+	}
+
 	// Expand the config and refresh the LXC config
 	err = d.expandConfig()
 	if err != nil {
@@ -4791,7 +4807,7 @@ func (d *lxc) Update(args db.InstanceArgs, userRequested bool) error {
 	}
 
 	// If raw.lxc changed, re-validate the config.
-	if slices.Contains(changedConfig, "raw.lxc") && d.expandedConfig["raw.lxc"] != "" {
+	if (slices.Contains(changedConfig, "raw.lxc") || slices.Contains(changedConfig, "raw.lxc.scriptlet")) && d.hasRawLXCConfig() {
 		// Get a new liblxc instance.
 		cc, err := liblxc.NewContainer(d.name, d.state.OS.LxcPath)
 		if err != nil {
@@ -6516,6 +6532,17 @@ func (d *lxc) MigrateReceive(args instance.MigrateReceiveArgs) error {
 
 		// A zero length Snapshots slice indicates volume only migration in
 		// VolumeTargetArgs. So if VolumeOnly was requested, do not populate them.
+		// Check that the project allows for the received snapshots. Cluster moves don't add any
+		// as the source instance and its snapshots are removed afterwards.
+		if args.Snapshots && args.ClusterMoveSourceName == "" {
+			err = d.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+				return project.AllowSnapshotsCreation(tx, &d.project, len(snapshots))
+			})
+			if err != nil {
+				return err
+			}
+		}
+
 		if args.Snapshots {
 			volTargetArgs.Snapshots = make([]*migration.Snapshot, 0, len(snapshots))
 			for _, snap := range snapshots {
@@ -6869,7 +6896,7 @@ func (d *lxc) migrate(args *instance.CriuMigrationArgs) error {
 	} else {
 		// Load the go-lxc struct
 		var cc *liblxc.Container
-		if d.expandedConfig["raw.lxc"] != "" {
+		if d.hasRawLXCConfig() {
 			cc, err = d.initLXC(true)
 			if err != nil {
 				return err
@@ -8513,6 +8540,11 @@ func (d *lxc) CanMigrate() string {
 	return d.canMigrate(d)
 }
 
+// NonMigratableDevices returns the names of the devices which can't be migrated.
+func (d *lxc) NonMigratableDevices() []string {
+	return d.nonMigratableDevices(d)
+}
+
 // LockExclusive attempts to get exclusive access to the instance's root volume.
 func (d *lxc) LockExclusive() (*operationlock.InstanceOperation, error) {
 	if d.IsRunning() {
@@ -9049,7 +9081,23 @@ func (d *lxc) getFSStats() (*metrics.MetricSet, error) {
 	return out, nil
 }
 
+// hasRawLXCConfig returns whether the instance alters the generated LXC configuration through raw.lxc or raw.lxc.scriptlet.
+func (d *lxc) hasRawLXCConfig() bool {
+	return d.expandedConfig["raw.lxc"] != "" || d.expandedConfig["raw.lxc.scriptlet"] != ""
+}
+
+// loadRawLXCConfig applies raw.lxc and then runs the LXC scriptlet against the resulting configuration.
 func (d *lxc) loadRawLXCConfig(cc *liblxc.Container) error {
+	err := d.applyRawLXC(cc)
+	if err != nil {
+		return err
+	}
+
+	return d.runLXCScriptlet(cc)
+}
+
+// applyRawLXC loads the raw.lxc configuration into the liblxc instance.
+func (d *lxc) applyRawLXC(cc *liblxc.Container) error {
 	// Load the LXC raw config.
 	lxcConfig, ok := d.expandedConfig["raw.lxc"]
 	if !ok {
@@ -9083,6 +9131,33 @@ func (d *lxc) loadRawLXCConfig(cc *liblxc.Container) error {
 	return nil
 }
 
+// runLXCScriptlet runs the LXC scriptlet against the generated LXC configuration.
+func (d *lxc) runLXCScriptlet(cc *liblxc.Container) error {
+	src := d.expandedConfig["raw.lxc.scriptlet"]
+	if src == "" {
+		return nil
+	}
+
+	err := scriptletLoad.LXCSet(src, d.Name())
+	if err != nil {
+		return fmt.Errorf("Failed loading LXC scriptlet: %w", err)
+	}
+
+	// Render cannot return errors here.
+	render, _, _ := d.Render()
+	instanceData, ok := render.(*api.Instance)
+	if !ok {
+		return errors.New("Unexpected instance type")
+	}
+
+	err = scriptlet.LXCRun(logger.Log, instanceData, cc)
+	if err != nil {
+		return fmt.Errorf("Failed running LXC scriptlet: %w", err)
+	}
+
+	return nil
+}
+
 // forfileRunningLockName returns the forkfile-running_ID lock name.
 func (d *common) forkfileRunningLockName() string {
 	return fmt.Sprintf("forkfile-running_%d", d.id)
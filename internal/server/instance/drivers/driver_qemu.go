@@ -42,6 +42,7 @@ import (
 
 	incus "github.com/lxc/incus/v6/client"
 	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/instance/qemudefault"
 	"github.com/lxc/incus/v6/internal/instancewriter"
 	"github.com/lxc/incus/v6/internal/jmap"
 	"github.com/lxc/incus/v6/internal/linux"
@@ -57,7 +58,6 @@ import (
 	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/instance/drivers/cfg"
 	"github.com/lxc/incus/v6/internal/server/instance/drivers/edk2"
-	"github.com/lxc/incus/v6/internal/server/instance/drivers/qemudefault"
 	"github.com/lxc/incus/v6/internal/server/instance/drivers/qmp"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
 	"github.com/lxc/incus/v6/internal/server/instance/operationlock"
@@ -436,6 +436,16 @@ func (d *qemu) getMonitorEventHandler() func(event string, data map[string]any)
 				d.logger.Warn("Failed to advertise vsock address to instance agent", logger.Ctx{"err": err})
 				return
 			}
+
+			// Check the boot measurements against the reference policy.
+			if d.expandedConfig["security.attestation.pcrs"] != "" {
+				go func() {
+					_, err := d.Attest(nil)
+					if err != nil {
+						d.logger.Warn("Failed to attest instance", logger.Ctx{"err": err})
+					}
+				}()
+			}
 		} else if event == qmp.EventVMShutdown {
 			target := "stop"
 			entry, ok := data["reason"]
@@ -747,6 +757,12 @@ func (d *qemu) Shutdown(timeout time.Duration) error {
 		return err
 	}
 
+	// Run the pre-stop scriptlet stage, failures must not prevent the VM from stopping.
+	err = d.runScriptlet(monitor, "pre-stop", nil)
+	if err != nil {
+		d.logger.Warn("Failed running QEMU scriptlet", logger.Ctx{"err": err})
+	}
+
 	// Indicate to the onStop hook that if the VM stops it was due to a clean shutdown because the VM responded
 	// to the powerdown request.
 	op.SetInstanceInitiated(true)
@@ -803,8 +819,8 @@ func (d *qemu) Restart(timeout time.Duration) error {
 }
 
 // Rebuild rebuilds the instance using the supplied image fingerprint as source.
-func (d *qemu) Rebuild(img *api.Image, op *operations.Operation) error {
-	return d.rebuildCommon(d, img, op)
+func (d *qemu) Rebuild(img *api.Image, keepSnapshots bool, op *operations.Operation) error {
+	return d.rebuildCommon(d, img, keepSnapshots, op)
 }
 
 // killQemuProcess kills specified process. Optimistically attempts to wait for the process to fully exit, but does
@@ -864,6 +880,12 @@ func (d *qemu) restoreStateHandle(ctx context.Context, monitor *qmp.Monitor, f *
 // restoreState restores VM state from state file or from migration source if d.migrationReceiveStateful set.
 func (d *qemu) restoreState(monitor *qmp.Monitor) error {
 	if d.migrationReceiveStateful != nil {
+		// Let the scriptlet adjust the migration setup (capabilities, parameters, ...).
+		err := d.runScriptlet(monitor, "migration-target", nil)
+		if err != nil {
+			return err
+		}
+
 		stateConn := d.migrationReceiveStateful[api.SecretNameState]
 		if stateConn == nil {
 			return fmt.Errorf("Migration state connection is not initialized")
@@ -1137,22 +1159,56 @@ func (d *qemu) Start(stateful bool) error {
 
 // runStartupScriptlet runs startup scriptlets at config, early, pre-start and post-start stages.
 func (d *qemu) runStartupScriptlet(monitor *qmp.Monitor, stage string) error {
-	_, ok := d.expandedConfig["raw.qemu.scriptlet"]
-	if ok {
-		// Render cannot return errors here.
-		render, _, _ := d.Render()
-		instanceData, ok := render.(*api.Instance)
-		if !ok {
-			return errors.New("Unexpected instance type")
+	return d.runScriptlet(monitor, stage, nil)
+}
+
+// runScriptlet runs the QEMU scriptlet at the given stage, the device is only set for the hotplug stages.
+func (d *qemu) runScriptlet(monitor *qmp.Monitor, stage string, dev *scriptlet.QEMUDevice) error {
+	src, ok := d.expandedConfig["raw.qemu.scriptlet"]
+	if !ok {
+		return nil
+	}
+
+	// The scriptlet is precompiled when the VM starts, the other stages may be reached after a daemon restart.
+	_, _, err := scriptletLoad.QEMUProgram(d.Name())
+	if err != nil {
+		err = scriptletLoad.QEMUSet(src, d.Name())
+		if err != nil {
+			return fmt.Errorf("Failed loading QEMU scriptlet: %w", err)
 		}
+	}
 
-		err := scriptlet.QEMURun(logger.Log, instanceData, &d.cmdArgs, &d.conf, monitor, stage)
+	// Only run the scriptlet at the stages it opted into.
+	enabled, err := scriptlet.QEMUStageEnabled(d.Name(), stage)
+	if err != nil {
+		return fmt.Errorf("Failed checking QEMU scriptlet stages: %w", err)
+	}
+
+	if !enabled {
+		return nil
+	}
+
+	// Connect to the monitor when not provided by the caller.
+	if monitor == nil && stage != "config" {
+		monitor, err = qmp.Connect(d.monitorPath(), qemuSerialChardevName, d.getMonitorEventHandler(), d.QMPLogFilePath())
 		if err != nil {
-			err = fmt.Errorf("Failed running QEMU scriptlet at %s stage: %w", stage, err)
 			return err
 		}
 	}
 
+	// Render cannot return errors here.
+	render, _, _ := d.Render()
+	instanceData, ok := render.(*api.Instance)
+	if !ok {
+		return errors.New("Unexpected instance type")
+	}
+
+	err = scriptlet.QEMURun(logger.Log, instanceData, &d.cmdArgs, &d.conf, monitor, stage, dev)
+	if err != nil {
+		err = fmt.Errorf("Failed running QEMU scriptlet at %s stage: %w", stage, err)
+		return err
+	}
+
 	return nil
 }
 
@@ -1478,7 +1534,14 @@ func (d *qemu) start(stateful bool, op *operationlock.InstanceOperation) error {
 	}
 
 	if snapName != "" && expiry != nil {
-		err := d.snapshot(snapName, *expiry, false)
+		err := instance.PruneSnapshots(d)
+		if err != nil {
+			err = fmt.Errorf("Failed pruning snapshots: %w", err)
+			op.Done(err)
+			return err
+		}
+
+		err = d.snapshot(snapName, *expiry, false)
 		if err != nil {
 			err = fmt.Errorf("Failed taking startup snapshot: %w", err)
 			op.Done(err)
@@ -2372,6 +2435,13 @@ func (d *qemu) deviceStart(dev device.Device, instanceRunning bool) (*deviceConf
 	if runConf != nil {
 		// If instance is running and then live attach device.
 		if instanceRunning {
+			scriptletDev := &scriptlet.QEMUDevice{Name: dev.Name(), Config: configCopy}
+
+			err = d.runScriptlet(nil, "pre-hotplug", scriptletDev)
+			if err != nil {
+				return nil, err
+			}
+
 			// Attach NIC to running instance.
 			if len(runConf.NetworkInterface) > 0 {
 				err = d.deviceAttachNIC(dev.Name(), configCopy, runConf)
@@ -2417,6 +2487,11 @@ func (d *qemu) deviceStart(dev device.Device, instanceRunning bool) (*deviceConf
 			if err != nil {
 				return nil, err
 			}
+
+			err = d.runScriptlet(nil, "post-hotplug", scriptletDev)
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -2780,6 +2855,15 @@ func (d *qemu) deviceStop(dev device.Device, instanceRunning bool, _ string) err
 		return fmt.Errorf("Device cannot be stopped when instance is running")
 	}
 
+	scriptletDev := &scriptlet.QEMUDevice{Name: dev.Name(), Config: configCopy}
+
+	if instanceRunning {
+		err := d.runScriptlet(nil, "pre-hotunplug", scriptletDev)
+		if err != nil {
+			return err
+		}
+	}
+
 	runConf, err := dev.Stop()
 	if err != nil {
 		return err
@@ -2843,6 +2927,13 @@ func (d *qemu) deviceStop(dev device.Device, instanceRunning bool, _ string) err
 		}
 	}
 
+	if instanceRunning {
+		err = d.runScriptlet(nil, "post-hotunplug", scriptletDev)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -5146,6 +5237,12 @@ func (d *qemu) Stop(stateful bool) error {
 		return nil
 	}
 
+	// Run the pre-stop scriptlet stage, failures must not prevent the VM from stopping.
+	err = d.runScriptlet(monitor, "pre-stop", nil)
+	if err != nil {
+		d.logger.Warn("Failed running QEMU scriptlet", logger.Ctx{"err": err})
+	}
+
 	// Handle stateful stop.
 	if stateful {
 		// Dump the state.
@@ -5747,6 +5844,7 @@ func (d *qemu) Update(args db.InstanceArgs, userRequested bool) error {
 	}
 
 	oldExpiryDate := d.expiryDate
+	oldProject := d.project
 
 	// Revert local changes if update fails.
 	reverter.Add(func() {
@@ -5759,6 +5857,7 @@ func (d *qemu) Update(args db.InstanceArgs, userRequested bool) error {
 		d.localDevices = oldLocalDevices
 		d.profiles = oldProfiles
 		d.expiryDate = oldExpiryDate
+		d.project = oldProject
 	})
 
 	// Apply the various changes to local vars.
@@ -5770,6 +5869,12 @@ func (d *qemu) Update(args db.InstanceArgs, userRequested bool) error {
 	d.profiles = args.Profiles
 	d.expiryDate = args.ExpiryDate
 
+	// Refresh the project so that changes to its mandatory network ACLs get applied.
+	err = d.refreshProject()
+	if err != nil {
+		return err
+	}
+
 	// Expand the config.
 	err = d.expandConfig()
 	if err != nil {
@@ -5893,6 +5998,7 @@ func (d *qemu) Update(args db.InstanceArgs, userRequested bool) error {
 			"cluster.evacuate",
 			"limits.memory",
 			"security.agent.metrics",
+			"security.attestation.pcrs",
 			"security.csm",
 			"security.protection.delete",
 			"security.guestapi",
@@ -7195,6 +7301,12 @@ func (d *qemu) migrateSendLive(pool storagePools.Pool, clusterMoveSourceName str
 		}
 	}
 
+	// Let the scriptlet adjust the migration setup (capabilities, parameters, ...).
+	err = d.runScriptlet(monitor, "migration-source", nil)
+	if err != nil {
+		return err
+	}
+
 	// Perform storage transfer while instance is still running.
 	// For shared storage the storage driver will likely not do much here, but we still call it anyway for the
 	// sense checks it performs.
@@ -7694,6 +7806,17 @@ func (d *qemu) MigrateReceive(args instance.MigrateReceiveArgs) error {
 
 		// A zero length Snapshots slice indicates volume only migration in
 		// VolumeTargetArgs. So if VolumeOnly was requested, do not populate them.
+		// Check that the project allows for the received snapshots. Cluster moves don't add any
+		// as the source instance and its snapshots are removed afterwards.
+		if args.Snapshots && args.ClusterMoveSourceName == "" {
+			err = d.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+				return project.AllowSnapshotsCreation(tx, &d.project, len(snapshots))
+			})
+			if err != nil {
+				return err
+			}
+		}
+
 		if args.Snapshots {
 			volTargetArgs.Snapshots = make([]*migration.Snapshot, 0, len(snapshots))
 			for _, snap := range snapshots {
@@ -8391,6 +8514,16 @@ func (d *qemu) diskState() (map[string]api.InstanceStateDisk, error) {
 	return disk, nil
 }
 
+// AgentRunning returns whether the agent inside of the VM is currently running.
+func (d *qemu) AgentRunning() bool {
+	monitor, err := qmp.Connect(d.monitorPath(), qemuSerialChardevName, d.getMonitorEventHandler(), d.QMPLogFilePath())
+	if err != nil {
+		return false
+	}
+
+	return monitor.AgenStarted()
+}
+
 // agentGetState connects to the agent inside of the VM and does
 // an API call to get the current state.
 func (d *qemu) agentGetState() (*api.InstanceState, error) {
@@ -8429,6 +8562,11 @@ func (d *qemu) CanMigrate() string {
 	return d.canMigrate(d)
 }
 
+// NonMigratableDevices returns the names of the devices which can't be migrated.
+func (d *qemu) NonMigratableDevices() []string {
+	return d.nonMigratableDevices(d)
+}
+
 // LockExclusive attempts to get exclusive access to the instance's root volume.
 func (d *qemu) LockExclusive() (*operationlock.InstanceOperation, error) {
 	if d.IsRunning() {
@@ -0,0 +1,327 @@
+package drivers
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	incus "github.com/lxc/incus/v6/client"
+	"github.com/lxc/incus/v6/internal/server/attestation"
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/db/warningtype"
+	"github.com/lxc/incus/v6/internal/server/lifecycle"
+	"github.com/lxc/incus/v6/internal/server/warnings"
+	internalUtil "github.com/lxc/incus/v6/internal/util"
+	"github.com/lxc/incus/v6/shared/api"
+	agentAPI "github.com/lxc/incus/v6/shared/api/agent"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// Attest retrieves a quote of the requested PCRs (or those of the reference policy) from the instance's TPM
+// through the agent, verifies it and compares the measurements to the reference policy.
+// Verification failures and policy mismatches are reported through a warning and a lifecycle event.
+func (d *qemu) Attest(pcrs []int) (*api.InstanceAttestation, error) {
+	tpmName := ""
+	for _, dev := range d.expandedDevices.Sorted() {
+		if dev.Config["type"] == "tpm" {
+			tpmName = dev.Name
+			break
+		}
+	}
+
+	if tpmName == "" {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "Instance doesn't have a TPM device")
+	}
+
+	if !d.IsRunning() {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "Instance must be running to be attested")
+	}
+
+	policy, err := attestation.ParsePolicy(d.expandedConfig["security.attestation.pcrs"])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pcrs) == 0 {
+		pcrs = policy.PCRs()
+		if len(pcrs) == 0 {
+			pcrs = attestation.DefaultPCRs
+		}
+	}
+
+	client, err := d.getAgentClient()
+	if err != nil {
+		return nil, err
+	}
+
+	agent, err := incus.ConnectIncusHTTP(&incus.ConnectionArgs{SkipGetServer: true}, client)
+	if err != nil {
+		d.logger.Error("Failed to connect to the agent", logger.Ctx{"project": d.Project().Name, "instance": d.Name(), "err": err})
+		return nil, fmt.Errorf("Failed to connect to the agent")
+	}
+
+	defer agent.Disconnect()
+
+	// Retrieve the endorsement key certificate and a fresh attestation key.
+	resp, _, err := agent.RawQuery("GET", "/1.0/tpm", nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to retrieve the TPM identity: %w", err)
+	}
+
+	identity := agentAPI.TPM{}
+	err = json.Unmarshal(resp.Metadata, &identity)
+	if err != nil {
+		return nil, err
+	}
+
+	ek, err := d.checkEndorsementKey(tpmName, identity.EKCertificate)
+	if err != nil {
+		d.attestationFailed(err.Error())
+		return nil, err
+	}
+
+	ak, err := attestation.ParseAttestationKey(identity.AKPublic)
+	if err != nil {
+		d.attestationFailed(err.Error())
+		return nil, err
+	}
+
+	// Only the TPM holding the endorsement key can recover the secret, which proves that the attestation key is
+	// resident in the same TPM.
+	secret := make([]byte, 32)
+	nonce := make([]byte, 32)
+
+	for _, buf := range [][]byte{secret, nonce} {
+		_, err = rand.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	credential, err := attestation.MakeCredential(ek, ak.Name, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, _, err = agent.RawQuery("POST", "/1.0/tpm/quote", agentAPI.TPMQuotePost{Credential: credential, Nonce: nonce, PCRs: pcrs}, "")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to retrieve the TPM quote: %w", err)
+	}
+
+	quote := agentAPI.TPMQuote{}
+	err = json.Unmarshal(resp.Metadata, &quote)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := verifyAttestation(ak, secret, nonce, pcrs, policy, &quote)
+	if err != nil {
+		d.attestationFailed(err.Error())
+		return nil, err
+	}
+
+	if !result.Compliant {
+		mismatches := []int{}
+		for _, pcr := range result.PCRs {
+			if !pcr.Match {
+				mismatches = append(mismatches, pcr.Index)
+			}
+		}
+
+		d.attestationFailed(fmt.Sprintf("Measurements of PCRs %v don't match the reference policy", mismatches))
+		return result, nil
+	}
+
+	err = warnings.ResolveWarningsByLocalNodeAndProjectAndTypeAndEntity(d.state.DB.Cluster, d.project.Name, warningtype.InstanceAttestationFailure, dbCluster.TypeInstance, d.id)
+	if err != nil {
+		d.logger.Warn("Failed to resolve warning", logger.Ctx{"err": err})
+	}
+
+	return result, nil
+}
+
+// checkEndorsementKey checks that the endorsement key certificate is the one created along with the TPM and
+// returns its public key.
+func (d *qemu) checkEndorsementKey(tpmName string, certificate string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(certificate))
+	if block == nil {
+		return nil, errors.New("Invalid endorsement key certificate")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid endorsement key certificate: %w", err)
+	}
+
+	// The copy of the certificate kept with the TPM state follows the instance around and must match exactly.
+	certPaths, err := filepath.Glob(filepath.Join(d.Path(), fmt.Sprintf("tpm.%s", tpmName), "ek-*.crt"))
+	if err != nil {
+		return nil, err
+	}
+
+	provisioned := make([][]byte, 0, len(certPaths))
+	for _, certPath := range certPaths {
+		content, err := os.ReadFile(certPath)
+		if err != nil {
+			return nil, err
+		}
+
+		provisioned = append(provisioned, content)
+	}
+
+	if len(provisioned) > 0 {
+		if !endorsementKeyProvisioned(cert, provisioned) {
+			return nil, errors.New("Endorsement key certificate doesn't match the one provisioned for the TPM")
+		}
+	} else {
+		// Only TPMs created before their certificate was kept with the TPM state get here.
+		d.logger.Warn("No endorsement key certificate stored with the TPM, checking it against the local TPM CA", logger.Ctx{"device": tpmName})
+
+		content, err := os.ReadFile(internalUtil.VarPath("tpm", "issuercert.pem"))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil, errors.New("No endorsement key was provisioned for the TPM")
+			}
+
+			return nil, err
+		}
+
+		issuerBlock, _ := pem.Decode(content)
+		if issuerBlock == nil {
+			return nil, errors.New("Invalid TPM issuer certificate")
+		}
+
+		issuer, err := x509.ParseCertificate(issuerBlock.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid TPM issuer certificate: %w", err)
+		}
+
+		err = cert.CheckSignatureFrom(issuer)
+		if err != nil {
+			return nil, fmt.Errorf("Endorsement key certificate wasn't issued by this server: %w", err)
+		}
+	}
+
+	ek, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("Endorsement key isn't an RSA key")
+	}
+
+	return ek, nil
+}
+
+// endorsementKeyProvisioned returns whether the certificate is one of the provisioned (PEM or DER encoded) ones.
+func endorsementKeyProvisioned(cert *x509.Certificate, provisioned [][]byte) bool {
+	for _, content := range provisioned {
+		pemBlock, _ := pem.Decode(content)
+		if pemBlock != nil {
+			content = pemBlock.Bytes
+		}
+
+		if bytes.Equal(content, cert.Raw) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// attestationFailed records a failed attestation through a warning and a lifecycle event.
+func (d *qemu) attestationFailed(reason string) {
+	d.logger.Warn("Instance attestation failed", logger.Ctx{"reason": reason})
+
+	err := d.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.UpsertWarningLocalNode(ctx, d.project.Name, dbCluster.TypeInstance, d.id, warningtype.InstanceAttestationFailure, reason)
+	})
+	if err != nil {
+		d.logger.Warn("Failed to create warning", logger.Ctx{"err": err})
+	}
+
+	d.state.Events.SendLifecycle(d.project.Name, lifecycle.InstanceAttestationFailed.Event(d, map[string]any{"reason": reason}))
+}
+
+// verifyAttestation checks the quote returned by the agent and compares its measurements to the policy.
+func verifyAttestation(ak *attestation.AttestationKey, secret []byte, nonce []byte, pcrs []int, policy attestation.Policy, quote *agentAPI.TPMQuote) (*api.InstanceAttestation, error) {
+	if subtle.ConstantTimeCompare(secret, quote.Secret) != 1 {
+		return nil, errors.New("Attestation key isn't resident in the instance's TPM")
+	}
+
+	for _, index := range pcrs {
+		_, ok := quote.PCRs[index]
+		if !ok {
+			return nil, fmt.Errorf("Quote is missing PCR %d", index)
+		}
+	}
+
+	err := attestation.VerifyQuote(ak, quote.Quote, quote.Signature, nonce, quote.PCRs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &api.InstanceAttestation{
+		Compliant: true,
+		PCRs:      []api.InstanceAttestationPCR{},
+		Timestamp: time.Now(),
+	}
+
+	// The event log is covered by the quote through the PCRs it extended.
+	var replayed map[int][]byte
+	if len(quote.EventLog) > 0 {
+		events, err := attestation.ParseEventLog(quote.EventLog)
+		if err != nil {
+			return nil, err
+		}
+
+		result.EventLogEntries = len(events)
+		replayed = attestation.ReplayEventLog(events)
+	}
+
+	indexes := make([]int, 0, len(quote.PCRs))
+	for index := range quote.PCRs {
+		indexes = append(indexes, index)
+	}
+
+	slices.Sort(indexes)
+
+	for _, index := range indexes {
+		value := quote.PCRs[index]
+
+		pcr := api.InstanceAttestationPCR{
+			Index: index,
+			Value: hex.EncodeToString(value),
+			Match: policy.Match(index, value),
+		}
+
+		expected, ok := policy[index]
+		if ok {
+			pcr.Expected = hex.EncodeToString(expected)
+		}
+
+		logValue, ok := replayed[index]
+		if ok && !bytes.Equal(logValue, value) {
+			pcr.Match = false
+		}
+
+		if !pcr.Match {
+			result.Compliant = false
+		}
+
+		result.PCRs = append(result.PCRs, pcr)
+	}
+
+	return result, nil
+}
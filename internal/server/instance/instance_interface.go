@@ -82,7 +82,7 @@ type Instance interface {
 	Start(stateful bool) error
 	Stop(stateful bool) error
 	Restart(timeout time.Duration) error
-	Rebuild(img *api.Image, op *operations.Operation) error
+	Rebuild(img *api.Image, keepSnapshots bool, op *operations.Operation) error
 	Unfreeze() error
 
 	ReloadDevice(devName string) error
@@ -165,6 +165,7 @@ type Instance interface {
 
 	// Migration.
 	CanMigrate() string
+	NonMigratableDevices() []string
 	MigrateSend(args MigrateSendArgs) error
 	MigrateReceive(args MigrateReceiveArgs) error
 
@@ -195,6 +196,8 @@ type VM interface {
 	Instance
 
 	AgentCertificate() *x509.Certificate
+	AgentRunning() bool
+	Attest(pcrs []int) (*api.InstanceAttestation, error)
 	ConsoleLog() (string, error)
 	ConsoleScreenshot(screenshotFile *os.File) error
 	DumpGuestMemory(w *os.File, format string) error
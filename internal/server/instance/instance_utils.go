@@ -22,15 +22,16 @@ import (
 
 	incus "github.com/lxc/incus/v6/client"
 	"github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/instance/qemudefault"
 	"github.com/lxc/incus/v6/internal/migration"
 	"github.com/lxc/incus/v6/internal/server/backup"
 	"github.com/lxc/incus/v6/internal/server/db"
 	"github.com/lxc/incus/v6/internal/server/db/cluster"
 	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
-	"github.com/lxc/incus/v6/internal/server/instance/drivers/qemudefault"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
 	"github.com/lxc/incus/v6/internal/server/instance/operationlock"
 	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/resources"
 	"github.com/lxc/incus/v6/internal/server/seccomp"
 	"github.com/lxc/incus/v6/internal/server/state"
@@ -386,15 +387,24 @@ func LoadByProjectAndName(s *state.State, projectName string, instanceName strin
 
 // LoadNodeAll loads all instances on this server.
 func LoadNodeAll(s *state.State, instanceType instancetype.Type) ([]Instance, error) {
-	var err error
-	var instances []Instance
-
 	filter := cluster.InstanceFilter{Type: instanceType.Filter()}
 	if s.ServerName != "" {
 		filter.Node = &s.ServerName
 	}
 
-	err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+	return loadAll(s, filter)
+}
+
+// LoadAll loads all instances of the cluster.
+func LoadAll(s *state.State, instanceType instancetype.Type) ([]Instance, error) {
+	return loadAll(s, cluster.InstanceFilter{Type: instanceType.Filter()})
+}
+
+// loadAll loads all instances matching the filter.
+func loadAll(s *state.State, filter cluster.InstanceFilter) ([]Instance, error) {
+	var instances []Instance
+
+	err := s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
 		return tx.InstanceList(ctx, func(dbInst db.InstanceArgs, p api.Project) error {
 			inst, err := Load(s, dbInst, p)
 			if err != nil {
@@ -518,7 +528,32 @@ func ResolveImage(ctx context.Context, tx *db.ClusterTx, projectName string, sou
 			return source.Alias, nil
 		}
 
-		_, alias, err := tx.GetImageAlias(ctx, projectName, source.Alias, true)
+		aliasName := source.Alias
+
+		// Apply the project's image pins, those only apply to local aliases.
+		dbProject, err := cluster.GetProject(ctx, tx.Tx(), projectName)
+		if err != nil {
+			return "", err
+		}
+
+		projectConfig, err := cluster.GetProjectConfig(ctx, tx.Tx(), dbProject.ID)
+		if err != nil {
+			return "", err
+		}
+
+		pinnedFingerprint, pinnedChannel := project.ImagePin(projectConfig, aliasName)
+		if pinnedFingerprint != "" {
+			_, image, err := tx.GetImageByFingerprintPrefix(ctx, pinnedFingerprint, cluster.ImageFilter{Project: &projectName})
+			if err != nil {
+				return "", fmt.Errorf("Failed loading image %q pinned for alias %q: %w", pinnedFingerprint, aliasName, err)
+			}
+
+			return image.Fingerprint, nil
+		} else if pinnedChannel != "" {
+			aliasName = aliasName + "@" + pinnedChannel
+		}
+
+		_, alias, err := tx.GetImageAlias(ctx, projectName, aliasName, true)
 		if err != nil {
 			return "", err
 		}
@@ -660,6 +695,7 @@ func SuitableArchitectures(ctx context.Context, s *state.State, tx *db.ClusterTx
 					Proxy:         s.Proxy,
 					CachePath:     s.OS.CacheDir,
 					CacheExpiry:   time.Hour,
+					RegistryAuth:  req.Source.RegistryAuth,
 				})
 				if err != nil {
 					return nil, err
@@ -1104,6 +1140,59 @@ func NextSnapshotName(s *state.State, inst Instance, defaultPattern string) (str
 	return pattern, nil
 }
 
+// CheckSnapshotsMax returns an error if the instance already has the maximum number of snapshots allowed by snapshots.max.
+func CheckSnapshotsMax(inst Instance) error {
+	snapshotsMax, err := instance.GetSnapshotsMax(inst.ExpandedConfig())
+	if err != nil {
+		return err
+	}
+
+	if snapshotsMax < 0 {
+		return nil
+	}
+
+	snapshots, err := inst.Snapshots()
+	if err != nil {
+		return err
+	}
+
+	if len(snapshots) >= snapshotsMax {
+		return fmt.Errorf("Instance %q already has the maximum number of snapshots (%d)", inst.Name(), snapshotsMax)
+	}
+
+	return nil
+}
+
+// PruneSnapshots deletes the oldest snapshots of the instance so that a new one can be created without going
+// above snapshots.max.
+func PruneSnapshots(inst Instance) error {
+	snapshotsMax, err := instance.GetSnapshotsMax(inst.ExpandedConfig())
+	if err != nil {
+		return err
+	}
+
+	if snapshotsMax < 0 {
+		return nil
+	}
+
+	snapshots, err := inst.Snapshots()
+	if err != nil {
+		return err
+	}
+
+	// Snapshots are sorted from oldest to newest.
+	for len(snapshots) >= snapshotsMax {
+		err = snapshots[0].Delete(true)
+		if err != nil {
+			return fmt.Errorf("Failed to delete snapshot %q: %w", snapshots[0].Name(), err)
+		}
+
+		snapshots = snapshots[1:]
+	}
+
+	return nil
+}
+
 // temporaryName returns the temporary instance name using a stable random generator.
 // The returned string is a valid DNS name.
 func temporaryName(instUUID string) (string, error) {
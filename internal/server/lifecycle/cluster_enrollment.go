@@ -0,0 +1,28 @@
+package lifecycle
+
+import (
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// ClusterEnrollmentAction represents a lifecycle event action for cluster enrollments.
+type ClusterEnrollmentAction string
+
+// All supported lifecycle events for cluster enrollments.
+const (
+	ClusterEnrollmentApproved = ClusterEnrollmentAction(api.EventLifecycleClusterEnrollmentApproved)
+	ClusterEnrollmentCreated  = ClusterEnrollmentAction(api.EventLifecycleClusterEnrollmentCreated)
+	ClusterEnrollmentRejected = ClusterEnrollmentAction(api.EventLifecycleClusterEnrollmentRejected)
+)
+
+// Event creates the lifecycle event for an action on a cluster enrollment.
+func (a ClusterEnrollmentAction) Event(id string, requestor *api.EventLifecycleRequestor, ctx map[string]any) api.EventLifecycle {
+	u := api.NewURL().Path(version.APIVersion, "cluster", "enrollments", id)
+
+	return api.EventLifecycle{
+		Action:    string(a),
+		Source:    u.String(),
+		Context:   ctx,
+		Requestor: requestor,
+	}
+}
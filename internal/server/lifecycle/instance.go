@@ -18,26 +18,27 @@ type InstanceAction string
 
 // All supported lifecycle events for instances.
 const (
-	InstanceConsole          = InstanceAction(api.EventLifecycleInstanceConsole)
-	InstanceConsoleReset     = InstanceAction(api.EventLifecycleInstanceConsoleReset)
-	InstanceConsoleRetrieved = InstanceAction(api.EventLifecycleInstanceConsoleRetrieved)
-	InstanceCreated          = InstanceAction(api.EventLifecycleInstanceCreated)
-	InstanceDeleted          = InstanceAction(api.EventLifecycleInstanceDeleted)
-	InstanceExec             = InstanceAction(api.EventLifecycleInstanceExec)
-	InstanceFileDeleted      = InstanceAction(api.EventLifecycleInstanceFileDeleted)
-	InstanceFilePushed       = InstanceAction(api.EventLifecycleInstanceFilePushed)
-	InstanceFileRetrieved    = InstanceAction(api.EventLifecycleInstanceFileRetrieved)
-	InstanceMigrated         = InstanceAction(api.EventLifecycleInstanceMigrated)
-	InstancePaused           = InstanceAction(api.EventLifecycleInstancePaused)
-	InstanceReady            = InstanceAction(api.EventLifecycleInstanceReady)
-	InstanceRenamed          = InstanceAction(api.EventLifecycleInstanceRenamed)
-	InstanceRestarted        = InstanceAction(api.EventLifecycleInstanceRestarted)
-	InstanceRestored         = InstanceAction(api.EventLifecycleInstanceRestored)
-	InstanceResumed          = InstanceAction(api.EventLifecycleInstanceResumed)
-	InstanceShutdown         = InstanceAction(api.EventLifecycleInstanceShutdown)
-	InstanceStarted          = InstanceAction(api.EventLifecycleInstanceStarted)
-	InstanceStopped          = InstanceAction(api.EventLifecycleInstanceStopped)
-	InstanceUpdated          = InstanceAction(api.EventLifecycleInstanceUpdated)
+	InstanceAttestationFailed = InstanceAction(api.EventLifecycleInstanceAttestationFailed)
+	InstanceConsole           = InstanceAction(api.EventLifecycleInstanceConsole)
+	InstanceConsoleReset      = InstanceAction(api.EventLifecycleInstanceConsoleReset)
+	InstanceConsoleRetrieved  = InstanceAction(api.EventLifecycleInstanceConsoleRetrieved)
+	InstanceCreated           = InstanceAction(api.EventLifecycleInstanceCreated)
+	InstanceDeleted           = InstanceAction(api.EventLifecycleInstanceDeleted)
+	InstanceExec              = InstanceAction(api.EventLifecycleInstanceExec)
+	InstanceFileDeleted       = InstanceAction(api.EventLifecycleInstanceFileDeleted)
+	InstanceFilePushed        = InstanceAction(api.EventLifecycleInstanceFilePushed)
+	InstanceFileRetrieved     = InstanceAction(api.EventLifecycleInstanceFileRetrieved)
+	InstanceMigrated          = InstanceAction(api.EventLifecycleInstanceMigrated)
+	InstancePaused            = InstanceAction(api.EventLifecycleInstancePaused)
+	InstanceReady             = InstanceAction(api.EventLifecycleInstanceReady)
+	InstanceRenamed           = InstanceAction(api.EventLifecycleInstanceRenamed)
+	InstanceRestarted         = InstanceAction(api.EventLifecycleInstanceRestarted)
+	InstanceRestored          = InstanceAction(api.EventLifecycleInstanceRestored)
+	InstanceResumed           = InstanceAction(api.EventLifecycleInstanceResumed)
+	InstanceShutdown          = InstanceAction(api.EventLifecycleInstanceShutdown)
+	InstanceStarted           = InstanceAction(api.EventLifecycleInstanceStarted)
+	InstanceStopped           = InstanceAction(api.EventLifecycleInstanceStopped)
+	InstanceUpdated           = InstanceAction(api.EventLifecycleInstanceUpdated)
 )
 
 // Event creates the lifecycle event for an action on an instance.
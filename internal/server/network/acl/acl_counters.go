@@ -0,0 +1,124 @@
+package acl
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	firewallDrivers "github.com/lxc/incus/v6/internal/server/firewall/drivers"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// CounterName returns the name of the counter accounting the packets matched by an ACL rule.
+// The name depends on all the fields of the rule, so the counter is reset when the rule is modified but
+// kept when other rules of the ACL are added, removed or reordered.
+func CounterName(aclID int64, direction string, rule api.NetworkACLRule) string {
+	fields := strings.Join([]string{rule.Action, rule.Source, rule.Destination, rule.Protocol, rule.SourcePort, rule.DestinationPort, rule.ICMPType, rule.ICMPCode, rule.Description, rule.State}, "|")
+	hash := sha256.Sum256([]byte(fields))
+
+	return fmt.Sprintf("incus_acl%d_%s_%x", aclID, direction, hash[0:4])
+}
+
+// FirewallDeleteUnusedCounters removes the ACL rule counters which are no longer used by any rule
+// from the firewall (only used with nftables).
+func FirewallDeleteUnusedCounters(s *state.State) error {
+	if s.Firewall == nil || s.Firewall.String() != "nftables" {
+		return nil
+	}
+
+	return s.Firewall.NetworkDeleteACLCountersIfUnused()
+}
+
+// LocalCounters returns the values of the ACL rule counters on this server, keyed by counter name.
+// Bridge networks and NICs count matched packets using the firewall, OVN networks using the OpenFlow
+// flows of the integration bridge.
+func LocalCounters(s *state.State) (map[string]firewallDrivers.ACLCounter, error) {
+	counters := map[string]firewallDrivers.ACLCounter{}
+
+	// Firewall counters (only supported with nftables).
+	if s.Firewall != nil && s.Firewall.String() == "nftables" {
+		fwCounters, err := s.Firewall.NetworkACLCounters()
+		if err != nil {
+			return nil, err
+		}
+
+		for name, counter := range fwCounters {
+			total := counters[name]
+			total.Packets += counter.Packets
+			total.Bytes += counter.Bytes
+			counters[name] = total
+		}
+	}
+
+	// OVN counters (only if the server is an OVN chassis).
+	vswitch, err := s.OVS()
+	if err != nil {
+		return counters, nil
+	}
+
+	integrationBridge := s.GlobalConfig.NetworkOVNIntegrationBridge()
+	_, err = vswitch.GetBridge(context.TODO(), integrationBridge)
+	if err != nil {
+		return counters, nil
+	}
+
+	ovnnb, ovnsb, err := s.OVN()
+	if err != nil {
+		return nil, err
+	}
+
+	aclCounters, err := ovnnb.GetACLCounterNames(context.TODO())
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting OVN ACL counters: %w", err)
+	}
+
+	cookies, err := ovnsb.GetLogicalFlowCookies(context.TODO(), slices.Collect(maps.Keys(aclCounters)))
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting OVN ACL logical flows: %w", err)
+	}
+
+	flowStats, err := vswitch.GetBridgeFlowStats(context.TODO(), integrationBridge)
+	if err != nil {
+		return nil, err
+	}
+
+	for cookie, aclUUID := range cookies {
+		stats, ok := flowStats[cookie]
+		if !ok {
+			continue
+		}
+
+		name := aclCounters[aclUUID]
+		total := counters[name]
+		total.Packets += stats.Packets
+		total.Bytes += stats.Bytes
+		counters[name] = total
+	}
+
+	return counters, nil
+}
+
+// RuleCounters returns the hit counters of the rules of an ACL from the provided counter values.
+func RuleCounters(aclID int64, aclInfo *api.NetworkACL, counters map[string]firewallDrivers.ACLCounter) *api.NetworkACLState {
+	ruleCounters := func(direction string, rules []api.NetworkACLRule) []api.NetworkACLRuleCounters {
+		result := make([]api.NetworkACLRuleCounters, 0, len(rules))
+		for _, rule := range rules {
+			counter := counters[CounterName(aclID, direction, rule)]
+			result = append(result, api.NetworkACLRuleCounters{
+				Packets: int64(counter.Packets),
+				Bytes:   int64(counter.Bytes),
+			})
+		}
+
+		return result
+	}
+
+	return &api.NetworkACLState{
+		Ingress: ruleCounters("ingress", aclInfo.Ingress),
+		Egress:  ruleCounters("egress", aclInfo.Egress),
+	}
+}
@@ -0,0 +1,34 @@
+package acl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+func TestCounterName(t *testing.T) {
+	rule := api.NetworkACLRule{Action: "allow", Destination: "192.0.2.0/24", Protocol: "tcp", DestinationPort: "22", State: "enabled"}
+	name := CounterName(1, "ingress", rule)
+
+	assert.Regexp(t, `^incus_acl1_ingress_[0-9a-f]{8}$`, name)
+	assert.Equal(t, name, CounterName(1, "ingress", rule))
+
+	// The counter differs per ACL and direction.
+	assert.NotEqual(t, name, CounterName(2, "ingress", rule))
+	assert.NotEqual(t, name, CounterName(1, "egress", rule))
+
+	// Any change to the rule gets a new counter.
+	for _, change := range []func(r *api.NetworkACLRule){
+		func(r *api.NetworkACLRule) { r.Action = "drop" },
+		func(r *api.NetworkACLRule) { r.Source = "198.51.100.1" },
+		func(r *api.NetworkACLRule) { r.DestinationPort = "2222" },
+		func(r *api.NetworkACLRule) { r.State = "logged" },
+		func(r *api.NetworkACLRule) { r.Description = "SSH" },
+	} {
+		changed := rule
+		change(&changed)
+		assert.NotEqual(t, name, CounterName(1, "ingress", changed))
+	}
+}
@@ -30,7 +30,7 @@ func FirewallACLRules(s *state.State, aclDeviceName string, aclProjectName strin
 	var allowStatelessRules []firewallDrivers.ACLRule
 
 	// convertACLRules converts the ACL rules to Firewall ACL rules.
-	convertACLRules := func(direction string, logPrefix string, rules ...api.NetworkACLRule) error {
+	convertACLRules := func(aclID int64, direction string, logPrefix string, rules ...api.NetworkACLRule) error {
 		for ruleIndex, rule := range rules {
 			if rule.State == "disabled" {
 				continue
@@ -46,6 +46,7 @@ func FirewallACLRules(s *state.State, aclDeviceName string, aclProjectName strin
 				DestinationPort: rule.DestinationPort,
 				ICMPType:        rule.ICMPType,
 				ICMPCode:        rule.ICMPCode,
+				Counter:         CounterName(aclID, direction, rule),
 			}
 
 			if rule.State == "logged" {
@@ -75,12 +76,13 @@ func FirewallACLRules(s *state.State, aclDeviceName string, aclProjectName strin
 
 	// Load ACLs specified by network.
 	for _, aclName := range util.SplitNTrimSpace(config["security.acls"], ",", -1, true) {
+		var aclID int64
 		var aclInfo *api.NetworkACL
 
 		err := s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
 			var err error
 
-			_, aclInfo, err = tx.GetNetworkACL(ctx, aclProjectName, aclName)
+			aclID, aclInfo, err = tx.GetNetworkACL(ctx, aclProjectName, aclName)
 
 			return err
 		})
@@ -88,12 +90,12 @@ func FirewallACLRules(s *state.State, aclDeviceName string, aclProjectName strin
 			return nil, fmt.Errorf("Failed loading ACL %q for network %q: %w", aclName, aclDeviceName, err)
 		}
 
-		err = convertACLRules("ingress", logPrefix, aclInfo.Ingress...)
+		err = convertACLRules(aclID, "ingress", logPrefix, aclInfo.Ingress...)
 		if err != nil {
 			return nil, fmt.Errorf("Failed converting ACL %q ingress rules for network %q: %w", aclInfo.Name, aclDeviceName, err)
 		}
 
-		err = convertACLRules("egress", logPrefix, aclInfo.Egress...)
+		err = convertACLRules(aclID, "egress", logPrefix, aclInfo.Egress...)
 		if err != nil {
 			return nil, fmt.Errorf("Failed converting ACL %q egress rules for network %q: %w", aclInfo.Name, aclDeviceName, err)
 		}
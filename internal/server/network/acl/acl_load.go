@@ -243,6 +243,12 @@ func UsedBy(s *state.State, aclProjectName string, usageFunc func(ctx context.Co
 			}
 		}
 
+		// Get the networks of the ACL's project to find the NICs the mandatory ACLs apply to.
+		networks, err := tx.GetCreatedNetworksByProject(ctx, aclProjectName)
+		if err != nil {
+			return err
+		}
+
 		// Find instances using the ACLs. Most expensive to do.
 		err = tx.InstanceList(ctx, func(inst db.InstanceArgs, p api.Project) error {
 			// Get the instance's effective network project name.
@@ -255,6 +261,9 @@ func UsedBy(s *state.State, aclProjectName string, usageFunc func(ctx context.Co
 
 			devices := db.ExpandInstanceDevices(inst.Devices.Clone(), inst.Profiles)
 
+			// Account for the mandatory ACLs of the project.
+			project.ApplyNetworkACLsMandatory(&p, devices, networks)
+
 			// Iterate through each of the instance's devices, looking for NICs that are using any of the ACLs.
 			for devName, devConfig := range devices {
 				matchedACLNames := isInUseByDevice(devConfig, matchACLNames...)
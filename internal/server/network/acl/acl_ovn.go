@@ -412,6 +412,8 @@ func ovnApplyToPortGroup(s *state.State, l logger.Logger, client *ovn.NB, aclInf
 				continue
 			}
 
+			counter := CounterName(aclNameIDs[aclInfo.Name], direction, rule)
+
 			// Replace address set subjects
 			rule.Source = replaceAddressSetNames(rule.Source, addressSetIDs)
 			rule.Destination = replaceAddressSetNames(rule.Destination, addressSetIDs)
@@ -421,6 +423,8 @@ func ovnApplyToPortGroup(s *state.State, l logger.Logger, client *ovn.NB, aclInf
 				return err
 			}
 
+			ovnACLRule.Counter = counter
+
 			if rule.State == "logged" {
 				ovnACLRule.Log = true
 				ovnACLRule.LogName = fmt.Sprintf("%s-%s-%d", portGroupName, direction, ruleIndex)
@@ -19,6 +19,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/db"
 	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
 	addressset "github.com/lxc/incus/v6/internal/server/network/address-set"
+	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/state"
 	localUtil "github.com/lxc/incus/v6/internal/server/util"
 	"github.com/lxc/incus/v6/internal/version"
@@ -131,8 +132,40 @@ func (d *common) Info() *api.NetworkACL {
 func (d *common) usedBy(firstOnly bool) ([]string, error) {
 	usedBy := []string{}
 
+	// Find all projects making this Network ACL mandatory.
+	err := d.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		projects, err := dbCluster.GetProjects(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		for _, p := range projects {
+			apiProject, err := p.ToAPI(ctx, tx.Tx())
+			if err != nil {
+				return err
+			}
+
+			if project.NetworkProjectFromRecord(apiProject) != d.projectName {
+				continue
+			}
+
+			if slices.Contains(project.NetworkACLsMandatory(apiProject), d.info.Name) {
+				usedBy = append(usedBy, fmt.Sprintf("/%s/projects/%s", version.APIVersion, apiProject.Name))
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting ACL usage: %w", err)
+	}
+
+	if firstOnly && len(usedBy) > 0 {
+		return usedBy, nil
+	}
+
 	// Find all networks, profiles and instance NICs that use this Network ACL.
-	err := UsedBy(d.state, d.projectName, func(ctx context.Context, tx *db.ClusterTx, _ []string, usageType any, _ string, _ map[string]string) error {
+	err = UsedBy(d.state, d.projectName, func(ctx context.Context, tx *db.ClusterTx, _ []string, usageType any, _ string, _ map[string]string) error {
 		switch u := usageType.(type) {
 		case db.InstanceArgs:
 			uri := fmt.Sprintf("/%s/instances/%s", version.APIVersion, u.Name)
@@ -692,6 +725,14 @@ func (d *common) Update(config *api.NetworkACLPut, clientType request.ClientType
 		}
 	}
 
+	// Remove the counters of the rules which were changed or removed.
+	if len(aclNets) > 0 || len(aclBridgeNICs) > 0 {
+		err = FirewallDeleteUnusedCounters(d.state)
+		if err != nil {
+			d.logger.Warn("Failed removing unused ACL counters", logger.Ctx{"err": err})
+		}
+	}
+
 	// If there are affected OVN networks, then apply the changes, but only if the request type is normal.
 	// This way we won't apply the same changes multiple times for each cluster member.
 	if len(aclOVNNets) > 0 && clientType == request.ClientTypeNormal {
@@ -807,9 +848,20 @@ func (d *common) Delete() error {
 		return fmt.Errorf("Cannot delete an ACL that is in use")
 	}
 
-	return d.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+	err = d.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
 		return tx.DeleteNetworkACL(ctx, d.id)
 	})
+	if err != nil {
+		return err
+	}
+
+	// Remove any counters left over from the ACL's rules.
+	err = FirewallDeleteUnusedCounters(d.state)
+	if err != nil {
+		d.logger.Warn("Failed removing unused ACL counters", logger.Ctx{"err": err})
+	}
+
+	return nil
 }
 
 // GetLog gets the ACL log.
@@ -899,3 +951,52 @@ func (d *common) GetLog(clientType request.ClientType) (string, error) {
 
 	return strings.Join(logEntries, "\n") + "\n", nil
 }
+
+// GetState returns the hit counters of the ACL rules, summed across the cluster members.
+func (d *common) GetState(clientType request.ClientType) (*api.NetworkACLState, error) {
+	counters, err := LocalCounters(d.state)
+	if err != nil {
+		return nil, err
+	}
+
+	aclState := RuleCounters(d.id, d.info, counters)
+
+	// Aggregates the counters from the rest of the cluster.
+	if clientType == request.ClientTypeNormal {
+		// Setup notifier to reach the rest of the cluster.
+		notifier, err := cluster.NewNotifier(d.state, d.state.Endpoints.NetworkCert(), d.state.ServerCert(), cluster.NotifyAll)
+		if err != nil {
+			return nil, err
+		}
+
+		mu := sync.Mutex{}
+		err = notifier(func(client incus.InstanceServer) error {
+			memberState, err := client.UseProject(d.projectName).GetNetworkACLState(d.info.Name)
+			if err != nil {
+				return err
+			}
+
+			// Prevent concurrent writes to the counters.
+			mu.Lock()
+			defer mu.Unlock()
+
+			addRuleCounters(aclState.Ingress, memberState.Ingress)
+			addRuleCounters(aclState.Egress, memberState.Egress)
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return aclState, nil
+}
+
+// addRuleCounters adds the values of the counters in src to the counters in dst.
+func addRuleCounters(dst []api.NetworkACLRuleCounters, src []api.NetworkACLRuleCounters) {
+	for i := range min(len(dst), len(src)) {
+		dst[i].Packets += src[i].Packets
+		dst[i].Bytes += src[i].Bytes
+	}
+}
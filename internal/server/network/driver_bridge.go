@@ -2,6 +2,7 @@ package network
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -12,6 +13,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/mdlayher/netx/eui64"
@@ -27,6 +29,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/dnsmasq"
 	"github.com/lxc/incus/v6/internal/server/dnsmasq/dhcpalloc"
 	firewallDrivers "github.com/lxc/incus/v6/internal/server/firewall/drivers"
+	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/ip"
 	"github.com/lxc/incus/v6/internal/server/network/acl"
 	addressset "github.com/lxc/incus/v6/internal/server/network/address-set"
@@ -46,6 +49,13 @@ import (
 // Default MTU for bridge interface.
 const bridgeMTUDefault = 1500
 
+// bridgeGatewayHosts records the hash of the DHCP host entries last applied by the member holding the
+// gateway of networks in `ha` gateway mode.
+var (
+	bridgeGatewayHostsMu sync.Mutex
+	bridgeGatewayHosts   = map[string][32]byte{}
+)
+
 // bridge represents a bridge network.
 type bridge struct {
 	common
@@ -182,6 +192,7 @@ func (n *bridge) Validate(config map[string]string) error {
 		//
 		// ---
 		//  type: string
+		//  allowedvalues: native, openvswitch
 		//  condition: -
 		//  default: `native`
 		//  shortdesc: Bridge driver: `native` or `openvswitch`
@@ -194,6 +205,16 @@ func (n *bridge) Validate(config map[string]string) error {
 		//  default: -
 		//  shortdesc: Comma-separated list of unconfigured network interfaces to include in the bridge
 		"bridge.external_interfaces": validate.Optional(validateExternalInterfaces),
+		// gendoc:generate(entity=network_bridge, group=common, key=bridge.gateway.mode)
+		// In `ha` mode, a single cluster member at a time holds the gateway addresses and runs the DHCP and DNS service of the bridge.
+		// See {ref}`network-bridge-ha-gateway`.
+		// ---
+		//  type: string
+		//  allowedvalues: local, ha
+		//  condition: -
+		//  default: `local`
+		//  shortdesc: Gateway mode: `local` (every member acts as gateway) or `ha` (a single member at a time)
+		"bridge.gateway.mode": validate.Optional(validate.IsOneOf("local", "ha")),
 		// gendoc:generate(entity=network_bridge, group=common, key=bridge.hwaddr)
 		//
 		// ---
@@ -245,6 +266,7 @@ func (n *bridge) Validate(config map[string]string) error {
 		//
 		// ---
 		//  type: string
+		//  allowedvalues: before, after
 		//  condition: IPv4 address
 		//  default: `before`
 		//  shortdesc: Whether to add the required NAT rules before or after any pre-existing rules
@@ -297,6 +319,14 @@ func (n *bridge) Validate(config map[string]string) error {
 		//  default: -
 		//  shortdesc: Static routes to provide via DHCP option 121, as a comma-separated list of alternating subnets (CIDR) and gateway addresses (same syntax as dnsmasq)
 		"ipv4.dhcp.routes": validate.Optional(validate.IsDHCPRouteList),
+		// gendoc:generate(entity=network_bridge, group=common, key=ipv4.dhcp.relay)
+		// See {ref}`network-bridge-dhcp-relay`.
+		// ---
+		//  type: string
+		//  condition: IPv4 DHCP
+		//  default: -
+		//  shortdesc: Address of an external DHCP server to relay DHCP requests to
+		"ipv4.dhcp.relay": validate.Optional(validate.IsNetworkAddressV4),
 		// gendoc:generate(entity=network_bridge, group=common, key=ipv4.routes)
 		//
 		// ---
@@ -356,6 +386,7 @@ func (n *bridge) Validate(config map[string]string) error {
 		//
 		// ---
 		//  type: string
+		//  allowedvalues: before, after
 		//  condition: IPv6 address
 		//  default: `before`
 		//  shortdesc: Whether to add the required NAT rules before or after any pre-existing rules
@@ -445,6 +476,7 @@ func (n *bridge) Validate(config map[string]string) error {
 		//
 		// ---
 		//  type: string
+		//  allowedvalues: dynamic, managed, none
 		//  condition: -
 		//  default: `managed`
 		//  shortdesc: DNS registration mode: none for no DNS record, managed for Incus-generated static records or dynamic for client-generated records
@@ -556,6 +588,7 @@ func (n *bridge) Validate(config map[string]string) error {
 				//
 				// ---
 				//  type: string
+				//  allowedvalues: gre, vxlan
 				//  condition: standard mode
 				//  default: -
 				//  shortdesc: Tunneling protocol: `vxlan` or `gre`
@@ -680,6 +713,11 @@ func (n *bridge) Validate(config map[string]string) error {
 		}
 	}
 
+	// Check there is a gateway address to make highly available.
+	if config["bridge.gateway.mode"] == "ha" && util.IsNoneOrEmpty(config["ipv4.address"]) && util.IsNoneOrEmpty(config["ipv6.address"]) {
+		return fmt.Errorf(`"bridge.gateway.mode" can only be set to "ha" when the bridge has an IPv4 or IPv6 address`)
+	}
+
 	// Check using same MAC address on every cluster node is safe.
 	if config["bridge.hwaddr"] != "" {
 		err = n.checkClusterWideMACSafe(config)
@@ -688,6 +726,12 @@ func (n *bridge) Validate(config map[string]string) error {
 		}
 	}
 
+	// Check DHCP relay.
+	err = validateDHCPRelay(config)
+	if err != nil {
+		return err
+	}
+
 	// Check IPv4 OVN ranges.
 	if config["ipv4.ovn.ranges"] != "" && util.IsTrueOrEmpty(config["ipv4.dhcp"]) {
 		dhcpSubnet := n.DHCPv4Subnet()
@@ -1195,6 +1239,12 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 		fwOpts.ACL = true
 	}
 
+	// Check whether this member holds the gateway addresses.
+	gatewayActive, err := n.isGatewayOwner()
+	if err != nil {
+		return err
+	}
+
 	// Snapshot container specific IPv4 routes (added with boot proto) before removing IPv4 addresses.
 	// This is because the kernel removes any static routes on an interface when all addresses removed.
 	ctRoutes, err := n.bootRoutesV4()
@@ -1300,7 +1350,9 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 
 		// Update the dnsmasq config.
 		dnsmasqCmd = append(dnsmasqCmd, fmt.Sprintf("--listen-address=%s", ipAddress.String()))
-		if n.DHCPv4Subnet() != nil {
+		if n.config["ipv4.dhcp.relay"] != "" {
+			dnsmasqCmd = append(dnsmasqCmd, fmt.Sprintf("--dhcp-relay=%s,%s", ipAddress.String(), n.config["ipv4.dhcp.relay"]))
+		} else if n.DHCPv4Subnet() != nil {
 			if !slices.Contains(dnsmasqCmd, "--dhcp-no-override") {
 				dnsmasqCmd = append(dnsmasqCmd, []string{"--dhcp-no-override", "--dhcp-authoritative", fmt.Sprintf("--dhcp-leasefile=%s", internalUtil.VarPath("networks", n.name, "dnsmasq.leases")), fmt.Sprintf("--dhcp-hostsfile=%s", internalUtil.VarPath("networks", n.name, "dnsmasq.hosts"))}...)
 			}
@@ -1346,15 +1398,17 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 		}
 
 		// Add the address.
-		addr := &ip.Addr{
-			DevName: n.name,
-			Address: n.config["ipv4.address"],
-			Family:  ip.FamilyV4,
-		}
+		if gatewayActive {
+			addr := &ip.Addr{
+				DevName: n.name,
+				Address: n.config["ipv4.address"],
+				Family:  ip.FamilyV4,
+			}
 
-		err = addr.Add()
-		if err != nil {
-			return err
+			err = addr.Add()
+			if err != nil {
+				return err
+			}
 		}
 
 		// Configure NAT.
@@ -1543,15 +1597,17 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 		}
 
 		// Add the address.
-		addr := &ip.Addr{
-			DevName: n.name,
-			Address: n.config["ipv6.address"],
-			Family:  ip.FamilyV6,
-		}
+		if gatewayActive {
+			addr := &ip.Addr{
+				DevName: n.name,
+				Address: n.config["ipv6.address"],
+				Family:  ip.FamilyV6,
+			}
 
-		err = addr.Add()
-		if err != nil {
-			return err
+			err = addr.Add()
+			if err != nil {
+				return err
+			}
 		}
 
 		// Configure NAT.
@@ -1724,8 +1780,8 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 		return err
 	}
 
-	// Configure dnsmasq.
-	if n.UsesDNSMasq() {
+	// Configure dnsmasq (only on the member holding the gateway addresses).
+	if n.UsesDNSMasq() && gatewayActive {
 		// Setup the dnsmasq domain.
 		dnsDomain := n.config["dns.domain"]
 		if dnsDomain == "" {
@@ -1886,6 +1942,11 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 		if err != nil {
 			return err
 		}
+
+		err = acl.FirewallDeleteUnusedCounters(n.state)
+		if err != nil {
+			n.logger.Warn("Failed removing unused ACL counters", logger.Ctx{"err": err})
+		}
 	}
 
 	// Setup network address forwards.
@@ -1900,6 +1961,19 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 		return err
 	}
 
+	if n.config["bridge.gateway.mode"] == "ha" {
+		if gatewayActive {
+			// Let the rest of the segment know about the new gateway location.
+			n.announceGateway()
+		} else {
+			// Only the member holding the gateway addresses advertises the network.
+			err = n.state.BGP.RemovePrefixByOwner(fmt.Sprintf("network_%d", n.id))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	reverter.Success()
 
 	return nil
@@ -1919,6 +1993,14 @@ func (n *bridge) Stop() error {
 		return err
 	}
 
+	// Let another member take over the gateway right away.
+	if n.config["bridge.gateway.mode"] == "ha" && n.state.ServerClustered {
+		err = n.releaseGatewayLease()
+		if err != nil {
+			n.logger.Warn("Failed releasing network gateway lease", logger.Ctx{"err": err})
+		}
+	}
+
 	err = n.deleteChildren()
 	if err != nil {
 		return fmt.Errorf("Failed to delete bridge children interfaces: %w", err)
@@ -2086,6 +2168,238 @@ func (n *bridge) Update(newNetwork api.NetworkPut, targetNode string, clientType
 	return nil
 }
 
+// gatewayLeaseNext returns the gateway lease resulting from the given member trying to acquire or renew it,
+// and whether it changed. The member holding the lease renews it, the others only take it over, with a new
+// term, once it has expired or if its holder can't hold the gateway anymore.
+func gatewayLeaseNext(lease *db.NetworkGatewayLease, nodeID int64, nodeName string, eligible func(nodeID int64) bool, now time.Time, duration time.Duration) (*db.NetworkGatewayLease, bool) {
+	expiry := now.Add(duration)
+
+	if lease == nil {
+		return &db.NetworkGatewayLease{NodeID: nodeID, Node: nodeName, Term: 1, Expiry: expiry}, true
+	}
+
+	if lease.NodeID == nodeID {
+		return &db.NetworkGatewayLease{NodeID: nodeID, Node: nodeName, Term: lease.Term, Expiry: expiry}, true
+	}
+
+	if now.After(lease.Expiry) || !eligible(lease.NodeID) {
+		return &db.NetworkGatewayLease{NodeID: nodeID, Node: nodeName, Term: lease.Term + 1, Expiry: expiry}, true
+	}
+
+	return lease, false
+}
+
+// acquireGatewayLease acquires or renews the cluster-wide lease on the gateway of the network in `ha` gateway
+// mode and returns the lease, which is held by another member if it couldn't be acquired.
+// The lease lasts for the cluster offline threshold and is renewed on each heartbeat by the member holding it.
+func (n *bridge) acquireGatewayLease() (*db.NetworkGatewayLease, error) {
+	var lease *db.NetworkGatewayLease
+
+	err := n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		current, err := tx.GetNetworkGatewayLease(ctx, n.id)
+		if err != nil {
+			return fmt.Errorf("Failed getting network gateway lease: %w", err)
+		}
+
+		offlineThreshold, err := tx.GetNodeOfflineThreshold(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed getting cluster offline threshold: %w", err)
+		}
+
+		nodes, err := tx.GetNodes(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed getting cluster members: %w", err)
+		}
+
+		online := map[int64]bool{}
+		for _, node := range nodes {
+			online[node.ID] = !node.IsOffline(offlineThreshold)
+		}
+
+		netNodes, err := tx.NetworkNodes(ctx, n.id)
+		if err != nil {
+			return fmt.Errorf("Failed getting network members: %w", err)
+		}
+
+		// A member can hold the gateway while it's online and the network isn't errored on it.
+		eligible := func(nodeID int64) bool {
+			netNode, ok := netNodes[nodeID]
+			return ok && online[nodeID] && db.NetworkStateToAPIStatus(netNode.State) != api.NetworkStatusErrored
+		}
+
+		var changed bool
+		lease, changed = gatewayLeaseNext(current, tx.GetNodeID(), n.state.ServerName, eligible, time.Now(), offlineThreshold)
+		if !changed {
+			return nil
+		}
+
+		return tx.SetNetworkGatewayLease(ctx, n.id, *lease)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return lease, nil
+}
+
+// releaseGatewayLease releases the gateway lease of the network if held by this member.
+func (n *bridge) releaseGatewayLease() error {
+	return n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.DeleteNetworkGatewayLease(ctx, n.id, tx.GetNodeID())
+	})
+}
+
+// isGatewayOwner returns whether this member should hold the gateway addresses of the network.
+func (n *bridge) isGatewayOwner() (bool, error) {
+	if n.config["bridge.gateway.mode"] != "ha" || !n.state.ServerClustered {
+		return true, nil
+	}
+
+	lease, err := n.acquireGatewayLease()
+	if err != nil {
+		return false, err
+	}
+
+	return lease.Node == n.state.ServerName, nil
+}
+
+// hasGatewayAddresses returns whether the gateway addresses of the network are configured on the bridge.
+func (n *bridge) hasGatewayAddresses() bool {
+	iface, err := net.InterfaceByName(n.name)
+	if err != nil {
+		return false
+	}
+
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return false
+	}
+
+	for _, key := range []string{"ipv4.address", "ipv6.address"} {
+		gatewayIP, _, err := net.ParseCIDR(n.config[key])
+		if err != nil {
+			continue
+		}
+
+		for _, addr := range addrs {
+			addrIP, _, err := net.ParseCIDR(addr.String())
+			if err == nil && addrIP.Equal(gatewayIP) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// announceGateway sends a gratuitous ARP and an unsolicited neighbour advertisement for the gateway
+// addresses so that the instances and routers on the segment update their neighbour caches.
+func (n *bridge) announceGateway() {
+	for _, key := range []string{"ipv4.address", "ipv6.address"} {
+		gatewayIP, _, err := net.ParseCIDR(n.config[key])
+		if err != nil {
+			continue
+		}
+
+		err = bridgeAnnounceAddress(n.name, gatewayIP)
+		if err != nil {
+			n.logger.Warn("Failed announcing gateway address", logger.Ctx{"address": gatewayIP.String(), "err": err})
+		}
+	}
+}
+
+// HandleHeartbeat renews the gateway lease of the network in `ha` gateway mode and moves the gateway
+// addresses when the lease changes hands. The member holding the gateway also picks up the DHCP host
+// entries of the instances running on the other members.
+func (n *bridge) HandleHeartbeat(heartbeatData *cluster.APIHeartbeat) error {
+	if n.config["bridge.gateway.mode"] != "ha" || !n.isRunning() {
+		return nil
+	}
+
+	gatewayActive, err := n.isGatewayOwner()
+	if err != nil {
+		return err
+	}
+
+	if gatewayActive != n.hasGatewayAddresses() {
+		if gatewayActive {
+			n.logger.Info("Taking over network gateway")
+		} else {
+			n.logger.Info("Releasing network gateway")
+		}
+
+		return n.setup(n.config)
+	}
+
+	if gatewayActive && n.UsesDNSMasq() {
+		return n.syncGatewayHosts()
+	}
+
+	return nil
+}
+
+// syncGatewayHosts refreshes the DHCP host entries of the network when the instances connected to it changed.
+func (n *bridge) syncGatewayHosts() error {
+	entries, err := dnsmasqStaticEntries(n.state, map[string]Network{n.name: n}, true)
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.Sum256([]byte(fmt.Sprint(entries[n.name])))
+
+	bridgeGatewayHostsMu.Lock()
+	defer bridgeGatewayHostsMu.Unlock()
+
+	if bridgeGatewayHosts[n.name] == hash {
+		return nil
+	}
+
+	err = UpdateDNSMasqStatic(n.state, n.name)
+	if err != nil {
+		return err
+	}
+
+	bridgeGatewayHosts[n.name] = hash
+
+	return nil
+}
+
+// State returns the network state, including the gateway status in `ha` gateway mode.
+func (n *bridge) State() (*api.NetworkState, error) {
+	netState, err := n.common.State()
+	if err != nil {
+		return nil, err
+	}
+
+	if n.config["bridge.gateway.mode"] == "ha" {
+		owner := n.state.ServerName
+		if n.state.ServerClustered {
+			var lease *db.NetworkGatewayLease
+
+			err = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+				lease, err = tx.GetNetworkGatewayLease(ctx, n.id)
+				return err
+			})
+			if err != nil {
+				return nil, fmt.Errorf("Failed getting network gateway lease: %w", err)
+			}
+
+			owner = ""
+			if lease != nil {
+				owner = lease.Node
+			}
+		}
+
+		netState.Gateway = &api.NetworkStateGateway{
+			Mode:   "ha",
+			Owner:  owner,
+			Active: n.hasGatewayAddresses(),
+		}
+	}
+
+	return netState, nil
+}
+
 func (n *bridge) getTunnels() []string {
 	tunnels := []string{}
 
@@ -2203,8 +2517,8 @@ func (n *bridge) hasDHCPv6() bool {
 
 // DHCPv4Subnet returns the DHCPv4 subnet (if DHCP is enabled on network).
 func (n *bridge) DHCPv4Subnet() *net.IPNet {
-	// DHCP is disabled on this network.
-	if !n.hasDHCPv4() {
+	// DHCP is disabled on this network or served by an external server.
+	if !n.hasDHCPv4() || n.config["ipv4.dhcp.relay"] != "" {
 		return nil
 	}
 
@@ -2397,6 +2711,25 @@ func (n *bridge) bridgedNICExternalRoutes(bridgeProjectNetworks map[string][]*ap
 						})
 					}
 				}
+
+				// Also add any NIC specific SNAT addresses.
+				for _, key := range []string{"ipv4.nat.address", "ipv6.nat.address"} {
+					ipNet, _ := ParseIPToNet(devConfig[key])
+					if ipNet == nil {
+						// Skip if NIC device doesn't have a valid SNAT address.
+						continue
+					}
+
+					externalRoutes = append(externalRoutes, externalSubnetUsage{
+						subnet:          *ipNet,
+						networkProject:  instNetworkProject,
+						networkName:     devConfig["network"],
+						instanceProject: inst.Project,
+						instanceName:    inst.Name,
+						instanceDevice:  devName,
+						usageType:       subnetUsageInstance,
+					})
+				}
 			}
 
 			return nil
@@ -2521,6 +2854,74 @@ func (n *bridge) getExternalSubnetInUse() ([]externalSubnetUsage, error) {
 	return externalSubnets, nil
 }
 
+// InstanceDeviceValidateNATAddresses checks that the NIC specific SNAT addresses of an instance NIC don't overlap
+// with the addresses used by other networks, NICs, forwards or load balancers on this member.
+func (n *bridge) InstanceDeviceValidateNATAddresses(deviceInstance instance.Instance, deviceName string, natAddresses []*net.IPNet) error {
+	externalSubnetsInUse, err := n.getExternalSubnetInUse()
+	if err != nil {
+		return err
+	}
+
+	netSubnets := []*net.IPNet{}
+	for _, keyPrefix := range []string{"ipv4", "ipv6"} {
+		_, netSubnet, _ := net.ParseCIDR(n.config[fmt.Sprintf("%s.address", keyPrefix)])
+		if netSubnet != nil {
+			netSubnets = append(netSubnets, netSubnet)
+		}
+	}
+
+	// Instance devices are only checked when an instance is supplied (not during profile validation).
+	instanceProject := ""
+	instanceName := ""
+	if deviceInstance != nil {
+		instanceProject = deviceInstance.Project().Name
+		instanceName = deviceInstance.Name()
+	}
+
+	return validateNATAddresses(natAddresses, netSubnets, externalSubnetsInUse, n.project, n.name, instanceProject, instanceName, deviceName)
+}
+
+// validateNATAddresses checks that the NAT addresses of an instance NIC aren't within the network's subnets and
+// don't overlap with the external subnets in use. The network's own SNAT address and the NIC itself are skipped,
+// as are all instance devices when instanceName is empty.
+func validateNATAddresses(natAddresses []*net.IPNet, netSubnets []*net.IPNet, externalSubnetsInUse []externalSubnetUsage, networkProject string, networkName string, instanceProject string, instanceName string, deviceName string) error {
+	for _, natAddress := range natAddresses {
+		// The SNAT address cannot be inside of the network's own subnets.
+		for _, netSubnet := range netSubnets {
+			if netSubnet.Contains(natAddress.IP) {
+				return fmt.Errorf("NAT address %q is within the network's subnet %q", natAddress.IP.String(), netSubnet.String())
+			}
+		}
+
+		for _, externalSubnetUser := range externalSubnetsInUse {
+			// Skip our own network's SNAT address (as it can be used for NICs in the network).
+			if externalSubnetUser.usageType == subnetUsageNetworkSNAT && externalSubnetUser.networkProject == networkProject && externalSubnetUser.networkName == networkName {
+				continue
+			}
+
+			if instanceName == "" {
+				// Skip checking instance devices during profile validation.
+				if externalSubnetUser.instanceDevice != "" {
+					continue
+				}
+			} else {
+				// Skip our own NIC device.
+				if externalSubnetUser.instanceProject == instanceProject && externalSubnetUser.instanceName == instanceName && externalSubnetUser.instanceDevice == deviceName {
+					continue
+				}
+			}
+
+			if SubnetContains(&externalSubnetUser.subnet, natAddress) || SubnetContains(natAddress, &externalSubnetUser.subnet) {
+				// This error is purposefully vague so that it doesn't reveal any names of
+				// resources potentially outside of the network.
+				return fmt.Errorf("NAT address %q overlaps with another network or NIC", natAddress.IP.String())
+			}
+		}
+	}
+
+	return nil
+}
+
 // ForwardCreate creates a network forward.
 func (n *bridge) ForwardCreate(forward api.NetworkForwardsPost, clientType request.ClientType) error {
 	memberSpecific := true // bridge supports per-member forwards.
@@ -3002,17 +3403,36 @@ func (n *bridge) Leases(projectName string, clientType request.ClientType) ([]ap
 		}
 	}
 
+	// Get the leases learned from the neighbor table when DHCP is relayed to an external server.
+	if n.config["ipv4.dhcp.relay"] != "" {
+		learnedLeases, err := n.relayLeases()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, lease := range learnedLeases {
+			// Skip leases that don't match any of the instance MACs from the project.
+			if clientType == request.ClientTypeNormal && !slices.Contains(projectMacs, lease.Hwaddr) {
+				continue
+			}
+
+			leases = append(leases, lease)
+		}
+	}
+
 	// Get dynamic leases.
+	var content []byte
 	leaseFile := internalUtil.VarPath("networks", n.name, "dnsmasq.leases")
-	if !util.PathExists(leaseFile) {
+	if util.PathExists(leaseFile) {
+		content, err = os.ReadFile(leaseFile)
+		if err != nil {
+			return nil, err
+		}
+	} else if n.config["ipv4.dhcp.relay"] == "" {
+		// Leases learned by other members still need collecting when relaying.
 		return leases, nil
 	}
 
-	content, err := os.ReadFile(leaseFile)
-	if err != nil {
-		return nil, err
-	}
-
 	for _, lease := range strings.Split(string(content), "\n") {
 		fields := strings.Fields(lease)
 		if len(fields) >= 5 {
@@ -3091,6 +3511,66 @@ func (n *bridge) Leases(projectName string, clientType request.ClientType) ([]ap
 	return leases, nil
 }
 
+// relayLeases returns the IPv4 addresses learned from the neighbor table of the bridge for the local instances.
+// This is used to keep track of the addresses handed out by an external DHCP server when relaying.
+func (n *bridge) relayLeases() ([]api.NetworkLease, error) {
+	_, subnet, err := net.ParseCIDR(n.config["ipv4.address"])
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing ipv4.address: %w", err)
+	}
+
+	// Map the MAC addresses of the local instances connected to the network to their names.
+	hostnames := map[string]string{}
+	filter := dbCluster.InstanceFilter{Node: &n.state.ServerName}
+	err = UsedByInstanceDevices(n.state, n.Project(), n.Name(), n.Type(), func(inst db.InstanceArgs, nicName string, nicConfig map[string]string) error {
+		hwAddr, _ := net.ParseMAC(nicConfig["hwaddr"])
+		if hwAddr == nil {
+			hwAddr, _ = net.ParseMAC(inst.Config[fmt.Sprintf("volatile.%s.hwaddr", nicName)])
+		}
+
+		if hwAddr != nil {
+			hostnames[hwAddr.String()] = inst.Name
+		}
+
+		return nil
+	}, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	neigh := &ip.Neigh{DevName: n.name}
+	neighbours, err := neigh.Show()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get IP neighbours for interface %q: %w", n.name, err)
+	}
+
+	leases := []api.NetworkLease{}
+	for _, neighbour := range neighbours {
+		if neighbour.MAC == nil || neighbour.Addr.To4() == nil || !subnet.Contains(neighbour.Addr) {
+			continue
+		}
+
+		if neighbour.State == ip.NeighbourIPStateIncomplete || neighbour.State == ip.NeighbourIPStateFailed {
+			continue
+		}
+
+		hostname, ok := hostnames[neighbour.MAC.String()]
+		if !ok {
+			continue
+		}
+
+		leases = append(leases, api.NetworkLease{
+			Hostname: hostname,
+			Address:  neighbour.Addr.String(),
+			Hwaddr:   neighbour.MAC.String(),
+			Type:     "dynamic",
+			Location: n.state.ServerName,
+		})
+	}
+
+	return leases, nil
+}
+
 // UsesDNSMasq indicates if network's config indicates if it needs to use dnsmasq.
 func (n *bridge) UsesDNSMasq() bool {
 	// Skip dnsmasq when no connectivity is configured.
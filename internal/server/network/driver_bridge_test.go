@@ -0,0 +1,96 @@
+package network
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/internal/server/db"
+)
+
+func TestGatewayLeaseNext(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	duration := 20 * time.Second
+	allEligible := func(nodeID int64) bool { return true }
+
+	// A free lease is taken with a first term.
+	lease, changed := gatewayLeaseNext(nil, 2, "server02", allEligible, now, duration)
+	assert.True(t, changed)
+	assert.Equal(t, &db.NetworkGatewayLease{NodeID: 2, Node: "server02", Term: 1, Expiry: now.Add(duration)}, lease)
+
+	held := &db.NetworkGatewayLease{NodeID: 1, Node: "server01", Term: 3, Expiry: now.Add(10 * time.Second)}
+
+	// The holder renews the lease within the same term.
+	lease, changed = gatewayLeaseNext(held, 1, "server01", allEligible, now, duration)
+	assert.True(t, changed)
+	assert.Equal(t, &db.NetworkGatewayLease{NodeID: 1, Node: "server01", Term: 3, Expiry: now.Add(duration)}, lease)
+
+	// Other members can't take a valid lease, whatever their ID.
+	lease, changed = gatewayLeaseNext(held, 0, "server00", allEligible, now, duration)
+	assert.False(t, changed)
+	assert.Equal(t, held, lease)
+
+	// An expired lease is taken over with a new term.
+	lease, changed = gatewayLeaseNext(held, 2, "server02", allEligible, now.Add(time.Minute), duration)
+	assert.True(t, changed)
+	assert.Equal(t, &db.NetworkGatewayLease{NodeID: 2, Node: "server02", Term: 4, Expiry: now.Add(time.Minute).Add(duration)}, lease)
+
+	// So is the lease of a member which can't hold the gateway anymore.
+	holderGone := func(nodeID int64) bool { return nodeID != 1 }
+	lease, changed = gatewayLeaseNext(held, 2, "server02", holderGone, now, duration)
+	assert.True(t, changed)
+	assert.Equal(t, int64(4), lease.Term)
+	assert.Equal(t, "server02", lease.Node)
+}
+
+func TestValidateNATAddresses(t *testing.T) {
+	parseNet := func(cidr string) *net.IPNet {
+		ip, subnet, err := net.ParseCIDR(cidr)
+		require.NoError(t, err)
+		subnet.IP = ip
+
+		return subnet
+	}
+
+	netSubnets := []*net.IPNet{parseNet("10.0.0.1/24"), parseNet("fd42::1/64")}
+	externalSubnetsInUse := []externalSubnetUsage{
+		{subnet: *parseNet("192.0.2.1/32"), usageType: subnetUsageNetworkSNAT, networkProject: "default", networkName: "br0"},
+		{subnet: *parseNet("192.0.2.2/32"), usageType: subnetUsageNetworkSNAT, networkProject: "default", networkName: "br1"},
+		{subnet: *parseNet("198.51.100.0/24"), usageType: subnetUsageNetwork, networkProject: "default", networkName: "br2"},
+		{subnet: *parseNet("192.0.2.10/32"), usageType: subnetUsageInstance, networkProject: "default", networkName: "br0", instanceProject: "default", instanceName: "c1", instanceDevice: "eth0"},
+		{subnet: *parseNet("2001:db8::10/128"), usageType: subnetUsageInstance, networkProject: "default", networkName: "br0", instanceProject: "default", instanceName: "c2", instanceDevice: "eth0"},
+	}
+
+	tests := []struct {
+		name         string
+		natAddress   string
+		instanceName string
+		err          string
+	}{
+		{name: "Unused address", natAddress: "192.0.2.20/32", instanceName: "c1"},
+		{name: "Network's own SNAT address", natAddress: "192.0.2.1/32", instanceName: "c1"},
+		{name: "Within the network's IPv4 subnet", natAddress: "10.0.0.20/32", instanceName: "c1", err: "within the network's subnet"},
+		{name: "Within the network's IPv6 subnet", natAddress: "fd42::20/128", instanceName: "c1", err: "within the network's subnet"},
+		{name: "Another network's SNAT address", natAddress: "192.0.2.2/32", instanceName: "c1", err: "overlaps with another network or NIC"},
+		{name: "Within another network's subnet", natAddress: "198.51.100.20/32", instanceName: "c1", err: "overlaps with another network or NIC"},
+		{name: "Subnet containing another network's SNAT address", natAddress: "192.0.2.0/24", instanceName: "c1", err: "overlaps with another network or NIC"},
+		{name: "Same NIC's address", natAddress: "192.0.2.10/32", instanceName: "c1"},
+		{name: "Another instance's NIC address", natAddress: "2001:db8::10/128", instanceName: "c1", err: "overlaps with another network or NIC"},
+		{name: "Instance NICs skipped for profiles", natAddress: "2001:db8::10/128"},
+		{name: "Networks checked for profiles", natAddress: "192.0.2.2/32", err: "overlaps with another network or NIC"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateNATAddresses([]*net.IPNet{parseNet(test.natAddress)}, netSubnets, externalSubnetsInUse, "default", "br0", "default", test.instanceName, "eth0")
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
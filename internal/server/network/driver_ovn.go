@@ -411,6 +411,7 @@ func (n *ovn) Validate(config map[string]string) error {
 		}),
 		"ipv4.dhcp.ranges": validate.Optional(validate.IsListOf(validate.IsNetworkRangeV4)),
 		"ipv4.dhcp.routes": validate.Optional(validate.IsDHCPRouteList),
+		"ipv4.dhcp.relay":  validate.Optional(validate.IsNetworkAddressV4),
 		"ipv6.address": validate.Optional(func(value string) error {
 			if validate.IsOneOf("none", "auto")(value) == nil {
 				return nil
@@ -531,6 +532,12 @@ func (n *ovn) Validate(config map[string]string) error {
 		}
 	}
 
+	// Check DHCP relay.
+	err = validateDHCPRelay(config)
+	if err != nil {
+		return err
+	}
+
 	// Check that ipv6.l3only mode is used with ipvp.dhcp.stateful.
 	// As otherwise the router advertisements will configure an address using the subnet's mask.
 	if util.IsTrue(config["ipv6.l3only"]) && util.IsTrueOrEmpty(config["ipv6.dhcp"]) && util.IsFalseOrEmpty(config["ipv6.dhcp.stateful"]) {
@@ -2303,12 +2310,19 @@ func (n *ovn) setup(update bool) error {
 			}
 		}
 
-		// Remove any existing SNAT rules on update. As currently these are only defined from the network
-		// config rather than from any instance NIC config, so we can re-create the active config below.
+		// Remove any existing network SNAT rules on update, so we can re-create the active config below.
+		// NIC specific SNAT rules are left in place as those are managed by the instance NICs.
 		if update {
-			err = n.ovnnb.DeleteLogicalRouterNAT(context.TODO(), n.getRouterName(), "snat", true)
+			snatRules, err := n.networkSNATRules()
 			if err != nil {
-				return fmt.Errorf("Failed removing existing router SNAT rules: %w", err)
+				return err
+			}
+
+			if len(snatRules) > 0 {
+				err = n.ovnnb.DeleteLogicalRouterSNAT(context.TODO(), n.getRouterName(), snatRules)
+				if err != nil {
+					return fmt.Errorf("Failed removing existing router SNAT rules: %w", err)
+				}
 			}
 		}
 
@@ -2566,8 +2580,14 @@ func (n *ovn) setup(update bool) error {
 	}
 
 	// Setup IP allocation config on logical switch.
+	// IPv4 addresses are handed out by the external DHCP server when relaying.
+	ipAllocationPrefixIPv4 := routerIntPortIPv4Net
+	if n.config["ipv4.dhcp.relay"] != "" {
+		ipAllocationPrefixIPv4 = nil
+	}
+
 	err = n.ovnnb.UpdateLogicalSwitchIPAllocation(context.TODO(), n.getIntSwitchName(), &networkOVN.OVNIPAllocationOpts{
-		PrefixIPv4:  routerIntPortIPv4Net,
+		PrefixIPv4:  ipAllocationPrefixIPv4,
 		PrefixIPv6:  routerIntPortIPv6Net,
 		ExcludeIPv4: dhcpReserveIPv4s,
 	})
@@ -2617,6 +2637,17 @@ func (n *ovn) setup(update bool) error {
 		}
 	}
 
+	// Configure DHCP relay on the internal router port.
+	var dhcpRelayServer net.IP
+	if routerIntPortIPv4 != nil {
+		dhcpRelayServer = net.ParseIP(n.config["ipv4.dhcp.relay"])
+	}
+
+	err = n.ovnnb.UpdateLogicalRouterPortDHCPRelay(context.TODO(), n.getIntSwitchName(), n.getRouterIntPortName(), dhcpRelayServer)
+	if err != nil {
+		return fmt.Errorf("Failed configuring DHCP relay: %w", err)
+	}
+
 	// Configure DHCP option sets.
 	var dhcpv4UUID, dhcpv6UUID networkOVN.OVNDHCPOptionsUUID
 	dhcpV4Subnet := n.DHCPv4Subnet()
@@ -3950,6 +3981,88 @@ func (n *ovn) InstanceDevicePortValidateExternalRoutes(deviceInstance instance.I
 	return nil
 }
 
+// networkSNATRules returns the network wide SNAT rules on the router (logical subnet to external IP).
+// NIC specific SNAT rules (those translating a single address) are excluded.
+func (n *ovn) networkSNATRules() (map[string]net.IP, error) {
+	natRules, err := n.ovnnb.GetLogicalRouterNAT(context.TODO(), n.getRouterName(), "snat")
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting router SNAT rules: %w", err)
+	}
+
+	return ovnNetworkSNATRules(natRules), nil
+}
+
+// ovnNetworkSNATRules returns the SNAT rules (logical IP to external IP) which apply to a whole subnet,
+// skipping the single address (/32 and /128) rules of the instance NICs.
+func ovnNetworkSNATRules(natRules map[string]net.IP) map[string]net.IP {
+	snatRules := make(map[string]net.IP, len(natRules))
+	for logicalIP, externalIP := range natRules {
+		_, logicalNet, err := net.ParseCIDR(logicalIP)
+		if err != nil || externalIP == nil {
+			continue
+		}
+
+		ones, bits := logicalNet.Mask.Size()
+		if ones == bits {
+			continue
+		}
+
+		snatRules[logicalIP] = externalIP
+	}
+
+	return snatRules
+}
+
+// ovnNICSNATRules returns the NIC specific SNAT rules (logical IP to external IP) for the NIC's addresses.
+// The network wide SNAT rules may use the same external IP, so both are needed to identify the NIC's rules.
+func ovnNICSNATRules(deviceConfig deviceConfig.Device, ips []net.IP) map[string]net.IP {
+	snatRules := map[string]net.IP{}
+	for _, ip := range ips {
+		natAddressKey := "ipv4.nat.address"
+		if ip.To4() == nil {
+			natAddressKey = "ipv6.nat.address"
+		}
+
+		snatIP := net.ParseIP(deviceConfig[natAddressKey])
+		if snatIP == nil {
+			continue
+		}
+
+		logicalNet := IPToNet(ip)
+		snatRules[logicalNet.String()] = snatIP
+	}
+
+	return snatRules
+}
+
+// InstanceDevicePortValidateNATAddresses validates the NIC specific SNAT addresses for an OVN instance port.
+// The addresses must be routed to the network by the uplink and not be in use elsewhere.
+func (n *ovn) InstanceDevicePortValidateNATAddresses(deviceInstance instance.Instance, deviceName string, natAddresses []*net.IPNet) error {
+	if n.config["network"] == "none" {
+		return fmt.Errorf("NIC specific NAT addresses require an uplink network")
+	}
+
+	var uplink *api.Network
+
+	err := n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		_, uplink, _, err = tx.GetNetworkInAnyState(ctx, api.ProjectDefaultName, n.config["network"])
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to load uplink network %q: %w", n.config["network"], err)
+	}
+
+	if uplink.Config["ovn.ingress_mode"] != "routed" {
+		return fmt.Errorf(`Cannot use NIC specific NAT addresses when uplink ovn.ingress_mode is not "routed"`)
+	}
+
+	// The addresses are subject to the same checks as external routes.
+	return n.InstanceDevicePortValidateExternalRoutes(deviceInstance, deviceName, natAddresses)
+}
+
 // InstanceDevicePortAdd adds empty DNS record (to indicate port has been added) and any DHCP reservations for
 // instance device port.
 func (n *ovn) InstanceDevicePortAdd(instanceUUID string, deviceName string, deviceConfig deviceConfig.Device) error {
@@ -4255,6 +4368,38 @@ func (n *ovn) InstanceDevicePortStart(opts *OVNInstanceNICSetupOpts, securityACL
 		}
 	}
 
+	// Add the NIC specific SNAT rules (using the IPs set for DNS as source).
+	for _, keyPrefix := range []string{"ipv4", "ipv6"} {
+		natAddressKey := fmt.Sprintf("%s.nat.address", keyPrefix)
+		if opts.DeviceConfig[natAddressKey] == "" {
+			continue
+		}
+
+		snatIP := net.ParseIP(opts.DeviceConfig[natAddressKey])
+		if snatIP == nil {
+			return "", nil, fmt.Errorf("Failed parsing %q", natAddressKey)
+		}
+
+		sourceIP := dnsIPv4
+		if keyPrefix == "ipv6" {
+			sourceIP = dnsIPv6
+		}
+
+		if sourceIP == nil {
+			return "", nil, fmt.Errorf("Cannot add SNAT rule for %q as source IP is not set", natAddressKey)
+		}
+
+		sourceNet := IPToNet(sourceIP)
+		err = n.ovnnb.CreateLogicalRouterNAT(context.TODO(), n.getRouterName(), "snat", &sourceNet, snatIP, nil, false, true)
+		if err != nil {
+			return "", nil, fmt.Errorf("Failed adding NIC SNAT rule for %q: %w", natAddressKey, err)
+		}
+
+		reverter.Add(func() {
+			_ = n.ovnnb.DeleteLogicalRouterSNAT(context.TODO(), n.getRouterName(), map[string]net.IP{sourceNet.String(): snatIP})
+		})
+	}
+
 	var routes []networkOVN.OVNRouterRoute
 
 	// In l3only mode we add the instance port's IPs as static routes to the router.
@@ -4562,6 +4707,27 @@ func (n *ovn) InstanceDevicePortIPs(instanceUUID string, deviceName string) ([]n
 		return nil, fmt.Errorf("Failed to get OVN switch port IPs: %w", err)
 	}
 
+	// Add the IPv4 addresses handed out by the external DHCP server, as learned by the router.
+	// The router only records those once the instance has sent traffic through it, so a freshly
+	// started instance may not have any yet.
+	if n.config["ipv4.dhcp.relay"] != "" {
+		hwAddr, err := n.ovnnb.GetLogicalSwitchPortHardwareAddress(context.TODO(), instancePortName)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get OVN switch port hardware address: %w", err)
+		}
+
+		learnedIPs, err := n.ovnsb.GetLogicalRouterPortLearnedIPs(context.TODO(), n.getRouterIntPortName(), hwAddr)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get OVN learned IPs: %w", err)
+		}
+
+		for _, learnedIP := range learnedIPs {
+			if learnedIP.To4() != nil && !IPInSlice(learnedIP, devIPs) {
+				devIPs = append(devIPs, learnedIP)
+			}
+		}
+	}
+
 	return devIPs, nil
 }
 
@@ -4693,6 +4859,15 @@ func (n *ovn) InstanceDevicePortStop(ovsExternalOVNPort networkOVN.OVNSwitchPort
 		}
 	}
 
+	// Delete the NIC specific SNAT rules (those of the DNS IPs, which are used as their source).
+	removeSNATRules := ovnNICSNATRules(opts.DeviceConfig, dnsIPs)
+	if len(removeSNATRules) > 0 {
+		err = n.ovnnb.DeleteLogicalRouterSNAT(context.TODO(), n.getRouterName(), removeSNATRules)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -4756,8 +4931,9 @@ func (n *ovn) InstanceDevicePortRemove(instanceUUID string, deviceName string, d
 
 // DHCPv4Subnet returns the DHCPv4 subnet (if DHCP is enabled on network).
 func (n *ovn) DHCPv4Subnet() *net.IPNet {
-	// DHCP is disabled on this network (an empty ipv4.dhcp setting indicates enabled by default).
-	if util.IsFalse(n.config["ipv4.dhcp"]) {
+	// DHCP is disabled on this network (an empty ipv4.dhcp setting indicates enabled by default) or served by
+	// an external server.
+	if util.IsFalse(n.config["ipv4.dhcp"]) || n.config["ipv4.dhcp.relay"] != "" {
 		return nil
 	}
 
@@ -4888,6 +5064,25 @@ func (n *ovn) ovnNICExternalRoutes(ovnProjectNetworksWithOurUplink map[string][]
 						})
 					}
 				}
+
+				// Also add any NIC specific SNAT addresses.
+				for _, key := range []string{"ipv4.nat.address", "ipv6.nat.address"} {
+					ipNet, _ := ParseIPToNet(devConfig[key])
+					if ipNet == nil {
+						// Skip if NIC device doesn't have a valid SNAT address.
+						continue
+					}
+
+					externalRoutes = append(externalRoutes, externalSubnetUsage{
+						subnet:          *ipNet,
+						networkProject:  instNetworkProject,
+						networkName:     devConfig["network"],
+						instanceProject: inst.Project,
+						instanceName:    inst.Name,
+						instanceDevice:  devName,
+						usageType:       subnetUsageInstance,
+					})
+				}
 			}
 
 			return nil
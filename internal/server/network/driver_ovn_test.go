@@ -0,0 +1,59 @@
+package network
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ovnTestRules returns the string representation of SNAT rules.
+func ovnTestRules(natRules map[string]net.IP) map[string]string {
+	rules := map[string]string{}
+	for logicalIP, externalIP := range natRules {
+		rules[logicalIP] = externalIP.String()
+	}
+
+	return rules
+}
+
+func TestOVNNetworkSNATRules(t *testing.T) {
+	natRules := map[string]net.IP{
+		"10.0.0.0/24":     net.ParseIP("192.0.2.1"),
+		"fd42::/64":       net.ParseIP("2001:db8::1"),
+		"10.0.0.5/32":     net.ParseIP("192.0.2.1"),
+		"fd42::5/128":     net.ParseIP("2001:db8::5"),
+		"10.0.0.6":        net.ParseIP("192.0.2.6"),
+		"10.0.1.0/24":     nil,
+		"2001:db8:1::/48": net.ParseIP("2001:db8::2"),
+	}
+
+	// Only the rules of whole subnets are returned, the NIC specific single address rules are kept
+	// (even when using the same external address as the network).
+	assert.Equal(t, map[string]string{
+		"10.0.0.0/24":     "192.0.2.1",
+		"fd42::/64":       "2001:db8::1",
+		"2001:db8:1::/48": "2001:db8::2",
+	}, ovnTestRules(ovnNetworkSNATRules(natRules)))
+
+	assert.Empty(t, ovnNetworkSNATRules(nil))
+	assert.Empty(t, ovnNetworkSNATRules(map[string]net.IP{"10.0.0.5/32": net.ParseIP("192.0.2.5")}))
+}
+
+func TestOVNNICSNATRules(t *testing.T) {
+	ips := []net.IP{net.ParseIP("10.0.0.5"), net.ParseIP("fd42::5")}
+
+	// The rules translate each of the NIC's addresses to the NAT address of its family.
+	config := map[string]string{"ipv4.nat.address": "192.0.2.1", "ipv6.nat.address": "2001:db8::5"}
+	assert.Equal(t, map[string]string{
+		"10.0.0.5/32": "192.0.2.1",
+		"fd42::5/128": "2001:db8::5",
+	}, ovnTestRules(ovnNICSNATRules(config, ips)))
+
+	// Families without a NAT address don't have a rule.
+	config = map[string]string{"ipv6.nat.address": "2001:db8::5"}
+	assert.Equal(t, map[string]string{"fd42::5/128": "2001:db8::5"}, ovnTestRules(ovnNICSNATRules(config, ips)))
+
+	assert.Empty(t, ovnNICSNATRules(config, nil))
+	assert.Empty(t, ovnNICSNATRules(nil, ips))
+}
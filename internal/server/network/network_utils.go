@@ -425,73 +425,10 @@ func UpdateDNSMasqStatic(s *state.State, networkName string) error {
 		networks = []string{networkName}
 	}
 
-	// Get all the instances.
-	insts, err := instance.LoadNodeAll(s, instancetype.Any)
-	if err != nil {
-		return err
-	}
-
-	// Build a list of dhcp host entries.
-	entries := map[string][][]string{}
-	for _, inst := range insts {
-		// Go through all its devices (including profiles).
-		for deviceName, d := range inst.ExpandedDevices() {
-			// Skip uninteresting entries.
-			if d["type"] != "nic" {
-				continue
-			}
-
-			nicType, err := nictype.NICType(s, inst.Project().Name, d)
-			if err != nil || nicType != "bridged" {
-				continue
-			}
-
-			// Temporarily populate parent from network setting if used.
-			if d["network"] != "" {
-				d["parent"] = d["network"]
-			}
-
-			// Skip devices not connected to managed networks.
-			if !slices.Contains(networks, d["parent"]) {
-				continue
-			}
-
-			// Fill in the hwaddr from volatile.
-			d, err = inst.FillNetworkDevice(deviceName, d)
-			if err != nil {
-				continue
-			}
-
-			// Add the new host entries.
-			_, ok := entries[d["parent"]]
-			if !ok {
-				entries[d["parent"]] = [][]string{}
-			}
-
-			if (util.IsTrue(d["security.ipv4_filtering"]) && d["ipv4.address"] == "") || (util.IsTrue(d["security.ipv6_filtering"]) && d["ipv6.address"] == "") {
-				deviceStaticFileName := dnsmasq.StaticAllocationFileName(inst.Project().Name, inst.Name(), deviceName)
-				_, curIPv4, curIPv6, err := dnsmasq.DHCPStaticAllocation(d["parent"], deviceStaticFileName)
-				if err != nil && !errors.Is(err, fs.ErrNotExist) {
-					return err
-				}
-
-				if d["ipv4.address"] == "" && curIPv4.IP != nil {
-					d["ipv4.address"] = curIPv4.IP.String()
-				}
-
-				if d["ipv6.address"] == "" && curIPv6.IP != nil {
-					d["ipv6.address"] = curIPv6.IP.String()
-				}
-			}
-
-			entries[d["parent"]] = append(entries[d["parent"]], []string{d["hwaddr"], inst.Project().Name, inst.Name(), d["ipv4.address"], d["ipv6.address"], deviceName})
-		}
-	}
-
-	// Update the host files.
+	// Only consider the networks whose DHCP service runs on this member.
+	managed := map[string]Network{}
+	clusterWide := false
 	for _, network := range networks {
-		entries := entries[network]
-
 		// Skip networks we don't manage (or don't have DHCP enabled).
 		if !util.PathExists(internalUtil.VarPath("networks", network, "dnsmasq.pid")) {
 			continue
@@ -503,6 +440,20 @@ func UpdateDNSMasqStatic(s *state.State, networkName string) error {
 			return fmt.Errorf("Failed to load network %q in project %q for dnsmasq update: %w", api.ProjectDefaultName, network, err)
 		}
 
+		managed[network] = n
+		if n.Config()["bridge.gateway.mode"] == "ha" && s.ServerClustered {
+			clusterWide = true
+		}
+	}
+
+	entries, err := dnsmasqStaticEntries(s, managed, clusterWide)
+	if err != nil {
+		return err
+	}
+
+	// Update the host files.
+	for network, n := range managed {
+		entries := entries[network]
 		config := n.Config()
 
 		// Wipe everything clean.
@@ -578,6 +529,90 @@ func UpdateDNSMasqStatic(s *state.State, networkName string) error {
 	return nil
 }
 
+// dnsmasqStaticEntries returns the DHCP host entries of the instances connected to the given networks.
+// Instances of other cluster members are only included when clusterWide is set and for networks with a highly
+// available gateway, as the member holding the gateway provides the DHCP service of the whole cluster.
+func dnsmasqStaticEntries(s *state.State, networks map[string]Network, clusterWide bool) (map[string][][]string, error) {
+	var insts []instance.Instance
+	var err error
+
+	// Get all the instances.
+	if clusterWide {
+		insts, err = instance.LoadAll(s, instancetype.Any)
+	} else {
+		insts, err = instance.LoadNodeAll(s, instancetype.Any)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Build a list of dhcp host entries.
+	entries := map[string][][]string{}
+	for _, inst := range insts {
+		// Go through all its devices (including profiles).
+		for deviceName, d := range inst.ExpandedDevices() {
+			// Skip uninteresting entries.
+			if d["type"] != "nic" {
+				continue
+			}
+
+			nicType, err := nictype.NICType(s, inst.Project().Name, d)
+			if err != nil || nicType != "bridged" {
+				continue
+			}
+
+			// Temporarily populate parent from network setting if used.
+			if d["network"] != "" {
+				d["parent"] = d["network"]
+			}
+
+			// Skip devices not connected to managed networks.
+			n, ok := networks[d["parent"]]
+			if !ok {
+				continue
+			}
+
+			// Skip remote instances unless the DHCP service of the network is provided cluster-wide.
+			if clusterWide && inst.Location() != s.ServerName && n.Config()["bridge.gateway.mode"] != "ha" {
+				continue
+			}
+
+			// Fill in the hwaddr from volatile.
+			d, err = inst.FillNetworkDevice(deviceName, d)
+			if err != nil {
+				continue
+			}
+
+			// Add the new host entries.
+			_, ok = entries[d["parent"]]
+			if !ok {
+				entries[d["parent"]] = [][]string{}
+			}
+
+			if (util.IsTrue(d["security.ipv4_filtering"]) && d["ipv4.address"] == "") || (util.IsTrue(d["security.ipv6_filtering"]) && d["ipv6.address"] == "") {
+				deviceStaticFileName := dnsmasq.StaticAllocationFileName(inst.Project().Name, inst.Name(), deviceName)
+				_, curIPv4, curIPv6, err := dnsmasq.DHCPStaticAllocation(d["parent"], deviceStaticFileName)
+				if err != nil && !errors.Is(err, fs.ErrNotExist) {
+					return nil, err
+				}
+
+				if d["ipv4.address"] == "" && curIPv4.IP != nil {
+					d["ipv4.address"] = curIPv4.IP.String()
+				}
+
+				if d["ipv6.address"] == "" && curIPv6.IP != nil {
+					d["ipv6.address"] = curIPv6.IP.String()
+				}
+			}
+
+			entries[d["parent"]] = append(entries[d["parent"]], []string{d["hwaddr"], inst.Project().Name, inst.Name(), d["ipv4.address"], d["ipv6.address"], deviceName})
+		}
+	}
+
+	return entries, nil
+}
+
 func randomSubnetV4() (string, error) {
 	for i := 0; i < 100; i++ {
 		cidr := fmt.Sprintf("10.%d.%d.1/24", rand.Intn(255), rand.Intn(255))
@@ -1481,3 +1516,28 @@ func validateExternalInterfaces(value string) error {
 
 	return nil
 }
+
+// validateDHCPRelay checks that the DHCPv4 relay settings of a network are consistent.
+func validateDHCPRelay(config map[string]string) error {
+	if config["ipv4.dhcp.relay"] == "" {
+		return nil
+	}
+
+	if util.IsNoneOrEmpty(config["ipv4.address"]) {
+		return fmt.Errorf(`"ipv4.dhcp.relay" requires "ipv4.address" to be set`)
+	}
+
+	if util.IsFalse(config["ipv4.dhcp"]) {
+		return fmt.Errorf(`"ipv4.dhcp.relay" cannot be used when "ipv4.dhcp" is disabled`)
+	}
+
+	if config["ipv4.dhcp.ranges"] != "" {
+		return fmt.Errorf(`"ipv4.dhcp.relay" cannot be used in conjunction with "ipv4.dhcp.ranges"`)
+	}
+
+	if util.IsTrue(config["ipv4.l3only"]) {
+		return fmt.Errorf(`"ipv4.dhcp.relay" cannot be used in conjunction with "ipv4.l3only"`)
+	}
+
+	return nil
+}
@@ -3,9 +3,14 @@ package network
 import (
 	"context"
 	"fmt"
+	"net"
+	"net/netip"
 	"os"
 	"strings"
 
+	"github.com/mdlayher/arp"
+	"github.com/mdlayher/ndp"
+
 	"github.com/lxc/incus/v6/internal/server/ip"
 	"github.com/lxc/incus/v6/internal/server/state"
 	"github.com/lxc/incus/v6/shared/util"
@@ -101,3 +106,63 @@ func DetachInterface(s *state.State, bridgeName string, devName string) error {
 
 	return nil
 }
+
+// bridgeAnnounceAddress sends a gratuitous ARP (IPv4) or an unsolicited neighbour advertisement (IPv6) for
+// an address of the bridge so that neighbours associate it with the MAC address of the bridge.
+func bridgeAnnounceAddress(bridgeName string, address net.IP) error {
+	iface, err := net.InterfaceByName(bridgeName)
+	if err != nil {
+		return err
+	}
+
+	// Handle IPv4 address.
+	if address.To4() != nil {
+		c, err := arp.Dial(iface)
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = c.Close() }()
+
+		netipAddr, ok := netip.AddrFromSlice(address.To4())
+		if !ok {
+			return fmt.Errorf("Invalid IPv4 address: %v", address)
+		}
+
+		broadcast := net.HardwareAddr{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+
+		packet, err := arp.NewPacket(arp.OperationRequest, iface.HardwareAddr, netipAddr, broadcast, netipAddr)
+		if err != nil {
+			return err
+		}
+
+		return c.WriteTo(packet, broadcast)
+	}
+
+	// Handle IPv6 address.
+	conn, _, err := ndp.Listen(iface, ndp.LinkLocal)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = conn.Close() }()
+
+	netipAddr, ok := netip.AddrFromSlice(address.To16())
+	if !ok {
+		return fmt.Errorf("Invalid IPv6 address: %v", address)
+	}
+
+	advertisement := &ndp.NeighborAdvertisement{
+		Router:        true,
+		Override:      true,
+		TargetAddress: netipAddr,
+		Options: []ndp.Option{
+			&ndp.LinkLayerAddress{
+				Direction: ndp.Target,
+				Addr:      iface.HardwareAddr,
+			},
+		},
+	}
+
+	return conn.WriteTo(advertisement, nil, netip.IPv6LinkLocalAllNodes())
+}
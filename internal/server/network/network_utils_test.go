@@ -3,6 +3,9 @@ package network
 import (
 	"fmt"
 	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
 
 	"github.com/lxc/incus/v6/internal/iprange"
 )
@@ -137,3 +140,28 @@ func Example_ipRangesOverlap() {
 	// Range1: 10.1.1.4, Range2: 10.1.1.8-10.1.1.9, overlapped: false
 	// Range1: 10.1.1.8-10.1.1.9, Range2: 10.1.1.4, overlapped: false
 }
+
+func TestValidateDHCPRelay(t *testing.T) {
+	tests := []struct {
+		config map[string]string
+		err    string
+	}{
+		{config: map[string]string{"ipv4.address": "10.0.0.1/24"}},
+		{config: map[string]string{"ipv4.address": "10.0.0.1/24", "ipv4.dhcp.relay": "192.0.2.10"}},
+		{config: map[string]string{"ipv4.address": "10.0.0.1/24", "ipv4.dhcp.relay": "192.0.2.10", "ipv4.dhcp": "true"}},
+		{config: map[string]string{"ipv4.dhcp.relay": "192.0.2.10"}, err: `"ipv4.dhcp.relay" requires "ipv4.address" to be set`},
+		{config: map[string]string{"ipv4.address": "none", "ipv4.dhcp.relay": "192.0.2.10"}, err: `"ipv4.dhcp.relay" requires "ipv4.address" to be set`},
+		{config: map[string]string{"ipv4.address": "10.0.0.1/24", "ipv4.dhcp.relay": "192.0.2.10", "ipv4.dhcp": "false"}, err: `"ipv4.dhcp.relay" cannot be used when "ipv4.dhcp" is disabled`},
+		{config: map[string]string{"ipv4.address": "10.0.0.1/24", "ipv4.dhcp.relay": "192.0.2.10", "ipv4.dhcp.ranges": "10.0.0.10-10.0.0.20"}, err: `"ipv4.dhcp.relay" cannot be used in conjunction with "ipv4.dhcp.ranges"`},
+		{config: map[string]string{"ipv4.address": "10.0.0.1/24", "ipv4.dhcp.relay": "192.0.2.10", "ipv4.l3only": "true"}, err: `"ipv4.dhcp.relay" cannot be used in conjunction with "ipv4.l3only"`},
+	}
+
+	for _, test := range tests {
+		err := validateDHCPRelay(test.config)
+		if test.err == "" {
+			assert.NoError(t, err, test.config)
+		} else {
+			assert.EqualError(t, err, test.err, test.config)
+		}
+	}
+}
@@ -19,6 +19,12 @@ import (
 	"github.com/lxc/incus/v6/shared/util"
 )
 
+// dhcpRelayTable is the Northbound table holding the DHCP relay configuration.
+const dhcpRelayTable = "DHCP_Relay"
+
+// dhcpRelayPortKey is the logical switch setting pointing to the router port relaying its DHCP requests.
+const dhcpRelayPortKey = "dhcp_relay_port"
+
 // OVNRouter OVN router name.
 type OVNRouter string
 
@@ -81,6 +87,7 @@ const (
 	ovnExtIDIncusProjectID  = "incus_project_id"
 	ovnExtIDIncusPortGroup  = "incus_port_group"
 	ovnExtIDIncusLocation   = "incus_location"
+	ovnExtIDIncusACLCounter = "incus_acl_counter"
 )
 
 // OVNIPv6RAOpts IPv6 router advertisements options that can be applied to a router.
@@ -143,6 +150,7 @@ type OVNACLRule struct {
 	Priority  int    // Priority (between 0 and 32767, inclusive). Higher values take precedence.
 	Log       bool   // Whether or not to log matched packets.
 	LogName   string // Log label name (requires Log be true).
+	Counter   string // Name of the counter the matched packets are accounted to (optional).
 }
 
 // OVNLoadBalancerTarget represents an OVN load balancer Virtual IP target.
@@ -399,6 +407,37 @@ func (o *NB) CreateLogicalRouterNAT(ctx context.Context, routerName OVNRouter, n
 	return nil
 }
 
+// GetLogicalRouterNAT returns the NAT rules of a particular type from a logical router.
+// The result maps the logical IP (or subnet) of each rule to its external IP.
+func (o *NB) GetLogicalRouterNAT(ctx context.Context, routerName OVNRouter, natType string) (map[string]net.IP, error) {
+	// Get the logical router.
+	logicalRouter, err := o.GetLogicalRouter(ctx, routerName)
+	if err != nil {
+		return nil, err
+	}
+
+	natRules := map[string]net.IP{}
+	for _, natUUID := range logicalRouter.Nat {
+		natRule := ovnNB.NAT{
+			UUID: natUUID,
+		}
+
+		err = o.get(ctx, &natRule)
+		if err != nil {
+			return nil, err
+		}
+
+		// Check if rule is of the requested type.
+		if natRule.Type != natType {
+			continue
+		}
+
+		natRules[natRule.LogicalIP] = net.ParseIP(natRule.ExternalIP)
+	}
+
+	return natRules, nil
+}
+
 // DeleteLogicalRouterNAT deletes all NAT rules of a particular type from a logical router.
 func (o *NB) DeleteLogicalRouterNAT(ctx context.Context, routerName OVNRouter, natType string, all bool, extIPs ...net.IP) error {
 	// Quick checks.
@@ -406,6 +445,35 @@ func (o *NB) DeleteLogicalRouterNAT(ctx context.Context, routerName OVNRouter, n
 		return fmt.Errorf("Can't ask for all NAT rules to be deleted and specify specific addresses")
 	}
 
+	return o.deleteLogicalRouterNAT(ctx, routerName, natType, func(natRule ovnNB.NAT) bool {
+		if all {
+			return true
+		}
+
+		// Check if the address matches.
+		for _, extIP := range extIPs {
+			if natRule.ExternalIP == extIP.String() {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// DeleteLogicalRouterSNAT deletes the SNAT rules of a logical router matching both the logical IP (or subnet) and
+// the external IP of one of the provided rules (keyed by logical IP, as returned by GetLogicalRouterNAT).
+// Other rules using the same external IP are left in place.
+func (o *NB) DeleteLogicalRouterSNAT(ctx context.Context, routerName OVNRouter, natRules map[string]net.IP) error {
+	return o.deleteLogicalRouterNAT(ctx, routerName, "snat", func(natRule ovnNB.NAT) bool {
+		extIP, ok := natRules[natRule.LogicalIP]
+
+		return ok && extIP != nil && natRule.ExternalIP == extIP.String()
+	})
+}
+
+// deleteLogicalRouterNAT deletes the NAT rules of a particular type from a logical router for which match returns true.
+func (o *NB) deleteLogicalRouterNAT(ctx context.Context, routerName OVNRouter, natType string, match func(natRule ovnNB.NAT) bool) error {
 	// Get the logical router.
 	logicalRouter, err := o.GetLogicalRouter(ctx, routerName)
 	if err != nil {
@@ -425,26 +493,11 @@ func (o *NB) DeleteLogicalRouterNAT(ctx context.Context, routerName OVNRouter, n
 			return err
 		}
 
-		// Check if rule is of the requested type.
-		if natRule.Type != natType {
+		// Check if rule is of the requested type and matches.
+		if natRule.Type != natType || !match(natRule) {
 			continue
 		}
 
-		// Check if the address matches.
-		if !all {
-			found := false
-			for _, extIP := range extIPs {
-				if natRule.ExternalIP == extIP.String() {
-					found = true
-					break
-				}
-			}
-
-			if !found {
-				continue
-			}
-		}
-
 		// Delete the rule.
 		deleteOps, err := o.client.Where(&natRule).Delete()
 		if err != nil {
@@ -2813,6 +2866,25 @@ func (o *NB) UpdatePortGroupACLRules(ctx context.Context, portGroupName OVNPortG
 	return nil
 }
 
+// GetACLCounterNames returns the counter name of the ACLs having one, keyed by ACL UUID.
+func (o *NB) GetACLCounterNames(ctx context.Context) (map[string]string, error) {
+	acls := []ovnNB.ACL{}
+
+	err := o.client.WhereCache(func(acl *ovnNB.ACL) bool {
+		return acl.ExternalIDs != nil && acl.ExternalIDs[ovnExtIDIncusACLCounter] != ""
+	}).List(ctx, &acls)
+	if err != nil {
+		return nil, err
+	}
+
+	counters := make(map[string]string, len(acls))
+	for _, acl := range acls {
+		counters[acl.UUID] = acl.ExternalIDs[ovnExtIDIncusACLCounter]
+	}
+
+	return counters, nil
+}
+
 // aclRuleAddOperations returns the operations to add the provided ACL rules to the specified OVN entity.
 func (o *NB) aclRuleAddOperations(ctx context.Context, entityTable string, entityName string, externalIDs map[string]string, matchReplace map[string]string, aclRules ...OVNACLRule) ([]ovsdb.Operation, error) {
 	operations := []ovsdb.Operation{}
@@ -2846,6 +2918,10 @@ func (o *NB) aclRuleAddOperations(ctx context.Context, entityTable string, entit
 			acl.ExternalIDs[k] = v
 		}
 
+		if rule.Counter != "" {
+			acl.ExternalIDs[ovnExtIDIncusACLCounter] = rule.Counter
+		}
+
 		createOps, err := o.client.Create(&acl)
 		if err != nil {
 			return nil, err
@@ -4240,6 +4316,156 @@ func (o *NB) GetLogicalRouterPortHardwareAddress(ctx context.Context, ovnRouterP
 	return lrp.MAC, nil
 }
 
+// UpdateLogicalRouterPortDHCPRelay configures the logical router port to relay the DHCPv4 requests received on
+// the logical switch to an external server. A nil server removes the relay configuration.
+func (o *NB) UpdateLogicalRouterPortDHCPRelay(ctx context.Context, switchName OVNSwitch, portName OVNRouterPort, server net.IP) error {
+	// Get the logical switch.
+	logicalSwitch, err := o.GetLogicalSwitch(ctx, switchName)
+	if err != nil {
+		return err
+	}
+
+	// DHCP relay support was added in OVN 24.03 and isn't part of the generated schema.
+	_, ok := o.client.Schema().Tables[dhcpRelayTable]
+	if !ok {
+		if server == nil {
+			return nil
+		}
+
+		return errors.New("DHCP relay requires OVN 24.03 or higher")
+	}
+
+	// Nothing to do if the relay was never configured.
+	if server == nil && logicalSwitch.OtherConfig[dhcpRelayPortKey] == "" {
+		return nil
+	}
+
+	// Look for an existing relay.
+	selectOperations := []ovsdb.Operation{{
+		Op:      ovsdb.OperationSelect,
+		Table:   dhcpRelayTable,
+		Where:   []ovsdb.Condition{ovsdb.NewCondition("name", ovsdb.ConditionEqual, string(portName))},
+		Columns: []string{"_uuid"},
+	}}
+
+	resp, err := o.client.Transact(ctx, selectOperations...)
+	if err != nil {
+		return err
+	}
+
+	_, err = ovsdb.CheckOperationResults(resp, selectOperations)
+	if err != nil {
+		return err
+	}
+
+	relayUUID := ""
+	for _, row := range resp[0].Rows {
+		uuid, ok := row["_uuid"].(ovsdb.UUID)
+		if ok {
+			relayUUID = uuid.GoUUID
+		}
+	}
+
+	if logicalSwitch.OtherConfig == nil {
+		logicalSwitch.OtherConfig = map[string]string{}
+	}
+
+	operations := []ovsdb.Operation{}
+	routerPortWhere := []ovsdb.Condition{ovsdb.NewCondition("name", ovsdb.ConditionEqual, string(portName))}
+
+	if server != nil {
+		if relayUUID == "" {
+			relayUUID = "dhcp_relay"
+			operations = append(operations, ovsdb.Operation{
+				Op:       ovsdb.OperationInsert,
+				Table:    dhcpRelayTable,
+				UUIDName: relayUUID,
+				Row:      ovsdb.Row{"name": string(portName), "servers": server.String()},
+			})
+		} else {
+			operations = append(operations, ovsdb.Operation{
+				Op:    ovsdb.OperationUpdate,
+				Table: dhcpRelayTable,
+				Where: []ovsdb.Condition{ovsdb.NewCondition("_uuid", ovsdb.ConditionEqual, ovsdb.UUID{GoUUID: relayUUID})},
+				Row:   ovsdb.Row{"servers": server.String()},
+			})
+		}
+
+		operations = append(operations, ovsdb.Operation{
+			Op:    ovsdb.OperationUpdate,
+			Table: ovnNB.LogicalRouterPortTable,
+			Where: routerPortWhere,
+			Row:   ovsdb.Row{"dhcp_relay": ovsdb.UUID{GoUUID: relayUUID}},
+		})
+
+		logicalSwitch.OtherConfig[dhcpRelayPortKey] = string(portName)
+	} else {
+		operations = append(operations, ovsdb.Operation{
+			Op:    ovsdb.OperationUpdate,
+			Table: ovnNB.LogicalRouterPortTable,
+			Where: routerPortWhere,
+			Row:   ovsdb.Row{"dhcp_relay": ovsdb.OvsSet{GoSet: []any{}}},
+		})
+
+		if relayUUID != "" {
+			operations = append(operations, ovsdb.Operation{
+				Op:    ovsdb.OperationDelete,
+				Table: dhcpRelayTable,
+				Where: []ovsdb.Condition{ovsdb.NewCondition("_uuid", ovsdb.ConditionEqual, ovsdb.UUID{GoUUID: relayUUID})},
+			})
+		}
+
+		delete(logicalSwitch.OtherConfig, dhcpRelayPortKey)
+	}
+
+	updateOps, err := o.client.Where(logicalSwitch).Update(logicalSwitch, &logicalSwitch.OtherConfig)
+	if err != nil {
+		return err
+	}
+
+	operations = append(operations, updateOps...)
+
+	// Apply the database changes.
+	resp, err = o.client.Transact(ctx, operations...)
+	if err != nil {
+		return err
+	}
+
+	_, err = ovsdb.CheckOperationResults(resp, operations)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetLogicalSwitchPortHardwareAddress returns the hardware address of a logical switch port.
+func (o *NB) GetLogicalSwitchPortHardwareAddress(ctx context.Context, portName OVNSwitchPort) (net.HardwareAddr, error) {
+	lsp := ovnNB.LogicalSwitchPort{
+		Name: string(portName),
+	}
+
+	err := o.get(ctx, &lsp)
+	if err != nil {
+		return nil, err
+	}
+
+	// The hardware address is the first entry of the port addresses.
+	for _, address := range lsp.Addresses {
+		fields := strings.Fields(address)
+		if len(fields) == 0 {
+			continue
+		}
+
+		hwAddr, err := net.ParseMAC(fields[0])
+		if err == nil {
+			return hwAddr, nil
+		}
+	}
+
+	return nil, ovsClient.ErrNotFound
+}
+
 // GetName returns the OVN AZ name.
 func (o *NB) GetName(ctx context.Context) (string, error) {
 	// Get the global configuration.
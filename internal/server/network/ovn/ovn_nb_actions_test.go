@@ -0,0 +1,300 @@
+package ovn
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	ovsdbClient "github.com/ovn-org/libovsdb/client"
+	"github.com/ovn-org/libovsdb/database/inmemory"
+	ovsdbModel "github.com/ovn-org/libovsdb/model"
+	"github.com/ovn-org/libovsdb/ovsdb"
+	ovsdbServer "github.com/ovn-org/libovsdb/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ovnNB "github.com/lxc/incus/v6/internal/server/network/ovn/schema/ovn-nb"
+)
+
+// testDHCPRelay is the server side model of the DHCP_Relay table, which isn't part of the generated schema.
+type testDHCPRelay struct {
+	UUID        string            `ovsdb:"_uuid"`
+	Name        string            `ovsdb:"name"`
+	Servers     *string           `ovsdb:"servers"`
+	Options     map[string]string `ovsdb:"options"`
+	ExternalIDs map[string]string `ovsdb:"external_ids"`
+}
+
+// testLogicalRouterPort is the server side model of the Logical_Router_Port table including the DHCP relay.
+type testLogicalRouterPort struct {
+	UUID      string  `ovsdb:"_uuid"`
+	Name      string  `ovsdb:"name"`
+	MAC       string  `ovsdb:"mac"`
+	DHCPRelay *string `ovsdb:"dhcp_relay"`
+}
+
+// testNBSchema returns the Northbound schema, with the DHCP relay table and column if withRelay is set.
+func testNBSchema(t *testing.T, withRelay bool) ovsdb.DatabaseSchema {
+	t.Helper()
+
+	schema := ovnNB.Schema()
+	if !withRelay {
+		return schema
+	}
+
+	table := ovsdb.TableSchema{}
+	err := json.Unmarshal([]byte(`{
+		"columns": {
+			"name": {"type": "string"},
+			"servers": {"type": {"key": "string", "min": 0, "max": 1}},
+			"options": {"type": {"key": "string", "value": "string", "min": 0, "max": "unlimited"}},
+			"external_ids": {"type": {"key": "string", "value": "string", "min": 0, "max": "unlimited"}}
+		},
+		"isRoot": true
+	}`), &table)
+	require.NoError(t, err)
+
+	column := ovsdb.ColumnSchema{}
+	err = json.Unmarshal([]byte(`{"type": {"key": {"type": "uuid", "refTable": "DHCP_Relay", "refType": "weak"}, "min": 0, "max": 1}}`), &column)
+	require.NoError(t, err)
+
+	schema.Tables[dhcpRelayTable] = table
+	schema.Tables[ovnNB.LogicalRouterPortTable].Columns["dhcp_relay"] = &column
+
+	// Keep the router ports without having to create their routers.
+	routerPortTable := schema.Tables[ovnNB.LogicalRouterPortTable]
+	routerPortTable.IsRoot = true
+	schema.Tables[ovnNB.LogicalRouterPortTable] = routerPortTable
+
+	return schema
+}
+
+// testNB starts an in-memory Northbound database and returns a client connected to it.
+func testNB(t *testing.T, withRelay bool) *NB {
+	t.Helper()
+
+	schema := testNBSchema(t, withRelay)
+
+	tables := map[string]ovsdbModel.Model{
+		ovnNB.LogicalSwitchTable:     &ovnNB.LogicalSwitch{},
+		ovnNB.LogicalRouterTable:     &ovnNB.LogicalRouter{},
+		ovnNB.LogicalRouterPortTable: &ovnNB.LogicalRouterPort{},
+		ovnNB.NATTable:               &ovnNB.NAT{},
+	}
+
+	if withRelay {
+		tables[dhcpRelayTable] = &testDHCPRelay{}
+		tables[ovnNB.LogicalRouterPortTable] = &testLogicalRouterPort{}
+	}
+
+	serverModel, err := ovsdbModel.NewClientDBModel("OVN_Northbound", tables)
+	require.NoError(t, err)
+
+	dbModel, errs := ovsdbModel.NewDatabaseModel(schema, serverModel)
+	require.Empty(t, errs)
+
+	server, err := ovsdbServer.NewOvsdbServer(inmemory.NewDatabase(map[string]ovsdbModel.ClientDBModel{"OVN_Northbound": serverModel}), dbModel)
+	require.NoError(t, err)
+
+	socket := filepath.Join(t.TempDir(), "ovnnb.sock")
+	go func() { _ = server.Serve("unix", socket) }()
+	t.Cleanup(server.Close)
+
+	require.Eventually(t, server.Ready, 5*time.Second, 10*time.Millisecond)
+
+	// Only the logical switches, routers and NAT rules are cached, the other tables are accessed directly.
+	clientModel, err := ovsdbModel.NewClientDBModel("OVN_Northbound", map[string]ovsdbModel.Model{
+		ovnNB.LogicalSwitchTable: &ovnNB.LogicalSwitch{},
+		ovnNB.LogicalRouterTable: &ovnNB.LogicalRouter{},
+		ovnNB.NATTable:           &ovnNB.NAT{},
+	})
+	require.NoError(t, err)
+
+	clientModel.SetIndexes(map[string][]ovsdbModel.ClientIndex{
+		ovnNB.LogicalSwitchTable: {{Columns: []ovsdbModel.ColumnKey{{Column: "name"}}}},
+		ovnNB.LogicalRouterTable: {{Columns: []ovsdbModel.ColumnKey{{Column: "name"}}}},
+	})
+
+	discard := logr.Discard()
+	client, err := ovsdbClient.NewOVSDBClient(clientModel, ovsdbClient.WithEndpoint("unix:"+socket), ovsdbClient.WithLogger(&discard))
+	require.NoError(t, err)
+	require.NoError(t, client.Connect(context.Background()))
+	t.Cleanup(client.Close)
+
+	_, err = client.MonitorAll(context.Background())
+	require.NoError(t, err)
+
+	return &NB{client: client}
+}
+
+// testNBSelect returns the rows of a Northbound table.
+func testNBSelect(t *testing.T, o *NB, table string, columns ...string) []ovsdb.Row {
+	t.Helper()
+
+	operations := []ovsdb.Operation{{Op: ovsdb.OperationSelect, Table: table, Columns: columns}}
+	resp, err := o.client.Transact(context.Background(), operations...)
+	require.NoError(t, err)
+
+	_, err = ovsdb.CheckOperationResults(resp, operations)
+	require.NoError(t, err)
+
+	return resp[0].Rows
+}
+
+// testNBRelayPort returns the DHCP relay port set on the logical switch.
+func testNBRelayPort(t *testing.T, o *NB, switchName OVNSwitch) string {
+	t.Helper()
+
+	logicalSwitch, err := o.GetLogicalSwitch(context.Background(), switchName)
+	require.NoError(t, err)
+
+	return logicalSwitch.OtherConfig[dhcpRelayPortKey]
+}
+
+func TestUpdateLogicalRouterPortDHCPRelay(t *testing.T) {
+	ctx := context.Background()
+	o := testNB(t, true)
+
+	operations := []ovsdb.Operation{
+		{Op: ovsdb.OperationInsert, Table: ovnNB.LogicalSwitchTable, Row: ovsdb.Row{"name": "incus-net1-ls-int"}},
+		{Op: ovsdb.OperationInsert, Table: ovnNB.LogicalRouterPortTable, Row: ovsdb.Row{"name": "incus-net1-lr-lrp-int", "mac": "00:16:3e:00:00:01"}},
+	}
+
+	resp, err := o.client.Transact(ctx, operations...)
+	require.NoError(t, err)
+	_, err = ovsdb.CheckOperationResults(resp, operations)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		_, err := o.GetLogicalSwitch(ctx, "incus-net1-ls-int")
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// relayState returns the relay servers and the relay referenced by the router port.
+	relayState := func() ([]string, string) {
+		servers := []string{}
+		for _, row := range testNBSelect(t, o, dhcpRelayTable, "name", "servers") {
+			assert.Equal(t, "incus-net1-lr-lrp-int", row["name"])
+			servers = append(servers, row["servers"].(string))
+		}
+
+		portRelay := ""
+		for _, row := range testNBSelect(t, o, ovnNB.LogicalRouterPortTable, "dhcp_relay") {
+			uuid, ok := row["dhcp_relay"].(ovsdb.UUID)
+			if ok {
+				portRelay = uuid.GoUUID
+			}
+		}
+
+		return servers, portRelay
+	}
+
+	// Turning the relay off when it was never configured is a no-op.
+	require.NoError(t, o.UpdateLogicalRouterPortDHCPRelay(ctx, "incus-net1-ls-int", "incus-net1-lr-lrp-int", nil))
+	servers, portRelay := relayState()
+	assert.Empty(t, servers)
+	assert.Empty(t, portRelay)
+
+	// Turning it on creates the relay and points the router port and the switch to it.
+	require.NoError(t, o.UpdateLogicalRouterPortDHCPRelay(ctx, "incus-net1-ls-int", "incus-net1-lr-lrp-int", net.ParseIP("192.0.2.10")))
+	servers, portRelay = relayState()
+	assert.Equal(t, []string{"192.0.2.10"}, servers)
+	assert.NotEmpty(t, portRelay)
+	assert.Eventually(t, func() bool { return testNBRelayPort(t, o, "incus-net1-ls-int") == "incus-net1-lr-lrp-int" }, 5*time.Second, 10*time.Millisecond)
+
+	// Changing the server updates the existing relay.
+	require.NoError(t, o.UpdateLogicalRouterPortDHCPRelay(ctx, "incus-net1-ls-int", "incus-net1-lr-lrp-int", net.ParseIP("192.0.2.20")))
+	servers, newPortRelay := relayState()
+	assert.Equal(t, []string{"192.0.2.20"}, servers)
+	assert.Equal(t, portRelay, newPortRelay)
+
+	// Turning it off removes the relay and the references to it.
+	require.NoError(t, o.UpdateLogicalRouterPortDHCPRelay(ctx, "incus-net1-ls-int", "incus-net1-lr-lrp-int", nil))
+	servers, portRelay = relayState()
+	assert.Empty(t, servers)
+	assert.Empty(t, portRelay)
+	assert.Eventually(t, func() bool { return testNBRelayPort(t, o, "incus-net1-ls-int") == "" }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestUpdateLogicalRouterPortDHCPRelayUnsupported(t *testing.T) {
+	ctx := context.Background()
+	o := testNB(t, false)
+
+	operations := []ovsdb.Operation{{Op: ovsdb.OperationInsert, Table: ovnNB.LogicalSwitchTable, Row: ovsdb.Row{"name": "incus-net1-ls-int"}}}
+	_, err := o.client.Transact(ctx, operations...)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		_, err := o.GetLogicalSwitch(ctx, "incus-net1-ls-int")
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Without DHCP relay support, turning it off is a no-op while turning it on fails.
+	require.NoError(t, o.UpdateLogicalRouterPortDHCPRelay(ctx, "incus-net1-ls-int", "incus-net1-lr-lrp-int", nil))
+	assert.ErrorContains(t, o.UpdateLogicalRouterPortDHCPRelay(ctx, "incus-net1-ls-int", "incus-net1-lr-lrp-int", net.ParseIP("192.0.2.10")), "requires OVN 24.03")
+}
+
+func TestDeleteLogicalRouterSNAT(t *testing.T) {
+	ctx := context.Background()
+	o := testNB(t, false)
+
+	operations := []ovsdb.Operation{{Op: ovsdb.OperationInsert, Table: ovnNB.LogicalRouterTable, Row: ovsdb.Row{"name": "incus-net1-lr"}}}
+	_, err := o.client.Transact(ctx, operations...)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		_, err := o.GetLogicalRouter(ctx, "incus-net1-lr")
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// waitNAT waits for the router SNAT rules to match the expected ones.
+	waitNAT := func(expected map[string]string) {
+		t.Helper()
+
+		assert.Eventually(t, func() bool {
+			natRules, err := o.GetLogicalRouterNAT(ctx, "incus-net1-lr", "snat")
+			if err != nil || len(natRules) != len(expected) {
+				return false
+			}
+
+			for logicalIP, externalIP := range expected {
+				if natRules[logicalIP].String() != externalIP {
+					return false
+				}
+			}
+
+			return true
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+
+	// The network wide rule and a NIC specific rule using the same external address.
+	_, networkNet, err := net.ParseCIDR("10.0.0.0/24")
+	require.NoError(t, err)
+	require.NoError(t, o.CreateLogicalRouterNAT(ctx, "incus-net1-lr", "snat", networkNet, net.ParseIP("192.0.2.1"), nil, false, false))
+	waitNAT(map[string]string{"10.0.0.0/24": "192.0.2.1"})
+
+	_, nicNet, err := net.ParseCIDR("10.0.0.5/32")
+	require.NoError(t, err)
+	require.NoError(t, o.CreateLogicalRouterNAT(ctx, "incus-net1-lr", "snat", nicNet, net.ParseIP("192.0.2.1"), nil, false, false))
+
+	_, otherNICNet, err := net.ParseCIDR("10.0.0.6/32")
+	require.NoError(t, err)
+	require.NoError(t, o.CreateLogicalRouterNAT(ctx, "incus-net1-lr", "snat", otherNICNet, net.ParseIP("192.0.2.6"), nil, false, false))
+	waitNAT(map[string]string{"10.0.0.0/24": "192.0.2.1", "10.0.0.5/32": "192.0.2.1", "10.0.0.6/32": "192.0.2.6"})
+
+	// Removing the NIC rule keeps the network wide rule using the same external address.
+	require.NoError(t, o.DeleteLogicalRouterSNAT(ctx, "incus-net1-lr", map[string]net.IP{"10.0.0.5/32": net.ParseIP("192.0.2.1")}))
+	waitNAT(map[string]string{"10.0.0.0/24": "192.0.2.1", "10.0.0.6/32": "192.0.2.6"})
+
+	// Rules only matching the logical IP or the external IP are kept.
+	require.NoError(t, o.DeleteLogicalRouterSNAT(ctx, "incus-net1-lr", map[string]net.IP{"10.0.0.6/32": net.ParseIP("192.0.2.1"), "10.0.0.7/32": net.ParseIP("192.0.2.6")}))
+	waitNAT(map[string]string{"10.0.0.0/24": "192.0.2.1", "10.0.0.6/32": "192.0.2.6"})
+
+	// Removing the network wide rule keeps the NIC rules.
+	require.NoError(t, o.DeleteLogicalRouterSNAT(ctx, "incus-net1-lr", map[string]net.IP{"10.0.0.0/24": net.ParseIP("192.0.2.1")}))
+	waitNAT(map[string]string{"10.0.0.6/32": "192.0.2.6"})
+}
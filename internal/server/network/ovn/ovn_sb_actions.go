@@ -7,6 +7,8 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/ovn-org/libovsdb/ovsdb"
+
 	ovnNB "github.com/lxc/incus/v6/internal/server/network/ovn/schema/ovn-nb"
 	ovnSB "github.com/lxc/incus/v6/internal/server/network/ovn/schema/ovn-sb"
 )
@@ -95,3 +97,103 @@ func (o *SB) CheckLoadBalancerOnline(ctx context.Context, lb ovnNB.LoadBalancer)
 
 	return false, nil
 }
+
+// GetLogicalFlowCookies returns the OpenFlow cookies of the logical flows generated from the Northbound
+// records with the provided UUIDs. The result maps the cookies to the Northbound record UUIDs.
+func (o *SB) GetLogicalFlowCookies(ctx context.Context, uuids []string) (map[uint64]string, error) {
+	if len(uuids) == 0 {
+		return map[uint64]string{}, nil
+	}
+
+	// The logical flows aren't cached, so query them directly. Logical flows reference the
+	// Northbound record they were generated from through the first 8 characters of its UUID.
+	operations := make([]ovsdb.Operation, 0, len(uuids))
+	for _, uuid := range uuids {
+		if len(uuid) < 8 {
+			return nil, fmt.Errorf("Invalid UUID %q", uuid)
+		}
+
+		hint, err := ovsdb.NewOvsMap(map[string]string{"stage-hint": uuid[0:8]})
+		if err != nil {
+			return nil, err
+		}
+
+		operations = append(operations, ovsdb.Operation{
+			Op:      ovsdb.OperationSelect,
+			Table:   ovnSB.LogicalFlowTable,
+			Where:   []ovsdb.Condition{ovsdb.NewCondition("external_ids", ovsdb.ConditionIncludes, hint)},
+			Columns: []string{"_uuid"},
+		})
+	}
+
+	resp, err := o.client.Transact(ctx, operations...)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = ovsdb.CheckOperationResults(resp, operations)
+	if err != nil {
+		return nil, err
+	}
+
+	// The OpenFlow cookie of a flow is the first 32 bits of the UUID of its logical flow.
+	cookies := map[uint64]string{}
+	for i, result := range resp {
+		for _, row := range result.Rows {
+			flowUUID, ok := row["_uuid"].(ovsdb.UUID)
+			if !ok || len(flowUUID.GoUUID) < 8 {
+				continue
+			}
+
+			cookie, err := strconv.ParseUint(flowUUID.GoUUID[0:8], 16, 64)
+			if err != nil {
+				continue
+			}
+
+			cookies[cookie] = uuids[i]
+		}
+	}
+
+	return cookies, nil
+}
+
+// GetLogicalRouterPortLearnedIPs returns the IPs the logical router port has learned for a MAC address.
+func (o *SB) GetLogicalRouterPortLearnedIPs(ctx context.Context, ovnRouterPort OVNRouterPort, mac net.HardwareAddr) ([]net.IP, error) {
+	// The MAC bindings aren't cached, so query them directly.
+	operations := []ovsdb.Operation{{
+		Op:    ovsdb.OperationSelect,
+		Table: ovnSB.MACBindingTable,
+		Where: []ovsdb.Condition{
+			ovsdb.NewCondition("logical_port", ovsdb.ConditionEqual, string(ovnRouterPort)),
+			ovsdb.NewCondition("mac", ovsdb.ConditionEqual, mac.String()),
+		},
+		Columns: []string{"ip"},
+	}}
+
+	resp, err := o.client.Transact(ctx, operations...)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = ovsdb.CheckOperationResults(resp, operations)
+	if err != nil {
+		return nil, err
+	}
+
+	ips := []net.IP{}
+	for _, result := range resp {
+		for _, row := range result.Rows {
+			value, ok := row["ip"].(string)
+			if !ok {
+				continue
+			}
+
+			ip := net.ParseIP(value)
+			if ip != nil {
+				ips = append(ips, ip)
+			}
+		}
+	}
+
+	return ips, nil
+}
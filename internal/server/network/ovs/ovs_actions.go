@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/lxc/incus/v6/internal/server/ip"
 	ovsSwitch "github.com/lxc/incus/v6/internal/server/network/ovs/schema/ovs"
+	"github.com/lxc/incus/v6/shared/subprocess"
 	"github.com/lxc/incus/v6/shared/util"
 )
 
@@ -730,3 +732,53 @@ func (o *VSwitch) GetOVNSouthboundDBRemoteAddress(ctx context.Context) (string,
 
 	return val, nil
 }
+
+// FlowStats represents the packets and bytes matched by OpenFlow flows.
+type FlowStats struct {
+	Packets uint64
+	Bytes   uint64
+}
+
+// GetBridgeFlowStats returns the statistics of the OpenFlow flows of a bridge, summed by flow cookie.
+func (o *VSwitch) GetBridgeFlowStats(ctx context.Context, bridgeName string) (map[uint64]FlowStats, error) {
+	// The flow statistics aren't part of the database, get them through OpenFlow.
+	output, err := subprocess.RunCommandContext(ctx, "ovs-ofctl", "dump-flows", bridgeName)
+	if err != nil {
+		return nil, fmt.Errorf("Failed dumping flows of %q: %w", bridgeName, err)
+	}
+
+	stats := map[uint64]FlowStats{}
+	for _, line := range strings.Split(output, "\n") {
+		var cookie uint64
+		var flowStats FlowStats
+		var found bool
+
+		for _, field := range strings.Split(strings.TrimSpace(line), ", ") {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+
+			switch key {
+			case "cookie":
+				cookie, err = strconv.ParseUint(strings.TrimPrefix(value, "0x"), 16, 64)
+				found = err == nil
+			case "n_packets":
+				flowStats.Packets, _ = strconv.ParseUint(value, 10, 64)
+			case "n_bytes":
+				flowStats.Bytes, _ = strconv.ParseUint(value, 10, 64)
+			}
+		}
+
+		if !found || cookie == 0 {
+			continue
+		}
+
+		total := stats[cookie]
+		total.Packets += flowStats.Packets
+		total.Bytes += flowStats.Bytes
+		stats[cookie] = total
+	}
+
+	return stats, nil
+}
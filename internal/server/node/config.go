@@ -125,6 +125,11 @@ func (c *Config) SyslogSocket() bool {
 	return c.m.GetBool("core.syslog_socket")
 }
 
+// OperationsConcurrency returns the maximum number of concurrent operations for the workload (0 means unlimited).
+func (c *Config) OperationsConcurrency(workload string) int64 {
+	return c.m.GetInt64("operations.concurrency." + workload)
+}
+
 // Dump current configuration keys and their values. Keys with values matching
 // their defaults are omitted.
 func (c *Config) Dump() map[string]string {
@@ -253,6 +258,46 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Whether to enable the syslog unixgram socket listener
 	"core.syslog_socket": {Validator: validate.Optional(validate.IsBool), Type: config.Bool},
 
+	// gendoc:generate(entity=server, group=operations, key=operations.concurrency.image_unpack)
+	// Limits the number of instance creations, rebuilds and conversions as well as image downloads running at the same time on this server.
+	// Additional operations are queued until a slot frees up. Set to `0` for no limit.
+	// ---
+	//  type: integer
+	//  scope: local
+	//  defaultdesc: `0`
+	//  shortdesc: Maximum number of concurrent operations unpacking images
+	"operations.concurrency.image_unpack": {Type: config.Int64, Default: "0", Validator: validate.Optional(validate.IsUint32)},
+
+	// gendoc:generate(entity=server, group=operations, key=operations.concurrency.volume_copy)
+	// Limits the number of storage volume copies and moves running at the same time on this server.
+	// Additional operations are queued until a slot frees up. Set to `0` for no limit.
+	// ---
+	//  type: integer
+	//  scope: local
+	//  defaultdesc: `0`
+	//  shortdesc: Maximum number of concurrent storage volume copies
+	"operations.concurrency.volume_copy": {Type: config.Int64, Default: "0", Validator: validate.Optional(validate.IsUint32)},
+
+	// gendoc:generate(entity=server, group=operations, key=operations.concurrency.migration)
+	// Limits the number of instance and storage volume migrations running at the same time on this server.
+	// Additional operations are queued until a slot frees up. Set to `0` for no limit.
+	// ---
+	//  type: integer
+	//  scope: local
+	//  defaultdesc: `0`
+	//  shortdesc: Maximum number of concurrent migrations
+	"operations.concurrency.migration": {Type: config.Int64, Default: "0", Validator: validate.Optional(validate.IsUint32)},
+
+	// gendoc:generate(entity=server, group=operations, key=operations.concurrency.backup)
+	// Limits the number of backup creations, restorations and verifications running at the same time on this server.
+	// Additional operations are queued until a slot frees up. Set to `0` for no limit.
+	// ---
+	//  type: integer
+	//  scope: local
+	//  defaultdesc: `0`
+	//  shortdesc: Maximum number of concurrent backup operations
+	"operations.concurrency.backup": {Type: config.Int64, Default: "0", Validator: validate.Optional(validate.IsUint32)},
+
 	// gendoc:generate(entity=server, group=miscellaneous, key=network.ovs.connection)
 	//
 	// ---
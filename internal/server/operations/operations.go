@@ -108,6 +108,11 @@ type Operation struct {
 	requestor   *api.EventLifecycleRequestor
 	logger      logger.Logger
 
+	// Scheduling of the operation, queued is protected by schedulerLock.
+	workload string
+	priority Priority
+	queued   *queueEntry
+
 	// Those functions are called at various points in the Operation lifecycle
 	onRun     func(*Operation) error
 	onCancel  func(*Operation) error
@@ -139,6 +144,7 @@ func OperationCreate(s *state.State, projectName string, opClass OperationClass,
 	op.objectType, op.entitlement = opType.Permission()
 	op.dbOpType = opType
 	op.class = opClass
+	op.priority = requestPriority(r)
 	op.createdAt = time.Now()
 	op.updatedAt = op.createdAt
 	op.status = api.Pending
@@ -164,6 +170,11 @@ func OperationCreate(s *state.State, projectName string, opClass OperationClass,
 	op.onCancel = onCancel
 	op.onConnect = onConnect
 
+	// Only tasks are scheduled, websocket operations are driven by their peer and must start right away.
+	if op.class == OperationClassTask {
+		op.workload = workloadForType(opType)
+	}
+
 	// Quick check.
 	if op.class != OperationClassWebsocket && op.onConnect != nil {
 		return nil, fmt.Errorf("Only websocket operations can have a Connect hook")
@@ -284,9 +295,31 @@ func (op *Operation) Start() error {
 
 	op.status = api.Running
 
+	// Take a slot if the workload is concurrency limited, queuing the operation if none is available.
+	var entry *queueEntry
+	var queueUpdates []queueUpdate
+	if op.onRun != nil && op.workload != "" {
+		entry, queueUpdates = op.enqueue()
+		if entry != nil {
+			op.status = api.Queued
+		}
+	}
+
 	if op.onRun != nil {
+		onRun := op.onRun
+
 		go func(op *Operation) {
-			err := op.onRun(op)
+			// Wait for the queued operation to be handed a slot.
+			if entry != nil && !op.wait(entry) {
+				return
+			}
+
+			err := onRun(op)
+
+			if op.workload != "" {
+				op.release()
+			}
+
 			if err != nil {
 				op.lock.Lock()
 				op.status = api.Failure
@@ -320,6 +353,11 @@ func (op *Operation) Start() error {
 
 	op.lock.Unlock()
 
+	if entry != nil {
+		op.logger.Debug("Queued operation", logger.Ctx{"workload": op.workload, "priority": op.priority.String()})
+		applyQueueUpdates(queueUpdates)
+	}
+
 	op.logger.Debug("Started operation")
 	_, md, _ := op.Render()
 
@@ -334,7 +372,7 @@ func (op *Operation) Start() error {
 // returns an error.
 func (op *Operation) Cancel() (chan error, error) {
 	op.lock.Lock()
-	if op.status != api.Running {
+	if op.status != api.Running && op.status != api.Queued {
 		op.lock.Unlock()
 		return nil, fmt.Errorf("Only running operations can be cancelled")
 	}
@@ -345,8 +383,39 @@ func (op *Operation) Cancel() (chan error, error) {
 	}
 
 	chanCancel := make(chan error, 1)
+	op.lock.Unlock()
 
+	// Operations which haven't left their queue yet can be cancelled without running any hook.
+	if op.dequeue() {
+		op.lock.Lock()
+		op.status = api.Cancelled
+		op.lock.Unlock()
+		op.done()
+		chanCancel <- nil
+
+		op.logger.Debug("Cancelled queued operation")
+		_, md, _ := op.Render()
+
+		op.lock.Lock()
+		op.sendEvent(md)
+		op.lock.Unlock()
+
+		return chanCancel, nil
+	}
+
+	// The operation may have left its queue in the meantime.
+	op.lock.Lock()
+	if !op.mayCancel() {
+		op.lock.Unlock()
+		return nil, fmt.Errorf("This operation can't be cancelled")
+	}
+
+	// Operations which left their queue are running even if not yet marked as such.
 	oldStatus := op.status
+	if oldStatus == api.Queued {
+		oldStatus = api.Running
+	}
+
 	op.status = api.Cancelling
 	op.lock.Unlock()
 
@@ -464,6 +533,10 @@ func (op *Operation) mayCancel() bool {
 		return true
 	}
 
+	if op.isQueued() {
+		return true
+	}
+
 	return false
 }
 
@@ -531,7 +604,7 @@ func (op *Operation) Wait(ctx context.Context) error {
 // if the operation is not pending or running, or the operation is read-only.
 func (op *Operation) UpdateResources(opResources map[string][]api.URL) error {
 	op.lock.Lock()
-	if op.status != api.Pending && op.status != api.Running {
+	if op.status != api.Pending && op.status != api.Queued && op.status != api.Running {
 		op.lock.Unlock()
 		return fmt.Errorf("Only pending or running operations can be updated")
 	}
@@ -559,7 +632,7 @@ func (op *Operation) UpdateResources(opResources map[string][]api.URL) error {
 // if the operation is not pending or running, or the operation is read-only.
 func (op *Operation) UpdateMetadata(opMetadata any) error {
 	op.lock.Lock()
-	if op.status != api.Pending && op.status != api.Running {
+	if op.status != api.Pending && op.status != api.Queued && op.status != api.Running {
 		op.lock.Unlock()
 		return fmt.Errorf("Only pending or running operations can be updated")
 	}
@@ -594,7 +667,7 @@ func (op *Operation) ExtendMetadata(metadata any) error {
 	op.lock.Lock()
 
 	// Quick checks.
-	if op.status != api.Pending && op.status != api.Running {
+	if op.status != api.Pending && op.status != api.Queued && op.status != api.Running {
 		op.lock.Unlock()
 		return fmt.Errorf("Only pending or running operations can be updated")
 	}
@@ -639,6 +712,66 @@ func (op *Operation) ExtendMetadata(metadata any) error {
 	return nil
 }
 
+// setRunning marks a queued operation which was handed a slot as running.
+func (op *Operation) setRunning() {
+	op.lock.Lock()
+	if op.status != api.Queued {
+		op.lock.Unlock()
+		return
+	}
+
+	op.status = api.Running
+	op.updatedAt = time.Now()
+	op.lock.Unlock()
+
+	op.logger.Debug("Dequeued operation")
+	_, md, _ := op.Render()
+
+	op.lock.Lock()
+	op.sendEvent(md)
+	op.lock.Unlock()
+}
+
+// setQueueMetadata sets the "queue" metadata entry of the operation, or removes it if nil.
+func (op *Operation) setQueueMetadata(queue map[string]any) {
+	if queue != nil && !op.isQueued() {
+		return
+	}
+
+	op.lock.Lock()
+	if op.readonly {
+		op.lock.Unlock()
+		return
+	}
+
+	_, ok := op.metadata["queue"]
+	if queue == nil && !ok {
+		op.lock.Unlock()
+		return
+	}
+
+	newMetadata := util.CloneMap(op.metadata)
+	if newMetadata == nil {
+		newMetadata = make(map[string]any)
+	}
+
+	if queue == nil {
+		delete(newMetadata, "queue")
+	} else {
+		newMetadata["queue"] = queue
+	}
+
+	op.updatedAt = time.Now()
+	op.metadata = newMetadata
+	op.lock.Unlock()
+
+	_, md, _ := op.Render()
+
+	op.lock.Lock()
+	op.sendEvent(md)
+	op.lock.Unlock()
+}
+
 // ID returns the operation ID.
 func (op *Operation) ID() string {
 	return op.id
@@ -0,0 +1,344 @@
+package operations
+
+import (
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+)
+
+// Workloads group operation types which compete for the same local resources.
+const (
+	// WorkloadImageUnpack covers operations unpacking images into new instances.
+	WorkloadImageUnpack = "image_unpack"
+
+	// WorkloadVolumeCopy covers storage volume copies and moves.
+	WorkloadVolumeCopy = "volume_copy"
+
+	// WorkloadMigration covers instance and storage volume migrations.
+	WorkloadMigration = "migration"
+
+	// WorkloadBackup covers the creation, restoration and verification of backups.
+	WorkloadBackup = "backup"
+)
+
+// Workloads lists all the workloads which can be concurrency limited.
+var Workloads = []string{WorkloadImageUnpack, WorkloadVolumeCopy, WorkloadMigration, WorkloadBackup}
+
+// Priority represents the priority of an operation in the scheduler queues.
+type Priority int
+
+const (
+	// PriorityBulk is the priority of background tasks and bulk jobs.
+	PriorityBulk Priority = iota
+
+	// PriorityNormal is the default priority of API requests.
+	PriorityNormal
+
+	// PriorityInteractive is the priority of requests from interactive users.
+	PriorityInteractive
+)
+
+// PriorityHeader is the request header used by clients to set the priority of the resulting operation.
+const PriorityHeader = "X-Incus-priority"
+
+func (p Priority) String() string {
+	return map[Priority]string{
+		PriorityBulk:        "bulk",
+		PriorityNormal:      "normal",
+		PriorityInteractive: "interactive",
+	}[p]
+}
+
+// requestPriority returns the priority of an operation created for the request.
+func requestPriority(r *http.Request) Priority {
+	// Operations not tied to a request are internal background tasks.
+	if r == nil {
+		return PriorityBulk
+	}
+
+	switch r.Header.Get(PriorityHeader) {
+	case PriorityBulk.String():
+		return PriorityBulk
+	case PriorityInteractive.String():
+		return PriorityInteractive
+	}
+
+	return PriorityNormal
+}
+
+// workloadForType returns the workload an operation type belongs to, if any.
+func workloadForType(opType operationtype.Type) string {
+	switch opType {
+	case operationtype.InstanceCreate, operationtype.InstanceRebuild, operationtype.InstanceConvert, operationtype.ImageDownload:
+		return WorkloadImageUnpack
+	case operationtype.VolumeCopy, operationtype.VolumeMove:
+		return WorkloadVolumeCopy
+	case operationtype.InstanceMigrate, operationtype.InstanceLiveMigrate, operationtype.VolumeMigrate:
+		return WorkloadMigration
+	case operationtype.BackupCreate, operationtype.BackupRestore, operationtype.BackupVerify,
+		operationtype.CustomVolumeBackupCreate, operationtype.CustomVolumeBackupRestore, operationtype.CustomVolumeBackupVerify,
+		operationtype.BucketBackupCreate, operationtype.BucketBackupRestore, operationtype.BucketBackupVerify:
+		return WorkloadBackup
+	}
+
+	return ""
+}
+
+// queueEntry represents an operation waiting for a slot.
+type queueEntry struct {
+	op         *Operation
+	priority   Priority
+	queuedAt   time.Time
+	position   int
+	dispatched bool
+	ready      chan struct{}
+}
+
+// workloadQueue tracks the running and waiting operations of a workload.
+type workloadQueue struct {
+	limit       int
+	running     int
+	queue       []*queueEntry
+	waitSeconds float64
+}
+
+// WorkloadStats represents the current state of a workload queue.
+type WorkloadStats struct {
+	Workload    string
+	Limit       int
+	Running     int
+	Queued      int
+	WaitSeconds float64
+}
+
+// queueUpdate is a pending change to the queue metadata of an operation.
+type queueUpdate struct {
+	op       *Operation
+	metadata map[string]any
+}
+
+var (
+	schedulerLock sync.Mutex
+	scheduler     = make(map[string]*workloadQueue)
+)
+
+func init() {
+	for _, workload := range Workloads {
+		scheduler[workload] = &workloadQueue{}
+	}
+}
+
+// SetConcurrencyLimit sets the maximum number of operations of the workload allowed to run at the same
+// time on this server. A limit of 0 means unlimited.
+func SetConcurrencyLimit(workload string, limit int) {
+	schedulerLock.Lock()
+	q, ok := scheduler[workload]
+	if !ok {
+		schedulerLock.Unlock()
+		return
+	}
+
+	q.limit = limit
+	updates := q.dispatch()
+	schedulerLock.Unlock()
+
+	applyQueueUpdates(updates)
+}
+
+// GetWorkloadStats returns the current state of all workload queues.
+func GetWorkloadStats() []WorkloadStats {
+	schedulerLock.Lock()
+	defer schedulerLock.Unlock()
+
+	stats := make([]WorkloadStats, 0, len(Workloads))
+	for _, workload := range Workloads {
+		q := scheduler[workload]
+		stats = append(stats, WorkloadStats{
+			Workload:    workload,
+			Limit:       q.limit,
+			Running:     q.running,
+			Queued:      len(q.queue),
+			WaitSeconds: q.waitSeconds,
+		})
+	}
+
+	return stats
+}
+
+// hasSlot returns whether another operation may start.
+func (q *workloadQueue) hasSlot() bool {
+	return q.limit <= 0 || q.running < q.limit
+}
+
+// dispatch starts as many queued operations as the limit allows and returns the resulting
+// metadata updates. Must be called with schedulerLock held.
+func (q *workloadQueue) dispatch() []queueUpdate {
+	var updates []queueUpdate
+
+	for len(q.queue) > 0 && q.hasSlot() {
+		entry := q.queue[0]
+		q.queue = q.queue[1:]
+
+		entry.dispatched = true
+		q.running++
+		q.waitSeconds += time.Since(entry.queuedAt).Seconds()
+		close(entry.ready)
+
+		updates = append(updates, queueUpdate{op: entry.op})
+	}
+
+	return append(updates, q.positions()...)
+}
+
+// positions refreshes the position of the queued operations and returns the resulting metadata
+// updates. Must be called with schedulerLock held.
+func (q *workloadQueue) positions() []queueUpdate {
+	var updates []queueUpdate
+
+	for i, entry := range q.queue {
+		if entry.position == i+1 {
+			continue
+		}
+
+		entry.position = i + 1
+		updates = append(updates, queueUpdate{
+			op: entry.op,
+			metadata: map[string]any{
+				"workload": entry.op.workload,
+				"priority": entry.priority.String(),
+				"position": entry.position,
+			},
+		})
+	}
+
+	return updates
+}
+
+// remove takes an entry out of the queue. Must be called with schedulerLock held.
+func (q *workloadQueue) remove(entry *queueEntry) ([]queueUpdate, bool) {
+	idx := slices.Index(q.queue, entry)
+	if idx < 0 {
+		return nil, false
+	}
+
+	q.queue = slices.Delete(q.queue, idx, idx+1)
+
+	return append([]queueUpdate{{op: entry.op}}, q.positions()...), true
+}
+
+// applyQueueUpdates records queue changes in the metadata of the affected operations.
+func applyQueueUpdates(updates []queueUpdate) {
+	for _, update := range updates {
+		update.op.setQueueMetadata(update.metadata)
+	}
+}
+
+// enqueue takes a slot for the operation or queues it if the workload limit is reached. It returns the
+// queue entry (nil if the operation got a slot right away) and the metadata updates to apply once the
+// caller released its locks.
+func (op *Operation) enqueue() (*queueEntry, []queueUpdate) {
+	schedulerLock.Lock()
+	defer schedulerLock.Unlock()
+
+	q := scheduler[op.workload]
+	if len(q.queue) == 0 && q.hasSlot() {
+		q.running++
+		return nil, nil
+	}
+
+	entry := &queueEntry{
+		op:       op,
+		priority: op.priority,
+		queuedAt: time.Now(),
+		ready:    make(chan struct{}),
+	}
+
+	// Higher priority operations go first, FIFO within the same priority.
+	idx := slices.IndexFunc(q.queue, func(e *queueEntry) bool { return e.priority < entry.priority })
+	if idx < 0 {
+		idx = len(q.queue)
+	}
+
+	q.queue = slices.Insert(q.queue, idx, entry)
+	op.queued = entry
+
+	return entry, q.positions()
+}
+
+// wait waits for the queued operation to be handed a slot. It returns false if the operation
+// finished (was cancelled) while waiting.
+func (op *Operation) wait(entry *queueEntry) bool {
+	select {
+	case <-entry.ready:
+		schedulerLock.Lock()
+		op.queued = nil
+		schedulerLock.Unlock()
+
+		op.setRunning()
+
+		return true
+	case <-op.finished.Done():
+	}
+
+	// The operation finished while queued, give back the slot if it was handed one meanwhile.
+	schedulerLock.Lock()
+	q := scheduler[op.workload]
+	op.queued = nil
+
+	var updates []queueUpdate
+	if entry.dispatched {
+		q.running--
+		updates = q.dispatch()
+	} else {
+		updates, _ = q.remove(entry)
+	}
+
+	schedulerLock.Unlock()
+
+	applyQueueUpdates(updates)
+
+	return false
+}
+
+// release gives back the slot held by the operation.
+func (op *Operation) release() {
+	schedulerLock.Lock()
+	q := scheduler[op.workload]
+	q.running--
+	updates := q.dispatch()
+	schedulerLock.Unlock()
+
+	applyQueueUpdates(updates)
+}
+
+// dequeue removes the operation from its queue. It returns false if the operation wasn't queued.
+func (op *Operation) dequeue() bool {
+	schedulerLock.Lock()
+	if op.queued == nil || op.queued.dispatched {
+		schedulerLock.Unlock()
+		return false
+	}
+
+	updates, ok := scheduler[op.workload].remove(op.queued)
+	schedulerLock.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	// Skip the update of the dequeued operation itself as it's being cancelled.
+	applyQueueUpdates(updates[1:])
+
+	return true
+}
+
+// isQueued returns whether the operation is waiting in a queue.
+func (op *Operation) isQueued() bool {
+	schedulerLock.Lock()
+	defer schedulerLock.Unlock()
+
+	return op.queued != nil && !op.queued.dispatched
+}
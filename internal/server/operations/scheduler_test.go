@@ -0,0 +1,188 @@
+package operations
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// schedulerTestOp is an operation which runs until told to finish.
+type schedulerTestOp struct {
+	*Operation
+	finish chan struct{}
+}
+
+// startSchedulerTestOp starts a backup operation with the given priority, reporting its name on started
+// once it runs.
+func startSchedulerTestOp(t *testing.T, name string, priority string, started chan string) *schedulerTestOp {
+	t.Helper()
+
+	r := httptest.NewRequest(http.MethodPost, "/1.0/instances/c1/backups", nil)
+	if priority != "" {
+		r.Header.Set(PriorityHeader, priority)
+	}
+
+	finish := make(chan struct{})
+	onRun := func(op *Operation) error {
+		started <- name
+		<-finish
+		return nil
+	}
+
+	op, err := OperationCreate(nil, api.ProjectDefaultName, OperationClassTask, operationtype.BackupCreate, nil, nil, onRun, nil, nil, r)
+	require.NoError(t, err)
+	require.NoError(t, op.Start())
+
+	return &schedulerTestOp{Operation: op, finish: finish}
+}
+
+// waitStarted returns the name of the next operation to run.
+func waitStarted(t *testing.T, started chan string) string {
+	t.Helper()
+
+	select {
+	case name := <-started:
+		return name
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for an operation to run")
+	}
+
+	return ""
+}
+
+// assertNotStarted checks that no other operation runs.
+func assertNotStarted(t *testing.T, started chan string) {
+	t.Helper()
+
+	select {
+	case name := <-started:
+		t.Fatalf("Operation %q unexpectedly started", name)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// backupStats returns the state of the backup workload queue.
+func backupStats() WorkloadStats {
+	for _, stats := range GetWorkloadStats() {
+		if stats.Workload == WorkloadBackup {
+			return stats
+		}
+	}
+
+	return WorkloadStats{}
+}
+
+func TestSchedulerConcurrencyLimit(t *testing.T) {
+	SetConcurrencyLimit(WorkloadBackup, 2)
+	defer SetConcurrencyLimit(WorkloadBackup, 0)
+
+	started := make(chan string, 4)
+	ops := []*schedulerTestOp{}
+	for _, name := range []string{"op1", "op2", "op3", "op4"} {
+		ops = append(ops, startSchedulerTestOp(t, name, "", started))
+	}
+
+	assert.ElementsMatch(t, []string{"op1", "op2"}, []string{waitStarted(t, started), waitStarted(t, started)})
+	assertNotStarted(t, started)
+
+	stats := backupStats()
+	assert.Equal(t, 2, stats.Limit)
+	assert.Equal(t, 2, stats.Running)
+	assert.Equal(t, 2, stats.Queued)
+
+	// Queued operations have their own status and report their position.
+	for i, op := range ops[2:] {
+		assert.Equal(t, api.Queued, op.Status())
+		assert.Equal(t, map[string]any{"workload": WorkloadBackup, "priority": "normal", "position": i + 1}, op.Metadata()["queue"])
+	}
+
+	// Finishing an operation lets the first queued one run.
+	close(ops[0].finish)
+	assert.Equal(t, "op3", waitStarted(t, started))
+	assertNotStarted(t, started)
+	require.NoError(t, ops[0].Wait(context.Background()))
+
+	assert.Eventually(t, func() bool { return ops[2].Status() == api.Running }, 5*time.Second, 10*time.Millisecond)
+	assert.NotContains(t, ops[2].Metadata(), "queue")
+	assert.Equal(t, map[string]any{"workload": WorkloadBackup, "priority": "normal", "position": 1}, ops[3].Metadata()["queue"])
+
+	// Raising the limit dispatches the remaining operations.
+	SetConcurrencyLimit(WorkloadBackup, 3)
+	assert.Equal(t, "op4", waitStarted(t, started))
+
+	for _, op := range ops[1:] {
+		close(op.finish)
+		require.NoError(t, op.Wait(context.Background()))
+	}
+
+	stats = backupStats()
+	assert.Equal(t, 0, stats.Running)
+	assert.Equal(t, 0, stats.Queued)
+}
+
+func TestSchedulerPriority(t *testing.T) {
+	SetConcurrencyLimit(WorkloadBackup, 1)
+	defer SetConcurrencyLimit(WorkloadBackup, 0)
+
+	started := make(chan string, 5)
+	first := startSchedulerTestOp(t, "first", "", started)
+	assert.Equal(t, "first", waitStarted(t, started))
+
+	ops := map[string]*schedulerTestOp{}
+	for _, entry := range []struct{ name, priority string }{
+		{"bulk", "bulk"},
+		{"normal1", ""},
+		{"interactive", "interactive"},
+		{"normal2", "normal"},
+	} {
+		ops[entry.name] = startSchedulerTestOp(t, entry.name, entry.priority, started)
+	}
+
+	assertNotStarted(t, started)
+
+	// Higher priorities go first, in the order they were queued within the same priority.
+	close(first.finish)
+	for _, name := range []string{"interactive", "normal1", "normal2", "bulk"} {
+		assert.Equal(t, name, waitStarted(t, started))
+		assertNotStarted(t, started)
+
+		close(ops[name].finish)
+		require.NoError(t, ops[name].Wait(context.Background()))
+	}
+}
+
+func TestSchedulerCancelQueued(t *testing.T) {
+	SetConcurrencyLimit(WorkloadBackup, 1)
+	defer SetConcurrencyLimit(WorkloadBackup, 0)
+
+	started := make(chan string, 3)
+	first := startSchedulerTestOp(t, "first", "", started)
+	assert.Equal(t, "first", waitStarted(t, started))
+
+	cancelled := startSchedulerTestOp(t, "cancelled", "", started)
+	last := startSchedulerTestOp(t, "last", "", started)
+	assert.Equal(t, 2, last.Metadata()["queue"].(map[string]any)["position"])
+
+	// Queued operations can be cancelled without running.
+	chanCancel, err := cancelled.Cancel()
+	require.NoError(t, err)
+	require.NoError(t, <-chanCancel)
+	assert.Equal(t, api.Cancelled, cancelled.Status())
+	assert.Equal(t, 1, last.Metadata()["queue"].(map[string]any)["position"])
+	assert.Equal(t, 1, backupStats().Queued)
+
+	close(first.finish)
+	assert.Equal(t, "last", waitStarted(t, started))
+	assertNotStarted(t, started)
+
+	close(last.finish)
+	require.NoError(t, last.Wait(context.Background()))
+}
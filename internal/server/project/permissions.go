@@ -288,23 +288,32 @@ func AllowVolumeCreation(tx *db.ClusterTx, projectName string, poolName string,
 //
 // If no limit is in place, return -1.
 func GetImageSpaceBudget(tx *db.ClusterTx, projectName string) (int64, error) {
-	info, err := fetchProject(tx, projectName, true)
+	ctx := context.Background()
+
+	// Get the budget left by "limits.images.size" in the project the images are stored in.
+	imagesProject, err := getImagesProject(ctx, tx, projectName)
 	if err != nil {
 		return -1, err
 	}
 
-	if info == nil {
-		return -1, nil
+	imagesBudget, err := getImagesSizeBudget(ctx, tx, imagesProject)
+	if err != nil {
+		return -1, err
 	}
 
-	// If "features.images" is not enabled, the budget is unlimited.
-	if util.IsFalse(info.Project.Config["features.images"]) {
-		return -1, nil
+	// If "features.images" is not enabled, only the limits of the default project apply.
+	if imagesProject.Name != projectName {
+		return imagesBudget, nil
 	}
 
-	// If "limits.disk" is not set, the budget is unlimited.
-	if info.Project.Config["limits.disk"] == "" {
-		return -1, nil
+	info, err := fetchProject(tx, projectName, true)
+	if err != nil {
+		return -1, err
+	}
+
+	// If "limits.disk" is not set, only the images limit applies.
+	if info == nil || info.Project.Config["limits.disk"] == "" {
+		return imagesBudget, nil
 	}
 
 	parser := aggregateLimitConfigValueParsers["limits.disk"]
@@ -325,11 +334,12 @@ func GetImageSpaceBudget(tx *db.ClusterTx, projectName string) (int64, error) {
 		return -1, err
 	}
 
-	if totals["limits.disk"] < quota {
-		return quota - totals["limits.disk"], nil
+	diskBudget := max(quota-totals["limits.disk"], 0)
+	if imagesBudget >= 0 && imagesBudget < diskBudget {
+		return imagesBudget, nil
 	}
 
-	return 0, nil
+	return diskBudget, nil
 }
 
 // Check that we would not violate the project limits or restrictions if we
@@ -884,6 +894,7 @@ func isContainerLowLevelOptionForbidden(key string) bool {
 		"raw.apparmor",
 		"raw.idmap",
 		"raw.lxc",
+		"raw.lxc.scriptlet",
 		"raw.seccomp",
 		"security.guestapi.images",
 		"security.idmap.base",
@@ -1072,6 +1083,18 @@ func AllowProjectUpdate(tx *db.ClusterTx, projectName string, config map[string]
 				return fmt.Errorf("Can't change %q in project %q: %w", key, projectName, err)
 			}
 
+		case "limits.snapshots", "limits.backups", "limits.buckets", "limits.images":
+			err := validateCountLimit(tx, info.Project, key, config[key])
+			if err != nil {
+				return fmt.Errorf("Can't change %q in project %q: %w", key, projectName, err)
+			}
+
+		case "limits.images.size":
+			err := validateImagesSizeLimit(tx, projectName, config[key])
+			if err != nil {
+				return fmt.Errorf("Can't change %q in project %q: %w", key, projectName, err)
+			}
+
 		case "limits.processes":
 			fallthrough
 		case "limits.cpu":
@@ -1153,6 +1176,53 @@ func validateInstanceCountLimit(instances []api.Instance, key, value, project st
 	return nil
 }
 
+// Check that limits.snapshots, limits.backups, limits.buckets or limits.images is equal or above
+// the current count.
+func validateCountLimit(tx *db.ClusterTx, project api.Project, key, value string) error {
+	if value == "" {
+		return nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil {
+		return err
+	}
+
+	count, _, err := getCountLimit(context.Background(), tx, &project, key)
+	if err != nil {
+		return err
+	}
+
+	if limit < count {
+		return fmt.Errorf(`%q is too low: there currently are %d %s in project %q`, key, count, strings.TrimPrefix(key, "limits."), project.Name)
+	}
+
+	return nil
+}
+
+// Check that limits.images.size is equal or above the current total size of the images.
+func validateImagesSizeLimit(tx *db.ClusterTx, projectName, value string) error {
+	if value == "" {
+		return nil
+	}
+
+	limit, err := units.ParseByteSizeString(value)
+	if err != nil {
+		return err
+	}
+
+	_, size, err := tx.GetProjectImagesUsage(context.Background(), projectName)
+	if err != nil {
+		return err
+	}
+
+	if limit < size {
+		return fmt.Errorf(`"limits.images.size" is too low: current total is %q`, units.GetByteSizeStringIEC(size, 1))
+	}
+
+	return nil
+}
+
 var countConfigInstanceType = map[string]api.InstanceType{
 	"limits.containers":       api.InstanceTypeContainer,
 	"limits.virtual-machines": api.InstanceTypeVM,
@@ -1609,7 +1679,7 @@ func CheckClusterTargetRestriction(authorizer auth.Authorizer, r *http.Request,
 	return nil
 }
 
-// AllowBackupCreation returns an error if any project-specific restriction is violated
+// AllowBackupCreation returns an error if any project-specific restriction or limit is violated
 // when creating a new backup in a project.
 func AllowBackupCreation(tx *db.ClusterTx, projectName string) error {
 	ctx := context.Background()
@@ -1627,19 +1697,181 @@ func AllowBackupCreation(tx *db.ClusterTx, projectName string) error {
 		return fmt.Errorf("Project %q doesn't allow for backup creation", projectName)
 	}
 
-	return nil
+	return checkCountLimit(ctx, tx, project, "limits.backups", 1)
 }
 
-// AllowSnapshotCreation returns an error if any project-specific restriction is violated
+// AllowSnapshotCreation returns an error if any project-specific restriction or limit is violated
 // when creating a new snapshot in a project.
-func AllowSnapshotCreation(p *api.Project) error {
+func AllowSnapshotCreation(tx *db.ClusterTx, p *api.Project) error {
+	return AllowSnapshotsCreation(tx, p, 1)
+}
+
+// AllowSnapshotsCreation returns an error if any project-specific restriction or limit is violated
+// when creating the given number of snapshots in a project, such as when copying, migrating or
+// importing an instance or volume along with its snapshots.
+func AllowSnapshotsCreation(tx *db.ClusterTx, p *api.Project, count int) error {
+	if count <= 0 {
+		return nil
+	}
+
 	if projectHasRestriction(p, "restricted.snapshots", "block") {
 		return fmt.Errorf("Project %q doesn't allow for snapshot creation", p.Name)
 	}
 
+	return checkCountLimit(context.Background(), tx, p, "limits.snapshots", count)
+}
+
+// AllowBucketCreation returns an error if any project-specific limit is violated
+// when creating a new storage bucket in a project.
+func AllowBucketCreation(tx *db.ClusterTx, projectName string) error {
+	ctx := context.Background()
+	dbProject, err := cluster.GetProject(ctx, tx.Tx(), projectName)
+	if err != nil {
+		return err
+	}
+
+	project, err := dbProject.ToAPI(ctx, tx.Tx())
+	if err != nil {
+		return err
+	}
+
+	return checkCountLimit(ctx, tx, project, "limits.buckets", 1)
+}
+
+// AllowImageCreation returns an error if any project-specific limit is violated
+// when adding a new image of the given size to a project. The size is -1 if not known yet.
+func AllowImageCreation(tx *db.ClusterTx, projectName string, size int64) error {
+	ctx := context.Background()
+
+	// If "features.images" is not enabled, the images are stored in and limited by the default project.
+	project, err := getImagesProject(ctx, tx, projectName)
+	if err != nil {
+		return err
+	}
+
+	err = checkCountLimit(ctx, tx, project, "limits.images", 1)
+	if err != nil {
+		return err
+	}
+
+	if size < 0 {
+		return nil
+	}
+
+	budget, err := getImagesSizeBudget(ctx, tx, project)
+	if err != nil {
+		return err
+	}
+
+	if budget >= 0 && size > budget {
+		return fmt.Errorf("Image size %s exceeds the space left for images in project %q (%s)", units.GetByteSizeStringIEC(size, 1), project.Name, units.GetByteSizeStringIEC(budget, 1))
+	}
+
 	return nil
 }
 
+// getImagesProject returns the project the images of the given project are stored in.
+func getImagesProject(ctx context.Context, tx *db.ClusterTx, projectName string) (*api.Project, error) {
+	dbProject, err := cluster.GetProject(ctx, tx.Tx(), projectName)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := dbProject.ToAPI(ctx, tx.Tx())
+	if err != nil {
+		return nil, err
+	}
+
+	if ImageProjectFromRecord(project) == project.Name {
+		return project, nil
+	}
+
+	dbProject, err = cluster.GetProject(ctx, tx.Tx(), api.ProjectDefaultName)
+	if err != nil {
+		return nil, err
+	}
+
+	return dbProject.ToAPI(ctx, tx.Tx())
+}
+
+// getImagesSizeBudget returns how much space is left by "limits.images.size" in the given project (-1 if not set).
+func getImagesSizeBudget(ctx context.Context, tx *db.ClusterTx, p *api.Project) (int64, error) {
+	if p.Config["limits.images.size"] == "" {
+		return -1, nil
+	}
+
+	quota, err := units.ParseByteSizeString(p.Config["limits.images.size"])
+	if err != nil {
+		return -1, err
+	}
+
+	_, size, err := tx.GetProjectImagesUsage(ctx, p.Name)
+	if err != nil {
+		return -1, err
+	}
+
+	return max(quota-size, 0), nil
+}
+
+// countLimits maps the project limits on the number of entities to the function returning their current count.
+var countLimits = map[string]func(ctx context.Context, tx *db.ClusterTx, projectName string) (int, error){
+	"limits.snapshots": func(ctx context.Context, tx *db.ClusterTx, projectName string) (int, error) {
+		return tx.GetProjectSnapshotsCount(ctx, projectName)
+	},
+	"limits.backups": func(ctx context.Context, tx *db.ClusterTx, projectName string) (int, error) {
+		return tx.GetProjectBackupsCount(ctx, projectName)
+	},
+	"limits.buckets": func(ctx context.Context, tx *db.ClusterTx, projectName string) (int, error) {
+		return tx.GetProjectBucketsCount(ctx, projectName)
+	},
+	"limits.images": func(ctx context.Context, tx *db.ClusterTx, projectName string) (int, error) {
+		count, _, err := tx.GetProjectImagesUsage(ctx, projectName)
+		return count, err
+	},
+}
+
+// getCountLimit returns the current count and the limit set in the given project key (-1 if not set).
+func getCountLimit(ctx context.Context, tx *db.ClusterTx, p *api.Project, key string) (int, int, error) {
+	count, err := countLimits[key](ctx, tx, p.Name)
+	if err != nil {
+		return -1, -1, err
+	}
+
+	value, ok := p.Config[key]
+	if !ok || value == "" {
+		return count, -1, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		return -1, -1, fmt.Errorf("Unexpected %q value: %q", key, value)
+	}
+
+	return count, limit, nil
+}
+
+// Check that adding the given number of entities doesn't go above the maximum set in the given project key.
+func checkCountLimit(ctx context.Context, tx *db.ClusterTx, p *api.Project, key string, added int) error {
+	if p.Config[key] == "" {
+		return nil
+	}
+
+	count, limit, err := getCountLimit(ctx, tx, p, key)
+	if err != nil {
+		return err
+	}
+
+	if limit < 0 || count+added <= limit {
+		return nil
+	}
+
+	if added == 1 {
+		return fmt.Errorf("Reached maximum number of %s in project %q", strings.TrimPrefix(key, "limits."), p.Name)
+	}
+
+	return fmt.Errorf("Adding %d %s would go above the maximum of %d in project %q (currently %d)", added, strings.TrimPrefix(key, "limits."), limit, p.Name, count)
+}
+
 // GetRestrictedClusterGroups returns a slice of restricted cluster groups for the given project.
 func GetRestrictedClusterGroups(p *api.Project) []string {
 	return util.SplitNTrimSpace(p.Config["restricted.cluster.groups"], ",", -1, true)
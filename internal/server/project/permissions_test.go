@@ -5,6 +5,7 @@ import (
 	"crypto/x509"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -249,3 +250,106 @@ func TestCheckClusterTargetRestriction_RestrictedFalse(t *testing.T) {
 	err = project.CheckClusterTargetRestriction(authorizer, req, p, "n1")
 	assert.NoError(t, err)
 }
+
+// Snapshots created at once, such as when copying an instance with its snapshots, can't go above the limit.
+func TestAllowSnapshotsCreation(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	id, err := cluster.CreateProject(ctx, tx.Tx(), cluster.Project{Name: "p1"})
+	require.NoError(t, err)
+
+	err = cluster.CreateProjectConfig(ctx, tx.Tx(), id, map[string]string{"limits.snapshots": "3"})
+	require.NoError(t, err)
+
+	_, err = cluster.CreateInstance(ctx, tx.Tx(), cluster.Instance{
+		Project:      "p1",
+		Name:         "c1",
+		Type:         instancetype.Container,
+		Architecture: 1,
+		Node:         "none",
+	})
+	require.NoError(t, err)
+
+	_, err = cluster.CreateInstanceSnapshot(ctx, tx.Tx(), cluster.InstanceSnapshot{
+		Project:      "p1",
+		Instance:     "c1",
+		Name:         "snap0",
+		CreationDate: time.Now(),
+	})
+	require.NoError(t, err)
+
+	dbProject, err := cluster.GetProject(ctx, tx.Tx(), "p1")
+	require.NoError(t, err)
+
+	p, err := dbProject.ToAPI(ctx, tx.Tx())
+	require.NoError(t, err)
+
+	assert.NoError(t, project.AllowSnapshotsCreation(tx, p, 0))
+	assert.NoError(t, project.AllowSnapshotCreation(tx, p))
+	assert.NoError(t, project.AllowSnapshotsCreation(tx, p, 2))
+
+	err = project.AllowSnapshotsCreation(tx, p, 3)
+	assert.EqualError(t, err, `Adding 3 snapshots would go above the maximum of 3 in project "p1" (currently 1)`)
+}
+
+// The images added to a project can't go above the limit on their total size.
+func TestAllowImageCreation_Size(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	id, err := cluster.CreateProject(ctx, tx.Tx(), cluster.Project{Name: "p1"})
+	require.NoError(t, err)
+
+	err = cluster.CreateProjectConfig(ctx, tx.Tx(), id, map[string]string{"features.images": "true", "limits.images": "2", "limits.images.size": "1MiB"})
+	require.NoError(t, err)
+
+	err = tx.CreateImage(ctx, "p1", "abc", "x.gz", 768*1024, false, false, "amd64", time.Now(), time.Now(), map[string]string{}, "container", nil)
+	require.NoError(t, err)
+
+	assert.NoError(t, project.AllowImageCreation(tx, "p1", -1))
+	assert.NoError(t, project.AllowImageCreation(tx, "p1", 256*1024))
+
+	err = project.AllowImageCreation(tx, "p1", 512*1024)
+	assert.EqualError(t, err, `Image size 512.0KiB exceeds the space left for images in project "p1" (256.0KiB)`)
+
+	budget, err := project.GetImageSpaceBudget(tx, "p1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(256*1024), budget)
+
+	err = tx.CreateImage(ctx, "p1", "def", "y.gz", 16, false, false, "amd64", time.Now(), time.Now(), map[string]string{}, "container", nil)
+	require.NoError(t, err)
+
+	err = project.AllowImageCreation(tx, "p1", 16)
+	assert.EqualError(t, err, `Reached maximum number of images in project "p1"`)
+}
+
+// If features.images is disabled, the limits of the default project apply.
+func TestAllowImageCreation_FeaturesImagesDisabled(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	_, err := cluster.CreateProject(ctx, tx.Tx(), cluster.Project{Name: "p1"})
+	require.NoError(t, err)
+
+	dbProject, err := cluster.GetProject(ctx, tx.Tx(), api.ProjectDefaultName)
+	require.NoError(t, err)
+
+	err = cluster.CreateProjectConfig(ctx, tx.Tx(), int64(dbProject.ID), map[string]string{"limits.images": "1", "limits.images.size": "1MiB"})
+	require.NoError(t, err)
+
+	assert.NoError(t, project.AllowImageCreation(tx, "p1", 1024))
+
+	budget, err := project.GetImageSpaceBudget(tx, "p1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1024*1024), budget)
+
+	err = tx.CreateImage(ctx, api.ProjectDefaultName, "abc", "x.gz", 16, false, false, "amd64", time.Now(), time.Now(), map[string]string{}, "container", nil)
+	require.NoError(t, err)
+
+	err = project.AllowImageCreation(tx, "p1", -1)
+	assert.EqualError(t, err, `Reached maximum number of images in project "default"`)
+}
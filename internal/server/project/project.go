@@ -8,6 +8,7 @@ import (
 
 	"github.com/lxc/incus/v6/internal/server/db"
 	"github.com/lxc/incus/v6/internal/server/db/cluster"
+	deviceconfig "github.com/lxc/incus/v6/internal/server/device/config"
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/util"
 )
@@ -18,6 +19,9 @@ const separator = "_"
 // projectLimitDiskPool is the prefix used for pool-specific disk limits.
 var projectLimitDiskPool = "limits.disk.pool."
 
+// projectImagePin is the prefix used for image alias pins.
+var projectImagePin = "images.pin."
+
 // Instance adds the "<project>_" prefix to instance name when the given project name is not "default".
 func Instance(projectName string, instanceName string) string {
 	if projectName != api.ProjectDefaultName {
@@ -204,6 +208,57 @@ func NetworkProjectFromRecord(p *api.Project) string {
 	return api.ProjectDefaultName
 }
 
+// NetworkACLsMandatory returns the network ACLs which must be applied to every NIC of the instances in the
+// project. Those are enforced whether the project is restricted or not.
+func NetworkACLsMandatory(p *api.Project) []string {
+	return util.SplitNTrimSpace(p.Config["restricted.networks.acls.mandatory"], ",", -1, true)
+}
+
+// NICNetworkACLs returns the comma separated list of ACLs to apply to a NIC of an instance in the project,
+// made of the mandatory ACLs of the project followed by the ACLs set on the NIC.
+func NICNetworkACLs(p *api.Project, nicACLs string) string {
+	aclNames := NetworkACLsMandatory(p)
+	if len(aclNames) == 0 {
+		return nicACLs
+	}
+
+	for _, aclName := range util.SplitNTrimSpace(nicACLs, ",", -1, true) {
+		if !slices.Contains(aclNames, aclName) {
+			aclNames = append(aclNames, aclName)
+		}
+	}
+
+	return strings.Join(aclNames, ",")
+}
+
+// ApplyNetworkACLsMandatory adds the mandatory network ACLs of the project to the NICs connected to bridge and
+// OVN networks, as network ACLs aren't supported by the other NIC types.
+// The networks are those of the project's effective network project.
+func ApplyNetworkACLsMandatory(p *api.Project, devices deviceconfig.Devices, networks map[int64]api.Network) {
+	if len(NetworkACLsMandatory(p)) == 0 {
+		return
+	}
+
+	networkTypes := make(map[string]string, len(networks))
+	for _, network := range networks {
+		networkTypes[network.Name] = network.Type
+	}
+
+	for devName, dev := range devices {
+		if dev["type"] != "nic" || dev["network"] == "" {
+			continue
+		}
+
+		if !slices.Contains([]string{"bridge", "ovn"}, networkTypes[dev["network"]]) {
+			continue
+		}
+
+		dev = dev.Clone()
+		dev["security.acls"] = NICNetworkACLs(p, dev["security.acls"])
+		devices[devName] = dev
+	}
+}
+
 // NetworkAllowed returns whether access is allowed to a particular network based on projectConfig.
 func NetworkAllowed(reqProjectConfig map[string]string, networkName string, isManaged bool) bool {
 	// If project is not restricted, then access to network is allowed.
@@ -261,6 +316,39 @@ func ImageProjectFromRecord(p *api.Project) string {
 	return api.ProjectDefaultName
 }
 
+// ImagePin returns the image fingerprint or the channel that an image alias is pinned to in the project.
+// Both are empty if the alias isn't pinned.
+func ImagePin(projectConfig map[string]string, alias string) (string, string) {
+	value := projectConfig[projectImagePin+alias]
+
+	channel, isChannel := strings.CutPrefix(value, "@")
+	if isChannel {
+		return "", channel
+	}
+
+	return value, ""
+}
+
+// ImagePinned returns whether an image alias is pinned to the image with the given fingerprint in the project.
+func ImagePinned(projectConfig map[string]string, fingerprint string) bool {
+	for key, value := range projectConfig {
+		if !strings.HasPrefix(key, projectImagePin) || strings.HasPrefix(value, "@") {
+			continue
+		}
+
+		if value != "" && strings.HasPrefix(fingerprint, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ImagePinAlias returns the image alias of the pin set by the given project configuration key (if any).
+func ImagePinAlias(key string) (string, bool) {
+	return strings.CutPrefix(key, projectImagePin)
+}
+
 // ProfileProject returns the effective project to use for the profile based on the requested project.
 // If the requested project has the "features.profiles" flag enabled then the requested project's info is returned,
 // otherwise the default project's info is returned.
@@ -3,6 +3,7 @@ package project_test
 import (
 	"fmt"
 
+	deviceconfig "github.com/lxc/incus/v6/internal/server/device/config"
 	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/shared/api"
 )
@@ -45,3 +46,80 @@ func ExampleStorageVolume() {
 	// Output: default_test
 	// project_name_test1
 }
+
+func ExampleImagePin() {
+	config := map[string]string{
+		"images.pin.debian/12":    "@candidate",
+		"images.pin.ubuntu/24.04": "84a71299044b",
+	}
+
+	fingerprint, channel := project.ImagePin(config, "debian/12")
+	fmt.Printf("%q %q\n", fingerprint, channel)
+
+	fingerprint, channel = project.ImagePin(config, "ubuntu/24.04")
+	fmt.Printf("%q %q\n", fingerprint, channel)
+
+	fingerprint, channel = project.ImagePin(config, "alpine/edge")
+	fmt.Printf("%q %q\n", fingerprint, channel)
+
+	// Output: "" "candidate"
+	// "84a71299044b" ""
+	// "" ""
+}
+
+func ExampleImagePinned() {
+	config := map[string]string{
+		"images.pin.debian/12":    "@candidate",
+		"images.pin.ubuntu/24.04": "84a71299044b",
+	}
+
+	fmt.Println(project.ImagePinned(config, "84a71299044b1b9c3b6cbb0c6e46b0ad4b3d2c1e"))
+	fmt.Println(project.ImagePinned(config, "3c4a4d5e3bc0aa6e3fbf0e0a4c4b0a0f5e5d3c2b"))
+	fmt.Println(project.ImagePinned(nil, "84a71299044b1b9c3b6cbb0c6e46b0ad4b3d2c1e"))
+
+	// Output: true
+	// false
+	// false
+}
+
+func ExampleNICNetworkACLs() {
+	p := &api.Project{ProjectPut: api.ProjectPut{Config: map[string]string{"restricted.networks.acls.mandatory": "block-metadata, deny-smtp"}}}
+
+	fmt.Println(project.NICNetworkACLs(p, ""))
+	fmt.Println(project.NICNetworkACLs(p, "web,deny-smtp"))
+	fmt.Println(project.NICNetworkACLs(&api.Project{}, "web"))
+
+	// Output: block-metadata,deny-smtp
+	// block-metadata,deny-smtp,web
+	// web
+}
+
+func ExampleApplyNetworkACLsMandatory() {
+	p := &api.Project{ProjectPut: api.ProjectPut{Config: map[string]string{"restricted.networks.acls.mandatory": "block-metadata"}}}
+
+	networks := map[int64]api.Network{
+		1: {Name: "incusbr0", Type: "bridge"},
+		2: {Name: "ovn0", Type: "ovn"},
+		3: {Name: "macvlan0", Type: "macvlan"},
+	}
+
+	devices := deviceconfig.Devices{
+		"eth0": {"type": "nic", "network": "incusbr0"},
+		"eth1": {"type": "nic", "network": "ovn0", "security.acls": "web"},
+		"eth2": {"type": "nic", "network": "macvlan0"},
+		"eth3": {"type": "nic", "nictype": "bridged", "parent": "br0"},
+		"root": {"type": "disk", "path": "/", "pool": "default"},
+	}
+
+	project.ApplyNetworkACLsMandatory(p, devices, networks)
+
+	for _, name := range []string{"eth0", "eth1", "eth2", "eth3", "root"} {
+		fmt.Printf("%s: %q\n", name, devices[name]["security.acls"])
+	}
+
+	// Output: eth0: "block-metadata"
+	// eth1: "block-metadata,web"
+	// eth2: ""
+	// eth3: ""
+	// root: ""
+}
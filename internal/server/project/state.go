@@ -110,5 +110,18 @@ func GetCurrentAllocations(ctx context.Context, tx *db.ClusterTx, projectName st
 		Usage: int64(len(networks[projectName])),
 	}
 
+	// Get the snapshot, backup, bucket and image limits and usage.
+	for _, key := range []string{"limits.snapshots", "limits.backups", "limits.buckets", "limits.images"} {
+		count, limit, err := getCountLimit(ctx, tx, &info.Project, key)
+		if err != nil {
+			return nil, err
+		}
+
+		result[strings.TrimPrefix(key, "limits.")] = api.ProjectStateResource{
+			Limit: int64(limit),
+			Usage: int64(count),
+		}
+	}
+
 	return result, nil
 }
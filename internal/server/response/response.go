@@ -9,6 +9,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	incus "github.com/lxc/incus/v6/client"
@@ -111,6 +112,9 @@ type syncResponse struct {
 	headers   map[string]string
 	plaintext bool
 	compress  bool
+
+	// conditional indicates that the etag changes whenever the metadata does.
+	conditional bool
 }
 
 // EmptySyncResponse represents an empty syncResponse.
@@ -127,6 +131,12 @@ func SyncResponseETag(success bool, metadata any, etag any) Response {
 	return &syncResponse{success: success, metadata: metadata, etag: etag}
 }
 
+// SyncResponseConditional returns a new syncResponse with an etag that changes whenever the metadata does.
+// Such responses are replaced by a not modified response when the client already has the same etag.
+func SyncResponseConditional(success bool, metadata any, etag any) Response {
+	return &syncResponse{success: success, metadata: metadata, etag: etag, conditional: true}
+}
+
 // SyncResponseLocation returns a new syncResponse with a location.
 func SyncResponseLocation(success bool, metadata any, location string) Response {
 	return &syncResponse{success: success, metadata: metadata, location: location}
@@ -253,6 +263,75 @@ func (r *syncResponse) Code() int {
 	return r.code
 }
 
+// Not modified response.
+type notModifiedResponse struct {
+	etag string
+}
+
+// ConditionalResponse returns a not modified response (304) if the ETag of the provided response matches one
+// of those listed in the If-None-Match header of the request, otherwise the response is returned unchanged.
+// Only the responses created through SyncResponseConditional are considered as their ETag covers their whole content.
+func ConditionalResponse(r *http.Request, resp Response) Response {
+	syncResp, ok := resp.(*syncResponse)
+	if !ok || !syncResp.success || !syncResp.conditional {
+		return resp
+	}
+
+	if !ETagMatch(r, syncResp.etag) {
+		return resp
+	}
+
+	etag, err := localUtil.EtagHash(syncResp.etag)
+	if err != nil {
+		return resp
+	}
+
+	return &notModifiedResponse{etag: etag}
+}
+
+// ETagMatch returns whether the ETag is listed in the If-None-Match header of a GET or HEAD request.
+// This allows handlers to skip loading data the client already has.
+func ETagMatch(r *http.Request, etag any) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	match := r.Header.Get("If-None-Match")
+	if match == "" || etag == nil {
+		return false
+	}
+
+	hash, err := localUtil.EtagHash(etag)
+	if err != nil {
+		return false
+	}
+
+	for _, value := range strings.Split(match, ",") {
+		value = strings.TrimPrefix(strings.TrimSpace(value), "W/")
+		if value == "*" || strings.Trim(value, "\"") == hash {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *notModifiedResponse) Render(w http.ResponseWriter) error {
+	w.Header().Set("ETag", fmt.Sprintf("\"%s\"", r.etag))
+	w.WriteHeader(http.StatusNotModified)
+
+	return nil
+}
+
+func (r *notModifiedResponse) String() string {
+	return "not modified"
+}
+
+// Code returns the HTTP code.
+func (r *notModifiedResponse) Code() int {
+	return http.StatusNotModified
+}
+
 // Error response.
 type errorResponse struct {
 	code int    // Code to return in both the HTTP header and Code field of the response body.
@@ -0,0 +1,76 @@
+package response
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	localUtil "github.com/lxc/incus/v6/internal/server/util"
+)
+
+func TestConditionalResponse(t *testing.T) {
+	etag := []any{"epoch", uint64(42)}
+	hash, err := localUtil.EtagHash(etag)
+	require.NoError(t, err)
+
+	newRequest := func(method string, match string) *http.Request {
+		r := httptest.NewRequest(method, "/1.0/instances", nil)
+		if match != "" {
+			r.Header.Set("If-None-Match", match)
+		}
+
+		return r
+	}
+
+	tests := []struct {
+		name        string
+		request     *http.Request
+		response    Response
+		notModified bool
+	}{
+		{"Matching ETag", newRequest(http.MethodGet, fmt.Sprintf("%q", hash)), SyncResponseConditional(true, "data", etag), true},
+		{"Weak matching ETag in a list", newRequest(http.MethodGet, fmt.Sprintf(`"foo", W/%q`, hash)), SyncResponseConditional(true, "data", etag), true},
+		{"Wildcard", newRequest(http.MethodGet, "*"), SyncResponseConditional(true, "data", etag), true},
+		{"Different ETag", newRequest(http.MethodGet, `"foo"`), SyncResponseConditional(true, "data", etag), false},
+		{"No If-None-Match", newRequest(http.MethodGet, ""), SyncResponseConditional(true, "data", etag), false},
+		{"Not a GET request", newRequest(http.MethodPut, fmt.Sprintf("%q", hash)), SyncResponseConditional(true, "data", etag), false},
+		{"Not conditional", newRequest(http.MethodGet, fmt.Sprintf("%q", hash)), SyncResponseETag(true, "data", etag), false},
+		{"Not a sync response", newRequest(http.MethodGet, fmt.Sprintf("%q", hash)), NotFound(nil), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := ConditionalResponse(test.request, test.response)
+			if !test.notModified {
+				assert.Equal(t, test.response, resp)
+				return
+			}
+
+			assert.Equal(t, http.StatusNotModified, resp.Code())
+
+			w := httptest.NewRecorder()
+			require.NoError(t, resp.Render(w))
+			assert.Equal(t, http.StatusNotModified, w.Code)
+			assert.Equal(t, fmt.Sprintf("%q", hash), w.Header().Get("ETag"))
+			assert.Empty(t, w.Body.String())
+		})
+	}
+}
+
+func TestETagMatch(t *testing.T) {
+	etag := []any{"epoch", uint64(42)}
+	hash, err := localUtil.EtagHash(etag)
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodGet, "/1.0/instances", nil)
+	assert.False(t, ETagMatch(r, etag))
+
+	r.Header.Set("If-None-Match", fmt.Sprintf("%q", hash))
+	assert.True(t, ETagMatch(r, etag))
+	assert.False(t, ETagMatch(r, []any{"epoch", uint64(43)}))
+	assert.False(t, ETagMatch(r, nil))
+}
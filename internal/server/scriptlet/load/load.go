@@ -1,6 +1,8 @@
 package load
 
 import (
+	"fmt"
+	"slices"
 	"sync"
 
 	"go.starlark.net/starlark"
@@ -12,6 +14,9 @@ const nameInstancePlacement = "instance_placement"
 // prefixQEMU is the prefix used in Starlark for the QEMU scriptlet.
 const prefixQEMU = "qemu"
 
+// prefixLXC is the prefix used in Starlark for the LXC scriptlet.
+const prefixLXC = "lxc"
+
 // nameAuthorization is the name used in Starlark for the Authorization scriptlet.
 const nameAuthorization = "authorization"
 
@@ -83,14 +88,64 @@ func QEMUCompile(name string, src string) (*starlark.Program, error) {
 		"set_qemu_cmdline",
 		"get_qemu_conf",
 		"set_qemu_conf",
+
+		"get_device",
 	})
 }
 
+// QEMUOptionalStages are the QEMU scriptlet stages only run when listed in the qemu_hook_stages global.
+var QEMUOptionalStages = []string{"pre-hotplug", "post-hotplug", "pre-hotunplug", "post-hotunplug", "pre-stop", "migration-source", "migration-target"}
+
 // QEMUValidate validates the QEMU scriptlet.
 func QEMUValidate(src string) error {
-	return validate(QEMUCompile, prefixQEMU, src, declaration{
+	err := validate(QEMUCompile, prefixQEMU, src, declaration{
 		required("qemu_hook"): {"instance", "stage"},
 	})
+	if err != nil {
+		return err
+	}
+
+	prog, err := QEMUCompile(prefixQEMU, src)
+	if err != nil {
+		return err
+	}
+
+	globals, err := prog.Init(&starlark.Thread{Name: prefixQEMU}, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = QEMUStages(globals)
+	return err
+}
+
+// QEMUStages returns the optional stages listed in the qemu_hook_stages global of the QEMU scriptlet.
+func QEMUStages(globals starlark.StringDict) ([]string, error) {
+	value, ok := globals["qemu_hook_stages"]
+	if !ok {
+		return nil, nil
+	}
+
+	list, ok := value.(*starlark.List)
+	if !ok {
+		return nil, fmt.Errorf("qemu_hook_stages must be a list, got %s", value.Type())
+	}
+
+	stages := make([]string, 0, list.Len())
+	for i := range list.Len() {
+		stage, ok := starlark.AsString(list.Index(i))
+		if !ok {
+			return nil, fmt.Errorf("qemu_hook_stages must only contain strings, got %s", list.Index(i).Type())
+		}
+
+		if !slices.Contains(QEMUOptionalStages, stage) {
+			return nil, fmt.Errorf("Unknown QEMU scriptlet stage %q in qemu_hook_stages", stage)
+		}
+
+		stages = append(stages, stage)
+	}
+
+	return stages, nil
 }
 
 // QEMUSet compiles the QEMU scriptlet into memory for use with QEMURun.
@@ -104,6 +159,39 @@ func QEMUProgram(instance string) (*starlark.Program, *starlark.Thread, error) {
 	return program("QEMU", prefixQEMU+"/"+instance)
 }
 
+// LXCCompile compiles the LXC scriptlet.
+func LXCCompile(name string, src string) (*starlark.Program, error) {
+	return compile(name, src, []string{
+		"log_info",
+		"log_warn",
+		"log_error",
+
+		"get_lxc_config",
+		"set_lxc_config",
+		"unset_lxc_config",
+		"get_instance_config",
+		"get_instance_devices",
+	})
+}
+
+// LXCValidate validates the LXC scriptlet.
+func LXCValidate(src string) error {
+	return validate(LXCCompile, prefixLXC, src, declaration{
+		required("lxc_hook"): {"instance"},
+	})
+}
+
+// LXCSet compiles the LXC scriptlet into memory for use with LXCRun.
+// If empty src is provided the current program is deleted.
+func LXCSet(src string, instance string) error {
+	return set(LXCCompile, prefixLXC+"/"+instance, src)
+}
+
+// LXCProgram returns the precompiled LXC scriptlet program.
+func LXCProgram(instance string) (*starlark.Program, *starlark.Thread, error) {
+	return program("LXC", prefixLXC+"/"+instance)
+}
+
 // AuthorizationCompile compiles the authorization scriptlet.
 func AuthorizationCompile(name string, src string) (*starlark.Program, error) {
 	return compile(name, src, []string{
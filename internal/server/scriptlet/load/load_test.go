@@ -0,0 +1,33 @@
+package load
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQEMUValidateStages(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		wantErr bool
+	}{
+		{"No stages", "def qemu_hook(instance, stage):\n    pass\n", false},
+		{"Valid stages", "qemu_hook_stages = [\"pre-stop\", \"migration-source\"]\n\ndef qemu_hook(instance, stage):\n    pass\n", false},
+		{"Unknown stage", "qemu_hook_stages = [\"pre-start\"]\n\ndef qemu_hook(instance, stage):\n    pass\n", true},
+		{"Not a list", "qemu_hook_stages = \"pre-stop\"\n\ndef qemu_hook(instance, stage):\n    pass\n", true},
+		{"Not a string", "qemu_hook_stages = [1]\n\ndef qemu_hook(instance, stage):\n    pass\n", true},
+		{"Missing hook", "qemu_hook_stages = [\"pre-stop\"]\n", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := QEMUValidate(test.src)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package scriptlet
+
+import (
+	"fmt"
+
+	"go.starlark.net/starlark"
+
+	scriptletLoad "github.com/lxc/incus/v6/internal/server/scriptlet/load"
+	"github.com/lxc/incus/v6/internal/server/scriptlet/log"
+	"github.com/lxc/incus/v6/internal/server/scriptlet/marshal"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// LXCConfig is the LXC container configuration the LXC scriptlet operates on.
+type LXCConfig interface {
+	ConfigItem(key string) []string
+	SetConfigItem(key string, value string) error
+	ClearConfigItem(key string) error
+}
+
+// LXCRun runs the LXC scriptlet.
+func LXCRun(l logger.Logger, instance *api.Instance, conf LXCConfig) error {
+	logFunc := log.CreateLogger(l, "LXC scriptlet")
+
+	getLXCConfigFunc := func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var key string
+		err := starlark.UnpackArgs(b.Name(), args, kwargs, "key", &key)
+		if err != nil {
+			return nil, err
+		}
+
+		// Unset keys come back as a single empty value.
+		values := []string{}
+		for _, value := range conf.ConfigItem(key) {
+			if value != "" {
+				values = append(values, value)
+			}
+		}
+
+		rv, err := marshal.StarlarkMarshal(values)
+		if err != nil {
+			return nil, fmt.Errorf("Marshalling LXC configuration failed: %w", err)
+		}
+
+		return rv, nil
+	}
+
+	setLXCConfigFunc := func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var key string
+		var value string
+		err := starlark.UnpackArgs(b.Name(), args, kwargs, "key", &key, "value", &value)
+		if err != nil {
+			return nil, err
+		}
+
+		err = conf.SetConfigItem(key, value)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to set LXC config: %s=%s: %w", key, value, err)
+		}
+
+		return starlark.None, nil
+	}
+
+	unsetLXCConfigFunc := func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var key string
+		err := starlark.UnpackArgs(b.Name(), args, kwargs, "key", &key)
+		if err != nil {
+			return nil, err
+		}
+
+		err = conf.ClearConfigItem(key)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to unset LXC config %q: %w", key, err)
+		}
+
+		return starlark.None, nil
+	}
+
+	getInstanceConfigFunc := func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		err := starlark.UnpackArgs(b.Name(), args, kwargs)
+		if err != nil {
+			return nil, err
+		}
+
+		rv, err := marshal.StarlarkMarshal(instance.ExpandedConfig)
+		if err != nil {
+			return nil, fmt.Errorf("Marshalling instance configuration failed: %w", err)
+		}
+
+		return rv, nil
+	}
+
+	getInstanceDevicesFunc := func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		err := starlark.UnpackArgs(b.Name(), args, kwargs)
+		if err != nil {
+			return nil, err
+		}
+
+		rv, err := marshal.StarlarkMarshal(instance.ExpandedDevices)
+		if err != nil {
+			return nil, fmt.Errorf("Marshalling instance devices failed: %w", err)
+		}
+
+		return rv, nil
+	}
+
+	// Remember to match the entries in scriptletLoad.LXCCompile() with this list so Starlark can
+	// perform compile time validation of functions used.
+	env := starlark.StringDict{
+		"log_info":  starlark.NewBuiltin("log_info", logFunc),
+		"log_warn":  starlark.NewBuiltin("log_warn", logFunc),
+		"log_error": starlark.NewBuiltin("log_error", logFunc),
+
+		"get_lxc_config":       starlark.NewBuiltin("get_lxc_config", getLXCConfigFunc),
+		"set_lxc_config":       starlark.NewBuiltin("set_lxc_config", setLXCConfigFunc),
+		"unset_lxc_config":     starlark.NewBuiltin("unset_lxc_config", unsetLXCConfigFunc),
+		"get_instance_config":  starlark.NewBuiltin("get_instance_config", getInstanceConfigFunc),
+		"get_instance_devices": starlark.NewBuiltin("get_instance_devices", getInstanceDevicesFunc),
+	}
+
+	prog, thread, err := scriptletLoad.LXCProgram(instance.Name)
+	if err != nil {
+		return err
+	}
+
+	globals, err := prog.Init(thread, env)
+	if err != nil {
+		return fmt.Errorf("Failed initializing: %w", err)
+	}
+
+	globals.Freeze()
+
+	// Retrieve a global variable from starlark environment.
+	lxcHook := globals["lxc_hook"]
+	if lxcHook == nil {
+		return fmt.Errorf("Scriptlet missing lxc_hook function")
+	}
+
+	instancev, err := marshal.StarlarkMarshal(instance)
+	if err != nil {
+		return fmt.Errorf("Marshalling instance failed: %w", err)
+	}
+
+	// Call starlark function from Go.
+	v, err := starlark.Call(thread, lxcHook, nil, []starlark.Tuple{
+		{
+			starlark.String("instance"),
+			instancev,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to run: %w", err)
+	}
+
+	if v.Type() != "NoneType" {
+		return fmt.Errorf("Failed with unexpected return value: %v", v)
+	}
+
+	return nil
+}
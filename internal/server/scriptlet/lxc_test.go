@@ -0,0 +1,95 @@
+package scriptlet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	scriptletLoad "github.com/lxc/incus/v6/internal/server/scriptlet/load"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// lxcConfigMock is an in-memory LXC configuration.
+type lxcConfigMock map[string][]string
+
+func (c lxcConfigMock) ConfigItem(key string) []string {
+	values, ok := c[key]
+	if !ok {
+		return []string{""}
+	}
+
+	return values
+}
+
+func (c lxcConfigMock) SetConfigItem(key string, value string) error {
+	c[key] = append(c[key], value)
+	return nil
+}
+
+func (c lxcConfigMock) ClearConfigItem(key string) error {
+	delete(c, key)
+	return nil
+}
+
+func TestLXCRun(t *testing.T) {
+	src := `
+def lxc_hook(instance):
+    if get_instance_config()["user.mode"] != "strict":
+        return
+
+    if get_lxc_config("lxc.apparmor.profile") != []:
+        fail("unexpected profile")
+
+    for hook in get_lxc_config("lxc.hook.pre-start"):
+        log_info("Dropping hook ", hook)
+
+    unset_lxc_config("lxc.hook.pre-start")
+    set_lxc_config("lxc.apparmor.profile", "incus-" + instance.name)
+    set_lxc_config("lxc.environment", "DEVICES=" + ",".join(sorted(get_instance_devices().keys())))
+`
+
+	require.NoError(t, scriptletLoad.LXCValidate(src))
+	require.NoError(t, scriptletLoad.LXCSet(src, "c1"))
+	defer func() { _ = scriptletLoad.LXCSet("", "c1") }()
+
+	inst := &api.Instance{
+		Name:            "c1",
+		ExpandedConfig:  map[string]string{"user.mode": "strict"},
+		ExpandedDevices: map[string]map[string]string{"root": {"type": "disk"}, "eth0": {"type": "nic"}},
+	}
+
+	// The scriptlet runs every time the configuration is regenerated, so applying it again to a
+	// fresh configuration must give the same result.
+	for range 2 {
+		conf := lxcConfigMock{"lxc.hook.pre-start": {"/bin/true"}}
+		require.NoError(t, LXCRun(logger.Log, inst, conf))
+		assert.Equal(t, lxcConfigMock{
+			"lxc.apparmor.profile": {"incus-c1"},
+			"lxc.environment":      {"DEVICES=eth0,root"},
+		}, conf)
+	}
+
+	// The scriptlet can leave the configuration untouched.
+	inst.ExpandedConfig["user.mode"] = "relaxed"
+	conf := lxcConfigMock{"lxc.hook.pre-start": {"/bin/true"}}
+	require.NoError(t, LXCRun(logger.Log, inst, conf))
+	assert.Equal(t, lxcConfigMock{"lxc.hook.pre-start": {"/bin/true"}}, conf)
+}
+
+func TestLXCRunErrors(t *testing.T) {
+	inst := &api.Instance{Name: "c2"}
+
+	// Not loaded.
+	assert.Error(t, LXCRun(logger.Log, inst, lxcConfigMock{}))
+
+	// Failing scriptlet.
+	require.NoError(t, scriptletLoad.LXCSet("def lxc_hook(instance):\n    fail(\"denied\")\n", "c2"))
+	defer func() { _ = scriptletLoad.LXCSet("", "c2") }()
+	assert.ErrorContains(t, LXCRun(logger.Log, inst, lxcConfigMock{}), "denied")
+
+	// Unexpected return value.
+	require.NoError(t, scriptletLoad.LXCSet("def lxc_hook(instance):\n    return True\n", "c2"))
+	assert.ErrorContains(t, LXCRun(logger.Log, inst, lxcConfigMock{}), "unexpected return value")
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"go.starlark.net/starlark"
@@ -26,6 +27,12 @@ type qemuCfgSection struct {
 	Entries map[string]string `json:"entries"`
 }
 
+// QEMUDevice represents the device being hotplugged or hot-unplugged when running the QEMU scriptlet.
+type QEMUDevice struct {
+	Name   string            `json:"name"`
+	Config map[string]string `json:"config"`
+}
+
 // marshalQEMUConf marshals a configuration into a []map[string]any.
 func marshalQEMUConf(conf any) ([]map[string]any, error) {
 	jsonConf, err := json.Marshal(conf)
@@ -58,8 +65,34 @@ func unmarshalQEMUConf(conf any) ([]qemuCfgSection, error) {
 	return newConf, nil
 }
 
+// QEMUStageEnabled returns whether the loaded QEMU scriptlet of the instance should be run at the given stage.
+// The startup stages are always run, the others only if listed in the qemu_hook_stages global of the scriptlet.
+func QEMUStageEnabled(instance string, stage string) (bool, error) {
+	if !slices.Contains(scriptletLoad.QEMUOptionalStages, stage) {
+		return true, nil
+	}
+
+	prog, thread, err := scriptletLoad.QEMUProgram(instance)
+	if err != nil {
+		return false, err
+	}
+
+	globals, err := prog.Init(thread, nil)
+	if err != nil {
+		return false, fmt.Errorf("Failed initializing: %w", err)
+	}
+
+	stages, err := scriptletLoad.QEMUStages(globals)
+	if err != nil {
+		return false, err
+	}
+
+	return slices.Contains(stages, stage), nil
+}
+
 // QEMURun runs the QEMU scriptlet.
-func QEMURun(l logger.Logger, instance *api.Instance, cmdArgs *[]string, conf *[]cfg.Section, m *qmp.Monitor, stage string) error {
+// The device is only set for the hotplug and hot-unplug stages.
+func QEMURun(l logger.Logger, instance *api.Instance, cmdArgs *[]string, conf *[]cfg.Section, m *qmp.Monitor, stage string, device *QEMUDevice) error {
 	logFunc := log.CreateLogger(l, "QEMU scriptlet ("+stage+")")
 
 	// We first convert from []cfg.Section to []qemuCfgSection. This conversion is temporary.
@@ -357,6 +390,24 @@ func QEMURun(l logger.Logger, instance *api.Instance, cmdArgs *[]string, conf *[
 		return starlark.None, nil
 	}
 
+	getDeviceFunc := func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		err := starlark.UnpackArgs(b.Name(), args, kwargs)
+		if err != nil {
+			return nil, err
+		}
+
+		if device == nil {
+			return starlark.None, nil
+		}
+
+		rv, err := marshal.StarlarkMarshal(map[string]any{"name": device.Name, "config": device.Config})
+		if err != nil {
+			return nil, fmt.Errorf("Marshalling device failed: %w", err)
+		}
+
+		return rv, nil
+	}
+
 	// Remember to match the entries in scriptletLoad.QEMUCompile() with this list so Starlark can
 	// perform compile time validation of functions used.
 	env := starlark.StringDict{
@@ -385,6 +436,8 @@ func QEMURun(l logger.Logger, instance *api.Instance, cmdArgs *[]string, conf *[
 		"set_qemu_cmdline": starlark.NewBuiltin("set_qemu_cmdline", setCmdArgsFunc),
 		"get_qemu_conf":    starlark.NewBuiltin("get_qemu_conf", getConfFunc),
 		"set_qemu_conf":    starlark.NewBuiltin("set_qemu_conf", setConfFunc),
+
+		"get_device": starlark.NewBuiltin("get_device", getDeviceFunc),
 	}
 
 	prog, thread, err := scriptletLoad.QEMUProgram(instance.Name)
@@ -0,0 +1,79 @@
+package scriptlet
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/internal/server/instance/drivers/cfg"
+	scriptletLoad "github.com/lxc/incus/v6/internal/server/scriptlet/load"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+func TestQEMUStageEnabled(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		enabled []string
+	}{
+		{
+			name:    "No optional stages",
+			src:     "def qemu_hook(instance, stage):\n    pass\n",
+			enabled: []string{"config", "early", "pre-start", "post-start"},
+		},
+		{
+			name:    "Hotplug stages",
+			src:     "qemu_hook_stages = [\"pre-hotplug\", \"post-hotplug\"]\n\ndef qemu_hook(instance, stage):\n    pass\n",
+			enabled: []string{"config", "early", "pre-start", "post-start", "pre-hotplug", "post-hotplug"},
+		},
+	}
+
+	stages := append([]string{"config", "early", "pre-start", "post-start"}, scriptletLoad.QEMUOptionalStages...)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.NoError(t, scriptletLoad.QEMUSet(test.src, "c1"))
+			defer func() { _ = scriptletLoad.QEMUSet("", "c1") }()
+
+			for _, stage := range stages {
+				enabled, err := QEMUStageEnabled("c1", stage)
+				require.NoError(t, err)
+				assert.Equal(t, slices.Contains(test.enabled, stage), enabled, stage)
+			}
+		})
+	}
+}
+
+func TestQEMURunGetDevice(t *testing.T) {
+	src := `
+def qemu_hook(instance, stage):
+    device = get_device()
+    if stage == "config":
+        if device != None:
+            fail("unexpected device at config stage")
+        return
+
+    if device == None or device["name"] != "eth0" or device["config"]["type"] != "nic":
+        fail("unexpected device %s" % device)
+`
+
+	require.NoError(t, scriptletLoad.QEMUSet(src, "c1"))
+	defer func() { _ = scriptletLoad.QEMUSet("", "c1") }()
+
+	instance := &api.Instance{Name: "c1"}
+	cmdArgs := []string{}
+	conf := []cfg.Section{}
+
+	err := QEMURun(logger.Log, instance, &cmdArgs, &conf, nil, "config", nil)
+	require.NoError(t, err)
+
+	device := &QEMUDevice{Name: "eth0", Config: map[string]string{"type": "nic"}}
+	err = QEMURun(logger.Log, instance, &cmdArgs, &conf, nil, "pre-hotplug", device)
+	require.NoError(t, err)
+
+	err = QEMURun(logger.Log, instance, &cmdArgs, &conf, nil, "pre-hotplug", &QEMUDevice{Name: "disk0", Config: map[string]string{"type": "disk"}})
+	assert.Error(t, err)
+}
@@ -55,6 +55,7 @@ import (
 	"github.com/lxc/incus/v6/shared/ioprogress"
 	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/revert"
+	"github.com/lxc/incus/v6/shared/subprocess"
 	"github.com/lxc/incus/v6/shared/units"
 	"github.com/lxc/incus/v6/shared/util"
 )
@@ -1913,6 +1914,116 @@ func (b *backend) CreateInstanceFromImage(inst instance.Instance, fingerprint st
 	return nil
 }
 
+// ReplaceInstanceFromImage replaces the content of an existing instance volume with the image requested.
+// Unlike deleting and re-creating the volume, this keeps any existing snapshots of the instance.
+func (b *backend) ReplaceInstanceFromImage(inst instance.Instance, fingerprint string, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
+	l.Debug("ReplaceInstanceFromImage started")
+	defer l.Debug("ReplaceInstanceFromImage finished")
+
+	err := b.isStatusReady()
+	if err != nil {
+		return err
+	}
+
+	volType, err := InstanceTypeToVolumeType(inst.Type())
+	if err != nil {
+		return err
+	}
+
+	contentType := InstanceContentType(inst)
+
+	// Load storage volume from database.
+	dbVol, err := VolumeDBGet(b, inst.Project().Name, inst.Name(), volType)
+	if err != nil {
+		return err
+	}
+
+	// Generate the effective root device volume for instance.
+	volStorageName := project.Instance(inst.Project().Name, inst.Name())
+	vol := b.GetVolume(volType, contentType, volStorageName, dbVol.Config)
+	err = b.applyInstanceRootDiskOverrides(inst, &vol)
+	if err != nil {
+		return err
+	}
+
+	err = vol.MountTask(func(mountPath string, op *operations.Operation) error {
+		var err error
+		var rootBlockPath string
+		var rootBlockSize int64
+
+		if contentType == drivers.ContentTypeBlock {
+			rootBlockPath, err = b.driver.GetVolumeDiskPath(vol)
+			if err != nil {
+				return err
+			}
+
+			rootBlockSize, err = drivers.BlockDiskSizeBytes(rootBlockPath)
+			if err != nil {
+				return err
+			}
+
+			// The image is unpacked sparsely onto block devices, so clear any existing data first.
+			if linux.IsBlockdevPath(rootBlockPath) {
+				_, err = subprocess.RunCommand("blkdiscard", "-f", "-z", rootBlockPath)
+				if err != nil {
+					return fmt.Errorf("Failed clearing root disk %q: %w", rootBlockPath, err)
+				}
+			}
+		}
+
+		// Remove the existing metadata, templates and root filesystem.
+		entries, err := os.ReadDir(mountPath)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			entryPath := filepath.Join(mountPath, entry.Name())
+			if entryPath == rootBlockPath || entry.Name() == "lost+found" {
+				continue
+			}
+
+			err = os.RemoveAll(entryPath)
+			if err != nil {
+				return fmt.Errorf("Failed removing %q: %w", entryPath, err)
+			}
+		}
+
+		_, err = b.imageFiller(fingerprint, op)(vol, rootBlockPath, false)
+		if err != nil {
+			return err
+		}
+
+		// Converting the image into a file-backed root disk resizes it to the image size, so restore the previous size.
+		if rootBlockPath != "" && !linux.IsBlockdevPath(rootBlockPath) {
+			newSize, err := drivers.BlockDiskSizeBytes(rootBlockPath)
+			if err != nil {
+				return err
+			}
+
+			if newSize < rootBlockSize {
+				err = b.driver.SetVolumeQuota(vol, fmt.Sprintf("%d", rootBlockSize), false, op)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}, op)
+	if err != nil {
+		return err
+	}
+
+	err = inst.DeferTemplateApply(instance.TemplateTriggerCreate)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // CreateInstanceFromMigration receives an instance being migrated.
 // The args.Name and args.Config fields are ignored and, instance properties are used instead.
 func (b *backend) CreateInstanceFromMigration(inst instance.Instance, conn io.ReadWriteCloser, args localMigration.VolumeTargetArgs, op *operations.Operation) error {
@@ -2500,6 +2611,33 @@ func (b *backend) UpdateInstance(inst instance.Instance, newDesc string, newConf
 		}
 	}
 
+	// Apply the new I/O limits to the root disk if the instance is running (possibly on another cluster member).
+	if volumeLimitsChanged(changedConfig) {
+		rootDiskName, _, err := internalInstance.GetRootDiskDevice(inst.ExpandedDevices().CloneNative())
+		if err != nil {
+			return err
+		}
+
+		c, err := ConnectIfInstanceIsRemote(b.state, inst.Project().Name, inst.Name(), nil)
+		if err != nil {
+			return err
+		}
+
+		if c != nil {
+			// Send a remote notification.
+			uri := fmt.Sprintf("/internal/instances/%d/onlimits?devices=%s", inst.ID(), url.QueryEscape(rootDiskName))
+			_, _, err := c.RawQuery("GET", uri, nil, "")
+			if err != nil {
+				return fmt.Errorf("Failed applying I/O limits to root disk: %w", err)
+			}
+		} else if inst.IsRunning() {
+			err = inst.ReloadDevice(rootDiskName)
+			if err != nil {
+				return fmt.Errorf("Failed applying I/O limits to root disk: %w", err)
+			}
+		}
+	}
+
 	b.state.Events.SendLifecycle(inst.Project().Name, lifecycle.StorageVolumeUpdated.Event(newVol, string(newVol.Type()), inst.Project().Name, op, nil))
 
 	return nil
@@ -2800,6 +2938,36 @@ func (b *backend) GetInstanceUsage(inst instance.Instance) (*VolumeUsage, error)
 	return &val, nil
 }
 
+// GetInstanceSpaceUsage returns the breakdown of the space used by the instance's root volume and its snapshots.
+func (b *backend) GetInstanceSpaceUsage(inst instance.Instance) (*drivers.VolumeSpaceUsage, error) {
+	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
+	l.Debug("GetInstanceSpaceUsage started")
+	defer l.Debug("GetInstanceSpaceUsage finished")
+
+	err := b.isStatusReady()
+	if err != nil {
+		return nil, err
+	}
+
+	volType, err := InstanceTypeToVolumeType(inst.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := InstanceContentType(inst)
+
+	// There's no need to pass config as it's not needed when retrieving the volume usage.
+	volStorageName := project.Instance(inst.Project().Name, inst.Name())
+	vol := b.GetVolume(volType, contentType, volStorageName, nil)
+
+	snapshots, err := b.volumeSnapshotNames(inst.Project().Name, inst.Name(), volType)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.driver.GetVolumeSpaceUsage(vol, snapshots)
+}
+
 // SetInstanceQuota sets the quota on the instance's root volume.
 // Returns ErrInUse if the instance is running and the storage driver doesn't support online resizing.
 func (b *backend) SetInstanceQuota(inst instance.Instance, size string, vmStateSize string, op *operations.Operation) error {
@@ -5429,6 +5597,18 @@ func (b *backend) detectChangedConfig(curConfig, newConfig map[string]string) (m
 	return changedConfig, userOnly
 }
 
+// volumeLimitsChanged returns whether any of the I/O limits of a volume are part of the changed config.
+func volumeLimitsChanged(changedConfig map[string]string) bool {
+	for _, key := range []string{"limits.read", "limits.write", "limits.max"} {
+		_, ok := changedConfig[key]
+		if ok {
+			return true
+		}
+	}
+
+	return false
+}
+
 // UpdateCustomVolume applies the supplied config to the custom volume.
 func (b *backend) UpdateCustomVolume(projectName string, volName string, newDesc string, newConfig map[string]string, op *operations.Operation) error {
 	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": volName, "newDesc": newDesc, "newConfig": newConfig})
@@ -5631,6 +5811,59 @@ func (b *backend) UpdateCustomVolume(projectName string, volName string, newDesc
 		}
 	}
 
+	// Apply the new I/O limits to the running instances using the volume, including those on other cluster members.
+	if volumeLimitsChanged(changedConfig) {
+		type instDevice struct {
+			args    db.InstanceArgs
+			project api.Project
+			devices []string
+		}
+
+		instDevices := []instDevice{}
+		err = VolumeUsedByInstanceDevices(b.state, b.name, projectName, &curVol.StorageVolume, true, func(dbInst db.InstanceArgs, project api.Project, usedByDevices []string) error {
+			instDevices = append(instDevices, instDevice{args: dbInst, project: project, devices: usedByDevices})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range instDevices {
+			c, err := ConnectIfInstanceIsRemote(b.state, entry.args.Project, entry.args.Name, nil)
+			if err != nil {
+				return err
+			}
+
+			if c != nil {
+				// Send a remote notification.
+				uri := fmt.Sprintf("/internal/instances/%d/onlimits?devices=%s", entry.args.ID, url.QueryEscape(strings.Join(entry.devices, ",")))
+				_, _, err := c.RawQuery("GET", uri, nil, "")
+				if err != nil {
+					return fmt.Errorf("Failed applying I/O limits to instance %q: %w", entry.args.Name, err)
+				}
+
+				continue
+			}
+
+			// Update the local instance.
+			inst, err := instance.Load(b.state, entry.args, entry.project)
+			if err != nil {
+				return err
+			}
+
+			if !inst.IsRunning() {
+				continue
+			}
+
+			for _, devName := range entry.devices {
+				err = inst.ReloadDevice(devName)
+				if err != nil {
+					return fmt.Errorf("Failed applying I/O limits to device %q of instance %q: %w", devName, inst.Name(), err)
+				}
+			}
+		}
+	}
+
 	b.state.Events.SendLifecycle(projectName, lifecycle.StorageVolumeUpdated.Event(newVol, string(newVol.Type()), projectName, op, nil))
 
 	return nil
@@ -5839,6 +6072,48 @@ func (b *backend) GetCustomVolumeUsage(projectName, volName string) (*VolumeUsag
 	return &val, nil
 }
 
+// GetCustomVolumeSpaceUsage returns the breakdown of the space used by the custom volume and its snapshots.
+func (b *backend) GetCustomVolumeSpaceUsage(projectName, volName string) (*drivers.VolumeSpaceUsage, error) {
+	err := b.isStatusReady()
+	if err != nil {
+		return nil, err
+	}
+
+	volume, err := VolumeDBGet(b, projectName, volName, drivers.VolumeTypeCustom)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the volume name on storage.
+	volStorageName := project.StorageVolume(projectName, volName)
+
+	// There's no need to pass config as it's not needed when getting the volume usage.
+	vol := b.GetVolume(drivers.VolumeTypeCustom, drivers.ContentType(volume.ContentType), volStorageName, nil)
+
+	snapshots, err := b.volumeSnapshotNames(projectName, volName, drivers.VolumeTypeCustom)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.driver.GetVolumeSpaceUsage(vol, snapshots)
+}
+
+// volumeSnapshotNames returns the names (without the parent volume name) of the snapshots of a volume.
+func (b *backend) volumeSnapshotNames(projectName string, volName string, volType drivers.VolumeType) ([]string, error) {
+	dbSnapshots, err := VolumeDBSnapshotsGet(b, projectName, volName, volType)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]string, 0, len(dbSnapshots))
+	for _, dbSnapshot := range dbSnapshots {
+		_, snapName, _ := api.GetParentAndSnapshotName(dbSnapshot.Name)
+		snapshots = append(snapshots, snapName)
+	}
+
+	return snapshots, nil
+}
+
 // MountCustomVolume mounts a custom volume.
 func (b *backend) MountCustomVolume(projectName, volName string, op *operations.Operation) (*MountInfo, error) {
 	l := b.logger.AddContext(logger.Ctx{"project": projectName, "volName": volName})
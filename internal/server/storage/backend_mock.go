@@ -125,6 +125,10 @@ func (b *mockBackend) CreateInstanceFromImage(inst instance.Instance, fingerprin
 	return nil
 }
 
+func (b *mockBackend) ReplaceInstanceFromImage(inst instance.Instance, fingerprint string, op *operations.Operation) error {
+	return nil
+}
+
 func (b *mockBackend) CreateInstanceFromMigration(inst instance.Instance, conn io.ReadWriteCloser, args migration.VolumeTargetArgs, op *operations.Operation) error {
 	return nil
 }
@@ -190,6 +194,10 @@ func (b *mockBackend) GetInstanceUsage(inst instance.Instance) (*VolumeUsage, er
 	return nil, nil
 }
 
+func (b *mockBackend) GetInstanceSpaceUsage(inst instance.Instance) (*drivers.VolumeSpaceUsage, error) {
+	return nil, nil
+}
+
 func (b *mockBackend) SetInstanceQuota(inst instance.Instance, size string, vmStateSize string, op *operations.Operation) error {
 	return nil
 }
@@ -319,6 +327,10 @@ func (b *mockBackend) GetCustomVolumeUsage(projectName string, volName string) (
 	return nil, nil
 }
 
+func (b *mockBackend) GetCustomVolumeSpaceUsage(projectName string, volName string) (*drivers.VolumeSpaceUsage, error) {
+	return nil, nil
+}
+
 func (b *mockBackend) MountCustomVolume(projectName string, volName string, op *operations.Operation) (*MountInfo, error) {
 	return nil, nil
 }
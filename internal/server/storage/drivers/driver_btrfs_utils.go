@@ -344,6 +344,47 @@ func (d *btrfs) getQGroup(path string) (string, int64, error) {
 	return qgroup, usage, nil
 }
 
+// getQGroupUsage returns the referenced and exclusive space of the subvolume at the given path.
+func (d *btrfs) getQGroupUsage(path string) (int64, int64, error) {
+	output, err := subprocess.RunCommand("btrfs", "qgroup", "show", "-e", "-f", "--raw", path)
+	if err != nil {
+		return -1, -1, errBtrfsNoQuota
+	}
+
+	return btrfsParseQGroupUsage(output)
+}
+
+// btrfsParseQGroupUsage parses the referenced and exclusive space from the output of "btrfs qgroup show --raw".
+func btrfsParseQGroupUsage(output string) (int64, int64, error) {
+	for _, line := range strings.Split(output, "\n") {
+		// Use case-insensitive field title match because BTRFS tooling changed casing between versions.
+		if line == "" || strings.HasPrefix(strings.ToLower(line), "qgroupid") || strings.HasPrefix(line, "-") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+
+		// The BTRFS tooling changed the number of columns between versions so we only check for minimum.
+		if len(fields) < 3 {
+			continue
+		}
+
+		referenced, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return -1, -1, fmt.Errorf("Failed parsing referenced space (%q): %w", fields[1], err)
+		}
+
+		exclusive, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return -1, -1, fmt.Errorf("Failed parsing exclusive space (%q): %w", fields[2], err)
+		}
+
+		return referenced, exclusive, nil
+	}
+
+	return -1, -1, errBtrfsNoQGroup
+}
+
 func (d *btrfs) sendSubvolume(path string, parent string, conn io.ReadWriteCloser, tracker *ioprogress.ProgressTracker) error {
 	defer func() { _ = conn.Close() }()
 
@@ -0,0 +1,51 @@
+package drivers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_btrfsParseQGroupUsage(t *testing.T) {
+	tests := []struct {
+		name       string
+		output     string
+		referenced int64
+		exclusive  int64
+		err        error
+	}{
+		{
+			"Older btrfs-progs",
+			"qgroupid         rfer         excl     max_excl \n--------         ----         ----     -------- \n0/257        16384        16384         none \n",
+			16384,
+			16384,
+			nil,
+		},
+		{
+			"Newer btrfs-progs",
+			"Qgroupid    Referenced    Exclusive   Max exclusive   Path \n--------    ----------    ---------   -------------   ---- \n0/258          2097152        65536            none   containers/c1\n",
+			2097152,
+			65536,
+			nil,
+		},
+		{
+			"No quota group",
+			"qgroupid         rfer         excl     max_excl \n--------         ----         ----     -------- \n",
+			-1,
+			-1,
+			errBtrfsNoQGroup,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			referenced, exclusive, err := btrfsParseQGroupUsage(tt.output)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.referenced, referenced)
+			assert.Equal(t, tt.exclusive, exclusive)
+		})
+	}
+
+	_, _, err := btrfsParseQGroupUsage("0/257 16384 invalid none\n")
+	assert.Error(t, err)
+}
@@ -1029,6 +1029,43 @@ func (d *btrfs) GetVolumeUsage(vol Volume) (int64, error) {
 	return usage, nil
 }
 
+// GetVolumeSpaceUsage returns the breakdown of the space used by the volume and the given snapshots.
+// Snapshots are separate subvolumes so the space they only use is the exclusive space of their qgroups.
+func (d *btrfs) GetVolumeSpaceUsage(vol Volume, snapshots []string) (*VolumeSpaceUsage, error) {
+	referenced, _, err := d.getQGroupUsage(vol.MountPath())
+	if err != nil {
+		if errors.Is(err, errBtrfsNoQuota) || errors.Is(err, errBtrfsNoQGroup) {
+			return nil, ErrNotSupported
+		}
+
+		return nil, err
+	}
+
+	usage := &VolumeSpaceUsage{
+		Referenced: referenced,
+		Snapshots:  0,
+		Snapshot:   make(map[string]VolumeSnapshotSpaceUsage, len(snapshots)),
+	}
+
+	for _, snapName := range snapshots {
+		snapVol, err := vol.NewSnapshot(snapName)
+		if err != nil {
+			return nil, err
+		}
+
+		snapReferenced, snapExclusive, err := d.getQGroupUsage(snapVol.MountPath())
+		if err != nil {
+			usage.Snapshot[snapName] = VolumeSnapshotSpaceUsage{Referenced: -1, Freed: -1}
+			continue
+		}
+
+		usage.Snapshot[snapName] = VolumeSnapshotSpaceUsage{Referenced: snapReferenced, Freed: snapExclusive}
+		usage.Snapshots += snapExclusive
+	}
+
+	return usage, nil
+}
+
 // SetVolumeQuota applies a size limit on volume.
 // Does nothing if supplied with an empty/zero size for block volumes, and for filesystem volumes removes quota.
 func (d *btrfs) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, op *operations.Operation) error {
@@ -1,6 +1,7 @@
 package drivers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -1172,6 +1173,49 @@ func (d *ceph) generateUUID(fsType string, devPath string) error {
 	return nil
 }
 
+// cephDuLine represents the usage of an RBD image or snapshot as reported by rbd du.
+type cephDuLine struct {
+	Name            string `json:"name"`
+	Snapshot        string `json:"snapshot"`
+	ProvisionedSize int64  `json:"provisioned_size"`
+	UsedSize        int64  `json:"used_size"`
+}
+
+// rbdDiskUsage returns the usage of the RBD image of the volume and of all its snapshots.
+func (d *ceph) rbdDiskUsage(vol Volume) ([]cephDuLine, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+	defer cancel()
+
+	jsonInfo, err := subprocess.RunCommandContext(ctx,
+		"rbd",
+		"du",
+		"--format", "json",
+		"--id", d.config["ceph.user.name"],
+		"--cluster", d.config["ceph.cluster_name"],
+		"--pool", d.config["ceph.osd.pool_name"],
+		d.getRBDVolumeName(vol, "", false),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return cephParseDiskUsage(jsonInfo)
+}
+
+// cephParseDiskUsage parses the output of "rbd du --format json".
+func cephParseDiskUsage(jsonInfo string) ([]cephDuLine, error) {
+	var result struct {
+		Images []cephDuLine `json:"images"`
+	}
+
+	err := json.Unmarshal([]byte(jsonInfo), &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Images, nil
+}
+
 func (d *ceph) getRBDVolumeName(vol Volume, snapName string, withPoolName bool) string {
 	out := CephGetRBDImageName(vol, snapName, vol.isDeleted)
 
@@ -3,6 +3,8 @@ package drivers
 import (
 	"fmt"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func Test_ceph_getRBDVolumeName(t *testing.T) {
@@ -226,3 +228,17 @@ func Example_ceph_parseParent() {
 	//   contentType: filesystem
 	//   config: map[]
 }
+
+func Test_cephParseDiskUsage(t *testing.T) {
+	output := `{"images":[{"name":"container_c1","snapshot":"snapshot_snap0","id":6,"provisioned_size":10737418240,"used_size":41943040},{"name":"container_c1","id":6,"provisioned_size":10737418240,"used_size":8388608}],"total_provisioned_size":21474836480,"total_used_size":50331648}`
+
+	images, err := cephParseDiskUsage(output)
+	assert.NoError(t, err)
+	assert.Equal(t, []cephDuLine{
+		{Name: "container_c1", Snapshot: "snapshot_snap0", ProvisionedSize: 10737418240, UsedSize: 41943040},
+		{Name: "container_c1", ProvisionedSize: 10737418240, UsedSize: 8388608},
+	}, images)
+
+	_, err = cephParseDiskUsage("rbd: error opening image")
+	assert.Error(t, err)
+}
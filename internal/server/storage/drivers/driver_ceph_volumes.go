@@ -899,46 +899,18 @@ func (d *ceph) GetVolumeUsage(vol Volume) (int64, error) {
 	// of all snapshots with the delta since last snapshot. This leads to
 	// volumes with lots of changes between snapshots potentially adding up far
 	// more usage than they actually have.
-	type cephDuLine struct {
-		Name            string `json:"name"`
-		Snapshot        string `json:"snapshot"`
-		ProvisionedSize int64  `json:"provisioned_size"`
-		UsedSize        int64  `json:"used_size"`
-	}
-
-	type cephDuInfo struct {
-		Images []cephDuLine `json:"images"`
-	}
-
-	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
-	defer cancel()
-
-	jsonInfo, err := subprocess.RunCommandContext(ctx,
-		"rbd",
-		"du",
-		"--format", "json",
-		"--id", d.config["ceph.user.name"],
-		"--cluster", d.config["ceph.cluster_name"],
-		"--pool", d.config["ceph.osd.pool_name"],
-		d.getRBDVolumeName(vol, "", false),
-	)
+	images, err := d.rbdDiskUsage(vol)
 	if err != nil {
 		return -1, err
 	}
 
 	var usedSize int64
-	var result cephDuInfo
-
-	err = json.Unmarshal([]byte(jsonInfo), &result)
-	if err != nil {
-		return -1, err
-	}
 
 	_, snapName, _ := api.GetParentAndSnapshotName(vol.Name())
 	snapName = fmt.Sprintf("snapshot_%s", snapName)
 
 	// rbd du gives the output of all related rbd images, snapshots included.
-	for _, image := range result.Images {
+	for _, image := range images {
 		if isSnap {
 			// For snapshot volumes we only want to get the specific image used so we can
 			// indicate how much CoW usage that snapshot has.
@@ -956,6 +928,47 @@ func (d *ceph) GetVolumeUsage(vol Volume) (int64, error) {
 	return usedSize, nil
 }
 
+// GetVolumeSpaceUsage returns the breakdown of the space used by the volume and the given snapshots.
+// RBD only reports the space written between a snapshot and the previous one, which is used as an
+// estimate of the space freed by deleting the snapshot.
+func (d *ceph) GetVolumeSpaceUsage(vol Volume, snapshots []string) (*VolumeSpaceUsage, error) {
+	if util.IsFalse(d.config["ceph.rbd.du"]) {
+		return nil, ErrNotSupported
+	}
+
+	images, err := d.rbdDiskUsage(vol)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &VolumeSpaceUsage{
+		Referenced: -1,
+		Snapshots:  0,
+		Snapshot:   make(map[string]VolumeSnapshotSpaceUsage, len(snapshots)),
+	}
+
+	snapUsages := map[string]int64{}
+	for _, image := range images {
+		if image.Snapshot == "" {
+			continue
+		}
+
+		snapUsages[image.Snapshot] = image.UsedSize
+		usage.Snapshots += image.UsedSize
+	}
+
+	for _, snapName := range snapshots {
+		usedSize, ok := snapUsages[fmt.Sprintf("snapshot_%s", snapName)]
+		if !ok {
+			usedSize = -1
+		}
+
+		usage.Snapshot[snapName] = VolumeSnapshotSpaceUsage{Referenced: -1, Freed: usedSize}
+	}
+
+	return usage, nil
+}
+
 // SetVolumeQuota applies a size limit on volume.
 // Does nothing if supplied with an empty/zero size.
 func (d *ceph) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, op *operations.Operation) error {
@@ -386,6 +386,11 @@ func (d *common) GetVolumeUsage(vol Volume) (int64, error) {
 	return -1, ErrNotSupported
 }
 
+// GetVolumeSpaceUsage returns the breakdown of the space used by a volume and the given snapshots.
+func (d *common) GetVolumeSpaceUsage(vol Volume, snapshots []string) (*VolumeSpaceUsage, error) {
+	return nil, ErrNotSupported
+}
+
 // SetVolumeQuota applies a size limit on volume.
 func (d *common) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, op *operations.Operation) error {
 	return ErrNotSupported
@@ -763,6 +763,12 @@ func (d *lvm) thinPoolVolumeUsage(volDevPath string) (uint64, uint64, error) {
 		return 0, 0, err
 	}
 
+	return lvmParseThinVolumeUsage(out)
+}
+
+// lvmParseThinVolumeUsage parses the total and used size of a thin volume from the output of
+// "lvs -o lv_size,data_percent" (in bytes, without headings and comma separated).
+func lvmParseThinVolumeUsage(out string) (uint64, uint64, error) {
 	parts := util.SplitNTrimSpace(out, ",", -1, true)
 	if len(parts) < 2 {
 		return 0, 0, fmt.Errorf("Unexpected output from lvs command")
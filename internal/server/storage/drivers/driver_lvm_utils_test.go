@@ -2,6 +2,9 @@ package drivers
 
 import (
 	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func Example_lvm_parseLogicalVolumeName() {
@@ -66,3 +69,20 @@ func Example_lvm_parseLogicalVolumeName() {
 	// custom_proj_testvol--with--hyphens.block: Unrecognised
 	// custom_proj_testvol--with--hyphens.block-snap1--with--hyphens.block: snap1-with-hyphens.block
 }
+
+func Test_lvmParseThinVolumeUsage(t *testing.T) {
+	total, used, err := lvmParseThinVolumeUsage("  10737418240,25.00\n")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10737418240), total)
+	assert.Equal(t, uint64(2684354560), used)
+
+	// The used percentage isn't reported for inactive volumes.
+	_, _, err = lvmParseThinVolumeUsage("  10737418240,\n")
+	assert.ErrorIs(t, err, ErrNotSupported)
+
+	_, _, err = lvmParseThinVolumeUsage("  10737418240\n")
+	assert.Error(t, err)
+
+	_, _, err = lvmParseThinVolumeUsage("  invalid,25.00\n")
+	assert.Error(t, err)
+}
@@ -405,6 +405,58 @@ func (d *lvm) GetVolumeUsage(vol Volume) (int64, error) {
 	return -1, ErrNotSupported
 }
 
+// GetVolumeSpaceUsage returns the breakdown of the space used by the volume and the given snapshots.
+// Thin volumes and snapshots report the space allocated to them from the thin pool, which they may share,
+// whereas non-thin snapshots only allocate space for the data changed since they were taken.
+func (d *lvm) GetVolumeSpaceUsage(vol Volume, snapshots []string) (*VolumeSpaceUsage, error) {
+	usage := &VolumeSpaceUsage{
+		Referenced: -1,
+		Snapshots:  -1,
+		Snapshot:   make(map[string]VolumeSnapshotSpaceUsage, len(snapshots)),
+	}
+
+	thin := d.usesThinpool()
+	if thin {
+		volDevPath := d.lvmDevPath(d.config["lvm.vg_name"], vol.volType, vol.contentType, vol.name)
+		_, usedSize, err := d.thinPoolVolumeUsage(volDevPath)
+		if err == nil {
+			usage.Referenced = int64(usedSize)
+		}
+	} else {
+		usage.Snapshots = 0
+	}
+
+	for _, snapName := range snapshots {
+		snapUsage := VolumeSnapshotSpaceUsage{Referenced: -1, Freed: -1}
+
+		snapVol, err := vol.NewSnapshot(snapName)
+		if err != nil {
+			return nil, err
+		}
+
+		snapDevPath := d.lvmDevPath(d.config["lvm.vg_name"], snapVol.volType, snapVol.contentType, snapVol.name)
+		_, usedSize, err := d.thinPoolVolumeUsage(snapDevPath)
+		if err != nil {
+			// The usage isn't known when the snapshot isn't active.
+			if !thin {
+				usage.Snapshots = -1
+			}
+		} else if thin {
+			snapUsage.Referenced = int64(usedSize)
+		} else {
+			snapUsage.Freed = int64(usedSize)
+
+			if usage.Snapshots >= 0 {
+				usage.Snapshots += int64(usedSize)
+			}
+		}
+
+		usage.Snapshot[snapName] = snapUsage
+	}
+
+	return usage, nil
+}
+
 // SetVolumeQuota applies a size limit on volume.
 // Does nothing if supplied with an empty/zero size.
 func (d *lvm) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, op *operations.Operation) error {
@@ -29,3 +29,18 @@ type VolumeFiller struct {
 
 	Fingerprint string // If the Filler will unpack an image, it should be this fingerprint.
 }
+
+// VolumeSpaceUsage represents the breakdown of the space used by a volume and its snapshots.
+// Values which can't be determined by the driver are set to -1.
+type VolumeSpaceUsage struct {
+	Referenced int64                               // Space referenced by the current data of the volume (shared with snapshots or not).
+	Snapshots  int64                               // Space only used by the snapshots of the volume.
+	Snapshot   map[string]VolumeSnapshotSpaceUsage // Space used by each snapshot (indexed by snapshot name).
+}
+
+// VolumeSnapshotSpaceUsage represents the space used by a volume snapshot.
+// Values which can't be determined by the driver are set to -1.
+type VolumeSnapshotSpaceUsage struct {
+	Referenced int64 // Space referenced by the snapshot.
+	Freed      int64 // Estimated space freed by deleting the snapshot.
+}
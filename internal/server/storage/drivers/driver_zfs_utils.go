@@ -8,6 +8,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/google/uuid"
@@ -472,3 +473,31 @@ func (d *zfs) delegateDataset(vol Volume, pid int) error {
 func ZFSSupportsDelegation() bool {
 	return zfsDelegate
 }
+
+// zfsParseSize parses a size in bytes as reported by "zfs get -p", returning -1 if it isn't known.
+func zfsParseSize(value string) int64 {
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return -1
+	}
+
+	return size
+}
+
+// zfsParseSnapshotSpaceUsage parses the output of "zfs list -H -p -o name,used,referenced" for snapshots.
+func zfsParseSnapshotSpaceUsage(output string) map[string]VolumeSnapshotSpaceUsage {
+	snapUsages := map[string]VolumeSnapshotSpaceUsage{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 3 {
+			continue
+		}
+
+		snapUsages[fields[0]] = VolumeSnapshotSpaceUsage{
+			Freed:      zfsParseSize(fields[1]),
+			Referenced: zfsParseSize(fields[2]),
+		}
+	}
+
+	return snapUsages
+}
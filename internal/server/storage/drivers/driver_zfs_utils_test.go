@@ -0,0 +1,30 @@
+package drivers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_zfsParseSize(t *testing.T) {
+	assert.Equal(t, int64(1048576), zfsParseSize("1048576"))
+	assert.Equal(t, int64(0), zfsParseSize("0"))
+	assert.Equal(t, int64(-1), zfsParseSize("-"))
+	assert.Equal(t, int64(-1), zfsParseSize(""))
+}
+
+func Test_zfsParseSnapshotSpaceUsage(t *testing.T) {
+	output := "pool/containers/c1@snapshot-snap0\t12288\t1048576\n" +
+		"pool/containers/c1@snapshot-snap1\t0\t2097152\n" +
+		"pool/containers/c1@snapshot-snap2\t-\t-\n" +
+		"invalid line\n"
+
+	usages := zfsParseSnapshotSpaceUsage(output)
+	assert.Equal(t, map[string]VolumeSnapshotSpaceUsage{
+		"pool/containers/c1@snapshot-snap0": {Freed: 12288, Referenced: 1048576},
+		"pool/containers/c1@snapshot-snap1": {Freed: 0, Referenced: 2097152},
+		"pool/containers/c1@snapshot-snap2": {Freed: -1, Referenced: -1},
+	}, usages)
+
+	assert.Empty(t, zfsParseSnapshotSpaceUsage(""))
+}
@@ -1752,6 +1752,42 @@ func (d *zfs) GetVolumeUsage(vol Volume) (int64, error) {
 	return valueInt, nil
 }
 
+// GetVolumeSpaceUsage returns the breakdown of the space used by the volume and the given snapshots.
+func (d *zfs) GetVolumeSpaceUsage(vol Volume, snapshots []string) (*VolumeSpaceUsage, error) {
+	dataset := d.dataset(vol, false)
+
+	props, err := d.getDatasetProperties(dataset, "referenced", "usedbysnapshots")
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &VolumeSpaceUsage{
+		Referenced: zfsParseSize(props["referenced"]),
+		Snapshots:  zfsParseSize(props["usedbysnapshots"]),
+		Snapshot:   make(map[string]VolumeSnapshotSpaceUsage, len(snapshots)),
+	}
+
+	// Get the usage of all the snapshots at once, the "used" property of a snapshot is the space
+	// which is only used by that snapshot and so freed when deleting it.
+	output, err := subprocess.RunCommand("zfs", "list", "-H", "-p", "-t", "snapshot", "-d", "1", "-o", "name,used,referenced", dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	snapUsages := zfsParseSnapshotSpaceUsage(output)
+
+	for _, snapName := range snapshots {
+		snapUsage, ok := snapUsages[fmt.Sprintf("%s@snapshot-%s", dataset, snapName)]
+		if !ok {
+			snapUsage = VolumeSnapshotSpaceUsage{Referenced: -1, Freed: -1}
+		}
+
+		usage.Snapshot[snapName] = snapUsage
+	}
+
+	return usage, nil
+}
+
 // SetVolumeQuota sets the quota/reservation on the volume.
 // Does nothing if supplied with an empty/zero size for block volumes.
 func (d *zfs) SetVolumeQuota(vol Volume, size string, allowUnsafeResize bool, op *operations.Operation) error {
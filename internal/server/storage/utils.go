@@ -6,9 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"math"
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -489,6 +491,7 @@ func poolAndVolumeCommonRules(vol *drivers.Volume) map[string]func(string) error
 		},
 		"snapshots.schedule": validate.Optional(validate.IsCron([]string{"@hourly", "@daily", "@midnight", "@weekly", "@monthly", "@annually", "@yearly"})),
 		"snapshots.pattern":  validate.IsAny,
+		"snapshots.max":      validate.Optional(validate.IsInRange(1, math.MaxUint32)),
 	}
 
 	// Options relevant for custom filesystem volumes.
@@ -552,9 +555,31 @@ func validateVolumeCommonRules(vol drivers.Volume) map[string]func(string) error
 		rules["volatile.rootfs.size"] = validate.Optional(validate.IsInt64)
 	}
 
+	// I/O limits are only relevant for volumes that get attached to instances.
+	if slices.Contains([]drivers.VolumeType{drivers.VolumeTypeCustom, drivers.VolumeTypeContainer, drivers.VolumeTypeVM}, vol.Type()) {
+		rules["limits.read"] = validate.Optional(validateIOLimit)
+		rules["limits.write"] = validate.Optional(validateIOLimit)
+		rules["limits.max"] = validate.Optional(validateIOLimit)
+	}
+
 	return rules
 }
 
+// validateIOLimit validates an I/O limit expressed either in bytes per second or in IOPS.
+func validateIOLimit(value string) error {
+	iops, isIOPS := strings.CutSuffix(value, "iops")
+	if isIOPS {
+		_, err := strconv.ParseUint(iops, 10, 64)
+		if err != nil {
+			return fmt.Errorf("Invalid IOPS limit %q", value)
+		}
+
+		return nil
+	}
+
+	return validate.IsSize(value)
+}
+
 // ImageUnpack unpacks a filesystem image into the destination path.
 // There are several formats that images can come in:
 // Container Format A: Separate metadata tarball and root squashfs file.
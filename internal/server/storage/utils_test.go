@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateIOLimit(t *testing.T) {
+	for _, value := range []string{"0", "10MB", "1GiB", "100iops", "0iops"} {
+		assert.NoError(t, validateIOLimit(value), value)
+	}
+
+	for _, value := range []string{"fast", "iops", "-10iops", "1.5iops", "10MBiops", "10 iops"} {
+		assert.Error(t, validateIOLimit(value), value)
+	}
+}
+
+func TestVolumeLimitsChanged(t *testing.T) {
+	assert.False(t, volumeLimitsChanged(map[string]string{}))
+	assert.False(t, volumeLimitsChanged(map[string]string{"size": "10GiB"}))
+	assert.True(t, volumeLimitsChanged(map[string]string{"limits.read": "10MB"}))
+	assert.True(t, volumeLimitsChanged(map[string]string{"limits.max": ""}))
+}
@@ -480,6 +480,29 @@ var APIExtensions = []string{
 	"server_logging",
 	"network_forward_snat",
 	"memory_hotplug",
+	"nic_nat_address",
+	"instances_rebuild_upgrade",
+	"instances_convert",
+	"backup_encryption",
+	"operations_scheduler",
+	"oci_registry_auth",
+	"nic_live_network_move",
+	"network_acl_counters",
+	"network_bridge_ha_gateway",
+	"network_acls_mandatory",
+	"storage_volume_limits",
+	"instance_wait",
+	"instance_lxc_scriptlet",
+	"qemu_scriptlet_stages",
+	"instance_migration_check",
+	"project_limits_storage",
+	"storage_volume_state_space",
+	"image_channels",
+	"changes_api",
+	"instance_attestation",
+	"network_dhcp_relay",
+	"cluster_enrollment",
+	"metadata_configuration_validation",
 }
 
 // APIExtensionsCount returns the number of available API extensions.
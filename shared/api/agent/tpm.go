@@ -0,0 +1,42 @@
+package api
+
+// TPM contains the identity of the instance's TPM used for attestation.
+type TPM struct {
+	// Endorsement key certificate as PEM encoded X509
+	// Example: X509 PEM certificate
+	EKCertificate string `json:"ek_certificate" yaml:"ek_certificate"`
+
+	// Public area (TPM2B_PUBLIC) of the attestation key
+	AKPublic []byte `json:"ak_public" yaml:"ak_public"`
+}
+
+// TPMQuotePost represents the fields of a TPM quote request.
+type TPMQuotePost struct {
+	// Credential (tpm2-tools format) to activate with the attestation key
+	Credential []byte `json:"credential" yaml:"credential"`
+
+	// Nonce to include in the quote
+	Nonce []byte `json:"nonce" yaml:"nonce"`
+
+	// List of SHA256 PCRs to quote
+	// Example: [0, 1, 2, 3, 4, 5, 6, 7]
+	PCRs []int `json:"pcrs" yaml:"pcrs"`
+}
+
+// TPMQuote represents a TPM quote along with the measurements it covers.
+type TPMQuote struct {
+	// Secret recovered from the credential
+	Secret []byte `json:"secret" yaml:"secret"`
+
+	// Quoted attestation structure (TPMS_ATTEST)
+	Quote []byte `json:"quote" yaml:"quote"`
+
+	// Signature of the quote by the attestation key
+	Signature []byte `json:"signature" yaml:"signature"`
+
+	// Values of the quoted SHA256 PCRs
+	PCRs map[int][]byte `json:"pcrs" yaml:"pcrs"`
+
+	// Binary TPM event log of the firmware
+	EventLog []byte `json:"event_log" yaml:"event_log"`
+}
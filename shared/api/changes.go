@@ -0,0 +1,58 @@
+package api
+
+import (
+	"time"
+)
+
+// Changes represents the entities modified since a given generation.
+//
+// swagger:model
+//
+// API extension: changes_api.
+type Changes struct {
+	// Identifier of the change log, generations from a different epoch can't be compared
+	// Example: 7b3f5c1e-2a4b-4e0c-9a51-3c1fd1c3b0d4
+	Epoch string `json:"epoch" yaml:"epoch"`
+
+	// Current generation
+	// Example: 1042
+	Generation uint64 `json:"generation" yaml:"generation"`
+
+	// Whether some of the changes since the requested generation are no longer known (full reload needed)
+	// Example: false
+	Reset bool `json:"reset" yaml:"reset"`
+
+	// List of modified entities (most recent last)
+	Entities []ChangesEntity `json:"entities" yaml:"entities"`
+}
+
+// ChangesEntity represents an entity that was modified.
+//
+// swagger:model
+//
+// API extension: changes_api.
+type ChangesEntity struct {
+	// URL of the entity
+	// Example: /1.0/instances/c1?project=default
+	URL string `json:"url" yaml:"url"`
+
+	// Project the entity belongs to (empty for global entities)
+	// Example: default
+	Project string `json:"project" yaml:"project"`
+
+	// Last action performed on the entity
+	// Example: instance-updated
+	Action string `json:"action" yaml:"action"`
+
+	// Generation at which the entity was last modified
+	// Example: 1041
+	Generation uint64 `json:"generation" yaml:"generation"`
+
+	// Time at which the entity was last modified
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+
+	// Cluster member on which the change happened
+	// Example: server01
+	Location string `json:"location" yaml:"location"`
+}
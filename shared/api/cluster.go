@@ -136,6 +136,80 @@ func (t *ClusterMemberJoinToken) String() string {
 	return base64.StdEncoding.EncodeToString(joinTokenJSON)
 }
 
+// ClusterEnrollmentStatusPending is the status of enrollments waiting for approval.
+const ClusterEnrollmentStatusPending = "pending"
+
+// ClusterEnrollmentStatusApproved is the status of enrollments allowed to join the cluster.
+const ClusterEnrollmentStatusApproved = "approved"
+
+// ClusterEnrollmentStatusJoining is the status of enrollments whose join token was used, until the server joins.
+const ClusterEnrollmentStatusJoining = "joining"
+
+// ClusterEnrollmentsPost represents the fields of a new server asking to join the cluster.
+//
+// swagger:model
+//
+// API extension: cluster_enrollment.
+type ClusterEnrollmentsPost struct {
+	// The name of the new cluster member
+	// Example: server02
+	ServerName string `json:"server_name" yaml:"server_name"`
+
+	// The enrollment secret of the cluster group
+	// Example: blah
+	Secret string `json:"secret" yaml:"secret"`
+
+	// The cluster group to enroll into
+	// Example: edge
+	Group string `json:"group" yaml:"group"`
+}
+
+// ClusterEnrollment represents an enrollment request from a new server.
+//
+// swagger:model
+//
+// API extension: cluster_enrollment.
+type ClusterEnrollment struct {
+	// Enrollment identifier
+	// Example: 8b0d0bb3-f6a6-4b53-a4b5-a0e7c3a1b9d2
+	ID string `json:"id" yaml:"id"`
+
+	// The name of the new cluster member
+	// Example: server02
+	ServerName string `json:"server_name" yaml:"server_name"`
+
+	// Address the enrollment request came from
+	// Example: 10.0.0.2
+	Address string `json:"address" yaml:"address"`
+
+	// Fingerprint of the certificate of the new server
+	// Example: 57bb0ff4340b5bb28517e062023101adf788c37846dc8b619eb2c3cb4ef29436
+	Fingerprint string `json:"fingerprint" yaml:"fingerprint"`
+
+	// The cluster group the server enrolls into
+	// Example: edge
+	Group string `json:"group" yaml:"group"`
+
+	// Status of the enrollment (pending, approved or joining)
+	// Example: pending
+	Status string `json:"status" yaml:"status"`
+
+	// When the enrollment was requested
+	// Example: 2021-03-23T16:38:37.753398689-04:00
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+
+	// When the enrollment expires
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	ExpiresAt time.Time `json:"expires_at" yaml:"expires_at"`
+
+	// Join token, only provided to the enrolling server once approved
+	// Example: eyJzZXJ2ZXJfbmFtZSI6InNlcnZlcjAyIi...
+	JoinToken string `json:"join_token,omitempty" yaml:"join_token,omitempty"`
+
+	// Member configuration to use when joining
+	MemberConfig []ClusterMemberConfigKey `json:"member_config" yaml:"member_config"`
+}
+
 // ClusterMemberPost represents the fields required to rename a cluster member.
 //
 // swagger:model
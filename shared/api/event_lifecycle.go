@@ -8,6 +8,9 @@ const (
 	EventLifecycleClusterCertificateUpdated         = "cluster-certificate-updated"
 	EventLifecycleClusterDisabled                   = "cluster-disabled"
 	EventLifecycleClusterEnabled                    = "cluster-enabled"
+	EventLifecycleClusterEnrollmentApproved         = "cluster-enrollment-approved"
+	EventLifecycleClusterEnrollmentCreated          = "cluster-enrollment-created"
+	EventLifecycleClusterEnrollmentRejected         = "cluster-enrollment-rejected"
 	EventLifecycleClusterGroupCreated               = "cluster-group-created"
 	EventLifecycleClusterGroupDeleted               = "cluster-group-deleted"
 	EventLifecycleClusterGroupRenamed               = "cluster-group-renamed"
@@ -35,6 +38,7 @@ const (
 	EventLifecycleInstanceBackupDeleted             = "instance-backup-deleted"
 	EventLifecycleInstanceBackupRenamed             = "instance-backup-renamed"
 	EventLifecycleInstanceBackupRetrieved           = "instance-backup-retrieved"
+	EventLifecycleInstanceAttestationFailed         = "instance-attestation-failed"
 	EventLifecycleInstanceConsole                   = "instance-console"
 	EventLifecycleInstanceConsoleReset              = "instance-console-reset"
 	EventLifecycleInstanceConsoleRetrieved          = "instance-console-retrieved"
@@ -99,6 +99,11 @@ type ImagesPostSource struct {
 	//
 	// API extension: image_source_project
 	Project string `json:"project" yaml:"project"`
+
+	// Credentials and settings for the source OCI registry
+	//
+	// API extension: oci_registry_auth
+	RegistryAuth *ImageRegistryAuth `json:"registry_auth,omitempty" yaml:"registry_auth,omitempty"`
 }
 
 // ImagePut represents the modifiable fields of an image
@@ -237,6 +242,37 @@ type ImageSource struct {
 	ImageType string `json:"image_type" yaml:"image_type"`
 }
 
+// ImageRegistryAuth represents the credentials and settings used to access an authenticated OCI registry
+//
+// swagger:model
+//
+// API extension: oci_registry_auth.
+type ImageRegistryAuth struct {
+	// Registry username
+	// Example: robot$incus
+	Username string `json:"username,omitempty" yaml:"username,omitempty"`
+
+	// Registry password or access token
+	// Example: RANDOM-STRING
+	Password string `json:"password,omitempty" yaml:"password,omitempty"`
+
+	// Content of a container registry auth file (docker "config.json" or "auth.json")
+	// Example: {"auths": {"registry.example.com": {"auth": "BASE64-STRING"}}}
+	AuthFile string `json:"auth_file,omitempty" yaml:"auth_file,omitempty"`
+
+	// CA certificate used to validate the registry (and its mirrors)
+	// Example: X509 PEM certificate
+	CACertificate string `json:"ca_certificate,omitempty" yaml:"ca_certificate,omitempty"`
+
+	// Mirrors to try, in order, before the registry itself
+	// Example: ["https://mirror.example.com"]
+	Mirrors []string `json:"mirrors,omitempty" yaml:"mirrors,omitempty"`
+
+	// Whether to skip TLS verification and allow plain HTTP
+	// Example: false
+	Insecure bool `json:"insecure,omitempty" yaml:"insecure,omitempty"`
+}
+
 // ImageAliasesPost represents a new image alias
 //
 // swagger:model
@@ -251,6 +287,12 @@ type ImageAliasesEntryPost struct {
 	// Alias name
 	// Example: ubuntu-22.04
 	Name string `json:"name" yaml:"name"`
+
+	// Point the alias with the new name to the target of this one (creating it if needed) instead of renaming
+	// Example: false
+	//
+	// API extension: image_channels
+	Promote bool `json:"promote" yaml:"promote"`
 }
 
 // ImageAliasesEntryPut represents the modifiable fields of an image alias
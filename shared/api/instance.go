@@ -127,6 +127,40 @@ type InstancePost struct {
 	//
 	// API extension: instance_move_config
 	Profiles []string
+
+	// Only check whether the migration can be performed
+	// Example: false
+	//
+	// API extension: instance_migration_check
+	CheckOnly bool `json:"check_only" yaml:"check_only"`
+}
+
+// InstanceMigrationCheck represents the result of a migration pre-flight check.
+//
+// swagger:model
+//
+// API extension: instance_migration_check.
+type InstanceMigrationCheck struct {
+	// Problems preventing the migration
+	Blockers []InstanceMigrationCheckEntry `json:"blockers" yaml:"blockers"`
+
+	// Problems which may affect the migrated instance
+	Warnings []InstanceMigrationCheckEntry `json:"warnings" yaml:"warnings"`
+}
+
+// InstanceMigrationCheckEntry represents a single problem found by a migration pre-flight check.
+//
+// swagger:model
+//
+// API extension: instance_migration_check.
+type InstanceMigrationCheckEntry struct {
+	// Area the problem relates to (cpu, memory, storage, network, device, driver or instance)
+	// Example: storage
+	Type string `json:"type" yaml:"type"`
+
+	// Description of the problem
+	// Example: Storage pool "local" doesn't exist on the target
+	Description string `json:"description" yaml:"description"`
 }
 
 // InstancePostTarget represents the migration target host and operation.
@@ -195,6 +229,65 @@ type InstancePut struct {
 type InstanceRebuildPost struct {
 	// Rebuild source
 	Source InstanceSource `json:"source" yaml:"source"`
+
+	// Whether to perform a safe upgrade (snapshot, health check and automatic rollback)
+	// Example: true
+	//
+	// API extension: instances_rebuild_upgrade
+	Upgrade bool `json:"upgrade" yaml:"upgrade"`
+
+	// Health check to run after an upgrade
+	//
+	// API extension: instances_rebuild_upgrade
+	HealthCheck *InstanceRebuildHealthCheck `json:"health_check,omitempty" yaml:"health_check,omitempty"`
+}
+
+// InstanceRebuildHealthCheck represents the health check run after an instance upgrade.
+//
+// swagger:model
+//
+// API extension: instances_rebuild_upgrade.
+type InstanceRebuildHealthCheck struct {
+	// Command to run inside the instance (defaults to checking that a command can be run, which for VMs requires the agent to be responsive)
+	// Example: ["systemctl", "is-system-running", "--wait"]
+	Command []string `json:"command" yaml:"command"`
+
+	// How long to wait for the health check to pass (in seconds)
+	// Example: 300
+	Timeout int `json:"timeout" yaml:"timeout"`
+}
+
+// InstanceWaitPost represents the fields required to wait for an instance to reach a condition.
+//
+// swagger:model
+//
+// API extension: instance_wait.
+type InstanceWaitPost struct {
+	// Condition to wait for (running, agent, ip, cloud-init, healthy or stopped)
+	// Example: cloud-init
+	For string `json:"for" yaml:"for"`
+
+	// How long to wait for the condition (in seconds, 0 means no timeout)
+	// Example: 300
+	Timeout int `json:"timeout" yaml:"timeout"`
+}
+
+// InstanceConvertPost represents the fields required to convert an instance into a new instance of another type.
+//
+// swagger:model
+//
+// API extension: instances_convert.
+type InstanceConvertPost struct {
+	// Name of the new instance
+	// Example: foo-vm
+	Name string `json:"name" yaml:"name"`
+
+	// Type of the new instance
+	// Example: virtual-machine
+	Type InstanceType `json:"type" yaml:"type"`
+
+	// Image providing the kernel and bootloader (defaults to the virtual-machine variant of the instance's image)
+	Source InstanceSource `json:"source" yaml:"source"`
 }
 
 // Instance represents an instance.
@@ -326,6 +419,11 @@ type InstanceSource struct {
 	// Example: RANDOM-STRING
 	Secret string `json:"secret,omitempty" yaml:"secret,omitempty"`
 
+	// Credentials and settings for the remote OCI registry (for remote images)
+	//
+	// API extension: oci_registry_auth
+	RegistryAuth *ImageRegistryAuth `json:"registry_auth,omitempty" yaml:"registry_auth,omitempty"`
+
 	// Protocol name (for remote image)
 	// Example: simplestreams
 	Protocol string `json:"protocol,omitempty" yaml:"protocol,omitempty"`
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"time"
+)
+
+// InstanceAttestation represents the result of the measured boot attestation of an instance.
+//
+// swagger:model
+//
+// API extension: instance_attestation.
+type InstanceAttestation struct {
+	// Whether all the measurements match the reference policy
+	// Example: true
+	Compliant bool `json:"compliant" yaml:"compliant"`
+
+	// List of quoted PCRs
+	PCRs []InstanceAttestationPCR `json:"pcrs" yaml:"pcrs"`
+
+	// Number of events in the TPM event log
+	// Example: 74
+	EventLogEntries int `json:"event_log_entries" yaml:"event_log_entries"`
+
+	// Time at which the measurements were retrieved
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+}
+
+// InstanceAttestationPCR represents the measurement of a single PCR.
+//
+// swagger:model
+//
+// API extension: instance_attestation.
+type InstanceAttestationPCR struct {
+	// PCR index
+	// Example: 7
+	Index int `json:"index" yaml:"index"`
+
+	// Quoted SHA256 value
+	// Example: 65caf8dd1e0ea7a6347b635d2b379c93b9a1351edc2afc3ecda700e534eb3068
+	Value string `json:"value" yaml:"value"`
+
+	// Expected SHA256 value from the reference policy (empty when not covered by the policy)
+	// Example: 65caf8dd1e0ea7a6347b635d2b379c93b9a1351edc2afc3ecda700e534eb3068
+	Expected string `json:"expected" yaml:"expected"`
+
+	// Whether the PCR value matches the reference policy and the event log
+	// Example: true
+	Match bool `json:"match" yaml:"match"`
+}
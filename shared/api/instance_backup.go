@@ -31,6 +31,12 @@ type InstanceBackupsPost struct {
 	//
 	// API extension: backup_compression_algorithm
 	CompressionAlgorithm string `json:"compression_algorithm" yaml:"compression_algorithm"`
+
+	// Recipients to encrypt the backup to (age or SSH public keys, or OpenPGP key fingerprints)
+	// Example: ["age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p"]
+	//
+	// API extension: backup_encryption
+	EncryptionRecipients []string `json:"encryption_recipients,omitempty" yaml:"encryption_recipients,omitempty"`
 }
 
 // InstanceBackup represents an instance backup.
@@ -70,3 +76,14 @@ type InstanceBackupPost struct {
 	// Example: backup1
 	Name string `json:"name" yaml:"name"`
 }
+
+// BackupVerifyPost represents the fields available for verifying an instance, custom volume or storage bucket backup.
+//
+// swagger:model
+//
+// API extension: backup_encryption.
+type BackupVerifyPost struct {
+	// Fingerprint of the certificate expected to have signed the backup (defaults to those of the cluster members)
+	// Example: 2d5f33c5eb2ea8d9abc4b7f2ad2a7c5d8c8d4e4b3e8f5a1b2c3d4e5f60718293
+	Fingerprint string `json:"fingerprint" yaml:"fingerprint"`
+}
@@ -70,6 +70,12 @@ type InstanceState struct {
 	//
 	// API extension: instances_state_os_info.
 	OSInfo *InstanceStateOSInfo `json:"os_info" yaml:"os_info"`
+
+	// Status of cloud-init in the instance (pending, running, done, error or disabled), empty if unavailable
+	// Example: done
+	//
+	// API extension: instance_wait.
+	CloudInit string `json:"cloud_init" yaml:"cloud_init"`
 }
 
 // InstanceStateDisk represents the disk information section of an instance's state.
@@ -106,4 +106,16 @@ type MetadataConfigKey struct {
 	// LongDesc provides long description for the option
 	// Example: "Specify the kernel modules as a comma-separated list."
 	LongDescription string `json:"longdesc" yaml:"longdesc"`
+
+	// AllowedValues lists the accepted values (comma-separated) when restricted to a fixed set
+	// Example: soft, hard
+	//
+	// API extension: metadata_configuration_validation
+	AllowedValues string `json:"allowedvalues,omitempty" yaml:"allowedvalues,omitempty"`
+
+	// Deprecated explains what to use instead when the option is deprecated
+	// Example: Use 'logging.*.target.address' instead
+	//
+	// API extension: metadata_configuration_validation
+	Deprecated string `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
 }
@@ -188,6 +188,11 @@ type NetworkState struct {
 	//
 	// API extension: network_state_ovn
 	OVN *NetworkStateOVN `json:"ovn" yaml:"ovn"`
+
+	// Highly available gateway information
+	//
+	// API extension: network_bridge_ha_gateway
+	Gateway *NetworkStateGateway `json:"gateway" yaml:"gateway"`
 }
 
 // NetworkStateAddress represents a network address
@@ -313,6 +318,25 @@ type NetworkStateVLAN struct {
 	VID uint64 `json:"vid" yaml:"vid"`
 }
 
+// NetworkStateGateway represents the state of a highly available bridge gateway
+//
+// swagger:model
+//
+// API extension: network_bridge_ha_gateway.
+type NetworkStateGateway struct {
+	// Gateway mode
+	// Example: ha
+	Mode string `json:"mode" yaml:"mode"`
+
+	// Name of the cluster member holding the gateway addresses
+	// Example: server01
+	Owner string `json:"owner" yaml:"owner"`
+
+	// Whether the gateway addresses are held by the queried member
+	// Example: true
+	Active bool `json:"active" yaml:"active"`
+}
+
 // NetworkStateOVN represents OVN specific state
 //
 // swagger:model
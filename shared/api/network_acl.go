@@ -161,3 +161,31 @@ type NetworkACLsPost struct {
 	NetworkACLPost `yaml:",inline"`
 	NetworkACLPut  `yaml:",inline"`
 }
+
+// NetworkACLState represents the hit counters of the rules of an ACL.
+//
+// swagger:model
+//
+// API extension: network_acl_counters.
+type NetworkACLState struct {
+	// Counters of the ingress rules (in the same order as the rules)
+	Ingress []NetworkACLRuleCounters `json:"ingress" yaml:"ingress"`
+
+	// Counters of the egress rules (in the same order as the rules)
+	Egress []NetworkACLRuleCounters `json:"egress" yaml:"egress"`
+}
+
+// NetworkACLRuleCounters represents the packets and bytes matched by an ACL rule.
+//
+// swagger:model
+//
+// API extension: network_acl_counters.
+type NetworkACLRuleCounters struct {
+	// Number of packets matched by the rule
+	// Example: 1024
+	Packets int64 `json:"packets" yaml:"packets"`
+
+	// Number of bytes matched by the rule
+	// Example: 65536
+	Bytes int64 `json:"bytes" yaml:"bytes"`
+}
@@ -40,6 +40,12 @@ type StorageBucketBackupsPost struct {
 	// What compression algorithm to use
 	// Example: gzip
 	CompressionAlgorithm string `json:"compression_algorithm" yaml:"compression_algorithm"`
+
+	// Recipients to encrypt the backup to (age or SSH public keys, or OpenPGP key fingerprints)
+	// Example: ["age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p"]
+	//
+	// API extension: backup_encryption
+	EncryptionRecipients []string `json:"encryption_recipients,omitempty" yaml:"encryption_recipients,omitempty"`
 }
 
 // StorageBucketBackupPost represents the fields available for the renaming of a bucket backup
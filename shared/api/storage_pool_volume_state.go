@@ -25,4 +25,36 @@ type StorageVolumeStateUsage struct {
 	//
 	// API extension: storage_volume_state_total
 	Total int64 `json:"total" yaml:"total"`
+
+	// Space referenced by the current data of the volume in bytes (whether shared with snapshots or not)
+	// Example: 1073741824
+	//
+	// API extension: storage_volume_state_space
+	Referenced uint64 `json:"referenced,omitempty" yaml:"referenced,omitempty"`
+
+	// Space only used by the snapshots of the volume in bytes
+	// Example: 620756992
+	//
+	// API extension: storage_volume_state_space
+	UsedBySnapshots uint64 `json:"used_by_snapshots,omitempty" yaml:"used_by_snapshots,omitempty"`
+
+	// Space used by each snapshot of the volume (indexed by snapshot name)
+	//
+	// API extension: storage_volume_state_space
+	Snapshots map[string]StorageVolumeStateUsageSnapshot `json:"snapshots,omitempty" yaml:"snapshots,omitempty"`
+}
+
+// StorageVolumeStateUsageSnapshot represents the disk usage of a volume snapshot
+//
+// swagger:model
+//
+// API extension: storage_volume_state_space.
+type StorageVolumeStateUsageSnapshot struct {
+	// Space referenced by the snapshot in bytes (-1 if unknown)
+	// Example: 1040187392
+	Referenced int64 `json:"referenced" yaml:"referenced"`
+
+	// Estimated space freed by deleting the snapshot in bytes (-1 if unknown)
+	// Example: 209715200
+	Freed int64 `json:"freed" yaml:"freed"`
 }
@@ -0,0 +1,53 @@
+package archive
+
+import (
+	"bytes"
+	"io"
+)
+
+// EncryptionAge is the encryption type of files encrypted with age.
+const EncryptionAge = "age"
+
+// EncryptionOpenPGP is the encryption type of files encrypted with OpenPGP.
+const EncryptionOpenPGP = "openpgp"
+
+// DetectEncryption detects whether the provided header (the first bytes of a file) belongs to an
+// encrypted file and returns the encryption type or an empty string if the file isn't encrypted.
+func DetectEncryption(header []byte) string {
+	// Binary and armored age files.
+	if bytes.HasPrefix(header, []byte("age-encryption.org/")) || bytes.HasPrefix(header, []byte("-----BEGIN AGE ENCRYPTED FILE-----")) {
+		return EncryptionAge
+	}
+
+	// Armored OpenPGP messages.
+	if bytes.HasPrefix(header, []byte("-----BEGIN PGP MESSAGE-----")) {
+		return EncryptionOpenPGP
+	}
+
+	// Binary OpenPGP messages start with a public-key or symmetric-key encrypted session key packet.
+	if len(header) > 0 && header[0]&0x80 != 0 {
+		var tag byte
+		if header[0]&0x40 != 0 {
+			tag = header[0] & 0x3f
+		} else {
+			tag = (header[0] >> 2) & 0x0f
+		}
+
+		if tag == 1 || tag == 3 {
+			return EncryptionOpenPGP
+		}
+	}
+
+	return ""
+}
+
+// DetectEncryptionFile reads the start of the provided file and detects its encryption.
+func DetectEncryptionFile(f io.Reader) (string, error) {
+	header := make([]byte, 64)
+	n, err := io.ReadFull(f, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", err
+	}
+
+	return DetectEncryption(header[:n]), nil
+}
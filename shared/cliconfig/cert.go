@@ -1,9 +1,20 @@
 package cliconfig
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"golang.org/x/crypto/ssh"
 
 	localtls "github.com/lxc/incus/v6/shared/tls"
 	"github.com/lxc/incus/v6/shared/util"
@@ -132,3 +143,181 @@ func (c *Config) CopyGlobalCert(src string, dst string) error {
 
 	return nil
 }
+
+// EncryptClientKey encrypts the client key, as well as the remote-specific client keys, with the provided passphrase.
+// The keys are stored in the OpenSSH format, which is the same as what "ssh-keygen -p -o" produces.
+func (c *Config) EncryptClientKey(passphrase string) error {
+	keys := []string{c.ConfigPath("client.key")}
+
+	remoteKeys, err := filepath.Glob(c.ConfigPath("clientcerts", "*.key"))
+	if err != nil {
+		return err
+	}
+
+	keys = append(keys, remoteKeys...)
+
+	encrypted := false
+	for _, keyf := range keys {
+		if !util.PathExists(keyf) {
+			continue
+		}
+
+		changed, err := encryptClientKeyFile(keyf, passphrase)
+		if err != nil {
+			return err
+		}
+
+		encrypted = encrypted || changed
+	}
+
+	if !encrypted {
+		return fmt.Errorf("The client keys are already encrypted")
+	}
+
+	return nil
+}
+
+// encryptClientKeyFile encrypts the client key stored at the given path, returning whether it wasn't already encrypted.
+func encryptClientKeyFile(keyf string, passphrase string) (bool, error) {
+	content, err := os.ReadFile(keyf)
+	if err != nil {
+		return false, err
+	}
+
+	pemKey, _ := pem.Decode(content)
+	if pemKey == nil {
+		return false, fmt.Errorf("Invalid client key %q", keyf)
+	}
+
+	if pemKey.Type == "OPENSSH PRIVATE KEY" || x509.IsEncryptedPEMBlock(pemKey) { //nolint:staticcheck
+		return false, nil
+	}
+
+	var key crypto.PrivateKey
+	key, err = x509.ParseECPrivateKey(pemKey.Bytes)
+	if err != nil {
+		key, err = x509.ParsePKCS8PrivateKey(pemKey.Bytes)
+		if err != nil {
+			key, err = x509.ParsePKCS1PrivateKey(pemKey.Bytes)
+			if err != nil {
+				return false, fmt.Errorf("Failed to parse client key %q: %w", keyf, err)
+			}
+		}
+	}
+
+	encryptedKey, err := ssh.MarshalPrivateKeyWithPassphrase(key, "", []byte(passphrase))
+	if err != nil {
+		return false, fmt.Errorf("Failed to encrypt client key %q: %w", keyf, err)
+	}
+
+	// Write to a temporary file first so the key never gets truncated.
+	err = os.WriteFile(keyf+".new", pem.EncodeToMemory(encryptedKey), 0o600)
+	if err != nil {
+		return false, err
+	}
+
+	err = os.Rename(keyf+".new", keyf)
+	if err != nil {
+		_ = os.Remove(keyf + ".new")
+		return false, err
+	}
+
+	return true, nil
+}
+
+// decryptClientKey decrypts a passphrase protected client key, returning it in PEM format.
+func decryptClientKey(content []byte, passphrase string) ([]byte, error) {
+	pemKey, _ := pem.Decode(content)
+	if pemKey == nil {
+		return nil, fmt.Errorf("Invalid client key")
+	}
+
+	if pemKey.Type == "OPENSSH PRIVATE KEY" {
+		sshKey, err := ssh.ParseRawPrivateKeyWithPassphrase(content, []byte(passphrase))
+		if err != nil {
+			if errors.Is(err, x509.IncorrectPasswordError) {
+				return nil, errWrongSecret
+			}
+
+			return nil, err
+		}
+
+		ecdsaKey, okEcdsa := (sshKey).(*ecdsa.PrivateKey)
+		rsaKey, okRsa := (sshKey).(*rsa.PrivateKey)
+		if okEcdsa {
+			derKey, err := x509.MarshalECPrivateKey(ecdsaKey)
+			if err != nil {
+				return nil, err
+			}
+
+			return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: derKey}), nil
+		} else if okRsa {
+			derKey := x509.MarshalPKCS1PrivateKey(rsaKey)
+			return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: derKey}), nil
+		}
+
+		return nil, fmt.Errorf("Unsupported key type: %T", sshKey)
+	}
+
+	derKey, err := x509.DecryptPEMBlock(pemKey, []byte(passphrase)) //nolint:staticcheck
+	if err != nil {
+		if errors.Is(err, x509.IncorrectPasswordError) {
+			return nil, errWrongSecret
+		}
+
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: derKey}), nil
+}
+
+// keyringTimeout returns how long passphrases and PINs are kept in the keyring.
+func keyringTimeout() time.Duration {
+	value := os.Getenv("INCUS_KEYRING_TIMEOUT")
+	if value == "" {
+		return 15 * time.Minute
+	}
+
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// withSecret calls fn with the passphrase or PIN protecting the named key.
+// The secret is taken from the keyring cache when available and otherwise prompted for.
+// Secrets which work get cached, the ones which are rejected (fn returns errWrongSecret) are
+// evicted from the cache and prompted for again. Other errors are returned as-is.
+func (c *Config) withSecret(name string, fn func(secret string) error) error {
+	description := fmt.Sprintf("incus:%s", name)
+
+	secret, ok := keyringGet(description)
+	if ok {
+		err := fn(secret)
+		if err == nil || !errors.Is(err, errWrongSecret) {
+			return err
+		}
+
+		keyringRemove(description)
+	}
+
+	if c.PromptPassword == nil {
+		return fmt.Errorf("Private key is password protected and no helper was configured")
+	}
+
+	secret, err := c.PromptPassword(name)
+	if err != nil {
+		return err
+	}
+
+	err = fn(secret)
+	if err != nil {
+		return err
+	}
+
+	keyringSet(description, secret, keyringTimeout())
+
+	return nil
+}
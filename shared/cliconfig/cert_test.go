@@ -0,0 +1,84 @@
+package cliconfig
+
+import (
+	"crypto/tls"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptClientKey(t *testing.T) {
+	c := NewConfig(t.TempDir(), false)
+	require.NoError(t, c.GenerateClientCertificate())
+
+	cert, err := os.ReadFile(c.ConfigPath("client.crt"))
+	require.NoError(t, err)
+
+	key, err := os.ReadFile(c.ConfigPath("client.key"))
+	require.NoError(t, err)
+
+	// Add a remote-specific client key.
+	require.NoError(t, os.MkdirAll(c.ConfigPath("clientcerts"), 0o700))
+	require.NoError(t, os.WriteFile(c.ConfigPath("clientcerts", "remote.crt"), cert, 0o600))
+	require.NoError(t, os.WriteFile(c.ConfigPath("clientcerts", "remote.key"), key, 0o600))
+
+	require.NoError(t, c.EncryptClientKey("passphrase"))
+
+	for _, path := range []string{c.ConfigPath("client.key"), c.ConfigPath("clientcerts", "remote.key")} {
+		encrypted, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(encrypted), "OPENSSH PRIVATE KEY", path)
+
+		// The decrypted key matches the certificate.
+		decrypted, err := decryptClientKey(encrypted, "passphrase")
+		require.NoError(t, err, path)
+
+		_, err = tls.X509KeyPair(cert, decrypted)
+		assert.NoError(t, err, path)
+
+		_, err = decryptClientKey(encrypted, "wrong")
+		assert.ErrorIs(t, err, errWrongSecret, path)
+	}
+
+	// Keys aren't encrypted twice.
+	assert.EqualError(t, c.EncryptClientKey("passphrase"), "The client keys are already encrypted")
+}
+
+func TestWithSecret(t *testing.T) {
+	// Don't use the session keyring.
+	t.Setenv("INCUS_KEYRING_TIMEOUT", "0")
+
+	c := NewConfig(t.TempDir(), false)
+	name := c.ConfigPath("client.key")
+
+	// Without a helper, secrets can't be prompted for.
+	err := c.withSecret(name, func(secret string) error { return nil })
+	assert.EqualError(t, err, "Private key is password protected and no helper was configured")
+
+	prompts := 0
+	c.PromptPassword = func(filename string) (string, error) {
+		prompts++
+		assert.Equal(t, name, filename)
+		return "secret", nil
+	}
+
+	var got string
+	err = c.withSecret(name, func(secret string) error {
+		got = secret
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "secret", got)
+	assert.Equal(t, 1, prompts)
+
+	// Errors are returned as-is.
+	failure := errors.New("Token not present")
+	err = c.withSecret(name, func(secret string) error { return failure })
+	assert.ErrorIs(t, err, failure)
+
+	err = c.withSecret(name, func(secret string) error { return errWrongSecret })
+	assert.ErrorIs(t, err, errWrongSecret)
+}
@@ -39,8 +39,17 @@ type Config struct {
 	// ProjectOverride allows overriding the default project
 	ProjectOverride string `yaml:"-"`
 
+	// PriorityOverride sets the scheduling priority of the operations created through the remotes
+	PriorityOverride string `yaml:"-"`
+
+	// ResponseCache keeps the responses of the remotes in memory, only fetching them again once they changed
+	ResponseCache bool `yaml:"-"`
+
 	// OIDC tokens
 	oidcTokens map[string]*oidc.Tokens[*oidc.IDTokenClaims]
+
+	// PKCS#11 client keys
+	pkcs11Keys map[string]*pkcs11Key
 }
 
 // GlobalConfigPath returns a joined path of the global configuration directory and passed arguments.
@@ -6,3 +6,6 @@ import (
 
 // ErrNotLinux is returned when attempting to access the "local" remote on non-Linux systems.
 var ErrNotLinux = fmt.Errorf("Can't connect to a local server on a non-Linux system")
+
+// errWrongSecret is returned when a passphrase or PIN is rejected.
+var errWrongSecret = fmt.Errorf("Wrong passphrase or PIN")
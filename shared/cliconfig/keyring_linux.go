@@ -0,0 +1,59 @@
+//go:build linux
+
+package cliconfig
+
+import (
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// keyringGet retrieves a secret previously cached in the session keyring.
+func keyringGet(description string) (string, bool) {
+	id, err := unix.KeyctlSearch(unix.KEY_SPEC_SESSION_KEYRING, "user", description, 0)
+	if err != nil {
+		return "", false
+	}
+
+	// Get the size of the payload first.
+	size, err := unix.KeyctlBuffer(unix.KEYCTL_READ, id, nil, 0)
+	if err != nil || size <= 0 {
+		return "", false
+	}
+
+	buf := make([]byte, size)
+	size, err = unix.KeyctlBuffer(unix.KEYCTL_READ, id, buf, 0)
+	if err != nil || size > len(buf) {
+		return "", false
+	}
+
+	return string(buf[:size]), true
+}
+
+// keyringSet caches a secret in the session keyring, the kernel discards it once the timeout expires.
+func keyringSet(description string, secret string, timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+
+	id, err := unix.AddKey("user", description, []byte(secret), unix.KEY_SPEC_SESSION_KEYRING)
+	if err != nil {
+		return
+	}
+
+	_, err = unix.KeyctlInt(unix.KEYCTL_SET_TIMEOUT, id, int(timeout.Seconds()), 0, 0)
+	if err != nil {
+		// Don't keep secrets around forever.
+		_, _ = unix.KeyctlInt(unix.KEYCTL_INVALIDATE, id, 0, 0, 0)
+	}
+}
+
+// keyringRemove removes a secret from the session keyring.
+func keyringRemove(description string) {
+	id, err := unix.KeyctlSearch(unix.KEY_SPEC_SESSION_KEYRING, "user", description, 0)
+	if err != nil {
+		return
+	}
+
+	_, _ = unix.KeyctlInt(unix.KEYCTL_INVALIDATE, id, 0, 0, 0)
+}
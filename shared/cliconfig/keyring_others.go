@@ -0,0 +1,20 @@
+//go:build !linux
+
+package cliconfig
+
+import (
+	"time"
+)
+
+// keyringGet retrieves a secret previously cached in the session keyring.
+func keyringGet(description string) (string, bool) {
+	return "", false
+}
+
+// keyringSet caches a secret in the session keyring, this isn't supported on this platform.
+func keyringSet(description string, secret string, timeout time.Duration) {
+}
+
+// keyringRemove removes a secret from the session keyring.
+func keyringRemove(description string) {
+}
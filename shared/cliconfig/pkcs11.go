@@ -0,0 +1,299 @@
+package cliconfig
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/lxc/incus/v6/shared/subprocess"
+)
+
+// pkcs11DigestInfoPrefixes holds the DER encoded DigestInfo prefixes needed for PKCS#1 v1.5 signatures.
+var pkcs11DigestInfoPrefixes = map[crypto.Hash][]byte{
+	crypto.SHA1:   {0x30, 0x21, 0x30, 0x09, 0x06, 0x05, 0x2b, 0x0e, 0x03, 0x02, 0x1a, 0x05, 0x00, 0x04, 0x14},
+	crypto.SHA256: {0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20},
+	crypto.SHA384: {0x30, 0x41, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x02, 0x05, 0x00, 0x04, 0x30},
+	crypto.SHA512: {0x30, 0x51, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x03, 0x05, 0x00, 0x04, 0x40},
+}
+
+// pkcs11Key is a client key held in a PKCS#11 token (smartcard, YubiKey, HSM, ...).
+// All token operations are done through OpenSC's pkcs11-tool so no PKCS#11 library needs to be linked in.
+type pkcs11Key struct {
+	config *Config
+
+	uri    string
+	module string
+	token  string
+	id     string
+	label  string
+
+	cert   string
+	public crypto.PublicKey
+}
+
+// getPKCS11Key returns the PKCS#11 key identified by the URI, using the provided certificate or
+// otherwise reading it from the token. Keys are cached so that the token only gets queried once.
+func (c *Config) getPKCS11Key(uri string, certPEM string) (*pkcs11Key, error) {
+	cacheKey := uri + "\n" + certPEM
+
+	key, ok := c.pkcs11Keys[cacheKey]
+	if ok {
+		return key, nil
+	}
+
+	key, err := c.newPKCS11Key(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	if certPEM == "" {
+		certPEM, err = key.certificate()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = key.setCertificate(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.pkcs11Keys == nil {
+		c.pkcs11Keys = map[string]*pkcs11Key{}
+	}
+
+	c.pkcs11Keys[cacheKey] = key
+
+	return key, nil
+}
+
+// newPKCS11Key parses a PKCS#11 URI (RFC 7512) identifying the client key.
+// The "token", "id" and "object" path attributes are supported along with the "module-path" query attribute.
+func (c *Config) newPKCS11Key(uri string) (*pkcs11Key, error) {
+	path, found := strings.CutPrefix(uri, "pkcs11:")
+	if !found {
+		return nil, fmt.Errorf("Unsupported client key %q (only PKCS#11 URIs are supported)", uri)
+	}
+
+	key := &pkcs11Key{config: c}
+
+	path, query, _ := strings.Cut(path, "?")
+	key.uri = "pkcs11:" + path
+
+	for _, attr := range strings.Split(path, ";") {
+		if attr == "" {
+			continue
+		}
+
+		name, value, _ := strings.Cut(attr, "=")
+
+		value, err := url.PathUnescape(value)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid PKCS#11 URI attribute %q: %w", attr, err)
+		}
+
+		switch name {
+		case "token":
+			key.token = value
+		case "id":
+			key.id = hex.EncodeToString([]byte(value))
+		case "object":
+			key.label = value
+		case "type":
+			if value != "private" {
+				return nil, fmt.Errorf("Invalid PKCS#11 object type %q (must be private)", value)
+			}
+
+		default:
+			return nil, fmt.Errorf("Unsupported PKCS#11 URI attribute %q", name)
+		}
+	}
+
+	for _, attr := range strings.Split(query, "&") {
+		if attr == "" {
+			continue
+		}
+
+		name, value, _ := strings.Cut(attr, "=")
+
+		value, err := url.QueryUnescape(value)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid PKCS#11 URI attribute %q: %w", attr, err)
+		}
+
+		switch name {
+		case "module-path":
+			key.module = value
+		default:
+			return nil, fmt.Errorf("Unsupported PKCS#11 URI attribute %q", name)
+		}
+	}
+
+	if key.id == "" && key.label == "" {
+		return nil, fmt.Errorf("PKCS#11 URI %q must identify the key through its id or object label", uri)
+	}
+
+	return key, nil
+}
+
+// args returns the pkcs11-tool arguments selecting the token and object.
+func (k *pkcs11Key) args() []string {
+	args := []string{}
+
+	if k.module != "" {
+		args = append(args, "--module", k.module)
+	}
+
+	if k.token != "" {
+		args = append(args, "--token-label", k.token)
+	}
+
+	if k.id != "" {
+		args = append(args, "--id", k.id)
+	}
+
+	if k.label != "" {
+		args = append(args, "--label", k.label)
+	}
+
+	return args
+}
+
+// certificate reads the certificate matching the key from the token, returning it in PEM format.
+func (k *pkcs11Key) certificate() (string, error) {
+	args := append(k.args(), "--read-object", "--type", "cert")
+
+	out, err := subprocess.RunCommandContext(context.TODO(), "pkcs11-tool", args...)
+	if err != nil {
+		return "", fmt.Errorf("Failed to read the client certificate from the PKCS#11 token: %w", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte(out)})), nil
+}
+
+// setCertificate records the public key from the certificate matching the key.
+func (k *pkcs11Key) setCertificate(certPEM string) error {
+	certBlock, _ := pem.Decode([]byte(certPEM))
+	if certBlock == nil {
+		return fmt.Errorf("Invalid client certificate")
+	}
+
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return fmt.Errorf("Invalid client certificate: %w", err)
+	}
+
+	k.cert = certPEM
+	k.public = cert.PublicKey
+
+	return nil
+}
+
+// Public returns the public key (implements crypto.Signer).
+func (k *pkcs11Key) Public() crypto.PublicKey {
+	return k.public
+}
+
+// Sign signs the digest using the key in the token (implements crypto.Signer).
+// The token PIN is prompted for and cached the same way as key passphrases.
+func (k *pkcs11Key) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	var args []string
+	input := digest
+
+	switch k.public.(type) {
+	case *rsa.PublicKey:
+		pssOpts, ok := opts.(*rsa.PSSOptions)
+		if ok {
+			hashName := strings.ReplaceAll(opts.HashFunc().String(), "-", "")
+
+			saltLength := pssOpts.SaltLength
+			if saltLength == rsa.PSSSaltLengthEqualsHash {
+				saltLength = opts.HashFunc().Size()
+			}
+
+			args = []string{"--mechanism", "RSA-PKCS-PSS", "--hash-algorithm", hashName, "--mgf", "MGF1-" + hashName, "--salt-len", strconv.Itoa(saltLength)}
+		} else {
+			prefix, ok := pkcs11DigestInfoPrefixes[opts.HashFunc()]
+			if !ok {
+				return nil, fmt.Errorf("Unsupported hash function %q", opts.HashFunc().String())
+			}
+
+			input = append(append([]byte{}, prefix...), digest...)
+			args = []string{"--mechanism", "RSA-PKCS"}
+		}
+
+	default:
+		args = []string{"--mechanism", "ECDSA", "--signature-format", "openssl"}
+	}
+
+	tmpDir, err := os.MkdirTemp("", "incus_pkcs11_")
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	inputPath := filepath.Join(tmpDir, "input")
+	outputPath := filepath.Join(tmpDir, "signature")
+
+	err = os.WriteFile(inputPath, input, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	args = append(append(k.args(), args...), "--sign", "--login", "--pin", "env:INCUS_PKCS11_PIN", "--input-file", inputPath, "--output-file", outputPath)
+
+	var signature []byte
+	err = k.config.withSecret(k.uri, func(pin string) error {
+		env := append(os.Environ(), "INCUS_PKCS11_PIN="+pin)
+
+		_, _, err := subprocess.RunCommandSplit(context.TODO(), env, nil, "pkcs11-tool", args...)
+		if err != nil {
+			if pkcs11WrongPIN(err) {
+				return fmt.Errorf("Failed to sign using the PKCS#11 token: %w", errWrongSecret)
+			}
+
+			return fmt.Errorf("Failed to sign using the PKCS#11 token: %w", err)
+		}
+
+		signature, err = os.ReadFile(outputPath)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return signature, nil
+}
+
+// pkcs11WrongPIN returns whether pkcs11-tool failed because of the PIN.
+func pkcs11WrongPIN(err error) bool {
+	var runErr subprocess.RunError
+	if !errors.As(err, &runErr) {
+		return false
+	}
+
+	stderr := runErr.StdErr().String()
+	for _, rv := range []string{"CKR_PIN_INCORRECT", "CKR_PIN_INVALID", "CKR_PIN_LEN_RANGE"} {
+		if strings.Contains(stderr, rv) {
+			return true
+		}
+	}
+
+	return false
+}
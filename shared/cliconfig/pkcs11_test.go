@@ -0,0 +1,94 @@
+package cliconfig
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/shared/subprocess"
+)
+
+func TestNewPKCS11Key(t *testing.T) {
+	c := NewConfig(t.TempDir(), false)
+
+	tests := []struct {
+		uri  string
+		key  pkcs11Key
+		args []string
+		err  string
+	}{
+		{
+			uri:  "pkcs11:id=%01?module-path=/usr/lib/x86_64-linux-gnu/libykcs11.so",
+			key:  pkcs11Key{uri: "pkcs11:id=%01", module: "/usr/lib/x86_64-linux-gnu/libykcs11.so", id: "01"},
+			args: []string{"--module", "/usr/lib/x86_64-linux-gnu/libykcs11.so", "--id", "01"},
+		},
+		{
+			uri:  "pkcs11:token=My%20Token;object=client%20key;type=private",
+			key:  pkcs11Key{uri: "pkcs11:token=My%20Token;object=client%20key;type=private", token: "My Token", label: "client key"},
+			args: []string{"--token-label", "My Token", "--label", "client key"},
+		},
+		{
+			uri:  "pkcs11:id=%ab%cd;object=client",
+			key:  pkcs11Key{uri: "pkcs11:id=%ab%cd;object=client", id: "abcd", label: "client"},
+			args: []string{"--id", "abcd", "--label", "client"},
+		},
+		{uri: "/home/user/client.key", err: `Unsupported client key "/home/user/client.key" (only PKCS#11 URIs are supported)`},
+		{uri: "pkcs11:token=token", err: `PKCS#11 URI "pkcs11:token=token" must identify the key through its id or object label`},
+		{uri: "pkcs11:id=%01;type=cert", err: `Invalid PKCS#11 object type "cert" (must be private)`},
+		{uri: "pkcs11:id=%01;serial=1234", err: `Unsupported PKCS#11 URI attribute "serial"`},
+		{uri: "pkcs11:id=%01?pin-value=1234", err: `Unsupported PKCS#11 URI attribute "pin-value"`},
+		{uri: "pkcs11:id=%zz", err: `Invalid PKCS#11 URI attribute "id=%zz": invalid URL escape "%zz"`},
+	}
+
+	for _, test := range tests {
+		key, err := c.newPKCS11Key(test.uri)
+		if test.err != "" {
+			assert.EqualError(t, err, test.err, test.uri)
+			continue
+		}
+
+		require.NoError(t, err, test.uri)
+
+		test.key.config = c
+		assert.Equal(t, &test.key, key, test.uri)
+		assert.Equal(t, test.args, key.args(), test.uri)
+	}
+}
+
+func TestGetPKCS11Key(t *testing.T) {
+	c := NewConfig(t.TempDir(), false)
+	require.NoError(t, c.GenerateClientCertificate())
+
+	cert, err := os.ReadFile(c.ConfigPath("client.crt"))
+	require.NoError(t, err)
+
+	// The provided certificate is used instead of the one from the token.
+	key, err := c.getPKCS11Key("pkcs11:id=%01", string(cert))
+	require.NoError(t, err)
+	assert.Equal(t, string(cert), key.cert)
+	assert.NotNil(t, key.Public())
+
+	// Keys are cached.
+	cached, err := c.getPKCS11Key("pkcs11:id=%01", string(cert))
+	require.NoError(t, err)
+	assert.Same(t, key, cached)
+
+	_, err = c.getPKCS11Key("pkcs11:id=%01", "invalid")
+	assert.EqualError(t, err, "Invalid client certificate")
+}
+
+func TestPKCS11WrongPIN(t *testing.T) {
+	runErr := func(stderr string) error {
+		return subprocess.NewRunError("pkcs11-tool", nil, errors.New("exit status 1"), &bytes.Buffer{}, bytes.NewBufferString(stderr))
+	}
+
+	assert.True(t, pkcs11WrongPIN(runErr("error: PKCS11 function C_Login failed: rv = CKR_PIN_INCORRECT (0xa0)")))
+	assert.True(t, pkcs11WrongPIN(fmt.Errorf("Failed signing: %w", runErr("error: PKCS11 function C_Login failed: rv = CKR_PIN_LEN_RANGE (0xa2)"))))
+	assert.False(t, pkcs11WrongPIN(runErr("error: PKCS11 function C_Login failed: rv = CKR_TOKEN_NOT_PRESENT (0xe0)")))
+	assert.False(t, pkcs11WrongPIN(errors.New("exec: \"pkcs11-tool\": executable file not found in $PATH")))
+}
@@ -0,0 +1,202 @@
+package cliconfig
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// registrySaltSize is the size of the salt used to derive the key encrypting the registry secrets.
+const registrySaltSize = 16
+
+// registryAuthFile is the on-disk format of the OCI registry credentials and settings of a remote.
+// The settings are stored as-is while the secrets are encrypted with a passphrase.
+type registryAuthFile struct {
+	api.ImageRegistryAuth
+
+	// Encrypted username, password and auth file.
+	Secrets []byte `json:"secrets,omitempty"`
+}
+
+// registrySecrets holds the secret part of the registry credentials.
+type registrySecrets struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	AuthFile string `json:"auth_file,omitempty"`
+}
+
+// RegistryAuthPath returns the path for the remote's OCI registry credentials.
+func (c *Config) RegistryAuthPath(remote string) string {
+	return c.ConfigPath("registries", fmt.Sprintf("%s.json", remote))
+}
+
+// SaveRegistryAuth saves the OCI registry credentials and settings of the remote to disk.
+// The username, password and auth file are encrypted with the passphrase, which is then
+// cached in the keyring like the client key passphrases.
+func (c *Config) SaveRegistryAuth(remote string, auth *api.ImageRegistryAuth, passphrase string) error {
+	file := registryAuthFile{ImageRegistryAuth: *auth}
+	file.Username = ""
+	file.Password = ""
+	file.AuthFile = ""
+
+	secrets := registrySecrets{Username: auth.Username, Password: auth.Password, AuthFile: auth.AuthFile}
+	if secrets != (registrySecrets{}) {
+		if passphrase == "" {
+			return errors.New("A passphrase is required to store the registry credentials")
+		}
+
+		data, err := json.Marshal(secrets)
+		if err != nil {
+			return err
+		}
+
+		file.Secrets, err = encryptRegistrySecrets(data, passphrase)
+		if err != nil {
+			return fmt.Errorf("Failed encrypting registry credentials: %w", err)
+		}
+	}
+
+	err := os.MkdirAll(c.ConfigPath("registries"), 0o700)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(c.RegistryAuthPath(remote), data, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if file.Secrets != nil {
+		keyringSet(fmt.Sprintf("incus:%s", c.RegistryAuthPath(remote)), passphrase, keyringTimeout())
+	}
+
+	return nil
+}
+
+// LoadRegistryAuth loads the OCI registry credentials and settings of the remote, if any.
+// The passphrase protecting the secrets is taken from the keyring or prompted for.
+func (c *Config) LoadRegistryAuth(remote string) (*api.ImageRegistryAuth, error) {
+	path := c.RegistryAuthPath(remote)
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	file := registryAuthFile{}
+	err = json.Unmarshal(content, &file)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing registry credentials for remote %q: %w", remote, err)
+	}
+
+	auth := file.ImageRegistryAuth
+	if file.Secrets == nil {
+		return &auth, nil
+	}
+
+	err = c.withSecret(path, func(passphrase string) error {
+		data, err := decryptRegistrySecrets(file.Secrets, passphrase)
+		if err != nil {
+			return err
+		}
+
+		secrets := registrySecrets{}
+		err = json.Unmarshal(data, &secrets)
+		if err != nil {
+			return err
+		}
+
+		auth.Username = secrets.Username
+		auth.Password = secrets.Password
+		auth.AuthFile = secrets.AuthFile
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed decrypting registry credentials for remote %q: %w", remote, err)
+	}
+
+	return &auth, nil
+}
+
+// registrySecretsCipher returns the AES-GCM cipher for the key derived from the passphrase and salt.
+func registrySecretsCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// encryptRegistrySecrets encrypts the data with the passphrase, returning the salt, nonce and ciphertext.
+func encryptRegistrySecrets(data []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, registrySaltSize)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := registrySecretsCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	out := append(salt, nonce...)
+
+	return aead.Seal(out, nonce, data, nil), nil
+}
+
+// decryptRegistrySecrets decrypts data produced by encryptRegistrySecrets.
+func decryptRegistrySecrets(data []byte, passphrase string) ([]byte, error) {
+	if len(data) < registrySaltSize {
+		return nil, errors.New("Invalid encrypted data")
+	}
+
+	aead, err := registrySecretsCipher(passphrase, data[:registrySaltSize])
+	if err != nil {
+		return nil, err
+	}
+
+	data = data[registrySaltSize:]
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("Invalid encrypted data")
+	}
+
+	plaintext, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	if err != nil {
+		// A wrong passphrase can't be told apart from corrupted data.
+		return nil, errWrongSecret
+	}
+
+	return plaintext, nil
+}
@@ -0,0 +1,69 @@
+package cliconfig
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+func TestRegistryAuth(t *testing.T) {
+	// Don't use the session keyring.
+	t.Setenv("INCUS_KEYRING_TIMEOUT", "0")
+
+	c := NewConfig(t.TempDir(), false)
+
+	passphrase := "passphrase"
+	prompts := 0
+	c.PromptPassword = func(filename string) (string, error) {
+		prompts++
+		return passphrase, nil
+	}
+
+	auth := &api.ImageRegistryAuth{
+		Username: "robot",
+		Password: "token",
+		Mirrors:  []string{"mirror.example.com"},
+		Insecure: true,
+	}
+
+	// Missing credentials.
+	loaded, err := c.LoadRegistryAuth("registry")
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+
+	// Credentials need a passphrase.
+	assert.Error(t, c.SaveRegistryAuth("registry", auth, ""))
+
+	require.NoError(t, c.SaveRegistryAuth("registry", auth, passphrase))
+
+	// The secrets aren't stored in clear.
+	content, err := os.ReadFile(c.RegistryAuthPath("registry"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "robot")
+	assert.NotContains(t, string(content), "token")
+	assert.Contains(t, string(content), "mirror.example.com")
+
+	loaded, err = c.LoadRegistryAuth("registry")
+	require.NoError(t, err)
+	assert.Equal(t, auth, loaded)
+	assert.Equal(t, 1, prompts)
+
+	// Wrong passphrase.
+	passphrase = "wrong"
+	_, err = c.LoadRegistryAuth("registry")
+	assert.ErrorIs(t, err, errWrongSecret)
+
+	// Settings alone don't need a passphrase.
+	prompts = 0
+	settings := &api.ImageRegistryAuth{CACertificate: "CA", Mirrors: []string{"https://mirror.example.com"}}
+	require.NoError(t, c.SaveRegistryAuth("public", settings, ""))
+
+	loaded, err = c.LoadRegistryAuth("public")
+	require.NoError(t, err)
+	assert.Equal(t, settings, loaded)
+	assert.Equal(t, 0, prompts)
+}
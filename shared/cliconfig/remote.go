@@ -1,8 +1,6 @@
 package cliconfig
 
 import (
-	"crypto/ecdsa"
-	"crypto/rsa"
 	"crypto/x509"
 	"encoding/json"
 	"encoding/pem"
@@ -16,7 +14,6 @@ import (
 	"time"
 
 	"github.com/zitadel/oidc/v3/pkg/oidc"
-	"golang.org/x/crypto/ssh"
 
 	incus "github.com/lxc/incus/v6/client"
 	"github.com/lxc/incus/v6/shared/api"
@@ -27,6 +24,7 @@ import (
 type Remote struct {
 	Addr      string `yaml:"addr"`
 	AuthType  string `yaml:"auth_type,omitempty"`
+	ClientKey string `yaml:"client_key,omitempty"`
 	KeepAlive int    `yaml:"keepalive,omitempty"`
 	Project   string `yaml:"project,omitempty"`
 	Protocol  string `yaml:"protocol,omitempty"`
@@ -109,11 +107,15 @@ func (c *Config) GetInstanceServer(name string) (incus.InstanceServer, error) {
 			d = d.UseProject(c.ProjectOverride)
 		}
 
+		if c.PriorityOverride != "" {
+			d = d.UsePriority(c.PriorityOverride)
+		}
+
 		return d, nil
 	}
 
 	// HTTPs
-	if !slices.Contains([]string{api.AuthenticationMethodOIDC}, remote.AuthType) && (args.TLSClientCert == "" || args.TLSClientKey == "") {
+	if !slices.Contains([]string{api.AuthenticationMethodOIDC}, remote.AuthType) && (args.TLSClientCert == "" || (args.TLSClientKey == "" && args.TLSClientSigner == nil)) {
 		return nil, fmt.Errorf("Missing TLS client certificate and key")
 	}
 
@@ -142,6 +144,10 @@ func (c *Config) GetInstanceServer(name string) (incus.InstanceServer, error) {
 		d = d.UseProject(c.ProjectOverride)
 	}
 
+	if c.PriorityOverride != "" {
+		d = d.UsePriority(c.PriorityOverride)
+	}
+
 	return d, nil
 }
 
@@ -242,8 +248,9 @@ func (c *Config) GetImageServer(name string) (incus.ImageServer, error) {
 func (c *Config) getConnectionArgs(name string) (*incus.ConnectionArgs, error) {
 	remote := c.Remotes[name]
 	args := incus.ConnectionArgs{
-		UserAgent: c.UserAgent,
-		AuthType:  remote.AuthType,
+		UserAgent:     c.UserAgent,
+		AuthType:      remote.AuthType,
+		ResponseCache: c.ResponseCache,
 	}
 
 	if args.AuthType == api.AuthenticationMethodOIDC {
@@ -291,6 +298,16 @@ func (c *Config) getConnectionArgs(name string) (*incus.ConnectionArgs, error) {
 		args.TLSServerCert = string(content)
 	}
 
+	// OCI registry credentials
+	if remote.Protocol == "oci" {
+		auth, err := c.LoadRegistryAuth(name)
+		if err != nil {
+			return nil, err
+		}
+
+		args.RegistryAuth = auth
+	}
+
 	// Stop here if no client certificate involved
 	if remote.Protocol != "incus" || slices.Contains([]string{api.AuthenticationMethodOIDC}, remote.AuthType) {
 		return &args, nil
@@ -300,7 +317,11 @@ func (c *Config) getConnectionArgs(name string) (*incus.ConnectionArgs, error) {
 	var pathClientCertificate string
 	var pathClientKey string
 	var pathClientCA string
-	if c.HasRemoteClientCertificate(name) {
+	if remote.ClientKey != "" {
+		// The key lives outside of the configuration directory, only the certificate may be stored alongside.
+		pathClientCertificate = c.ConfigPath("clientcerts", fmt.Sprintf("%s.crt", name))
+		pathClientCA = c.ConfigPath("clientcerts", fmt.Sprintf("%s.ca", name))
+	} else if c.HasRemoteClientCertificate(name) {
 		pathClientCertificate = c.ConfigPath("clientcerts", fmt.Sprintf("%s.crt", name))
 		pathClientKey = c.ConfigPath("clientcerts", fmt.Sprintf("%s.key", name))
 		pathClientCA = c.ConfigPath("clientcerts", fmt.Sprintf("%s.ca", name))
@@ -320,6 +341,17 @@ func (c *Config) getConnectionArgs(name string) (*incus.ConnectionArgs, error) {
 		args.TLSClientCert = string(content)
 	}
 
+	// Client key held in a PKCS#11 token
+	if remote.ClientKey != "" {
+		key, err := c.getPKCS11Key(remote.ClientKey, args.TLSClientCert)
+		if err != nil {
+			return nil, err
+		}
+
+		args.TLSClientCert = key.cert
+		args.TLSClientSigner = key
+	}
+
 	// Client CA
 	if util.PathExists(pathClientCA) {
 		content, err := os.ReadFile(pathClientCA)
@@ -331,57 +363,32 @@ func (c *Config) getConnectionArgs(name string) (*incus.ConnectionArgs, error) {
 	}
 
 	// Client key
-	if util.PathExists(pathClientKey) {
+	if pathClientKey != "" && util.PathExists(pathClientKey) {
 		content, err := os.ReadFile(pathClientKey)
 		if err != nil {
 			return nil, err
 		}
 
 		pemKey, _ := pem.Decode(content)
+		if pemKey == nil {
+			return nil, fmt.Errorf("Invalid client key %q", pathClientKey)
+		}
+
 		// Golang has deprecated all methods relating to PEM encryption due to a vulnerability.
 		// However, the weakness does not make PEM unsafe for our purposes as it pertains to password protection on the
 		// key file (client.key is only readable to the user in any case), so we'll ignore deprecation.
 		isEncrypted := x509.IsEncryptedPEMBlock(pemKey) //nolint:staticcheck
 		isSSH := pemKey.Type == "OPENSSH PRIVATE KEY"
 		if isEncrypted || isSSH {
-			if c.PromptPassword == nil {
-				return nil, fmt.Errorf("Private key is password protected and no helper was configured")
-			}
+			encryptedContent := content
 
-			password, err := c.PromptPassword(pathClientKey)
+			err = c.withSecret(pathClientKey, func(password string) error {
+				content, err = decryptClientKey(encryptedContent, password)
+				return err
+			})
 			if err != nil {
 				return nil, err
 			}
-
-			if isSSH {
-				sshKey, err := ssh.ParseRawPrivateKeyWithPassphrase(content, []byte(password))
-				if err != nil {
-					return nil, err
-				}
-
-				ecdsaKey, okEcdsa := (sshKey).(*ecdsa.PrivateKey)
-				rsaKey, okRsa := (sshKey).(*rsa.PrivateKey)
-				if okEcdsa {
-					derKey, err := x509.MarshalECPrivateKey(ecdsaKey)
-					if err != nil {
-						return nil, err
-					}
-
-					content = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: derKey})
-				} else if okRsa {
-					derKey := x509.MarshalPKCS1PrivateKey(rsaKey)
-					content = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: derKey})
-				} else {
-					return nil, fmt.Errorf("Unsupported key type: %T", sshKey)
-				}
-			} else {
-				derKey, err := x509.DecryptPEMBlock(pemKey, []byte(password)) //nolint:staticcheck
-				if err != nil {
-					return nil, err
-				}
-
-				content = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: derKey})
-			}
 		}
 
 		args.TLSClientKey = string(content)
@@ -784,6 +784,33 @@ func IsRequestURL(value string) error {
 	return nil
 }
 
+// IsRegistryAddress checks value is a valid container registry address.
+// The address may omit its scheme (defaulting to https) and include a path to a namespace.
+func IsRegistryAddress(value string) error {
+	if value == "" {
+		return fmt.Errorf("Empty registry address")
+	}
+
+	if !strings.Contains(value, "://") {
+		value = "https://" + value
+	}
+
+	u, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("Invalid registry address: %w", err)
+	}
+
+	if !slices.Contains([]string{"http", "https"}, u.Scheme) {
+		return fmt.Errorf("Unsupported registry scheme %q", u.Scheme)
+	}
+
+	if u.Host == "" || u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("Registry address must only contain a host and an optional path")
+	}
+
+	return nil
+}
+
 // IsCloudInitUserData checks value is valid cloud-init user data.
 func IsCloudInitUserData(value string) error {
 	if value == "#cloud-config" || strings.HasPrefix(value, "#cloud-config\n") {